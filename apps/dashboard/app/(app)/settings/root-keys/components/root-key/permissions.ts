@@ -150,6 +150,20 @@ export const workspacePermissions = {
       permission: "identity.*.delete_identity",
     },
   },
+  Webhooks: {
+    create_endpoint: {
+      description: "Create new webhook endpoints in this workspace",
+      permission: "webhook.*.create_endpoint",
+    },
+    read_endpoint: {
+      description: "List the webhook endpoints of this workspace",
+      permission: "webhook.*.read_endpoint",
+    },
+    delete_endpoint: {
+      description: "Delete webhook endpoints in this workspace",
+      permission: "webhook.*.delete_endpoint",
+    },
+  },
 } satisfies Record<string, UnkeyPermissions>;
 
 export function apiPermissions(apiId: string): {
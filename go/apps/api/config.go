@@ -15,6 +15,19 @@ type S3Config struct {
 	AccessKeySecret string
 }
 
+// FilesystemConfig stores vault objects in a local directory.
+type FilesystemConfig struct {
+	// Path is the directory the objects are stored in. It must be on a
+	// persistent volume, encrypted data can not be decrypted without them.
+	Path string
+}
+
+// MySQLConfig stores vault objects in a MySQL database.
+type MySQLConfig struct {
+	// DSN is the connection string of the database.
+	DSN string
+}
+
 type Config struct {
 	// InstanceID is the unique identifier for this instance of the API server
 	InstanceID string
@@ -40,6 +53,11 @@ type Config struct {
 	// RedisUrl is the Redis database connection string
 	RedisUrl string
 
+	// CacheL2Resources lists the caches that use Redis as a shared second
+	// tier behind the in-memory cache, for example "verification_key_by_hash".
+	// Empty disables the second tier.
+	CacheL2Resources []string
+
 	// Enable TestMode
 	TestMode bool
 
@@ -72,7 +90,12 @@ type Config struct {
 
 	// Vault Configuration
 	VaultMasterKeys []string
+
+	// The storage of the vault's encrypted data encryption keys, at most one
+	// may be set. Vault is disabled if none is set.
 	VaultS3         *S3Config
+	VaultFilesystem *FilesystemConfig
+	VaultMySQL      *MySQLConfig
 
 	// --- ClickHouse proxy configuration ---
 
@@ -101,5 +124,26 @@ func (c Config) Validate() error {
 		}
 	}
 
-	return nil
+	if c.VaultFilesystem != nil {
+		err := assert.NotEmpty(c.VaultFilesystem.Path, "vault filesystem path is empty")
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.VaultMySQL != nil {
+		err := assert.NotEmpty(c.VaultMySQL.DSN, "vault mysql dsn is empty")
+		if err != nil {
+			return err
+		}
+	}
+
+	vaultStorages := 0
+	for _, configured := range []bool{c.VaultS3 != nil, c.VaultFilesystem != nil, c.VaultMySQL != nil} {
+		if configured {
+			vaultStorages++
+		}
+	}
+
+	return assert.LessOrEqual(vaultStorages, 1, "only one vault storage can be configured")
 }
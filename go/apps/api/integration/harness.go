@@ -142,6 +142,7 @@ func (h *Harness) RunAPI(config ApiConfig) *ApiCluster {
 			DatabaseReadonlyReplica: "",
 			ClickhouseURL:           clickhouseHostDSN,
 			RedisUrl:                redisHostAddr,
+			CacheL2Resources:        nil,
 			Region:                  "test",
 			InstanceID:              fmt.Sprintf("test-node-%d", i),
 			Clock:                   clock.New(),
@@ -152,6 +153,8 @@ func (h *Harness) RunAPI(config ApiConfig) *ApiCluster {
 			TLSConfig:               nil,
 			VaultMasterKeys:         []string{"Ch9rZWtfMmdqMFBJdVhac1NSa0ZhNE5mOWlLSnBHenFPENTt7an5MRogENt9Si6wms4pQ2XIvqNSIgNpaBenJmXgcInhu6Nfv2U="}, // Test key from docker-compose
 			VaultS3:                 nil,
+			VaultFilesystem:         nil,
+			VaultMySQL:              nil,
 		}
 
 		// Start API server in goroutine
@@ -11,6 +11,7 @@ import (
 
 // TestCase defines a single ratelimit test configuration
 type TestCase struct {
+	Algorithm   string
 	NodeCount   int
 	Limit       int64
 	Duration    int64
@@ -50,6 +51,18 @@ var realisticCombinations = []TestCase{
 	{NodeCount: 5, Limit: 10, Duration: 60000, LoadFactor: 1.5, WindowCount: 10},
 }
 
+var algorithmCombinations = []TestCase{
+	{Algorithm: "fixed_window", NodeCount: 1, Limit: 100, Duration: 10000, LoadFactor: 0.9, WindowCount: 10},
+	{Algorithm: "fixed_window", NodeCount: 1, Limit: 100, Duration: 10000, LoadFactor: 2.0, WindowCount: 10},
+	{Algorithm: "fixed_window", NodeCount: 3, Limit: 100, Duration: 10000, LoadFactor: 2.0, WindowCount: 10},
+	{Algorithm: "fixed_window", NodeCount: 3, Limit: 10, Duration: 60000, LoadFactor: 1.5, WindowCount: 10},
+
+	{Algorithm: "token_bucket", NodeCount: 1, Limit: 100, Duration: 10000, LoadFactor: 0.9, WindowCount: 10},
+	{Algorithm: "token_bucket", NodeCount: 1, Limit: 100, Duration: 10000, LoadFactor: 2.0, WindowCount: 10},
+	{Algorithm: "token_bucket", NodeCount: 3, Limit: 100, Duration: 10000, LoadFactor: 2.0, WindowCount: 10},
+	{Algorithm: "token_bucket", NodeCount: 3, Limit: 10, Duration: 60000, LoadFactor: 1.5, WindowCount: 10},
+}
+
 // BuildTag returns the go:build constraint line (or empty) appropriate for tc.
 // It is used by the generator template to decide which tests are compiled by default.
 func (tc TestCase) BuildTag() string {
@@ -66,8 +79,39 @@ func (tc TestCase) BuildTag() string {
 	}
 }
 
+// AlgorithmConstant returns the db enum value for the test case's algorithm,
+// or an empty string for the default sliding window.
+func (tc TestCase) AlgorithmConstant() string {
+	switch tc.Algorithm {
+	case "fixed_window":
+		return "db.RatelimitNamespacesAlgorithmFixedWindow"
+	case "token_bucket":
+		return "db.RatelimitNamespacesAlgorithmTokenBucket"
+	default:
+		return ""
+	}
+}
+
+// algorithmPrefix keeps the names of sliding window tests unchanged, they
+// existed before other algorithms were supported.
+func (tc TestCase) algorithmPrefix(separator string, title bool) string {
+	if tc.Algorithm == "" || tc.Algorithm == "sliding_window" {
+		return ""
+	}
+	if !title {
+		return tc.Algorithm + separator
+	}
+
+	words := strings.Split(tc.Algorithm, "_")
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, "") + separator
+}
+
 func (tc TestCase) PackageName() string {
-	return fmt.Sprintf("ratelimit_nodes%02d_limit%04d_duration%09d_load%s_windows%03d",
+	return fmt.Sprintf("ratelimit_%snodes%02d_limit%04d_duration%09d_load%s_windows%03d",
+		tc.algorithmPrefix("_", false),
 		tc.NodeCount,
 		tc.Limit,
 		tc.Duration,
@@ -77,7 +121,8 @@ func (tc TestCase) PackageName() string {
 }
 
 func (tc TestCase) TestName() string {
-	return fmt.Sprintf("TestIntegration_RateLimit_Nodes%02d_Limit%04d_Duration%09d_Load%s_Windows%03d",
+	return fmt.Sprintf("TestIntegration_RateLimit_%sNodes%02d_Limit%04d_Duration%09d_Load%s_Windows%03d",
+		tc.algorithmPrefix("_", true),
 		tc.NodeCount,
 		tc.Limit,
 		tc.Duration,
@@ -95,7 +140,8 @@ import (
 	"testing"
 
 	"github.com/unkeyed/unkey/go/apps/api/integration"
-	run "github.com/unkeyed/unkey/go/apps/api/integration/multi_node_ratelimiting"
+	run "github.com/unkeyed/unkey/go/apps/api/integration/multi_node_ratelimiting"{{ if .AlgorithmConstant }}
+	"github.com/unkeyed/unkey/go/pkg/db"{{ end }}
 	"github.com/unkeyed/unkey/go/pkg/testutil"
 )
 
@@ -105,10 +151,16 @@ func {{ .TestName }}(t *testing.T) {
 	h := integration.New(t, integration.Config{
 		NumNodes: {{ .NodeCount }},
 	})
-
+{{ if .AlgorithmConstant }}
+	run.RunRateLimitTestWithAlgorithm(
+		t,
+		h,
+		{{ .AlgorithmConstant }}, // algorithm
+{{- else }}
 	run.RunRateLimitTest(
 		t,
 		h,
+{{- end }}
 		{{ .Limit }},            // limit
 		{{ .Duration }},         // duration
 		{{ .WindowCount }},      // window count
@@ -137,8 +189,9 @@ func main() {
 	}
 
 	// Combine all test cases
-	testCases := make([]TestCase, 0, len(realisticCombinations)+len(extremeEdgeCases))
+	testCases := make([]TestCase, 0, len(realisticCombinations)+len(algorithmCombinations)+len(extremeEdgeCases))
 	testCases = append(testCases, realisticCombinations...)
+	testCases = append(testCases, algorithmCombinations...)
 	testCases = append(testCases, extremeEdgeCases...)
 
 	// Generate test files
@@ -166,6 +219,6 @@ func main() {
 		}
 	}
 
-	fmt.Printf("Generated %d test cases (%d realistic + %d extreme)\n",
-		len(testCases), len(realisticCombinations), len(extremeEdgeCases))
+	fmt.Printf("Generated %d test cases (%d realistic + %d algorithms + %d extreme)\n",
+		len(testCases), len(realisticCombinations), len(algorithmCombinations), len(extremeEdgeCases))
 }
@@ -2,6 +2,7 @@ package multi_node_ratelimiting
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"math"
 	"net/http"
@@ -27,6 +28,21 @@ func RunRateLimitTest(
 	windowCount int,
 	loadFactor float64,
 	nodeCount int,
+) {
+	RunRateLimitTestWithAlgorithm(t, h, db.RatelimitNamespacesAlgorithmSlidingWindow, limit, duration, windowCount, loadFactor, nodeCount)
+}
+
+// RunRateLimitTestWithAlgorithm runs a single rate limit test against a namespace
+// that uses the given algorithm
+func RunRateLimitTestWithAlgorithm(
+	t *testing.T,
+	h *integration.Harness,
+	algorithm db.RatelimitNamespacesAlgorithm,
+	limit int64,
+	duration int64, // milliseconds
+	windowCount int,
+	loadFactor float64,
+	nodeCount int,
 ) {
 	ctx := context.Background()
 
@@ -44,6 +60,14 @@ func RunRateLimitTest(
 	})
 	require.NoError(t, err)
 
+	err = db.Query.UpdateRatelimitNamespaceAlgorithm(ctx, h.DB.RW(), db.UpdateRatelimitNamespaceAlgorithmParams{
+		Algorithm:  algorithm,
+		RefillRate: sql.NullInt32{},
+		Now:        sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()},
+		ID:         namespaceID,
+	})
+	require.NoError(t, err)
+
 	// Create a root key for authentication
 	rootKey := h.Seed.CreateRootKey(ctx, h.Seed.Resources.UserWorkspace.ID,
 		fmt.Sprintf("ratelimit.%s.limit", namespaceID))
@@ -86,6 +110,12 @@ func RunRateLimitTest(
 	// Maximum theoretical allowed requests across all windows
 	maxAllowed := math.Min(numWindows*float64(limit), float64(totalRequests))
 
+	// A token bucket starts full and refills limit tokens per window,
+	// so it admits one additional burst on top of the refilled tokens
+	if algorithm == db.RatelimitNamespacesAlgorithmTokenBucket {
+		maxAllowed = math.Min((numWindows+1)*float64(limit), float64(totalRequests))
+	}
+
 	// Set acceptance thresholds with 20% tolerance
 	upperLimit := int(maxAllowed * 1.2)
 	lowerLimit := int(maxAllowed * 0.95)
@@ -104,7 +134,7 @@ func RunRateLimitTest(
 	// Step 4: Run the load test
 	// ------------------------
 
-	t.Logf("Configuration: limit=%d, duration=%s, load=%.1fx", limit, time.Duration(duration)*time.Millisecond, loadFactor)
+	t.Logf("Configuration: algorithm=%s, limit=%d, duration=%s, load=%.1fx", algorithm, limit, time.Duration(duration)*time.Millisecond, loadFactor)
 	t.Logf("Sending %d requests at %d RPS across %d nodes", totalRequests, rps, nodeCount)
 
 	realStart := time.Now()
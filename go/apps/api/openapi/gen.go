@@ -11,12 +11,35 @@ const (
 	RootKeyScopes = "rootKey.Scopes"
 )
 
+// Defines values for AnalyticsGranularity.
+const (
+	Day    AnalyticsGranularity = "day"
+	Hour   AnalyticsGranularity = "hour"
+	Minute AnalyticsGranularity = "minute"
+	Month  AnalyticsGranularity = "month"
+)
+
+// Defines values for AnalyticsGroupBy.
+const (
+	Key     AnalyticsGroupBy = "key"
+	Outcome AnalyticsGroupBy = "outcome"
+	Tag     AnalyticsGroupBy = "tag"
+	Time    AnalyticsGroupBy = "time"
+)
+
 // Defines values for KeyCreditsRefillInterval.
 const (
 	Daily   KeyCreditsRefillInterval = "daily"
 	Monthly KeyCreditsRefillInterval = "monthly"
 )
 
+// Defines values for RatelimitAlgorithm.
+const (
+	FixedWindow   RatelimitAlgorithm = "fixed_window"
+	SlidingWindow RatelimitAlgorithm = "sliding_window"
+	TokenBucket   RatelimitAlgorithm = "token_bucket"
+)
+
 // Defines values for V2KeysUpdateCreditsRequestBodyOperation.
 const (
 	Decrement V2KeysUpdateCreditsRequestBodyOperation = "decrement"
@@ -37,6 +60,27 @@ const (
 	VALID                   V2KeysVerifyKeyResponseDataCode = "VALID"
 )
 
+// Defines values for WebhookEvent.
+const (
+	KeyCreate           WebhookEvent = "key.create"
+	KeyCreditsExhausted WebhookEvent = "key.credits_exhausted"
+	KeyCreditsLow       WebhookEvent = "key.credits_low"
+	KeyDelete           WebhookEvent = "key.delete"
+	KeyExpired          WebhookEvent = "key.expired"
+	KeyExpiringSoon     WebhookEvent = "key.expiring_soon"
+)
+
+// AnalyticsGranularity The size of the time buckets verifications are counted in.
+// Finer granularities can only be queried for shorter time ranges: `minute` for up to 24 hours, `hour` for up to 31 days and `day` for up to 3 years.
+type AnalyticsGranularity string
+
+// AnalyticsGroupBy A dimension to group verifications by.
+// - `time`: One group per time bucket of the selected granularity.
+// - `outcome`: One group per verification outcome, such as `VALID` or `RATE_LIMITED`.
+// - `tag`: One group per tag. Verifications with several tags count towards each of them, verifications without tags are omitted.
+// - `key`: One group per key.
+type AnalyticsGroupBy string
+
 // BadRequestErrorDetails defines model for BadRequestErrorDetails.
 type BadRequestErrorDetails struct {
 	// Detail A human-readable explanation specific to this occurrence of the problem. This provides detailed information about what went wrong and potential remediation steps. The message is intended to be helpful for developers troubleshooting the issue.
@@ -325,8 +369,41 @@ type PreconditionFailedErrorResponse struct {
 	Meta Meta `json:"meta"`
 }
 
+// RatelimitAlgorithm The algorithm used to enforce the rate limit.
+//
+// - `sliding_window`: Weighs the previous window into the current one to smooth out bursts at window boundaries. This is the default.
+// - `fixed_window`: Counts requests in discrete windows of `duration` milliseconds. Cheaper and easier to reason about, but allows up to twice the limit around a window boundary.
+// - `token_bucket`: Allows bursts of up to `limit` requests and refills `refillRate` tokens per `duration` continuously.
+type RatelimitAlgorithm string
+
+// RatelimitNamespace defines model for RatelimitNamespace.
+type RatelimitNamespace struct {
+	// Algorithm The algorithm used to enforce the rate limit.
+	//
+	// - `sliding_window`: Weighs the previous window into the current one to smooth out bursts at window boundaries. This is the default.
+	// - `fixed_window`: Counts requests in discrete windows of `duration` milliseconds. Cheaper and easier to reason about, but allows up to twice the limit around a window boundary.
+	// - `token_bucket`: Allows bursts of up to `limit` requests and refills `refillRate` tokens per `duration` continuously.
+	Algorithm RatelimitAlgorithm `json:"algorithm"`
+
+	// Name The name of the namespace, unique within the workspace. This is the value you pass as `namespace` to `ratelimit.limit`.
+	Name string `json:"name"`
+
+	// NamespaceId The unique identifier of the namespace. Use it instead of the name to reference the namespace in other ratelimit endpoints, it stays the same when the namespace is renamed.
+	NamespaceId string `json:"namespaceId"`
+
+	// RefillRate The number of tokens added back per duration when using the `token_bucket` algorithm. Ignored by other algorithms. Defaults to the limit if not set.
+	RefillRate *int64 `json:"refillRate,omitempty"`
+}
+
 // RatelimitOverride defines model for RatelimitOverride.
 type RatelimitOverride struct {
+	// Algorithm The algorithm used to enforce the rate limit.
+	//
+	// - `sliding_window`: Weighs the previous window into the current one to smooth out bursts at window boundaries. This is the default.
+	// - `fixed_window`: Counts requests in discrete windows of `duration` milliseconds. Cheaper and easier to reason about, but allows up to twice the limit around a window boundary.
+	// - `token_bucket`: Allows bursts of up to `limit` requests and refills `refillRate` tokens per `duration` continuously.
+	Algorithm *RatelimitAlgorithm `json:"algorithm,omitempty"`
+
 	// Duration The duration in milliseconds for this override's rate limit window. This may differ from the default duration for the namespace, allowing custom time windows for specific entities. After this duration elapses, the rate limit counter for affected identifiers resets to zero.
 	Duration int64 `json:"duration"`
 
@@ -355,6 +432,9 @@ type RatelimitOverride struct {
 
 	// OverrideId The unique identifier of this specific rate limit override. This ID is generated when the override is created and can be used for management operations like updating or deleting the override.
 	OverrideId string `json:"overrideId"`
+
+	// RefillRate The number of tokens added back per duration when using the `token_bucket` algorithm. Ignored by other algorithms. Defaults to the limit if not set.
+	RefillRate *int64 `json:"refillRate,omitempty"`
 }
 
 // RatelimitRequest defines model for RatelimitRequest.
@@ -455,6 +535,60 @@ type UnauthorizedErrorResponse struct {
 	Meta Meta `json:"meta"`
 }
 
+// V2AnalyticsGetVerificationsRequestBody defines model for V2AnalyticsGetVerificationsRequestBody.
+type V2AnalyticsGetVerificationsRequestBody struct {
+	// ApiId Only count verifications of keys of this API.
+	ApiId *string `json:"apiId,omitempty"`
+
+	// End End of the time range in Unix milliseconds, inclusive. Must not be before `start`.
+	End int64 `json:"end"`
+
+	// Granularity The size of the time buckets verifications are counted in.
+	// Finer granularities can only be queried for shorter time ranges: `minute` for up to 24 hours, `hour` for up to 31 days and `day` for up to 3 years.
+	Granularity *AnalyticsGranularity `json:"granularity,omitempty"`
+
+	// GroupBy The dimensions to group verifications by.
+	// Without any, a single group with the total number of verifications is returned.
+	GroupBy *[]AnalyticsGroupBy `json:"groupBy,omitempty"`
+
+	// IdentityIds Only count verifications of keys belonging to these identities.
+	IdentityIds *[]string `json:"identityIds,omitempty"`
+
+	// KeyIds Only count verifications of these keys.
+	KeyIds *[]string `json:"keyIds,omitempty"`
+
+	// Limit The maximum number of groups to return. Groups are ordered by time, then by count in descending order.
+	Limit *int `json:"limit,omitempty"`
+
+	// Outcomes Only count verifications with one of these outcomes.
+	// Outcomes are the codes returned by `/v2/keys.verifyKey`, such as `VALID`, `RATE_LIMITED` or `USAGE_EXCEEDED`.
+	Outcomes *[]string `json:"outcomes,omitempty"`
+
+	// Start Start of the time range in Unix milliseconds, inclusive. It is rounded down to the start of its time bucket.
+	Start int64 `json:"start"`
+
+	// Tags Only count verifications that carry all of these tags.
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// V2AnalyticsGetVerificationsResponseBody defines model for V2AnalyticsGetVerificationsResponseBody.
+type V2AnalyticsGetVerificationsResponseBody struct {
+	Data V2AnalyticsGetVerificationsResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2AnalyticsGetVerificationsResponseData defines model for V2AnalyticsGetVerificationsResponseData.
+type V2AnalyticsGetVerificationsResponseData struct {
+	// Granularity The size of the time buckets verifications are counted in.
+	// Finer granularities can only be queried for shorter time ranges: `minute` for up to 24 hours, `hour` for up to 31 days and `day` for up to 3 years.
+	Granularity AnalyticsGranularity `json:"granularity"`
+
+	// Verifications The verification counts, one entry per group.
+	Verifications []VerificationsGroup `json:"verifications"`
+}
+
 // V2ApisCreateApiRequestBody defines model for V2ApisCreateApiRequestBody.
 type V2ApisCreateApiRequestBody struct {
 	// Name Unique identifier for this API namespace within your workspace.
@@ -1403,6 +1537,23 @@ type V2KeysVerifyKeyResponseData struct {
 // `EXPIRED` (key has passed its expiration date).
 type V2KeysVerifyKeyResponseDataCode string
 
+// V2KeysVerifyKeysRequestBody defines model for V2KeysVerifyKeysRequestBody.
+type V2KeysVerifyKeysRequestBody struct {
+	// Keys The keys to verify, each with its own tags, permissions, credits and rate limits.
+	// Every key is verified exactly as `/v2/keys.verifyKey` would verify it, including credit consumption and analytics.
+	// The same key may appear more than once, every occurrence is verified and charged separately.
+	Keys []V2KeysVerifyKeyRequestBody `json:"keys"`
+}
+
+// V2KeysVerifyKeysResponseBody defines model for V2KeysVerifyKeysResponseBody.
+type V2KeysVerifyKeysResponseBody struct {
+	// Data The verification results, in the same order as the keys in the request.
+	Data []V2KeysVerifyKeyResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
 // V2KeysWhoamiRequestBody defines model for V2KeysWhoamiRequestBody.
 type V2KeysWhoamiRequestBody struct {
 	// Key The complete API key string provided by you, including any prefix.
@@ -1667,6 +1818,58 @@ type V2PermissionsListRolesResponseBody struct {
 // V2PermissionsListRolesResponseData Array of roles with their assigned permissions.
 type V2PermissionsListRolesResponseData = []Role
 
+// V2RatelimitCreateNamespaceRequestBody Creates a new rate limit namespace. Namespaces group related rate limits and share their overrides and algorithm configuration.
+type V2RatelimitCreateNamespaceRequestBody struct {
+	// Algorithm The algorithm used to enforce the rate limit.
+	//
+	// - `sliding_window`: Weighs the previous window into the current one to smooth out bursts at window boundaries. This is the default.
+	// - `fixed_window`: Counts requests in discrete windows of `duration` milliseconds. Cheaper and easier to reason about, but allows up to twice the limit around a window boundary.
+	// - `token_bucket`: Allows bursts of up to `limit` requests and refills `refillRate` tokens per `duration` continuously.
+	Algorithm *RatelimitAlgorithm `json:"algorithm,omitempty"`
+
+	// Name The name of the namespace, unique within your workspace. Pass this value as `namespace` when calling `ratelimit.limit`.
+	//
+	// Use a descriptive name that reflects what is being limited, for example `api.requests` or `email.outbound`.
+	Name string `json:"name"`
+
+	// RefillRate The number of tokens added back per duration when using the `token_bucket` algorithm. Ignored by other algorithms.
+	//
+	// If omitted, the bucket refills `limit` tokens per `duration`.
+	RefillRate *int64 `json:"refillRate,omitempty"`
+}
+
+// V2RatelimitCreateNamespaceResponseBody defines model for V2RatelimitCreateNamespaceResponseBody.
+type V2RatelimitCreateNamespaceResponseBody struct {
+	Data V2RatelimitCreateNamespaceResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2RatelimitCreateNamespaceResponseData defines model for V2RatelimitCreateNamespaceResponseData.
+type V2RatelimitCreateNamespaceResponseData struct {
+	// NamespaceId The unique identifier of the newly created namespace. Use it to reference the namespace in permissions and other ratelimit endpoints.
+	NamespaceId string `json:"namespaceId"`
+}
+
+// V2RatelimitDeleteNamespaceRequestBody Deletes a rate limit namespace. Requests to `ratelimit.limit` for this namespace are rejected as soon as the deletion takes effect.
+type V2RatelimitDeleteNamespaceRequestBody struct {
+	// Namespace The id or name of the namespace to delete.
+	Namespace string `json:"namespace"`
+}
+
+// V2RatelimitDeleteNamespaceResponseBody defines model for V2RatelimitDeleteNamespaceResponseBody.
+type V2RatelimitDeleteNamespaceResponseBody struct {
+	// Data Empty response object. A successful response indicates the namespace was deleted. The operation is immediate, rate limit requests for this namespace are rejected from now on.
+	Data V2RatelimitDeleteNamespaceResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2RatelimitDeleteNamespaceResponseData Empty response object. A successful response indicates the namespace was deleted. The operation is immediate, rate limit requests for this namespace are rejected from now on.
+type V2RatelimitDeleteNamespaceResponseData = map[string]interface{}
+
 // V2RatelimitDeleteOverrideRequestBody Deletes an existing rate limit override. This permanently removes a custom rate limit rule, reverting affected identifiers back to the default rate limits for the namespace.
 //
 // Use this endpoint when you need to:
@@ -1704,6 +1907,20 @@ type V2RatelimitDeleteOverrideResponseBody struct {
 // V2RatelimitDeleteOverrideResponseData Empty response object. A successful response indicates the override was successfully deleted. The operation is immediate - as soon as this response is received, the override no longer exists and affected identifiers have reverted to using the default rate limit for the namespace. No other data is returned as part of the deletion operation.
 type V2RatelimitDeleteOverrideResponseData = map[string]interface{}
 
+// V2RatelimitGetNamespaceRequestBody defines model for V2RatelimitGetNamespaceRequestBody.
+type V2RatelimitGetNamespaceRequestBody struct {
+	// Namespace The id or name of the namespace to retrieve.
+	Namespace string `json:"namespace"`
+}
+
+// V2RatelimitGetNamespaceResponseBody defines model for V2RatelimitGetNamespaceResponseBody.
+type V2RatelimitGetNamespaceResponseBody struct {
+	Data RatelimitNamespace `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
 // V2RatelimitGetOverrideRequestBody Gets the configuration of an existing rate limit override. Use this to retrieve details about custom rate limit rules that have been created for specific identifiers within a namespace.
 //
 // This endpoint is useful for:
@@ -1816,6 +2033,31 @@ type V2RatelimitLimitResponseData struct {
 	Success bool `json:"success"`
 }
 
+// V2RatelimitListNamespacesRequestBody defines model for V2RatelimitListNamespacesRequestBody.
+type V2RatelimitListNamespacesRequestBody struct {
+	// Cursor Pagination cursor from a previous response. Include this when fetching subsequent pages of results. Each response containing more results than the requested limit will include a cursor value in the pagination object that can be used here.
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Limit Maximum number of namespaces to return in a single response.
+	//
+	// Results exceeding this limit will be paginated, with a cursor provided for fetching subsequent pages.
+	Limit *int `json:"limit,omitempty"`
+}
+
+// V2RatelimitListNamespacesResponseBody defines model for V2RatelimitListNamespacesResponseBody.
+type V2RatelimitListNamespacesResponseBody struct {
+	Data V2RatelimitListNamespacesResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+
+	// Pagination Pagination metadata for list endpoints. Provides information necessary to traverse through large result sets efficiently using cursor-based pagination.
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// V2RatelimitListNamespacesResponseData defines model for V2RatelimitListNamespacesResponseData.
+type V2RatelimitListNamespacesResponseData = []RatelimitNamespace
+
 // V2RatelimitListOverridesRequestBody defines model for V2RatelimitListOverridesRequestBody.
 type V2RatelimitListOverridesRequestBody struct {
 	// Cursor Pagination cursor from a previous response. Include this when fetching subsequent pages of results. Each response containing more results than the requested limit will include a cursor value in the pagination object that can be used here.
@@ -1848,6 +2090,59 @@ type V2RatelimitListOverridesResponseBody struct {
 // V2RatelimitListOverridesResponseData defines model for V2RatelimitListOverridesResponseData.
 type V2RatelimitListOverridesResponseData = []RatelimitOverride
 
+// V2RatelimitMultiLimitCheck defines model for V2RatelimitMultiLimitCheck.
+type V2RatelimitMultiLimitCheck struct {
+	// Identifier The identifier as it was given in the request.
+	Identifier string `json:"identifier"`
+
+	// Limit The maximum number of operations allowed within the time window, either from the request or from a matching override.
+	Limit int64 `json:"limit"`
+
+	// Namespace The namespace as it was given in the request.
+	Namespace string `json:"namespace"`
+
+	// OverrideId If a rate limit override was applied for this identifier, this field contains the ID of the override that was used.
+	OverrideId *string `json:"overrideId,omitempty"`
+
+	// Remaining The number of operations remaining in the current window.
+	//
+	// When the overall check failed, nothing was consumed and this reflects the state before the request.
+	Remaining int64 `json:"remaining"`
+
+	// Reset The Unix timestamp in milliseconds when the rate limit window will reset.
+	Reset int64 `json:"reset"`
+
+	// Success Whether this individual limit would allow the request.
+	Success bool `json:"success"`
+}
+
+// V2RatelimitMultiLimitRequestBody defines model for V2RatelimitMultiLimitRequestBody.
+type V2RatelimitMultiLimitRequestBody struct {
+	// Limits The rate limits to check. Tokens are only consumed if every limit passes, otherwise none of them are touched.
+	//
+	// Each combination of namespace and identifier may only appear once.
+	Limits []V2RatelimitLimitRequestBody `json:"limits"`
+}
+
+// V2RatelimitMultiLimitResponseBody defines model for V2RatelimitMultiLimitResponseBody.
+type V2RatelimitMultiLimitResponseBody struct {
+	Data V2RatelimitMultiLimitResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2RatelimitMultiLimitResponseData defines model for V2RatelimitMultiLimitResponseData.
+type V2RatelimitMultiLimitResponseData struct {
+	// Limits The result of each limit, in the same order as the request.
+	Limits []V2RatelimitMultiLimitCheck `json:"limits"`
+
+	// Passed Whether all limits passed. Only then were tokens consumed and the request should proceed.
+	//
+	// You MUST check this field to determine if the request should proceed, as the endpoint always returns `HTTP 200` even when rate limited.
+	Passed bool `json:"passed"`
+}
+
 // V2RatelimitSetOverrideRequestBody Sets a new or overwrites an existing rate limit override. Overrides allow you to apply special rate limit rules to specific identifiers, providing custom limits that differ from the default.
 //
 // Overrides are useful for:
@@ -1857,6 +2152,13 @@ type V2RatelimitListOverridesResponseData = []RatelimitOverride
 // - Implementing temporary rate limit adjustments
 // - Prioritizing important clients with higher limits
 type V2RatelimitSetOverrideRequestBody struct {
+	// Algorithm The algorithm used to enforce the rate limit.
+	//
+	// - `sliding_window`: Weighs the previous window into the current one to smooth out bursts at window boundaries. This is the default.
+	// - `fixed_window`: Counts requests in discrete windows of `duration` milliseconds. Cheaper and easier to reason about, but allows up to twice the limit around a window boundary.
+	// - `token_bucket`: Allows bursts of up to `limit` requests and refills `refillRate` tokens per `duration` continuously.
+	Algorithm *RatelimitAlgorithm `json:"algorithm,omitempty"`
+
 	// Duration The duration in milliseconds for the rate limit window. This defines how long the rate limit counter accumulates before resetting to zero.
 	//
 	// Considerations:
@@ -1893,6 +2195,11 @@ type V2RatelimitSetOverrideRequestBody struct {
 
 	// Namespace The ID or name of the rate limit namespace.
 	Namespace string `json:"namespace"`
+
+	// RefillRate The number of tokens added back per duration when using the `token_bucket` algorithm. Ignored by other algorithms.
+	//
+	// If omitted, the bucket refills `limit` tokens per `duration`.
+	RefillRate *int64 `json:"refillRate,omitempty"`
 }
 
 // V2RatelimitSetOverrideResponseBody defines model for V2RatelimitSetOverrideResponseBody.
@@ -1916,6 +2223,97 @@ type V2RatelimitSetOverrideResponseData struct {
 	OverrideId string `json:"overrideId"`
 }
 
+// V2RatelimitUpdateNamespaceRequestBody Updates the name or algorithm of an existing rate limit namespace. Fields that are omitted keep their current value.
+type V2RatelimitUpdateNamespaceRequestBody struct {
+	// Algorithm The algorithm used to enforce the rate limit.
+	//
+	// - `sliding_window`: Weighs the previous window into the current one to smooth out bursts at window boundaries. This is the default.
+	// - `fixed_window`: Counts requests in discrete windows of `duration` milliseconds. Cheaper and easier to reason about, but allows up to twice the limit around a window boundary.
+	// - `token_bucket`: Allows bursts of up to `limit` requests and refills `refillRate` tokens per `duration` continuously.
+	Algorithm *RatelimitAlgorithm `json:"algorithm,omitempty"`
+
+	// Name The new name of the namespace, unique within your workspace.
+	//
+	// Renaming takes effect immediately: callers of `ratelimit.limit` must use the new name from now on, requests with the old name are rejected.
+	Name *string `json:"name,omitempty"`
+
+	// Namespace The id or name of the namespace to update.
+	Namespace string `json:"namespace"`
+
+	// RefillRate The number of tokens added back per duration when using the `token_bucket` algorithm. Ignored by other algorithms.
+	//
+	// Changing the algorithm without a refill rate resets it to the default.
+	RefillRate *int64 `json:"refillRate,omitempty"`
+}
+
+// V2RatelimitUpdateNamespaceResponseBody defines model for V2RatelimitUpdateNamespaceResponseBody.
+type V2RatelimitUpdateNamespaceResponseBody struct {
+	Data RatelimitNamespace `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2WebhooksCreateEndpointRequestBody Creates a webhook endpoint that receives signed deliveries of the events it subscribes to.
+type V2WebhooksCreateEndpointRequestBody struct {
+	// Events The events to deliver to this endpoint.
+	Events []WebhookEvent `json:"events"`
+
+	// Url The URL events are delivered to. Must use https.
+	Url string `json:"url"`
+}
+
+// V2WebhooksCreateEndpointResponseBody defines model for V2WebhooksCreateEndpointResponseBody.
+type V2WebhooksCreateEndpointResponseBody struct {
+	Data V2WebhooksCreateEndpointResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2WebhooksCreateEndpointResponseData defines model for V2WebhooksCreateEndpointResponseData.
+type V2WebhooksCreateEndpointResponseData struct {
+	// EndpointId The unique identifier of the created endpoint.
+	EndpointId string `json:"endpointId"`
+
+	// Secret The secret deliveries are signed with. It is only returned once, store it securely.
+	//
+	// Every delivery carries an `Unkey-Signature` header of the form `t=<unix seconds>,v1=<signature>`, where the signature is the hex encoded HMAC-SHA256 of `<t>.<body>` keyed with this secret.
+	Secret string `json:"secret"`
+}
+
+// V2WebhooksDeleteEndpointRequestBody Deletes a webhook endpoint. Pending deliveries to it are dropped.
+type V2WebhooksDeleteEndpointRequestBody struct {
+	// EndpointId The id of the endpoint to delete.
+	EndpointId string `json:"endpointId"`
+}
+
+// V2WebhooksDeleteEndpointResponseBody defines model for V2WebhooksDeleteEndpointResponseBody.
+type V2WebhooksDeleteEndpointResponseBody struct {
+	// Data Empty response object. A successful response indicates the endpoint was deleted. Events are no longer delivered to it, including retries of earlier deliveries.
+	Data V2WebhooksDeleteEndpointResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2WebhooksDeleteEndpointResponseData Empty response object. A successful response indicates the endpoint was deleted. Events are no longer delivered to it, including retries of earlier deliveries.
+type V2WebhooksDeleteEndpointResponseData = map[string]interface{}
+
+// V2WebhooksListEndpointsRequestBody Lists all webhook endpoints of the workspace.
+type V2WebhooksListEndpointsRequestBody = map[string]interface{}
+
+// V2WebhooksListEndpointsResponseBody defines model for V2WebhooksListEndpointsResponseBody.
+type V2WebhooksListEndpointsResponseBody struct {
+	Data V2WebhooksListEndpointsResponseData `json:"data"`
+
+	// Meta Metadata object included in every API response. This provides context about the request and is essential for debugging, audit trails, and support inquiries. The `requestId` is particularly important when troubleshooting issues with the Unkey support team.
+	Meta Meta `json:"meta"`
+}
+
+// V2WebhooksListEndpointsResponseData defines model for V2WebhooksListEndpointsResponseData.
+type V2WebhooksListEndpointsResponseData = []WebhookEndpoint
+
 // ValidationError Individual validation error details. Each validation error provides precise information about what failed, where it failed, and how to fix it, enabling efficient error resolution.
 type ValidationError struct {
 	// Fix A human-readable suggestion describing how to fix the error. This provides practical guidance on what changes would satisfy the validation requirements. Not all validation errors include fix suggestions, but when present, they offer specific remediation advice.
@@ -1933,6 +2331,24 @@ type ValidationError struct {
 	Message string `json:"message"`
 }
 
+// VerificationsGroup defines model for VerificationsGroup.
+type VerificationsGroup struct {
+	// Count The number of verifications in this group.
+	Count int64 `json:"count"`
+
+	// KeyId The key ID. Only set when grouping by `key`.
+	KeyId *string `json:"keyId,omitempty"`
+
+	// Outcome The verification outcome. Only set when grouping by `outcome`.
+	Outcome *string `json:"outcome,omitempty"`
+
+	// Tag The tag. Only set when grouping by `tag`.
+	Tag *string `json:"tag,omitempty"`
+
+	// Time Start of the time bucket in Unix milliseconds. Only set when grouping by `time`.
+	Time *int64 `json:"time,omitempty"`
+}
+
 // VerifyKeyRatelimitData defines model for VerifyKeyRatelimitData.
 type VerifyKeyRatelimitData struct {
 	// AutoApply Whether this rate limit should be automatically applied when verifying keys.
@@ -1961,6 +2377,34 @@ type VerifyKeyRatelimitData struct {
 	Reset int64 `json:"reset"`
 }
 
+// WebhookEndpoint defines model for WebhookEndpoint.
+type WebhookEndpoint struct {
+	// CreatedAt Unix timestamp in milliseconds when the endpoint was created.
+	CreatedAt int64 `json:"createdAt"`
+
+	// Enabled Whether events are delivered to this endpoint.
+	Enabled bool `json:"enabled"`
+
+	// EndpointId The unique identifier of the endpoint.
+	EndpointId string `json:"endpointId"`
+
+	// Events The events delivered to this endpoint.
+	Events []WebhookEvent `json:"events"`
+
+	// Url The URL events are delivered to.
+	Url string `json:"url"`
+}
+
+// WebhookEvent An event webhook endpoints can subscribe to.
+//
+// - `key.expiring_soon`: A key expires within the next days. Sent once per expiration date.
+// - `key.expired`: A key expired. Sent once per expiration date.
+// - `key.credits_low`: A key is running low on credits. Sent once per refill period.
+// - `key.credits_exhausted`: A key has no credits left. Sent once per refill period.
+// - `key.create`: A key was created.
+// - `key.delete`: A key was deleted.
+type WebhookEvent string
+
 // ChproxyMetricsJSONRequestBody defines body for ChproxyMetrics for application/json ContentType.
 type ChproxyMetricsJSONRequestBody = ChproxyMetricsRequestBody
 
@@ -1970,6 +2414,9 @@ type ChproxyRatelimitsJSONRequestBody = ChproxyRatelimitsRequestBody
 // ChproxyVerificationsJSONRequestBody defines body for ChproxyVerifications for application/json ContentType.
 type ChproxyVerificationsJSONRequestBody = ChproxyVerificationsRequestBody
 
+// GetVerificationsJSONRequestBody defines body for GetVerifications for application/json ContentType.
+type GetVerificationsJSONRequestBody = V2AnalyticsGetVerificationsRequestBody
+
 // CreateApiJSONRequestBody defines body for CreateApi for application/json ContentType.
 type CreateApiJSONRequestBody = V2ApisCreateApiRequestBody
 
@@ -2036,6 +2483,9 @@ type UpdateKeyJSONRequestBody = V2KeysUpdateKeyRequestBody
 // VerifyKeyJSONRequestBody defines body for VerifyKey for application/json ContentType.
 type VerifyKeyJSONRequestBody = V2KeysVerifyKeyRequestBody
 
+// VerifyKeysJSONRequestBody defines body for VerifyKeys for application/json ContentType.
+type VerifyKeysJSONRequestBody = V2KeysVerifyKeysRequestBody
+
 // WhoamiJSONRequestBody defines body for Whoami for application/json ContentType.
 type WhoamiJSONRequestBody = V2KeysWhoamiRequestBody
 
@@ -2063,17 +2513,44 @@ type ListPermissionsJSONRequestBody = V2PermissionsListPermissionsRequestBody
 // ListRolesJSONRequestBody defines body for ListRoles for application/json ContentType.
 type ListRolesJSONRequestBody = V2PermissionsListRolesRequestBody
 
+// RatelimitCreateNamespaceJSONRequestBody defines body for RatelimitCreateNamespace for application/json ContentType.
+type RatelimitCreateNamespaceJSONRequestBody = V2RatelimitCreateNamespaceRequestBody
+
+// RatelimitDeleteNamespaceJSONRequestBody defines body for RatelimitDeleteNamespace for application/json ContentType.
+type RatelimitDeleteNamespaceJSONRequestBody = V2RatelimitDeleteNamespaceRequestBody
+
 // RatelimitDeleteOverrideJSONRequestBody defines body for RatelimitDeleteOverride for application/json ContentType.
 type RatelimitDeleteOverrideJSONRequestBody = V2RatelimitDeleteOverrideRequestBody
 
+// RatelimitGetNamespaceJSONRequestBody defines body for RatelimitGetNamespace for application/json ContentType.
+type RatelimitGetNamespaceJSONRequestBody = V2RatelimitGetNamespaceRequestBody
+
 // RatelimitGetOverrideJSONRequestBody defines body for RatelimitGetOverride for application/json ContentType.
 type RatelimitGetOverrideJSONRequestBody = V2RatelimitGetOverrideRequestBody
 
 // RatelimitLimitJSONRequestBody defines body for RatelimitLimit for application/json ContentType.
 type RatelimitLimitJSONRequestBody = V2RatelimitLimitRequestBody
 
+// RatelimitListNamespacesJSONRequestBody defines body for RatelimitListNamespaces for application/json ContentType.
+type RatelimitListNamespacesJSONRequestBody = V2RatelimitListNamespacesRequestBody
+
 // RatelimitListOverridesJSONRequestBody defines body for RatelimitListOverrides for application/json ContentType.
 type RatelimitListOverridesJSONRequestBody = V2RatelimitListOverridesRequestBody
 
+// RatelimitMultiLimitJSONRequestBody defines body for RatelimitMultiLimit for application/json ContentType.
+type RatelimitMultiLimitJSONRequestBody = V2RatelimitMultiLimitRequestBody
+
 // RatelimitSetOverrideJSONRequestBody defines body for RatelimitSetOverride for application/json ContentType.
 type RatelimitSetOverrideJSONRequestBody = V2RatelimitSetOverrideRequestBody
+
+// RatelimitUpdateNamespaceJSONRequestBody defines body for RatelimitUpdateNamespace for application/json ContentType.
+type RatelimitUpdateNamespaceJSONRequestBody = V2RatelimitUpdateNamespaceRequestBody
+
+// WebhooksCreateEndpointJSONRequestBody defines body for WebhooksCreateEndpoint for application/json ContentType.
+type WebhooksCreateEndpointJSONRequestBody = V2WebhooksCreateEndpointRequestBody
+
+// WebhooksDeleteEndpointJSONRequestBody defines body for WebhooksDeleteEndpoint for application/json ContentType.
+type WebhooksDeleteEndpointJSONRequestBody = V2WebhooksDeleteEndpointRequestBody
+
+// WebhooksListEndpointsJSONRequestBody defines body for WebhooksListEndpoints for application/json ContentType.
+type WebhooksListEndpointsJSONRequestBody = V2WebhooksListEndpointsRequestBody
@@ -9,11 +9,19 @@ import (
 	chproxyRatelimits "github.com/unkeyed/unkey/go/apps/api/routes/chproxy_ratelimits"
 	chproxyVerifications "github.com/unkeyed/unkey/go/apps/api/routes/chproxy_verifications"
 
+	v2RatelimitCreateNamespace "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_create_namespace"
+	v2RatelimitDeleteNamespace "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_delete_namespace"
 	v2RatelimitDeleteOverride "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_delete_override"
+	v2RatelimitGetNamespace "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_get_namespace"
 	v2RatelimitGetOverride "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_get_override"
 	v2RatelimitLimit "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_limit"
+	v2RatelimitListNamespaces "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_list_namespaces"
 	v2RatelimitListOverrides "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_list_overrides"
+	v2RatelimitMultiLimit "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_multi_limit"
 	v2RatelimitSetOverride "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_set_override"
+	v2RatelimitUpdateNamespace "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_update_namespace"
+
+	v2AnalyticsGetVerifications "github.com/unkeyed/unkey/go/apps/api/routes/v2_analytics_get_verifications"
 
 	v2ApisCreateApi "github.com/unkeyed/unkey/go/apps/api/routes/v2_apis_create_api"
 	v2ApisDeleteApi "github.com/unkeyed/unkey/go/apps/api/routes/v2_apis_delete_api"
@@ -48,8 +56,13 @@ import (
 	v2KeysUpdateCredits "github.com/unkeyed/unkey/go/apps/api/routes/v2_keys_update_credits"
 	v2KeysUpdateKey "github.com/unkeyed/unkey/go/apps/api/routes/v2_keys_update_key"
 	v2KeysVerifyKey "github.com/unkeyed/unkey/go/apps/api/routes/v2_keys_verify_key"
+	v2KeysVerifyKeys "github.com/unkeyed/unkey/go/apps/api/routes/v2_keys_verify_keys"
 	v2KeysWhoami "github.com/unkeyed/unkey/go/apps/api/routes/v2_keys_whoami"
 
+	v2WebhooksCreateEndpoint "github.com/unkeyed/unkey/go/apps/api/routes/v2_webhooks_create_endpoint"
+	v2WebhooksDeleteEndpoint "github.com/unkeyed/unkey/go/apps/api/routes/v2_webhooks_delete_endpoint"
+	v2WebhooksListEndpoints "github.com/unkeyed/unkey/go/apps/api/routes/v2_webhooks_list_endpoints"
+
 	zen "github.com/unkeyed/unkey/go/pkg/zen"
 )
 
@@ -125,6 +138,20 @@ func Register(srv *zen.Server, svc *Services) {
 		},
 	)
 
+	// v2/ratelimit.multiLimit
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2RatelimitMultiLimit.Handler{
+			Logger:                  svc.Logger,
+			DB:                      svc.Database,
+			Keys:                    svc.Keys,
+			ClickHouse:              svc.ClickHouse,
+			Ratelimit:               svc.Ratelimit,
+			RatelimitNamespaceCache: svc.Caches.RatelimitNamespace,
+			TestMode:                srv.Flags().TestMode,
+		},
+	)
+
 	// v2/ratelimit.setOverride
 	srv.RegisterRoute(
 		defaultMiddlewares,
@@ -170,6 +197,62 @@ func Register(srv *zen.Server, svc *Services) {
 		},
 	)
 
+	// v2/ratelimit.createNamespace
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2RatelimitCreateNamespace.Handler{
+			Logger:                  svc.Logger,
+			DB:                      svc.Database,
+			Keys:                    svc.Keys,
+			Auditlogs:               svc.Auditlogs,
+			RatelimitNamespaceCache: svc.Caches.RatelimitNamespace,
+		},
+	)
+
+	// v2/ratelimit.getNamespace
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2RatelimitGetNamespace.Handler{
+			Logger: svc.Logger,
+			DB:     svc.Database,
+			Keys:   svc.Keys,
+		},
+	)
+
+	// v2/ratelimit.listNamespaces
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2RatelimitListNamespaces.Handler{
+			Logger: svc.Logger,
+			DB:     svc.Database,
+			Keys:   svc.Keys,
+		},
+	)
+
+	// v2/ratelimit.updateNamespace
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2RatelimitUpdateNamespace.Handler{
+			Logger:                  svc.Logger,
+			DB:                      svc.Database,
+			Keys:                    svc.Keys,
+			Auditlogs:               svc.Auditlogs,
+			RatelimitNamespaceCache: svc.Caches.RatelimitNamespace,
+		},
+	)
+
+	// v2/ratelimit.deleteNamespace
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2RatelimitDeleteNamespace.Handler{
+			Logger:                  svc.Logger,
+			DB:                      svc.Database,
+			Keys:                    svc.Keys,
+			Auditlogs:               svc.Auditlogs,
+			RatelimitNamespaceCache: svc.Caches.RatelimitNamespace,
+		},
+	)
+
 	// ---------------------------------------------------------------------------
 	// v2/identities
 
@@ -226,6 +309,20 @@ func Register(srv *zen.Server, svc *Services) {
 		},
 	)
 
+	// ---------------------------------------------------------------------------
+	// v2/analytics
+
+	// v2/analytics.getVerifications
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2AnalyticsGetVerifications.Handler{
+			Logger:     svc.Logger,
+			DB:         svc.Database,
+			Keys:       svc.Keys,
+			ClickHouse: svc.ClickHouse,
+		},
+	)
+
 	// ---------------------------------------------------------------------------
 	// v2/apis
 
@@ -375,6 +472,15 @@ func Register(srv *zen.Server, svc *Services) {
 		},
 	)
 
+	// v2/keys.verifyKeys
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2KeysVerifyKeys.Handler{
+			Logger: svc.Logger,
+			Keys:   svc.Keys,
+		},
+	)
+
 	// v2/keys.createKey
 	srv.RegisterRoute(
 		defaultMiddlewares,
@@ -531,6 +637,42 @@ func Register(srv *zen.Server, svc *Services) {
 		},
 	)
 
+	// ---------------------------------------------------------------------------
+	// v2/webhooks
+
+	// v2/webhooks.createEndpoint
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2WebhooksCreateEndpoint.Handler{
+			Logger:    svc.Logger,
+			DB:        svc.Database,
+			Keys:      svc.Keys,
+			Auditlogs: svc.Auditlogs,
+			Vault:     svc.Vault,
+		},
+	)
+
+	// v2/webhooks.listEndpoints
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2WebhooksListEndpoints.Handler{
+			Logger: svc.Logger,
+			DB:     svc.Database,
+			Keys:   svc.Keys,
+		},
+	)
+
+	// v2/webhooks.deleteEndpoint
+	srv.RegisterRoute(
+		defaultMiddlewares,
+		&v2WebhooksDeleteEndpoint.Handler{
+			Logger:    svc.Logger,
+			DB:        svc.Database,
+			Keys:      svc.Keys,
+			Auditlogs: svc.Auditlogs,
+		},
+	)
+
 	// ---------------------------------------------------------------------------
 	// misc
 
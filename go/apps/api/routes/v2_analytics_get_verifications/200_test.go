@@ -0,0 +1,147 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_analytics_get_verifications"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/testutil/seed"
+)
+
+func TestGetVerificationsSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		Logger:     h.Logger,
+		DB:         h.DB,
+		Keys:       h.Keys,
+		ClickHouse: h.ClickHouse,
+	}
+
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	otherApi := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.read_key")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	now := time.Now().UTC().Truncate(time.Minute)
+
+	// Writing to the aggregate table directly, the raw table is only
+	// aggregated after the buffer is flushed.
+	insert := func(keySpaceID, keyID, outcome string, tags []string, count int64, at time.Time) {
+		err := h.ClickHouse.Conn().Exec(ctx, `
+			INSERT INTO verifications.key_verifications_per_minute_v1
+			(time, workspace_id, key_space_id, identity_id, key_id, outcome, tags, count)
+			VALUES (?, ?, ?, '', ?, ?, ?, ?)`,
+			at, workspace.ID, keySpaceID, keyID, outcome, tags, count,
+		)
+		require.NoError(t, err)
+	}
+
+	insert(api.KeyAuthID.String, "key_1", "VALID", []string{"path=/a"}, 10, now.Add(-2*time.Minute))
+	insert(api.KeyAuthID.String, "key_1", "RATE_LIMITED", []string{"path=/a", "region=eu"}, 3, now.Add(-time.Minute))
+	insert(api.KeyAuthID.String, "key_2", "VALID", []string{}, 5, now.Add(-time.Minute))
+	insert(otherApi.KeyAuthID.String, "key_3", "VALID", []string{}, 7, now)
+
+	start := now.Add(-time.Hour).UnixMilli()
+	end := now.Add(time.Minute).UnixMilli()
+
+	t.Run("total without grouping", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Start: start,
+			End:   end,
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.Minute, res.Body.Data.Granularity)
+		require.Len(t, res.Body.Data.Verifications, 1)
+		require.Equal(t, int64(25), res.Body.Data.Verifications[0].Count)
+		require.Nil(t, res.Body.Data.Verifications[0].Time)
+	})
+
+	t.Run("filter by api and group by outcome", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Start:   start,
+			End:     end,
+			ApiId:   ptr.P(api.ID),
+			GroupBy: ptr.P([]openapi.AnalyticsGroupBy{openapi.Outcome}),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Len(t, res.Body.Data.Verifications, 2)
+
+		counts := map[string]int64{}
+		for _, v := range res.Body.Data.Verifications {
+			require.NotNil(t, v.Outcome)
+			require.Nil(t, v.KeyId)
+			counts[*v.Outcome] = v.Count
+		}
+		require.Equal(t, map[string]int64{"VALID": 15, "RATE_LIMITED": 3}, counts)
+	})
+
+	t.Run("group by time", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Start:   start,
+			End:     end,
+			GroupBy: ptr.P([]openapi.AnalyticsGroupBy{openapi.Time}),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Len(t, res.Body.Data.Verifications, 3)
+
+		require.Equal(t, now.Add(-2*time.Minute).UnixMilli(), *res.Body.Data.Verifications[0].Time)
+		require.Equal(t, int64(10), res.Body.Data.Verifications[0].Count)
+		require.Equal(t, now.Add(-time.Minute).UnixMilli(), *res.Body.Data.Verifications[1].Time)
+		require.Equal(t, int64(8), res.Body.Data.Verifications[1].Count)
+		require.Equal(t, now.UnixMilli(), *res.Body.Data.Verifications[2].Time)
+		require.Equal(t, int64(7), res.Body.Data.Verifications[2].Count)
+	})
+
+	t.Run("group by tag", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Start:   start,
+			End:     end,
+			GroupBy: ptr.P([]openapi.AnalyticsGroupBy{openapi.Tag}),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+		counts := map[string]int64{}
+		for _, v := range res.Body.Data.Verifications {
+			counts[*v.Tag] = v.Count
+		}
+		require.Equal(t, map[string]int64{"path=/a": 13, "region=eu": 3}, counts)
+	})
+
+	t.Run("filter by key and tags", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Start:  start,
+			End:    end,
+			KeyIds: ptr.P([]string{"key_1"}),
+			Tags:   ptr.P([]string{"region=eu"}),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Len(t, res.Body.Data.Verifications, 1)
+		require.Equal(t, int64(3), res.Body.Data.Verifications[0].Count)
+	})
+
+	t.Run("long time ranges use coarser tables", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Start: now.Add(-7 * 24 * time.Hour).UnixMilli(),
+			End:   end,
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.Hour, res.Body.Data.Granularity)
+	})
+}
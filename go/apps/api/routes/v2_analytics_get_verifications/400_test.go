@@ -0,0 +1,65 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_analytics_get_verifications"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+)
+
+func TestBadRequests(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		Logger:     h.Logger,
+		DB:         h.DB,
+		Keys:       h.Keys,
+		ClickHouse: h.ClickHouse,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "api.*.read_key")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	now := time.Now()
+
+	t.Run("end before start", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			Start: now.UnixMilli(),
+			End:   now.Add(-time.Hour).UnixMilli(),
+		})
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+
+	t.Run("time range too long for granularity", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			Start:       now.Add(-48 * time.Hour).UnixMilli(),
+			End:         now.UnixMilli(),
+			Granularity: ptr.P(openapi.Minute),
+		})
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+
+	t.Run("unknown group by", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			Start:   now.Add(-time.Hour).UnixMilli(),
+			End:     now.UnixMilli(),
+			GroupBy: ptr.P([]openapi.AnalyticsGroupBy{"region"}),
+		})
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+}
@@ -0,0 +1,70 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_analytics_get_verifications"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/testutil/seed"
+)
+
+func TestForbidden(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		Logger:     h.Logger,
+		DB:         h.DB,
+		Keys:       h.Keys,
+		ClickHouse: h.ClickHouse,
+	}
+
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	otherApi := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	// Only allowed to read the verifications of a single API
+	rootKey := h.CreateRootKey(workspace.ID, fmt.Sprintf("api.%s.read_key", api.ID))
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	now := time.Now()
+
+	t.Run("allowed api", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Start: now.Add(-time.Hour).UnixMilli(),
+			End:   now.UnixMilli(),
+			ApiId: ptr.P(api.ID),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+	})
+
+	t.Run("other api", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.ForbiddenErrorResponse](h, route, headers, handler.Request{
+			Start: now.Add(-time.Hour).UnixMilli(),
+			End:   now.UnixMilli(),
+			ApiId: ptr.P(otherApi.ID),
+		})
+		require.Equal(t, 403, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+
+	t.Run("all apis", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.ForbiddenErrorResponse](h, route, headers, handler.Request{
+			Start: now.Add(-time.Hour).UnixMilli(),
+			End:   now.UnixMilli(),
+		})
+		require.Equal(t, 403, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/pkg/clickhouse"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2AnalyticsGetVerificationsRequestBody
+type Response = openapi.V2AnalyticsGetVerificationsResponseBody
+
+// DefaultLimit is the maximum number of groups returned if the request
+// doesn't specify a limit.
+const DefaultLimit = 1000
+
+// Handler implements zen.Route interface for the v2 analytics get verifications endpoint
+type Handler struct {
+	// Services as public fields
+	Logger     logging.Logger
+	DB         db.Database
+	Keys       keys.KeyService
+	ClickHouse clickhouse.ClickHouse
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/analytics.getVerifications"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	h.Logger.Debug("handling request", "requestId", s.RequestID(), "path", "/v2/analytics.getVerifications")
+
+	auth, emit, err := h.Keys.GetRootKey(ctx, s)
+	defer emit()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	// Without an API filter the root key must be able to read the analytics
+	// of every API in the workspace
+	permissions := rbac.T(rbac.Tuple{
+		ResourceType: rbac.Api,
+		ResourceID:   "*",
+		Action:       rbac.ReadKey,
+	})
+	if req.ApiId != nil {
+		permissions = rbac.Or(
+			permissions,
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Api,
+				ResourceID:   *req.ApiId,
+				Action:       rbac.ReadKey,
+			}),
+		)
+	}
+
+	err = auth.VerifyRootKey(ctx, keys.WithPermissions(permissions))
+	if err != nil {
+		return err
+	}
+
+	start := time.UnixMilli(req.Start)
+	end := time.UnixMilli(req.End)
+	if end.Before(start) {
+		return fault.New("end before start",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Public("The end of the time range must not be before its start."),
+		)
+	}
+
+	granularity := clickhouse.PickGranularity(start, end)
+	if req.Granularity != nil {
+		granularity = clickhouse.Granularity(*req.Granularity)
+	}
+
+	err = granularity.Validate(start, end)
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("invalid granularity"),
+			fault.Public("The time range is too long for the requested granularity, use a coarser granularity or a shorter time range."),
+		)
+	}
+
+	keySpaceID := ""
+	if req.ApiId != nil {
+		api, findErr := db.Query.FindApiByID(ctx, h.DB.RO(), *req.ApiId)
+		if findErr != nil && !db.IsNotFound(findErr) {
+			return fault.Wrap(findErr,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database error"), fault.Public("Failed to retrieve API information."),
+			)
+		}
+
+		// Masking APIs of other workspaces as not found
+		if db.IsNotFound(findErr) || api.WorkspaceID != auth.AuthorizedWorkspaceID || api.DeletedAtM.Valid {
+			return fault.New("api not found",
+				fault.Code(codes.Data.Api.NotFound.URN()),
+				fault.Internal("api not found"), fault.Public("The requested API does not exist or has been deleted."),
+			)
+		}
+
+		keySpaceID = api.KeyAuthID.String
+	}
+
+	groupBy := []clickhouse.VerificationsGroupBy{}
+	for _, g := range ptr.SafeDeref(req.GroupBy) {
+		groupBy = append(groupBy, clickhouse.VerificationsGroupBy(g))
+	}
+
+	limit := DefaultLimit
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	rows, err := h.ClickHouse.GetVerifications(ctx, clickhouse.VerificationsRequest{
+		WorkspaceID: auth.AuthorizedWorkspaceID,
+		Start:       start,
+		End:         end,
+		Granularity: granularity,
+		KeySpaceID:  keySpaceID,
+		KeyIDs:      ptr.SafeDeref(req.KeyIds),
+		IdentityIDs: ptr.SafeDeref(req.IdentityIds),
+		Outcomes:    ptr.SafeDeref(req.Outcomes),
+		Tags:        ptr.SafeDeref(req.Tags),
+		GroupBy:     groupBy,
+		Limit:       limit,
+	})
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Public("We're unable to load the verifications right now."),
+		)
+	}
+
+	verifications := make([]openapi.VerificationsGroup, len(rows))
+	for i, row := range rows {
+		verifications[i] = openapi.VerificationsGroup{
+			Count:   row.Count,
+			Time:    nil,
+			Outcome: nil,
+			Tag:     nil,
+			KeyId:   nil,
+		}
+
+		for _, g := range groupBy {
+			switch g {
+			case clickhouse.GroupByTime:
+				verifications[i].Time = ptr.P(row.Time.UnixMilli())
+			case clickhouse.GroupByOutcome:
+				verifications[i].Outcome = ptr.P(row.Outcome)
+			case clickhouse.GroupByTag:
+				verifications[i].Tag = ptr.P(row.Tag)
+			case clickhouse.GroupByKey:
+				verifications[i].KeyId = ptr.P(row.KeyID)
+			}
+		}
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2AnalyticsGetVerificationsResponseData{
+			Granularity:   openapi.AnalyticsGranularity(granularity),
+			Verifications: verifications,
+		},
+	})
+}
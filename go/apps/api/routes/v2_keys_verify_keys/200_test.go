@@ -0,0 +1,309 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_keys_verify_keys"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/pkg/clickhouse"
+	"github.com/unkeyed/unkey/go/pkg/clickhouse/schema"
+	"github.com/unkeyed/unkey/go/pkg/counter"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/testutil/containers"
+	"github.com/unkeyed/unkey/go/pkg/testutil/seed"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestSuccess(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		Keys:   h.Keys,
+		Logger: h.Logger,
+	}
+
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.verify_key")
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("results are returned in request order", func(t *testing.T) {
+		valid := h.CreateKey(seed.CreateKeyRequest{
+			WorkspaceID: workspace.ID,
+			KeyAuthID:   api.KeyAuthID.String,
+		})
+		expired := h.CreateKey(seed.CreateKeyRequest{
+			WorkspaceID: workspace.ID,
+			KeyAuthID:   api.KeyAuthID.String,
+			Expires:     ptr.P(time.Now().Add(-time.Hour)),
+		})
+		disabled := h.CreateKey(seed.CreateKeyRequest{
+			WorkspaceID: workspace.ID,
+			KeyAuthID:   api.KeyAuthID.String,
+			Disabled:    true,
+		})
+
+		req := handler.Request{
+			Keys: []openapi.V2KeysVerifyKeyRequestBody{
+				{Key: valid.Key},
+				{Key: uid.New("test")},
+				{Key: expired.Key},
+				{Key: disabled.Key},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Len(t, res.Body.Data, 4)
+
+		require.Equal(t, openapi.VALID, res.Body.Data[0].Code)
+		require.True(t, res.Body.Data[0].Valid)
+		require.Equal(t, valid.KeyID, ptr.SafeDeref(res.Body.Data[0].KeyId))
+
+		require.Equal(t, openapi.NOTFOUND, res.Body.Data[1].Code)
+		require.Equal(t, openapi.EXPIRED, res.Body.Data[2].Code)
+		require.Equal(t, openapi.DISABLED, res.Body.Data[3].Code)
+		for _, data := range res.Body.Data[1:] {
+			require.False(t, data.Valid)
+		}
+	})
+
+	t.Run("every occurrence of a key is charged", func(t *testing.T) {
+		key := h.CreateKey(seed.CreateKeyRequest{
+			WorkspaceID: workspace.ID,
+			KeyAuthID:   api.KeyAuthID.String,
+			Remaining:   ptr.P(int32(3)),
+		})
+
+		req := handler.Request{
+			Keys: []openapi.V2KeysVerifyKeyRequestBody{
+				{Key: key.Key},
+				{Key: key.Key, Credits: &openapi.KeysVerifyKeyCredits{Cost: 2}},
+				{Key: key.Key},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Len(t, res.Body.Data, 3)
+
+		require.Equal(t, openapi.VALID, res.Body.Data[0].Code)
+		require.Equal(t, int32(2), ptr.SafeDeref(res.Body.Data[0].Credits))
+
+		require.Equal(t, openapi.VALID, res.Body.Data[1].Code)
+		require.Equal(t, int32(0), ptr.SafeDeref(res.Body.Data[1].Credits))
+
+		require.Equal(t, openapi.USAGEEXCEEDED, res.Body.Data[2].Code)
+		require.False(t, res.Body.Data[2].Valid)
+	})
+
+	t.Run("permissions are checked per key", func(t *testing.T) {
+		key := h.CreateKey(seed.CreateKeyRequest{
+			WorkspaceID: workspace.ID,
+			KeyAuthID:   api.KeyAuthID.String,
+			Permissions: []seed.CreatePermissionRequest{{
+				Name:        "documents.read",
+				Slug:        "documents.read",
+				Description: nil,
+				WorkspaceID: workspace.ID,
+			}},
+		})
+
+		req := handler.Request{
+			Keys: []openapi.V2KeysVerifyKeyRequestBody{
+				{Key: key.Key, Permissions: ptr.P("documents.read")},
+				{Key: key.Key, Permissions: ptr.P("documents.write")},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Len(t, res.Body.Data, 2)
+
+		require.Equal(t, openapi.VALID, res.Body.Data[0].Code)
+		require.Equal(t, openapi.INSUFFICIENTPERMISSIONS, res.Body.Data[1].Code)
+	})
+
+	t.Run("keys of apis the root key can't verify are not found", func(t *testing.T) {
+		otherApi := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+		limitedRootKey := h.CreateRootKey(workspace.ID, fmt.Sprintf("api.%s.verify_key", api.ID))
+
+		allowed := h.CreateKey(seed.CreateKeyRequest{
+			WorkspaceID: workspace.ID,
+			KeyAuthID:   api.KeyAuthID.String,
+		})
+		forbidden := h.CreateKey(seed.CreateKeyRequest{
+			WorkspaceID: workspace.ID,
+			KeyAuthID:   otherApi.KeyAuthID.String,
+		})
+
+		req := handler.Request{
+			Keys: []openapi.V2KeysVerifyKeyRequestBody{
+				{Key: forbidden.Key},
+				{Key: allowed.Key},
+			},
+		}
+
+		limitedHeaders := http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", limitedRootKey)},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, limitedHeaders, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Len(t, res.Body.Data, 2)
+
+		require.Equal(t, openapi.NOTFOUND, res.Body.Data[0].Code)
+		require.Equal(t, openapi.VALID, res.Body.Data[1].Code)
+	})
+
+	t.Run("keys of other workspaces are not found", func(t *testing.T) {
+		otherWorkspace := h.CreateWorkspace()
+		otherApi := h.CreateApi(seed.CreateApiRequest{WorkspaceID: otherWorkspace.ID})
+		key := h.CreateKey(seed.CreateKeyRequest{
+			WorkspaceID: otherWorkspace.ID,
+			KeyAuthID:   otherApi.KeyAuthID.String,
+		})
+
+		req := handler.Request{
+			Keys: []openapi.V2KeysVerifyKeyRequestBody{{Key: key.Key}},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Len(t, res.Body.Data, 1)
+		require.Equal(t, openapi.NOTFOUND, res.Body.Data[0].Code)
+	})
+}
+
+// recordingClickHouse records buffered key verifications instead of sending
+// them to ClickHouse.
+type recordingClickHouse struct {
+	clickhouse.ClickHouse
+
+	mu            sync.Mutex
+	verifications []schema.KeyVerificationRequestV1
+}
+
+func (r *recordingClickHouse) BufferKeyVerification(req schema.KeyVerificationRequestV1) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.verifications = append(r.verifications, req)
+}
+
+func (r *recordingClickHouse) keyIDs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, len(r.verifications))
+	for i, v := range r.verifications {
+		ids[i] = v.KeyID
+	}
+
+	return ids
+}
+
+func TestOnlyVerifiedKeysAreLogged(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	ctr, err := counter.NewRedis(counter.RedisConfig{
+		RedisURL: containers.Redis(t),
+		Logger:   h.Logger,
+	})
+	require.NoError(t, err)
+
+	ch := &recordingClickHouse{ClickHouse: h.ClickHouse}
+
+	keyService, err := keys.New(keys.Config{
+		Logger:      h.Logger,
+		DB:          h.DB,
+		KeyCache:    h.Caches.VerificationKeyByHash,
+		RateLimiter: h.Ratelimit,
+		RBAC:        rbac.New(),
+		Clickhouse:  ch,
+		Region:      "test",
+		Counter:     ctr,
+	})
+	require.NoError(t, err)
+
+	route := &handler.Handler{
+		Keys:   keyService,
+		Logger: h.Logger,
+	}
+
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	forbiddenApi := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+	rootKey := h.CreateRootKey(workspace.ID, fmt.Sprintf("api.%s.verify_key", api.ID))
+
+	otherWorkspace := h.CreateWorkspace()
+	otherApi := h.CreateApi(seed.CreateApiRequest{WorkspaceID: otherWorkspace.ID})
+
+	valid := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeyAuthID:   api.KeyAuthID.String,
+	})
+	forbidden := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeyAuthID:   forbiddenApi.KeyAuthID.String,
+	})
+	foreign := h.CreateKey(seed.CreateKeyRequest{
+		WorkspaceID: otherWorkspace.ID,
+		KeyAuthID:   otherApi.KeyAuthID.String,
+	})
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("keys of other workspaces are not logged", func(t *testing.T) {
+		req := handler.Request{
+			Keys: []openapi.V2KeysVerifyKeyRequestBody{
+				{Key: foreign.Key},
+				{Key: forbidden.Key},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.NOTFOUND, res.Body.Data[0].Code)
+		require.Equal(t, openapi.NOTFOUND, res.Body.Data[1].Code)
+
+		require.NotContains(t, ch.keyIDs(), foreign.KeyID)
+		require.NotContains(t, ch.keyIDs(), forbidden.KeyID)
+	})
+
+	t.Run("verified keys are logged", func(t *testing.T) {
+		req := handler.Request{
+			Keys: []openapi.V2KeysVerifyKeyRequestBody{
+				{Key: foreign.Key},
+				{Key: valid.Key},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.VALID, res.Body.Data[1].Code)
+
+		require.Contains(t, ch.keyIDs(), valid.KeyID)
+		require.NotContains(t, ch.keyIDs(), foreign.KeyID)
+	})
+}
@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_keys_verify_keys"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/testutil/seed"
+)
+
+func TestBadRequest(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		Keys:   h.Keys,
+		Logger: h.Logger,
+	}
+
+	h.Register(route)
+
+	workspace := h.Resources().UserWorkspace
+	rootKey := h.CreateRootKey(workspace.ID, "api.*.verify_key")
+	api := h.CreateApi(seed.CreateApiRequest{WorkspaceID: workspace.ID})
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("no keys", func(t *testing.T) {
+		req := handler.Request{
+			Keys: []openapi.V2KeysVerifyKeyRequestBody{},
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+
+	t.Run("too many keys", func(t *testing.T) {
+		req := handler.Request{
+			Keys: make([]openapi.V2KeysVerifyKeyRequestBody, 101),
+		}
+		for i := range req.Keys {
+			req.Keys[i].Key = fmt.Sprintf("sk_%d", i)
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+
+	t.Run("invalid permissions query charges no key", func(t *testing.T) {
+		key := h.CreateKey(seed.CreateKeyRequest{
+			WorkspaceID: workspace.ID,
+			KeyAuthID:   api.KeyAuthID.String,
+			Remaining:   ptr.P(int32(5)),
+		})
+
+		req := handler.Request{
+			Keys: []openapi.V2KeysVerifyKeyRequestBody{
+				{Key: key.Key},
+				{Key: key.Key, Permissions: ptr.P("documents.read AND")},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body.Error)
+
+		verify := handler.Request{
+			Keys: []openapi.V2KeysVerifyKeyRequestBody{{Key: key.Key}},
+		}
+
+		verified := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, verify)
+		require.Equal(t, 200, verified.Status)
+		require.Len(t, verified.Body.Data, 1)
+		require.Equal(t, int32(4), ptr.SafeDeref(verified.Body.Data[0].Credits))
+	})
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2KeysVerifyKeysRequestBody
+type Response = openapi.V2KeysVerifyKeysResponseBody
+
+const DefaultCost = 1
+
+// Handler implements zen.Route interface for the v2 keys.verifyKeys endpoint
+type Handler struct {
+	Logger logging.Logger
+	Keys   keys.KeyService
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/keys.verifyKeys"
+}
+
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	h.Logger.Debug("handling request", "requestId", s.RequestID(), "path", "/v2/keys.verifyKeys")
+
+	// Authentication
+	auth, rootEmit, err := h.Keys.GetRootKey(ctx, s)
+	defer rootEmit()
+	if err != nil {
+		return err
+	}
+
+	// Request validation
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	// Parse all permission queries before verifying anything, so a syntax
+	// error doesn't leave the request half way charged
+	queries := make([]*rbac.PermissionQuery, len(req.Keys))
+	rawKeys := make([]string, len(req.Keys))
+	for i, item := range req.Keys {
+		rawKeys[i] = item.Key
+
+		if item.Permissions == nil {
+			continue
+		}
+
+		query, parseErr := rbac.ParseQuery(*item.Permissions)
+		if parseErr != nil {
+			return fault.Wrap(parseErr,
+				fault.Code(codes.User.BadRequest.PermissionsQuerySyntaxError.URN()),
+				fault.Internal(fmt.Sprintf("failed to parse permissions query of key %d: %s", i, *item.Permissions)),
+			)
+		}
+
+		queries[i] = &query
+	}
+
+	// Every distinct key is loaded once, but each occurrence is verified on
+	// its own
+	verifiers, emits, err := h.Keys.GetMany(ctx, s, rawKeys)
+	if err != nil {
+		return err
+	}
+
+	// The root key needs permission to verify keys of each API, which is only
+	// evaluated once per API
+	canVerify := make(map[string]bool)
+
+	// Only keys that were verified are logged, keys reported as not found
+	// must not show up in anyone's analytics
+	verified := make([]func(), 0, len(req.Keys))
+
+	data := make([]openapi.V2KeysVerifyKeyResponseData, len(req.Keys))
+	for i, item := range req.Keys {
+		key := verifiers[i]
+
+		// Keys of other workspaces, deleted APIs and APIs the root key may
+		// not verify are all reported as not found, so their existence
+		// doesn't leak
+		if key.Key.WorkspaceID != auth.AuthorizedWorkspaceID || key.Key.ApiDeletedAtM.Valid {
+			data[i] = notFound()
+			continue
+		}
+
+		allowed, ok := canVerify[key.Key.ApiID]
+		if !ok {
+			allowed, err = auth.HasPermissions(rbac.Or(
+				rbac.T(rbac.Tuple{
+					ResourceType: rbac.Api,
+					ResourceID:   "*",
+					Action:       rbac.VerifyKey,
+				}),
+				rbac.T(rbac.Tuple{
+					ResourceType: rbac.Api,
+					ResourceID:   key.Key.ApiID,
+					Action:       rbac.VerifyKey,
+				}),
+			))
+			if err != nil {
+				return err
+			}
+			canVerify[key.Key.ApiID] = allowed
+		}
+
+		if !allowed {
+			data[i] = notFound()
+			continue
+		}
+
+		opts := []keys.VerifyOption{
+			keys.WithTags(ptr.SafeDeref(item.Tags)),
+			keys.WithIPWhitelist(),
+		}
+
+		// If a custom cost was specified, use it, otherwise use a DefaultCost of 1
+		if item.Credits != nil {
+			opts = append(opts, keys.WithCredits(item.Credits.Cost))
+		} else if key.Key.RemainingRequests.Valid {
+			opts = append(opts, keys.WithCredits(DefaultCost))
+		}
+
+		if item.Ratelimits != nil {
+			opts = append(opts, keys.WithRateLimits(*item.Ratelimits))
+		} else {
+			// check auto applied ratelimits
+			opts = append(opts, keys.WithRateLimits(nil))
+		}
+
+		if queries[i] != nil {
+			opts = append(opts, keys.WithPermissions(*queries[i]))
+		}
+
+		err = key.Verify(ctx, opts...)
+		if err != nil {
+			return err
+		}
+		verified = append(verified, emits[i])
+
+		data[i], err = keyData(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, emit := range verified {
+		emit()
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: data,
+	})
+}
+
+func notFound() openapi.V2KeysVerifyKeyResponseData {
+	// nolint:exhaustruct
+	return openapi.V2KeysVerifyKeyResponseData{
+		Code:  openapi.NOTFOUND,
+		Valid: false,
+	}
+}
+
+// keyData builds the verification result of a single key, the same way
+// /v2/keys.verifyKey does.
+func keyData(key *keys.KeyVerifier) (openapi.V2KeysVerifyKeyResponseData, error) {
+	keyData := openapi.V2KeysVerifyKeyResponseData{
+		Code:        key.ToOpenAPIStatus(),
+		Valid:       key.Status == keys.StatusValid,
+		Enabled:     ptr.P(key.Key.Enabled),
+		Name:        ptr.P(key.Key.Name.String),
+		KeyId:       ptr.P(key.Key.ID),
+		Permissions: nil,
+		Roles:       nil,
+		Credits:     nil,
+		Expires:     nil,
+		Identity:    nil,
+		Meta:        nil,
+		Ratelimits:  nil,
+	}
+
+	if len(key.Permissions) > 0 {
+		keyData.Permissions = ptr.P(key.Permissions)
+	}
+
+	if len(key.Roles) > 0 {
+		keyData.Roles = ptr.P(key.Roles)
+	}
+
+	remaining := key.Key.RemainingRequests
+	if remaining.Valid {
+		keyData.Credits = ptr.P(remaining.Int32)
+	}
+
+	if key.Key.Expires.Valid {
+		keyData.Expires = ptr.P(key.Key.Expires.Time.UnixMilli())
+	}
+
+	if key.Key.Meta.Valid {
+		err := json.Unmarshal([]byte(key.Key.Meta.String), &keyData.Meta)
+		if err != nil {
+			return keyData, fault.Wrap(err, fault.Code(codes.App.Internal.UnexpectedError.URN()),
+				fault.Internal("unable to unmarshal key meta"),
+				fault.Public("We encountered an error while trying to unmarshal the key meta data."),
+			)
+		}
+	}
+
+	if key.Key.IdentityID.Valid {
+		keyData.Identity = &openapi.Identity{
+			Id:         key.Key.IdentityID.String,
+			ExternalId: key.Key.ExternalID.String,
+			Ratelimits: nil,
+			Meta:       nil,
+		}
+
+		identityRatelimits := make([]openapi.RatelimitResponse, 0)
+		for _, ratelimit := range key.GetRatelimitConfigs() {
+			if ratelimit.IdentityID == "" {
+				continue
+			}
+
+			identityRatelimits = append(identityRatelimits, openapi.RatelimitResponse{
+				AutoApply: ratelimit.AutoApply == 1,
+				Duration:  int64(ratelimit.Duration),
+				Id:        ratelimit.ID,
+				Limit:     int64(ratelimit.Limit),
+				Name:      ratelimit.Name,
+			})
+		}
+
+		if len(identityRatelimits) > 0 {
+			keyData.Identity.Ratelimits = ptr.P(identityRatelimits)
+		}
+
+		if len(key.Key.IdentityMeta) > 0 {
+			err := json.Unmarshal(key.Key.IdentityMeta, &keyData.Identity.Meta)
+			if err != nil {
+				return keyData, fault.Wrap(err, fault.Code(codes.App.Internal.UnexpectedError.URN()),
+					fault.Internal("unable to unmarshal identity meta"),
+					fault.Public("We encountered an error while trying to unmarshal the identity meta data."),
+				)
+			}
+		}
+	}
+
+	if len(key.RatelimitResults) > 0 {
+		ratelimitResponse := make([]openapi.VerifyKeyRatelimitData, 0)
+		for _, result := range key.RatelimitResults {
+			if result.Response == nil {
+				continue
+			}
+
+			ratelimitResponse = append(ratelimitResponse, openapi.VerifyKeyRatelimitData{
+				AutoApply: result.AutoApply,
+				Duration:  result.Duration.Milliseconds(),
+				Exceeded:  !result.Response.Success,
+				Id:        result.ID,
+				Limit:     result.Limit,
+				Name:      result.Name,
+				Remaining: result.Response.Remaining,
+				Reset:     result.Response.Reset.UnixMilli(),
+			})
+		}
+
+		if len(ratelimitResponse) > 0 {
+			keyData.Ratelimits = ptr.P(ratelimitResponse)
+		}
+	}
+
+	return keyData, nil
+}
@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_create_namespace"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestCreateNamespaceSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		Logger:                  h.Logger,
+		DB:                      h.DB,
+		Keys:                    h.Keys,
+		Auditlogs:               h.Auditlogs,
+		RatelimitNamespaceCache: h.Caches.RatelimitNamespace,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.create_namespace")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("with default algorithm", func(t *testing.T) {
+		name := uid.New("test")
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Name:       name,
+			Algorithm:  nil,
+			RefillRate: nil,
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.NotEmpty(t, res.Body.Data.NamespaceId)
+
+		namespace, err := db.Query.FindRatelimitNamespaceByID(ctx, h.DB.RO(), res.Body.Data.NamespaceId)
+		require.NoError(t, err)
+		require.Equal(t, name, namespace.Name)
+		require.Equal(t, h.Resources().UserWorkspace.ID, namespace.WorkspaceID)
+		require.Equal(t, db.RatelimitNamespacesAlgorithmSlidingWindow, namespace.Algorithm)
+		require.False(t, namespace.RefillRate.Valid)
+	})
+
+	t.Run("with token bucket algorithm", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Name:       uid.New("test"),
+			Algorithm:  ptr.P(openapi.TokenBucket),
+			RefillRate: ptr.P(int64(5)),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+		namespace, err := db.Query.FindRatelimitNamespaceByID(ctx, h.DB.RO(), res.Body.Data.NamespaceId)
+		require.NoError(t, err)
+		require.Equal(t, db.RatelimitNamespacesAlgorithmTokenBucket, namespace.Algorithm)
+		require.Equal(t, int32(5), namespace.RefillRate.Int32)
+	})
+
+	t.Run("with the name of a deleted namespace", func(t *testing.T) {
+		name := uid.New("test")
+
+		created := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Name:       name,
+			Algorithm:  ptr.P(openapi.TokenBucket),
+			RefillRate: ptr.P(int64(5)),
+		})
+		require.Equal(t, 200, created.Status, "expected 200, received: %#v", created)
+
+		overrideID := uid.New(uid.RatelimitOverridePrefix)
+		err := db.Query.InsertRatelimitOverride(ctx, h.DB.RW(), db.InsertRatelimitOverrideParams{
+			ID:          overrideID,
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			NamespaceID: created.Body.Data.NamespaceId,
+			Identifier:  "user_123",
+			Limit:       10,
+			Duration:    1000,
+			Algorithm:   db.NullRatelimitOverridesAlgorithm{},
+			RefillRate:  sql.NullInt32{},
+			CreatedAt:   time.Now().UnixMilli(),
+			UpdatedAt:   sql.NullInt64{},
+		})
+		require.NoError(t, err)
+
+		err = db.Query.SoftDeleteRatelimitNamespace(ctx, h.DB.RW(), db.SoftDeleteRatelimitNamespaceParams{
+			ID:  created.Body.Data.NamespaceId,
+			Now: sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()},
+		})
+		require.NoError(t, err)
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Name:       name,
+			Algorithm:  nil,
+			RefillRate: nil,
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, created.Body.Data.NamespaceId, res.Body.Data.NamespaceId)
+
+		namespace, err := db.Query.FindRatelimitNamespaceByID(ctx, h.DB.RO(), res.Body.Data.NamespaceId)
+		require.NoError(t, err)
+		require.False(t, namespace.DeletedAtM.Valid)
+		require.Equal(t, db.RatelimitNamespacesAlgorithmSlidingWindow, namespace.Algorithm)
+		require.False(t, namespace.RefillRate.Valid)
+
+		// Overrides of the deleted namespace must not come back
+		override, err := db.Query.FindRatelimitOverrideByID(ctx, h.DB.RO(), db.FindRatelimitOverrideByIDParams{
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			OverrideID:  overrideID,
+		})
+		require.NoError(t, err)
+		require.True(t, override.DeletedAtM.Valid)
+	})
+}
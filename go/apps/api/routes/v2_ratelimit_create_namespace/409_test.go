@@ -0,0 +1,47 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_create_namespace"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestCreateNamespaceConflict(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		Logger:                  h.Logger,
+		DB:                      h.DB,
+		Keys:                    h.Keys,
+		Auditlogs:               h.Auditlogs,
+		RatelimitNamespaceCache: h.Caches.RatelimitNamespace,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.create_namespace")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	req := handler.Request{
+		Name:       uid.New("test"),
+		Algorithm:  nil,
+		RefillRate: nil,
+	}
+
+	res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+	require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+	conflict := testutil.CallRoute[handler.Request, openapi.ConflictErrorResponse](h, route, headers, req)
+	require.Equal(t, 409, conflict.Status)
+	require.NotNil(t, conflict.Body.Error)
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	"github.com/unkeyed/unkey/go/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/pkg/auditlog"
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2RatelimitCreateNamespaceRequestBody
+type Response = openapi.V2RatelimitCreateNamespaceResponseBody
+
+// Handler implements zen.Route interface for the v2 ratelimit create namespace endpoint
+type Handler struct {
+	// Services as public fields
+	Logger                  logging.Logger
+	DB                      db.Database
+	Keys                    keys.KeyService
+	Auditlogs               auditlogs.AuditLogService
+	RatelimitNamespaceCache cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/ratelimit.createNamespace"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	auth, emit, err := h.Keys.GetRootKey(ctx, s)
+	defer emit()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = auth.VerifyRootKey(ctx, keys.WithPermissions(rbac.T(rbac.Tuple{
+		ResourceType: rbac.Ratelimit,
+		ResourceID:   "*",
+		Action:       rbac.CreateNamespace,
+	})))
+	if err != nil {
+		return err
+	}
+
+	namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+
+	err = db.Tx(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		now := time.Now().UnixMilli()
+
+		existing, err := db.Query.FindRatelimitNamespaceByName(ctx, tx, db.FindRatelimitNamespaceByNameParams{
+			Name:        req.Name,
+			WorkspaceID: auth.AuthorizedWorkspaceID,
+		})
+		if err != nil && !db.IsNotFound(err) {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to find ratelimit namespace"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		switch {
+		case err == nil && !existing.DeletedAtM.Valid:
+			return fault.New("namespace already exists",
+				fault.Code(codes.Data.RatelimitNamespace.Duplicate.URN()),
+				fault.Internal("namespace already exists"),
+				fault.Public(fmt.Sprintf("A namespace with name %q already exists in this workspace.", req.Name)),
+			)
+
+		case err == nil:
+			// Deleted namespaces keep their row and with it their name, so
+			// the deleted namespace is restored as if it was created anew
+			namespaceID = existing.ID
+
+			err = db.Query.RestoreRatelimitNamespace(ctx, tx, db.RestoreRatelimitNamespaceParams{
+				Now: now,
+				ID:  namespaceID,
+			})
+			if err != nil {
+				return fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database failed to restore ratelimit namespace"),
+					fault.Public("The database is unavailable."),
+				)
+			}
+
+			err = db.Query.SoftDeleteRatelimitOverridesByNamespace(ctx, tx, db.SoftDeleteRatelimitOverridesByNamespaceParams{
+				Now:         sql.NullInt64{Int64: now, Valid: true},
+				NamespaceID: namespaceID,
+			})
+			if err != nil {
+				return fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database failed to delete overrides of restored ratelimit namespace"),
+					fault.Public("The database is unavailable."),
+				)
+			}
+
+		default:
+			err = db.Query.InsertRatelimitNamespace(ctx, tx, db.InsertRatelimitNamespaceParams{
+				ID:          namespaceID,
+				WorkspaceID: auth.AuthorizedWorkspaceID,
+				Name:        req.Name,
+				CreatedAt:   now,
+			})
+			if err != nil {
+				if db.IsDuplicateKeyError(err) {
+					return fault.Wrap(err,
+						fault.Code(codes.Data.RatelimitNamespace.Duplicate.URN()),
+						fault.Internal("namespace already exists"),
+						fault.Public(fmt.Sprintf("A namespace with name %q already exists in this workspace.", req.Name)),
+					)
+				}
+				return fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database failed to insert ratelimit namespace"),
+					fault.Public("The database is unavailable."),
+				)
+			}
+		}
+
+		// New namespaces use the column default unless an algorithm is requested
+		if req.Algorithm != nil {
+			refillRate := sql.NullInt32{} // nolint:exhaustruct
+			if req.RefillRate != nil {
+				refillRate = sql.NullInt32{Int32: int32(*req.RefillRate), Valid: true} // nolint:gosec
+			}
+
+			err = db.Query.UpdateRatelimitNamespaceAlgorithm(ctx, tx, db.UpdateRatelimitNamespaceAlgorithmParams{
+				Algorithm:  db.RatelimitNamespacesAlgorithm(*req.Algorithm),
+				RefillRate: refillRate,
+				Now:        sql.NullInt64{Int64: now, Valid: true},
+				ID:         namespaceID,
+			})
+			if err != nil {
+				return fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database failed to set ratelimit namespace algorithm"),
+					fault.Public("The database is unavailable."),
+				)
+			}
+		}
+
+		return h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID: auth.AuthorizedWorkspaceID,
+				Event:       auditlog.RatelimitNamespaceCreateEvent,
+				Display:     fmt.Sprintf("Created ratelimit namespace %s.", req.Name),
+				ActorID:     auth.Key.ID,
+				ActorType:   auditlog.RootKeyActor,
+				ActorName:   "root key",
+				ActorMeta:   map[string]any{},
+				RemoteIP:    s.Location(),
+				UserAgent:   s.UserAgent(),
+				Resources: []auditlog.AuditLogResource{
+					{
+						ID:          namespaceID,
+						Name:        req.Name,
+						DisplayName: req.Name,
+						Type:        auditlog.RatelimitNamespaceResourceType,
+						Meta:        nil,
+					},
+				},
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// Lookups of this name before it existed may have been cached as null,
+	// and a restored namespace may still be cached as deleted
+	h.RatelimitNamespaceCache.Remove(ctx,
+		cache.ScopedKey{
+			WorkspaceID: auth.AuthorizedWorkspaceID,
+			Key:         namespaceID,
+		},
+		cache.ScopedKey{
+			WorkspaceID: auth.AuthorizedWorkspaceID,
+			Key:         req.Name,
+		},
+	)
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2RatelimitCreateNamespaceResponseData{
+			NamespaceId: namespaceID,
+		},
+	})
+}
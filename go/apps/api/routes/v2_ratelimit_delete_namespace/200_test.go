@@ -0,0 +1,63 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_delete_namespace"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestDeleteNamespaceSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+	namespaceName := uid.New("test")
+	err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+		ID:          namespaceID,
+		WorkspaceID: h.Resources().UserWorkspace.ID,
+		Name:        namespaceName,
+		CreatedAt:   time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	route := &handler.Handler{
+		Logger:                  h.Logger,
+		DB:                      h.DB,
+		Keys:                    h.Keys,
+		Auditlogs:               h.Auditlogs,
+		RatelimitNamespaceCache: h.Caches.RatelimitNamespace,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.delete_namespace")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+		Namespace: namespaceName,
+	})
+	require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+
+	namespace, err := db.Query.FindRatelimitNamespaceByID(ctx, h.DB.RO(), namespaceID)
+	require.NoError(t, err)
+	require.True(t, namespace.DeletedAtM.Valid)
+
+	// Deleting again reports the namespace as missing
+	again := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+		Namespace: namespaceName,
+	})
+	require.Equal(t, 404, again.Status)
+}
@@ -0,0 +1,58 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_delete_namespace"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestDeleteNamespaceForbidden(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+	err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+		ID:          namespaceID,
+		WorkspaceID: h.Resources().UserWorkspace.ID,
+		Name:        uid.New("test"),
+		CreatedAt:   time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	route := &handler.Handler{
+		Logger:                  h.Logger,
+		DB:                      h.DB,
+		Keys:                    h.Keys,
+		Auditlogs:               h.Auditlogs,
+		RatelimitNamespaceCache: h.Caches.RatelimitNamespace,
+	}
+
+	h.Register(route)
+
+	// Permission for a different namespace
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.rlns_other.delete_namespace")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	res := testutil.CallRoute[handler.Request, openapi.ForbiddenErrorResponse](h, route, headers, handler.Request{
+		Namespace: namespaceID,
+	})
+	require.Equal(t, 403, res.Status)
+	require.NotNil(t, res.Body.Error)
+
+	namespace, err := db.Query.FindRatelimitNamespaceByID(ctx, h.DB.RO(), namespaceID)
+	require.NoError(t, err)
+	require.False(t, namespace.DeletedAtM.Valid)
+}
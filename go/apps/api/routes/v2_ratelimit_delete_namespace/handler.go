@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	"github.com/unkeyed/unkey/go/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/pkg/auditlog"
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2RatelimitDeleteNamespaceRequestBody
+type Response = openapi.V2RatelimitDeleteNamespaceResponseBody
+
+// Handler implements zen.Route interface for the v2 ratelimit delete namespace endpoint
+type Handler struct {
+	// Services as public fields
+	Logger                  logging.Logger
+	DB                      db.Database
+	Keys                    keys.KeyService
+	Auditlogs               auditlogs.AuditLogService
+	RatelimitNamespaceCache cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/ratelimit.deleteNamespace"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	auth, emit, err := h.Keys.GetRootKey(ctx, s)
+	defer emit()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	namespace, err := db.TxWithResult(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) (db.FindRatelimitNamespaceRow, error) {
+		namespace, err := db.Query.FindRatelimitNamespace(ctx, tx, db.FindRatelimitNamespaceParams{
+			WorkspaceID: auth.AuthorizedWorkspaceID,
+			Namespace:   req.Namespace,
+		})
+		if err != nil {
+			if db.IsNotFound(err) {
+				return db.FindRatelimitNamespaceRow{}, fault.New("namespace not found",
+					fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+					fault.Internal("namespace not found"),
+					fault.Public("This namespace does not exist."),
+				)
+			}
+			return db.FindRatelimitNamespaceRow{}, err
+		}
+
+		if namespace.DeletedAtM.Valid {
+			return db.FindRatelimitNamespaceRow{}, fault.New("namespace was deleted",
+				fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+				fault.Public("This namespace does not exist."),
+			)
+		}
+
+		err = auth.VerifyRootKey(ctx, keys.WithPermissions(rbac.Or(
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Ratelimit,
+				ResourceID:   namespace.ID,
+				Action:       rbac.DeleteNamespace,
+			}),
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Ratelimit,
+				ResourceID:   "*",
+				Action:       rbac.DeleteNamespace,
+			}),
+		)))
+		if err != nil {
+			return db.FindRatelimitNamespaceRow{}, err
+		}
+
+		err = db.Query.SoftDeleteRatelimitNamespace(ctx, tx, db.SoftDeleteRatelimitNamespaceParams{
+			ID:  namespace.ID,
+			Now: sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()},
+		})
+		if err != nil {
+			return db.FindRatelimitNamespaceRow{}, fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to soft delete ratelimit namespace"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		err = h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID: auth.AuthorizedWorkspaceID,
+				Event:       auditlog.RatelimitNamespaceDeleteEvent,
+				Display:     fmt.Sprintf("Deleted ratelimit namespace %s.", namespace.ID),
+				ActorID:     auth.Key.ID,
+				ActorType:   auditlog.RootKeyActor,
+				ActorName:   "root key",
+				ActorMeta:   map[string]any{},
+				RemoteIP:    s.Location(),
+				UserAgent:   s.UserAgent(),
+				Resources: []auditlog.AuditLogResource{
+					{
+						ID:          namespace.ID,
+						Name:        namespace.Name,
+						DisplayName: namespace.Name,
+						Type:        auditlog.RatelimitNamespaceResourceType,
+						Meta:        nil,
+					},
+				},
+			},
+		})
+		if err != nil {
+			return db.FindRatelimitNamespaceRow{}, err
+		}
+
+		return namespace, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only evict after the commit, or a concurrent lookup could cache the
+	// namespace again before it is deleted
+	h.RatelimitNamespaceCache.Remove(ctx,
+		cache.ScopedKey{
+			WorkspaceID: auth.AuthorizedWorkspaceID,
+			Key:         namespace.ID,
+		},
+		cache.ScopedKey{
+			WorkspaceID: auth.AuthorizedWorkspaceID,
+			Key:         namespace.Name,
+		},
+	)
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2RatelimitDeleteNamespaceResponseData{},
+	})
+}
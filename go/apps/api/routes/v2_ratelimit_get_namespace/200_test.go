@@ -0,0 +1,59 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_get_namespace"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestGetNamespaceSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+	namespaceName := uid.New("test")
+	err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+		ID:          namespaceID,
+		WorkspaceID: h.Resources().UserWorkspace.ID,
+		Name:        namespaceName,
+		CreatedAt:   time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	route := &handler.Handler{
+		Logger: h.Logger,
+		DB:     h.DB,
+		Keys:   h.Keys,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, fmt.Sprintf("ratelimit.%s.read_namespace", namespaceID))
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	for _, namespace := range []string{namespaceID, namespaceName} {
+		t.Run(namespace, func(t *testing.T) {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+				Namespace: namespace,
+			})
+			require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+			require.Equal(t, namespaceID, res.Body.Data.NamespaceId)
+			require.Equal(t, namespaceName, res.Body.Data.Name)
+			require.Equal(t, openapi.SlidingWindow, res.Body.Data.Algorithm)
+			require.Nil(t, res.Body.Data.RefillRate)
+		})
+	}
+}
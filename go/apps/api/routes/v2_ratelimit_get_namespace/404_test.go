@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_get_namespace"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestGetNamespaceNotFound(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		Logger: h.Logger,
+		DB:     h.DB,
+		Keys:   h.Keys,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.read_namespace")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("namespace does not exist", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			Namespace: uid.New(uid.RatelimitNamespacePrefix),
+		})
+		require.Equal(t, 404, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+
+	t.Run("namespace was deleted", func(t *testing.T) {
+		namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+		err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+			ID:          namespaceID,
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			Name:        uid.New("test"),
+			CreatedAt:   time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+
+		err = db.Query.SoftDeleteRatelimitNamespace(ctx, h.DB.RW(), db.SoftDeleteRatelimitNamespaceParams{
+			ID:  namespaceID,
+			Now: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		})
+		require.NoError(t, err)
+
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			Namespace: namespaceID,
+		})
+		require.Equal(t, 404, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+
+	t.Run("namespace of another workspace", func(t *testing.T) {
+		namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+		err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+			ID:          namespaceID,
+			WorkspaceID: h.CreateWorkspace().ID,
+			Name:        uid.New("test"),
+			CreatedAt:   time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+
+		res := testutil.CallRoute[handler.Request, openapi.NotFoundErrorResponse](h, route, headers, handler.Request{
+			Namespace: namespaceID,
+		})
+		require.Equal(t, 404, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+}
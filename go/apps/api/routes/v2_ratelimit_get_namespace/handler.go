@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2RatelimitGetNamespaceRequestBody
+type Response = openapi.V2RatelimitGetNamespaceResponseBody
+
+// Handler implements zen.Route interface for the v2 ratelimit get namespace endpoint
+type Handler struct {
+	// Services as public fields
+	Logger logging.Logger
+	DB     db.Database
+	Keys   keys.KeyService
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/ratelimit.getNamespace"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	auth, emit, err := h.Keys.GetRootKey(ctx, s)
+	defer emit()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	namespace, err := db.Query.FindRatelimitNamespace(ctx, h.DB.RO(), db.FindRatelimitNamespaceParams{
+		WorkspaceID: auth.AuthorizedWorkspaceID,
+		Namespace:   req.Namespace,
+	})
+	if err != nil {
+		if db.IsNotFound(err) {
+			return fault.New("namespace not found",
+				fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+				fault.Internal("namespace not found"), fault.Public("This namespace does not exist."),
+			)
+		}
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Public("An unexpected error occurred while loading your namespace."),
+		)
+	}
+
+	if namespace.DeletedAtM.Valid {
+		return fault.New("namespace was deleted",
+			fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+			fault.Internal("namespace was deleted"), fault.Public("This namespace does not exist."),
+		)
+	}
+
+	err = auth.VerifyRootKey(ctx, keys.WithPermissions(rbac.Or(
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Ratelimit,
+			ResourceID:   namespace.ID,
+			Action:       rbac.ReadNamespace,
+		}),
+		rbac.T(rbac.Tuple{
+			ResourceType: rbac.Ratelimit,
+			ResourceID:   "*",
+			Action:       rbac.ReadNamespace,
+		}),
+	)))
+	if err != nil {
+		return err
+	}
+
+	data := openapi.RatelimitNamespace{
+		NamespaceId: namespace.ID,
+		Name:        namespace.Name,
+		Algorithm:   openapi.RatelimitAlgorithm(namespace.Algorithm),
+		RefillRate:  nil,
+	}
+	if namespace.RefillRate.Valid {
+		data.RefillRate = ptr.P(int64(namespace.RefillRate.Int32))
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: data,
+	})
+}
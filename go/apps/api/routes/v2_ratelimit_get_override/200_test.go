@@ -2,12 +2,14 @@ package handler_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
 	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_get_override"
 	"github.com/unkeyed/unkey/go/pkg/db"
 	"github.com/unkeyed/unkey/go/pkg/testutil"
@@ -46,6 +48,24 @@ func TestGetOverrideSuccessfully(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	// Create an override that uses its own algorithm
+	tokenBucketIdentifier := "token_bucket_identifier"
+	err = db.Query.InsertRatelimitOverride(ctx, h.DB.RW(), db.InsertRatelimitOverrideParams{
+		ID:          uid.New(uid.RatelimitOverridePrefix),
+		WorkspaceID: h.Resources().UserWorkspace.ID,
+		NamespaceID: namespaceID,
+		Identifier:  tokenBucketIdentifier,
+		Limit:       limit,
+		Duration:    duration,
+		Algorithm: db.NullRatelimitOverridesAlgorithm{
+			RatelimitOverridesAlgorithm: db.RatelimitOverridesAlgorithmTokenBucket,
+			Valid:                       true,
+		},
+		RefillRate: sql.NullInt32{Int32: 5, Valid: true},
+		CreatedAt:  time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
 	route := &handler.Handler{
 		DB:                      h.DB,
 		Keys:                    h.Keys,
@@ -93,4 +113,18 @@ func TestGetOverrideSuccessfully(t *testing.T) {
 		require.Equal(t, int64(limit), res.Body.Data.Limit)
 		require.Equal(t, int64(duration), res.Body.Data.Duration)
 	})
+
+	t.Run("get override with algorithm", func(t *testing.T) {
+		req := handler.Request{
+			Namespace:  namespaceID,
+			Identifier: tokenBucketIdentifier,
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.NotNil(t, res.Body.Data.Algorithm)
+		require.Equal(t, openapi.TokenBucket, *res.Body.Data.Algorithm)
+		require.NotNil(t, res.Body.Data.RefillRate)
+		require.Equal(t, int64(5), *res.Body.Data.RefillRate)
+	})
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/unkeyed/unkey/go/pkg/fault"
 	"github.com/unkeyed/unkey/go/pkg/match"
 	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
 	"github.com/unkeyed/unkey/go/pkg/rbac"
 	"github.com/unkeyed/unkey/go/pkg/zen"
 )
@@ -82,6 +83,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 				ID:                response.ID,
 				WorkspaceID:       response.WorkspaceID,
 				Name:              response.Name,
+				Algorithm:         response.Algorithm,
+				RefillRate:        response.RefillRate,
 				CreatedAtM:        response.CreatedAtM,
 				UpdatedAtM:        response.UpdatedAtM,
 				DeletedAtM:        response.DeletedAtM,
@@ -162,16 +165,26 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		)
 	}
 
+	data := openapi.RatelimitOverride{
+		OverrideId: override.ID,
+		Limit:      override.Limit,
+		Duration:   override.Duration,
+		Identifier: override.Identifier,
+		Algorithm:  nil,
+		RefillRate: nil,
+	}
+	if override.Algorithm != "" {
+		data.Algorithm = ptr.P(openapi.RatelimitAlgorithm(override.Algorithm))
+	}
+	if override.RefillRate > 0 {
+		data.RefillRate = ptr.P(override.RefillRate)
+	}
+
 	return s.JSON(http.StatusOK, Response{
 		Meta: openapi.Meta{
 			RequestId: s.RequestID(),
 		},
-		Data: openapi.RatelimitOverride{
-			OverrideId: override.ID,
-			Limit:      override.Limit,
-			Duration:   override.Duration,
-			Identifier: override.Identifier,
-		},
+		Data: data,
 	})
 }
 
@@ -2,6 +2,7 @@ package v2RatelimitLimit_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"strings"
@@ -398,6 +399,187 @@ func TestLimitSuccessfully(t *testing.T) {
 		require.NotNil(t, res4.Body.Data.OverrideId)
 		require.Equal(t, overrideID, *res4.Body.Data.OverrideId)
 	})
+
+	t.Run("fixed window resets at the window boundary", func(t *testing.T) {
+		namespaceID, namespaceName := createNamespace(t, h)
+		setNamespaceAlgorithm(t, h, namespaceID, db.RatelimitNamespacesAlgorithmFixedWindow)
+		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, fmt.Sprintf("ratelimit.%s.limit", namespaceID))
+
+		headers := http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}
+		req := handler.Request{
+			Namespace:  namespaceName,
+			Identifier: uid.New("test"),
+			Limit:      5,
+			Duration:   60000,
+		}
+
+		for i := range 5 {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+			require.Equal(t, 200, res.Status)
+			require.True(t, res.Body.Data.Success, "request %d should succeed", i)
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status)
+		require.False(t, res.Body.Data.Success)
+
+		// A sliding window would still count the previous window here
+		now := h.Clock.Now()
+		h.Clock.Tick(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+
+		res = testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status)
+		require.True(t, res.Body.Data.Success)
+		require.Equal(t, int64(4), res.Body.Data.Remaining)
+	})
+
+	t.Run("token bucket allows a burst and refills over time", func(t *testing.T) {
+		namespaceID, namespaceName := createNamespace(t, h)
+		setNamespaceAlgorithm(t, h, namespaceID, db.RatelimitNamespacesAlgorithmTokenBucket)
+		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, fmt.Sprintf("ratelimit.%s.limit", namespaceID))
+
+		headers := http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}
+		req := handler.Request{
+			Namespace:  namespaceName,
+			Identifier: uid.New("test"),
+			Limit:      10,
+			Duration:   10000, // 1 token per second
+		}
+
+		for i := range 10 {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+			require.Equal(t, 200, res.Status)
+			require.True(t, res.Body.Data.Success, "request %d should succeed", i)
+			require.Equal(t, int64(10), res.Body.Data.Limit)
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status)
+		require.False(t, res.Body.Data.Success, "bucket should be empty")
+		require.Equal(t, int64(0), res.Body.Data.Remaining)
+		require.Greater(t, res.Body.Data.Reset, h.Clock.Now().UnixMilli())
+
+		h.Clock.Tick(time.Second)
+
+		res = testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status)
+		require.True(t, res.Body.Data.Success, "one token should have been refilled")
+	})
+
+	t.Run("override algorithm takes precedence over namespace", func(t *testing.T) {
+		namespaceID, namespaceName := createNamespace(t, h)
+		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, fmt.Sprintf("ratelimit.%s.limit", namespaceID))
+
+		headers := http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}
+
+		identifier := uid.New("test")
+		overrideID := uid.New(uid.RatelimitOverridePrefix)
+		err := db.Query.InsertRatelimitOverride(ctx, h.DB.RW(), db.InsertRatelimitOverrideParams{
+			ID:          overrideID,
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			NamespaceID: namespaceID,
+			Identifier:  identifier,
+			Limit:       2,
+			Duration:    60000,
+			Algorithm: db.NullRatelimitOverridesAlgorithm{
+				RatelimitOverridesAlgorithm: db.RatelimitOverridesAlgorithmTokenBucket,
+				Valid:                       true,
+			},
+			RefillRate: sql.NullInt32{Int32: 60, Valid: true},
+			CreatedAt:  time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+
+		req := handler.Request{
+			Namespace:  namespaceName,
+			Identifier: identifier,
+			Limit:      100,
+			Duration:   60000,
+		}
+
+		for range 2 {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+			require.Equal(t, 200, res.Status)
+			require.True(t, res.Body.Data.Success)
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status)
+		require.False(t, res.Body.Data.Success)
+
+		// 60 tokens per minute refill one token per second
+		h.Clock.Tick(time.Second)
+
+		res = testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status)
+		require.True(t, res.Body.Data.Success)
+		require.Equal(t, overrideID, *res.Body.Data.OverrideId)
+	})
+
+	t.Run("override without algorithm keeps the namespace refill rate", func(t *testing.T) {
+		namespaceID, namespaceName := createNamespace(t, h)
+		err := db.Query.UpdateRatelimitNamespaceAlgorithm(ctx, h.DB.RW(), db.UpdateRatelimitNamespaceAlgorithmParams{
+			Algorithm:  db.RatelimitNamespacesAlgorithmTokenBucket,
+			RefillRate: sql.NullInt32{Int32: 60, Valid: true},
+			Now:        sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()},
+			ID:         namespaceID,
+		})
+		require.NoError(t, err)
+		rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, fmt.Sprintf("ratelimit.%s.limit", namespaceID))
+
+		headers := http.Header{
+			"Content-Type":  {"application/json"},
+			"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+		}
+
+		identifier := uid.New("test")
+		err = db.Query.InsertRatelimitOverride(ctx, h.DB.RW(), db.InsertRatelimitOverrideParams{
+			ID:          uid.New(uid.RatelimitOverridePrefix),
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			NamespaceID: namespaceID,
+			Identifier:  identifier,
+			Limit:       2,
+			Duration:    60000,
+			Algorithm:   db.NullRatelimitOverridesAlgorithm{},
+			RefillRate:  sql.NullInt32{},
+			CreatedAt:   time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+
+		req := handler.Request{
+			Namespace:  namespaceName,
+			Identifier: identifier,
+			Limit:      100,
+			Duration:   60000,
+		}
+
+		for range 2 {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+			require.Equal(t, 200, res.Status)
+			require.True(t, res.Body.Data.Success)
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status)
+		require.False(t, res.Body.Data.Success)
+
+		// The namespace refills 60 tokens per minute, falling back to the
+		// override's limit would refill only 2
+		h.Clock.Tick(time.Second)
+
+		res = testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status)
+		require.True(t, res.Body.Data.Success)
+	})
 }
 
 func createNamespace(t *testing.T, h *testutil.Harness) (id, name string) {
@@ -414,3 +596,13 @@ func createNamespace(t *testing.T, h *testutil.Harness) (id, name string) {
 
 	return namespaceID, namespaceName
 }
+
+func setNamespaceAlgorithm(t *testing.T, h *testutil.Harness, namespaceID string, algorithm db.RatelimitNamespacesAlgorithm) {
+	err := db.Query.UpdateRatelimitNamespaceAlgorithm(context.Background(), h.DB.RW(), db.UpdateRatelimitNamespaceAlgorithmParams{
+		Algorithm:  algorithm,
+		RefillRate: sql.NullInt32{},
+		Now:        sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()},
+		ID:         namespaceID,
+	})
+	require.NoError(t, err)
+}
@@ -94,6 +94,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 				ID:                response.ID,
 				WorkspaceID:       response.WorkspaceID,
 				Name:              response.Name,
+				Algorithm:         response.Algorithm,
+				RefillRate:        response.RefillRate,
 				CreatedAtM:        response.CreatedAtM,
 				UpdatedAtM:        response.UpdatedAtM,
 				DeletedAtM:        response.DeletedAtM,
@@ -168,6 +170,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 	var (
 		limit      = req.Limit
 		duration   = req.Duration
+		algorithm  = ratelimit.Algorithm(namespace.Algorithm)
+		refillRate = int64(namespace.RefillRate.Int32)
 		overrideId = ""
 	)
 
@@ -183,6 +187,15 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		limit = override.Limit
 		duration = override.Duration
 		overrideId = override.ID
+
+		// Overrides without an algorithm keep the namespace's algorithm and
+		// refill rate, the override's refill rate belongs to its own algorithm
+		if override.Algorithm != "" {
+			algorithm = ratelimit.Algorithm(override.Algorithm)
+			if algorithm == ratelimit.TokenBucket {
+				refillRate = override.RefillRate
+			}
+		}
 	}
 
 	// Apply rate limit
@@ -192,6 +205,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 		Limit:      limit,
 		Cost:       cost,
 		Time:       time.Time{},
+		Algorithm:  algorithm,
+		RefillRate: refillRate,
 	}
 
 	if h.TestMode {
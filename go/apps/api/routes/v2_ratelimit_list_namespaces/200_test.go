@@ -0,0 +1,74 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_list_namespaces"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestListNamespacesSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	workspace := h.CreateWorkspace()
+
+	namespaceIDs := map[string]bool{}
+	for range 5 {
+		namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+		err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+			ID:          namespaceID,
+			WorkspaceID: workspace.ID,
+			Name:        uid.New("test"),
+			CreatedAt:   time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+		namespaceIDs[namespaceID] = true
+	}
+
+	route := &handler.Handler{
+		Logger: h.Logger,
+		DB:     h.DB,
+		Keys:   h.Keys,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(workspace.ID, "ratelimit.*.read_namespace")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	seen := map[string]bool{}
+	var cursor *string
+	for {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Cursor: cursor,
+			Limit:  ptr.P(2),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.LessOrEqual(t, len(res.Body.Data), 2)
+
+		for _, namespace := range res.Body.Data {
+			require.False(t, seen[namespace.NamespaceId], "namespace returned twice")
+			seen[namespace.NamespaceId] = true
+		}
+
+		if !res.Body.Pagination.HasMore {
+			break
+		}
+		cursor = res.Body.Pagination.Cursor
+	}
+
+	require.Equal(t, namespaceIDs, seen)
+}
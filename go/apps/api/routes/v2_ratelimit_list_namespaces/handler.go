@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2RatelimitListNamespacesRequestBody
+type Response = openapi.V2RatelimitListNamespacesResponseBody
+
+// Handler implements zen.Route interface for the v2 ratelimit list namespaces endpoint
+type Handler struct {
+	// Services as public fields
+	Logger logging.Logger
+	DB     db.Database
+	Keys   keys.KeyService
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/ratelimit.listNamespaces"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	auth, emit, err := h.Keys.GetRootKey(ctx, s)
+	defer emit()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = auth.VerifyRootKey(ctx, keys.WithPermissions(rbac.T(rbac.Tuple{
+		ResourceType: rbac.Ratelimit,
+		ResourceID:   "*",
+		Action:       rbac.ReadNamespace,
+	})))
+	if err != nil {
+		return err
+	}
+
+	limit := ptr.SafeDeref(req.Limit, 10)
+
+	namespaces, err := db.Query.ListRatelimitNamespaces(ctx, h.DB.RO(), db.ListRatelimitNamespacesParams{
+		WorkspaceID: auth.AuthorizedWorkspaceID,
+		CursorID:    ptr.SafeDeref(req.Cursor, ""),
+		Limit:       int32(limit) + 1, // nolint:gosec
+	})
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database failed to list ratelimit namespaces"),
+			fault.Public("The database is unavailable."),
+		)
+	}
+
+	hasMore := len(namespaces) > limit
+	var cursor *string
+	if hasMore {
+		cursor = ptr.P(namespaces[limit].ID)
+		namespaces = namespaces[:limit]
+	}
+
+	responseBody := Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: make([]openapi.RatelimitNamespace, len(namespaces)),
+		Pagination: &openapi.Pagination{
+			Cursor:  cursor,
+			HasMore: hasMore,
+		},
+	}
+
+	for i, namespace := range namespaces {
+		responseBody.Data[i] = openapi.RatelimitNamespace{
+			NamespaceId: namespace.ID,
+			Name:        namespace.Name,
+			Algorithm:   openapi.RatelimitAlgorithm(namespace.Algorithm),
+			RefillRate:  nil,
+		}
+		if namespace.RefillRate.Valid {
+			responseBody.Data[i].RefillRate = ptr.P(int64(namespace.RefillRate.Int32))
+		}
+	}
+
+	return s.JSON(http.StatusOK, responseBody)
+}
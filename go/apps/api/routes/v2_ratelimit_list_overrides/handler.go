@@ -127,6 +127,14 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Duration:   int64(override.Duration),
 			Identifier: override.Identifier,
 			Limit:      int64(override.Limit),
+			Algorithm:  nil,
+			RefillRate: nil,
+		}
+		if override.Algorithm.Valid {
+			responseBody.Data[i].Algorithm = ptr.P(openapi.RatelimitAlgorithm(override.Algorithm.RatelimitOverridesAlgorithm))
+		}
+		if override.RefillRate.Valid {
+			responseBody.Data[i].RefillRate = ptr.P(int64(override.RefillRate.Int32))
 		}
 	}
 
@@ -0,0 +1,208 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_multi_limit"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestMultiLimitSuccessfully(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:                      h.DB,
+		Keys:                    h.Keys,
+		Logger:                  h.Logger,
+		ClickHouse:              h.ClickHouse,
+		Ratelimit:               h.Ratelimit,
+		RatelimitNamespaceCache: h.Caches.RatelimitNamespace,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.limit")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("all limits pass", func(t *testing.T) {
+		_, userNamespace := createNamespace(t, h)
+		_, orgNamespace := createNamespace(t, h)
+
+		req := handler.Request{
+			Limits: []openapi.V2RatelimitLimitRequestBody{
+				{Namespace: userNamespace, Identifier: "user_123", Limit: 10, Duration: 60000},
+				{Namespace: orgNamespace, Identifier: "org_123", Limit: 100, Duration: 60000},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.True(t, res.Body.Data.Passed)
+		require.Len(t, res.Body.Data.Limits, 2)
+
+		require.Equal(t, userNamespace, res.Body.Data.Limits[0].Namespace)
+		require.Equal(t, "user_123", res.Body.Data.Limits[0].Identifier)
+		require.True(t, res.Body.Data.Limits[0].Success)
+		require.Equal(t, int64(10), res.Body.Data.Limits[0].Limit)
+		require.Equal(t, int64(9), res.Body.Data.Limits[0].Remaining)
+
+		require.Equal(t, orgNamespace, res.Body.Data.Limits[1].Namespace)
+		require.True(t, res.Body.Data.Limits[1].Success)
+		require.Equal(t, int64(99), res.Body.Data.Limits[1].Remaining)
+	})
+
+	t.Run("a rejected limit consumes no tokens", func(t *testing.T) {
+		_, userNamespace := createNamespace(t, h)
+		_, orgNamespace := createNamespace(t, h)
+
+		user := openapi.V2RatelimitLimitRequestBody{Namespace: userNamespace, Identifier: "user_123", Limit: 10, Duration: 60000}
+		org := openapi.V2RatelimitLimitRequestBody{Namespace: orgNamespace, Identifier: "org_123", Limit: 1, Duration: 60000}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Limits: []openapi.V2RatelimitLimitRequestBody{user, org},
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.True(t, res.Body.Data.Passed)
+		require.Equal(t, int64(9), res.Body.Data.Limits[0].Remaining)
+		require.Equal(t, int64(0), res.Body.Data.Limits[1].Remaining)
+
+		// The org limit is exhausted, so the user limit must not be consumed
+		for range 3 {
+			res = testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+				Limits: []openapi.V2RatelimitLimitRequestBody{user, org},
+			})
+			require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+			require.False(t, res.Body.Data.Passed)
+			require.True(t, res.Body.Data.Limits[0].Success)
+			require.False(t, res.Body.Data.Limits[1].Success)
+		}
+
+		res = testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Limits: []openapi.V2RatelimitLimitRequestBody{user},
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.True(t, res.Body.Data.Passed)
+		require.Equal(t, int64(8), res.Body.Data.Limits[0].Remaining)
+	})
+
+	t.Run("the same namespace with different identifiers", func(t *testing.T) {
+		_, namespace := createNamespace(t, h)
+
+		req := handler.Request{
+			Limits: []openapi.V2RatelimitLimitRequestBody{
+				{Namespace: namespace, Identifier: "user_123", Limit: 10, Duration: 60000},
+				{Namespace: namespace, Identifier: "user_456", Limit: 10, Duration: 60000},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.True(t, res.Body.Data.Passed)
+		require.Equal(t, int64(9), res.Body.Data.Limits[0].Remaining)
+		require.Equal(t, int64(9), res.Body.Data.Limits[1].Remaining)
+	})
+
+	t.Run("overrides are applied", func(t *testing.T) {
+		namespaceID, namespace := createNamespace(t, h)
+
+		overrideID := uid.New(uid.RatelimitOverridePrefix)
+		err := db.Query.InsertRatelimitOverride(context.Background(), h.DB.RW(), db.InsertRatelimitOverrideParams{
+			ID:          overrideID,
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			NamespaceID: namespaceID,
+			Identifier:  "premium_*",
+			Limit:       1000,
+			Duration:    60000,
+			CreatedAt:   time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+
+		req := handler.Request{
+			Limits: []openapi.V2RatelimitLimitRequestBody{
+				{Namespace: namespace, Identifier: "premium_user", Limit: 10, Duration: 60000},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.Equal(t, int64(1000), res.Body.Data.Limits[0].Limit)
+		require.NotNil(t, res.Body.Data.Limits[0].OverrideId)
+		require.Equal(t, overrideID, *res.Body.Data.Limits[0].OverrideId)
+	})
+
+	t.Run("override without algorithm keeps the namespace refill rate", func(t *testing.T) {
+		ctx := context.Background()
+		namespaceID, namespace := createNamespace(t, h)
+
+		err := db.Query.UpdateRatelimitNamespaceAlgorithm(ctx, h.DB.RW(), db.UpdateRatelimitNamespaceAlgorithmParams{
+			Algorithm:  db.RatelimitNamespacesAlgorithmTokenBucket,
+			RefillRate: sql.NullInt32{Int32: 60, Valid: true},
+			Now:        sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()},
+			ID:         namespaceID,
+		})
+		require.NoError(t, err)
+
+		err = db.Query.InsertRatelimitOverride(ctx, h.DB.RW(), db.InsertRatelimitOverrideParams{
+			ID:          uid.New(uid.RatelimitOverridePrefix),
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			NamespaceID: namespaceID,
+			Identifier:  "user_123",
+			Limit:       2,
+			Duration:    60000,
+			Algorithm:   db.NullRatelimitOverridesAlgorithm{},
+			RefillRate:  sql.NullInt32{},
+			CreatedAt:   time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+
+		req := handler.Request{
+			Limits: []openapi.V2RatelimitLimitRequestBody{
+				{Namespace: namespace, Identifier: "user_123", Limit: 100, Duration: 60000},
+			},
+		}
+
+		for range 2 {
+			res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+			require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+			require.True(t, res.Body.Data.Passed)
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.False(t, res.Body.Data.Passed)
+
+		// The namespace refills 60 tokens per minute, falling back to the
+		// override's limit would refill only 2
+		h.Clock.Tick(time.Second)
+
+		res = testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.True(t, res.Body.Data.Passed)
+	})
+}
+
+func createNamespace(t *testing.T, h *testutil.Harness) (id, name string) {
+	namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+	namespaceName := uid.New("test")
+	err := db.Query.InsertRatelimitNamespace(context.Background(), h.DB.RW(), db.InsertRatelimitNamespaceParams{
+		ID:          namespaceID,
+		WorkspaceID: h.Resources().UserWorkspace.ID,
+		Name:        namespaceName,
+		CreatedAt:   time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	return namespaceID, namespaceName
+}
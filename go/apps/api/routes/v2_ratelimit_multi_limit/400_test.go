@@ -0,0 +1,94 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_multi_limit"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+)
+
+func TestBadRequests(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:                      h.DB,
+		Keys:                    h.Keys,
+		Logger:                  h.Logger,
+		ClickHouse:              h.ClickHouse,
+		Ratelimit:               h.Ratelimit,
+		RatelimitNamespaceCache: h.Caches.RatelimitNamespace,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.limit")
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("no limits", func(t *testing.T) {
+		req := handler.Request{
+			Limits: []openapi.V2RatelimitLimitRequestBody{},
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status, "expected 400, received: %s", res.RawBody)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/application/invalid_input", res.Body.Error.Type)
+	})
+
+	t.Run("too many limits", func(t *testing.T) {
+		_, namespace := createNamespace(t, h)
+
+		req := handler.Request{
+			Limits: make([]openapi.V2RatelimitLimitRequestBody, 11),
+		}
+		for i := range req.Limits {
+			req.Limits[i] = openapi.V2RatelimitLimitRequestBody{
+				Namespace:  namespace,
+				Identifier: fmt.Sprintf("user_%d", i),
+				Limit:      10,
+				Duration:   60000,
+			}
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status, "expected 400, received: %s", res.RawBody)
+	})
+
+	t.Run("duplicate namespace and identifier", func(t *testing.T) {
+		_, namespace := createNamespace(t, h)
+
+		req := handler.Request{
+			Limits: []openapi.V2RatelimitLimitRequestBody{
+				{Namespace: namespace, Identifier: "user_123", Limit: 10, Duration: 60000},
+				{Namespace: namespace, Identifier: "user_123", Limit: 100, Duration: 3600000},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status, "expected 400, received: %s", res.RawBody)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/application/invalid_input", res.Body.Error.Type)
+		require.Equal(t, "limits[0] and limits[1] use the same namespace and identifier.", res.Body.Error.Detail)
+	})
+
+	t.Run("same namespace by id and by name", func(t *testing.T) {
+		namespaceID, namespaceName := createNamespace(t, h)
+
+		req := handler.Request{
+			Limits: []openapi.V2RatelimitLimitRequestBody{
+				{Namespace: namespaceID, Identifier: "user_123", Limit: 10, Duration: 60000},
+				{Namespace: namespaceName, Identifier: "user_123", Limit: 10, Duration: 60000},
+			},
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+		require.Equal(t, 400, res.Status, "expected 400, received: %s", res.RawBody)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/application/invalid_input", res.Body.Error.Type)
+		require.Equal(t, "limits[0] and limits[1] use the same namespace and identifier.", res.Body.Error.Detail)
+	})
+}
@@ -0,0 +1,53 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_multi_limit"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+)
+
+func TestInsufficientPermissions(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		DB:                      h.DB,
+		Keys:                    h.Keys,
+		Logger:                  h.Logger,
+		ClickHouse:              h.ClickHouse,
+		Ratelimit:               h.Ratelimit,
+		RatelimitNamespaceCache: h.Caches.RatelimitNamespace,
+	}
+
+	h.Register(route)
+
+	userNamespaceID, userNamespace := createNamespace(t, h)
+	_, orgNamespace := createNamespace(t, h)
+
+	// The key may only limit in one of the two namespaces
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, fmt.Sprintf("ratelimit.%s.limit", userNamespaceID))
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	req := handler.Request{
+		Limits: []openapi.V2RatelimitLimitRequestBody{
+			{Namespace: userNamespace, Identifier: "user_123", Limit: 10, Duration: 60000},
+			{Namespace: orgNamespace, Identifier: "org_123", Limit: 100, Duration: 60000},
+		},
+	}
+
+	res := testutil.CallRoute[handler.Request, openapi.ForbiddenErrorResponse](h, route, headers, req)
+	require.Equal(t, http.StatusForbidden, res.Status, "expected 403, received: %s", res.RawBody)
+
+	// Nothing was consumed in the namespace the key has access to
+	req.Limits = req.Limits[:1]
+	ok := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+	require.Equal(t, 200, ok.Status, "expected 200, received: %s", ok.RawBody)
+	require.Equal(t, int64(9), ok.Body.Data.Limits[0].Remaining)
+}
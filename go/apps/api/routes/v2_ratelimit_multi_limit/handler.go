@@ -0,0 +1,327 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	"github.com/unkeyed/unkey/go/internal/services/caches"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/internal/services/ratelimit"
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/clickhouse"
+	"github.com/unkeyed/unkey/go/pkg/clickhouse/schema"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/match"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2RatelimitMultiLimitRequestBody
+type Response = openapi.V2RatelimitMultiLimitResponseBody
+
+// Handler implements zen.Route interface for the v2 ratelimit multiLimit endpoint
+type Handler struct {
+	// Services as public fields
+	Logger                  logging.Logger
+	Keys                    keys.KeyService
+	DB                      db.Database
+	ClickHouse              clickhouse.Bufferer
+	Ratelimit               ratelimit.Service
+	RatelimitNamespaceCache cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
+	TestMode                bool
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/ratelimit.multiLimit"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	if s.Request().Header.Get("X-Unkey-Metrics") == "disabled" {
+		s.DisableClickHouseLogging()
+	}
+
+	// Authenticate the request with a root key
+	auth, emit, err := h.Keys.GetRootKey(ctx, s)
+	defer emit()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	// Load every namespace once, the same namespace may be used by many limits
+	namespaces := make(map[string]db.FindRatelimitNamespace)
+	for _, l := range req.Limits {
+		if _, ok := namespaces[l.Namespace]; ok {
+			continue
+		}
+
+		namespace, err := h.findNamespace(ctx, auth.AuthorizedWorkspaceID, l.Namespace)
+		if err != nil {
+			return err
+		}
+		namespaces[l.Namespace] = namespace
+	}
+
+	// Limits are deduplicated by namespace id, a namespace may be referred to
+	// by id in one limit and by name in another
+	seen := make(map[string]int, len(req.Limits))
+	for i, l := range req.Limits {
+		key := namespaces[l.Namespace].ID + "\x00" + l.Identifier
+		if j, ok := seen[key]; ok {
+			return fault.New("duplicate limit",
+				fault.Code(codes.App.Validation.InvalidInput.URN()),
+				fault.Internal("duplicate namespace and identifier"),
+				fault.Public(fmt.Sprintf("limits[%d] and limits[%d] use the same namespace and identifier.", j, i)),
+			)
+		}
+		seen[key] = i
+	}
+
+	// The root key needs permission to limit in every namespace
+	permissions := make([]rbac.PermissionQuery, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		permissions = append(permissions, rbac.Or(
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Ratelimit,
+				ResourceID:   namespace.ID,
+				Action:       rbac.Limit,
+			}),
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Ratelimit,
+				ResourceID:   "*",
+				Action:       rbac.Limit,
+			}),
+		))
+	}
+
+	err = auth.VerifyRootKey(ctx, keys.WithPermissions(rbac.And(permissions...)))
+	if err != nil {
+		return err
+	}
+
+	now := time.Time{}
+	if h.TestMode {
+		header := s.Request().Header.Get("X-Test-Time")
+		if header != "" {
+			i, parseErr := strconv.ParseInt(header, 10, 64)
+			if parseErr != nil {
+				h.Logger.Warn("invalid test time", "header", header)
+			} else {
+				now = time.UnixMilli(i)
+			}
+		}
+	}
+
+	limitReqs := make([]ratelimit.RatelimitRequest, len(req.Limits))
+	checks := make([]openapi.V2RatelimitMultiLimitCheck, len(req.Limits))
+	for i, l := range req.Limits {
+		namespace := namespaces[l.Namespace]
+
+		// Determine limit and duration from override or request
+		var (
+			limit      = l.Limit
+			duration   = l.Duration
+			algorithm  = ratelimit.Algorithm(namespace.Algorithm)
+			refillRate = int64(namespace.RefillRate.Int32)
+		)
+
+		override, found, err := matchOverride(l.Identifier, namespace)
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.UnexpectedError.URN()),
+				fault.Internal("error matching overrides"), fault.Public("Error matching ratelimit override"),
+			)
+		}
+
+		if found {
+			limit = override.Limit
+			duration = override.Duration
+			checks[i].OverrideId = &override.ID
+
+			// Overrides without an algorithm keep the namespace's algorithm and
+			// refill rate, the override's refill rate belongs to its own algorithm
+			if override.Algorithm != "" {
+				algorithm = ratelimit.Algorithm(override.Algorithm)
+				if algorithm == ratelimit.TokenBucket {
+					refillRate = override.RefillRate
+				}
+			}
+		}
+
+		cost := int64(1)
+		if l.Cost != nil {
+			cost = *l.Cost
+		}
+
+		limitReqs[i] = ratelimit.RatelimitRequest{
+			Identifier: namespace.ID + ":" + l.Identifier,
+			Duration:   time.Duration(duration) * time.Millisecond,
+			Limit:      limit,
+			Cost:       cost,
+			Time:       now,
+			Algorithm:  algorithm,
+			RefillRate: refillRate,
+		}
+
+		checks[i].Namespace = l.Namespace
+		checks[i].Identifier = l.Identifier
+		checks[i].Limit = limit
+	}
+
+	results, err := h.Ratelimit.RatelimitMany(ctx, limitReqs)
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Internal("rate limit failed"),
+			fault.Public("We're unable to process the rate limit request."),
+		)
+	}
+
+	passed := true
+	for i, result := range results {
+		checks[i].Success = result.Success
+		checks[i].Remaining = result.Remaining
+		checks[i].Reset = result.Reset.UnixMilli()
+
+		if !result.Success {
+			passed = false
+		}
+	}
+
+	if s.ShouldLogRequestToClickHouse() {
+		for _, l := range req.Limits {
+			h.ClickHouse.BufferRatelimit(schema.RatelimitRequestV1{
+				RequestID:   s.RequestID(),
+				WorkspaceID: auth.AuthorizedWorkspaceID,
+				Time:        time.Now().UnixMilli(),
+				NamespaceID: namespaces[l.Namespace].ID,
+				Identifier:  l.Identifier,
+				// A limit that passed on its own was still not consumed
+				Passed: passed,
+			})
+		}
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2RatelimitMultiLimitResponseData{
+			Passed: passed,
+			Limits: checks,
+		},
+	})
+}
+
+// findNamespace loads a namespace with its overrides by id or name and
+// returns a not found error if it doesn't exist or was deleted.
+func (h *Handler) findNamespace(ctx context.Context, workspaceID, namespaceKey string) (db.FindRatelimitNamespace, error) {
+	namespace, hit, err := h.RatelimitNamespaceCache.SWR(ctx,
+		cache.ScopedKey{WorkspaceID: workspaceID, Key: namespaceKey},
+		func(ctx context.Context) (db.FindRatelimitNamespace, error) {
+			result := db.FindRatelimitNamespace{} // nolint:exhaustruct
+
+			response, err := db.WithRetry(func() (db.FindRatelimitNamespaceRow, error) {
+				return db.Query.FindRatelimitNamespace(ctx, h.DB.RO(), db.FindRatelimitNamespaceParams{
+					WorkspaceID: workspaceID,
+					Namespace:   namespaceKey,
+				})
+			})
+			if err != nil {
+				return result, err
+			}
+
+			result = db.FindRatelimitNamespace{
+				ID:                response.ID,
+				WorkspaceID:       response.WorkspaceID,
+				Name:              response.Name,
+				Algorithm:         response.Algorithm,
+				RefillRate:        response.RefillRate,
+				CreatedAtM:        response.CreatedAtM,
+				UpdatedAtM:        response.UpdatedAtM,
+				DeletedAtM:        response.DeletedAtM,
+				DirectOverrides:   make(map[string]db.FindRatelimitNamespaceLimitOverride),
+				WildcardOverrides: make([]db.FindRatelimitNamespaceLimitOverride, 0),
+			}
+
+			overrides := make([]db.FindRatelimitNamespaceLimitOverride, 0)
+			if overrideBytes, ok := response.Overrides.([]byte); ok && overrideBytes != nil {
+				err = json.Unmarshal(overrideBytes, &overrides)
+				if err != nil {
+					return result, err
+				}
+			}
+
+			for _, override := range overrides {
+				result.DirectOverrides[override.Identifier] = override
+				if strings.Contains(override.Identifier, "*") {
+					result.WildcardOverrides = append(result.WildcardOverrides, override)
+				}
+			}
+
+			return result, nil
+		}, caches.DefaultFindFirstOp)
+
+	if err != nil {
+		if db.IsNotFound(err) {
+			return namespace, fault.New("namespace was deleted",
+				fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+				fault.Public(fmt.Sprintf("The namespace %q does not exist.", namespaceKey)),
+			)
+		}
+
+		return namespace, fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Public("An unexpected error occurred while fetching the namespace."),
+		)
+	}
+
+	if hit == cache.Null || namespace.DeletedAtM.Valid {
+		return namespace, fault.New("namespace not found",
+			fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+			fault.Public(fmt.Sprintf("The namespace %q does not exist.", namespaceKey)),
+		)
+	}
+
+	return namespace, nil
+}
+
+func matchOverride(identifier string, namespace db.FindRatelimitNamespace) (db.FindRatelimitNamespaceLimitOverride, bool, error) {
+	if override, ok := namespace.DirectOverrides[identifier]; ok {
+		return override, true, nil
+	}
+
+	for _, override := range namespace.WildcardOverrides {
+		ok, err := match.Wildcard(identifier, override.Identifier)
+		if err != nil {
+			return db.FindRatelimitNamespaceLimitOverride{}, false, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		return override, true, nil
+	}
+
+	return db.FindRatelimitNamespaceLimitOverride{}, false, nil
+}
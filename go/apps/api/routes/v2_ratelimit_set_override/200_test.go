@@ -2,14 +2,17 @@ package handler_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
 	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_set_override"
 	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
 	"github.com/unkeyed/unkey/go/pkg/testutil"
 	"github.com/unkeyed/unkey/go/pkg/uid"
 )
@@ -160,4 +163,43 @@ func TestSetOverrideSuccessfully(t *testing.T) {
 		require.EqualValues(t, req2.Limit, override.Limit)
 		require.EqualValues(t, req2.Duration, override.Duration)
 	})
+
+	t.Run("create override with token bucket algorithm", func(t *testing.T) {
+		req := handler.Request{
+			Namespace:  namespaceID,
+			Identifier: "user_789",
+			Limit:      100,
+			Duration:   60000,
+			Algorithm:  ptr.P(openapi.TokenBucket),
+			RefillRate: ptr.P(int64(10)),
+		}
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+		require.NotNil(t, res.Body)
+
+		override, err := db.Query.FindRatelimitOverrideByID(ctx, h.DB.RO(), db.FindRatelimitOverrideByIDParams{
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			OverrideID:  res.Body.Data.OverrideId,
+		})
+		require.NoError(t, err)
+		require.True(t, override.Algorithm.Valid)
+		require.Equal(t, db.RatelimitOverridesAlgorithmTokenBucket, override.Algorithm.RatelimitOverridesAlgorithm)
+		require.Equal(t, sql.NullInt32{Int32: 10, Valid: true}, override.RefillRate)
+
+		// Setting the override again without an algorithm falls back to the namespace's
+		req.Algorithm = nil
+		req.RefillRate = nil
+
+		res = testutil.CallRoute[handler.Request, handler.Response](h, route, headers, req)
+		require.Equal(t, 200, res.Status, "expected 200, received: %s", res.RawBody)
+
+		override, err = db.Query.FindRatelimitOverrideByID(ctx, h.DB.RO(), db.FindRatelimitOverrideByIDParams{
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			OverrideID:  res.Body.Data.OverrideId,
+		})
+		require.NoError(t, err)
+		require.False(t, override.Algorithm.Valid)
+		require.False(t, override.RefillRate.Valid)
+	})
 }
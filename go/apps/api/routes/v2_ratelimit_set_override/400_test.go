@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/unkeyed/unkey/go/apps/api/openapi"
 	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_set_override"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
 	"github.com/unkeyed/unkey/go/pkg/testutil"
 	"github.com/unkeyed/unkey/go/pkg/uid"
 )
@@ -171,6 +172,41 @@ func TestBadRequests(t *testing.T) {
 		require.Greater(t, len(res.Body.Error.Errors), 0)
 	})
 
+	t.Run("unknown algorithm", func(t *testing.T) {
+		req := openapi.V2RatelimitSetOverrideRequestBody{
+			Namespace:  "test_namespace_id",
+			Identifier: "test_identifier",
+			Limit:      10,
+			Duration:   1000,
+			Algorithm:  ptr.P(openapi.RatelimitAlgorithm("leaky_bucket")),
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+
+		require.Equal(t, 400, res.Status, "expected 400, sent: %+v, received: %s", req, res.RawBody)
+		require.NotNil(t, res.Body)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/application/invalid_input", res.Body.Error.Type)
+		require.Greater(t, len(res.Body.Error.Errors), 0)
+	})
+
+	t.Run("invalid refill rate (zero)", func(t *testing.T) {
+		req := openapi.V2RatelimitSetOverrideRequestBody{
+			Namespace:  "test_namespace_id",
+			Identifier: "test_identifier",
+			Limit:      10,
+			Duration:   1000,
+			Algorithm:  ptr.P(openapi.TokenBucket),
+			RefillRate: ptr.P(int64(0)),
+		}
+
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, req)
+
+		require.Equal(t, 400, res.Status, "expected 400, sent: %+v, received: %s", req, res.RawBody)
+		require.NotNil(t, res.Body)
+		require.Equal(t, "https://unkey.com/docs/errors/unkey/application/invalid_input", res.Body.Error.Type)
+		require.Greater(t, len(res.Body.Error.Errors), 0)
+	})
+
 	t.Run("malformed authorization header", func(t *testing.T) {
 		headers := http.Header{
 			"Content-Type":  {"application/json"},
@@ -114,6 +114,20 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			overrideID = override.ID
 		}
 
+		// Without an algorithm the override falls back to the namespace's
+		algorithm := db.NullRatelimitOverridesAlgorithm{} // nolint:exhaustruct
+		if req.Algorithm != nil {
+			algorithm = db.NullRatelimitOverridesAlgorithm{
+				RatelimitOverridesAlgorithm: db.RatelimitOverridesAlgorithm(*req.Algorithm),
+				Valid:                       true,
+			}
+		}
+
+		refillRate := sql.NullInt32{} // nolint:exhaustruct
+		if req.RefillRate != nil {
+			refillRate = sql.NullInt32{Int32: int32(*req.RefillRate), Valid: true} // nolint:gosec
+		}
+
 		now := time.Now().UnixMilli()
 
 		err = db.Query.InsertRatelimitOverride(ctx, tx, db.InsertRatelimitOverrideParams{
@@ -123,6 +137,8 @@ func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
 			Identifier:  req.Identifier,
 			Limit:       int32(req.Limit),    // nolint:gosec
 			Duration:    int32(req.Duration), //nolint:gosec
+			Algorithm:   algorithm,
+			RefillRate:  refillRate,
 			CreatedAt:   now,
 			UpdatedAt:   sql.NullInt64{Int64: now, Valid: true},
 		})
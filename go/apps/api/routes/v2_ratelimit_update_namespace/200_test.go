@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_update_namespace"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestUpdateNamespaceSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+	err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+		ID:          namespaceID,
+		WorkspaceID: h.Resources().UserWorkspace.ID,
+		Name:        uid.New("test"),
+		CreatedAt:   time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	route := &handler.Handler{
+		Logger:                  h.Logger,
+		DB:                      h.DB,
+		Keys:                    h.Keys,
+		Auditlogs:               h.Auditlogs,
+		RatelimitNamespaceCache: h.Caches.RatelimitNamespace,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, fmt.Sprintf("ratelimit.%s.update_namespace", namespaceID))
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("rename", func(t *testing.T) {
+		newName := uid.New("renamed")
+
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Namespace:  namespaceID,
+			Name:       ptr.P(newName),
+			Algorithm:  nil,
+			RefillRate: nil,
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, newName, res.Body.Data.Name)
+		require.Equal(t, openapi.SlidingWindow, res.Body.Data.Algorithm)
+
+		namespace, err := db.Query.FindRatelimitNamespaceByID(ctx, h.DB.RO(), namespaceID)
+		require.NoError(t, err)
+		require.Equal(t, newName, namespace.Name)
+		require.True(t, namespace.UpdatedAtM.Valid)
+	})
+
+	t.Run("change algorithm", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Namespace:  namespaceID,
+			Name:       nil,
+			Algorithm:  ptr.P(openapi.TokenBucket),
+			RefillRate: ptr.P(int64(3)),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.TokenBucket, res.Body.Data.Algorithm)
+		require.Equal(t, int64(3), *res.Body.Data.RefillRate)
+
+		namespace, err := db.Query.FindRatelimitNamespaceByID(ctx, h.DB.RO(), namespaceID)
+		require.NoError(t, err)
+		require.Equal(t, db.RatelimitNamespacesAlgorithmTokenBucket, namespace.Algorithm)
+		require.Equal(t, int32(3), namespace.RefillRate.Int32)
+	})
+
+	t.Run("change refill rate only", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+			Namespace:  namespaceID,
+			Name:       nil,
+			Algorithm:  nil,
+			RefillRate: ptr.P(int64(7)),
+		})
+		require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+		require.Equal(t, openapi.TokenBucket, res.Body.Data.Algorithm)
+		require.Equal(t, int64(7), *res.Body.Data.RefillRate)
+	})
+}
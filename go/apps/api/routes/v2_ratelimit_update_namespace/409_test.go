@@ -0,0 +1,63 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_ratelimit_update_namespace"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestUpdateNamespaceConflict(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	namespaceID := uid.New(uid.RatelimitNamespacePrefix)
+	takenName := uid.New("taken")
+	for id, name := range map[string]string{
+		namespaceID:                           uid.New("test"),
+		uid.New(uid.RatelimitNamespacePrefix): takenName,
+	} {
+		err := db.Query.InsertRatelimitNamespace(ctx, h.DB.RW(), db.InsertRatelimitNamespaceParams{
+			ID:          id,
+			WorkspaceID: h.Resources().UserWorkspace.ID,
+			Name:        name,
+			CreatedAt:   time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+	}
+
+	route := &handler.Handler{
+		Logger:                  h.Logger,
+		DB:                      h.DB,
+		Keys:                    h.Keys,
+		Auditlogs:               h.Auditlogs,
+		RatelimitNamespaceCache: h.Caches.RatelimitNamespace,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "ratelimit.*.update_namespace")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	res := testutil.CallRoute[handler.Request, openapi.ConflictErrorResponse](h, route, headers, handler.Request{
+		Namespace:  namespaceID,
+		Name:       ptr.P(takenName),
+		Algorithm:  nil,
+		RefillRate: nil,
+	})
+	require.Equal(t, 409, res.Status)
+	require.NotNil(t, res.Body.Error)
+}
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	"github.com/unkeyed/unkey/go/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/pkg/auditlog"
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2RatelimitUpdateNamespaceRequestBody
+type Response = openapi.V2RatelimitUpdateNamespaceResponseBody
+
+// Handler implements zen.Route interface for the v2 ratelimit update namespace endpoint
+type Handler struct {
+	// Services as public fields
+	Logger                  logging.Logger
+	DB                      db.Database
+	Keys                    keys.KeyService
+	Auditlogs               auditlogs.AuditLogService
+	RatelimitNamespaceCache cache.Cache[cache.ScopedKey, db.FindRatelimitNamespace]
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/ratelimit.updateNamespace"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	auth, emit, err := h.Keys.GetRootKey(ctx, s)
+	defer emit()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	updated, err := db.TxWithResult(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) (openapi.RatelimitNamespace, error) {
+		namespace, err := db.Query.FindRatelimitNamespace(ctx, tx, db.FindRatelimitNamespaceParams{
+			WorkspaceID: auth.AuthorizedWorkspaceID,
+			Namespace:   req.Namespace,
+		})
+		if err != nil {
+			if db.IsNotFound(err) {
+				return openapi.RatelimitNamespace{}, fault.New("namespace not found",
+					fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+					fault.Internal("namespace not found"),
+					fault.Public("This namespace does not exist."),
+				)
+			}
+			return openapi.RatelimitNamespace{}, err
+		}
+
+		if namespace.DeletedAtM.Valid {
+			return openapi.RatelimitNamespace{}, fault.New("namespace was deleted",
+				fault.Code(codes.Data.RatelimitNamespace.NotFound.URN()),
+				fault.Public("This namespace does not exist."),
+			)
+		}
+
+		err = auth.VerifyRootKey(ctx, keys.WithPermissions(rbac.Or(
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Ratelimit,
+				ResourceID:   namespace.ID,
+				Action:       rbac.UpdateNamespace,
+			}),
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Ratelimit,
+				ResourceID:   "*",
+				Action:       rbac.UpdateNamespace,
+			}),
+		)))
+		if err != nil {
+			return openapi.RatelimitNamespace{}, err
+		}
+
+		now := sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true}
+
+		name := namespace.Name
+		if req.Name != nil && *req.Name != namespace.Name {
+			name = *req.Name
+			err = db.Query.UpdateRatelimitNamespaceName(ctx, tx, db.UpdateRatelimitNamespaceNameParams{
+				Name: name,
+				Now:  now,
+				ID:   namespace.ID,
+			})
+			if err != nil {
+				if db.IsDuplicateKeyError(err) {
+					return openapi.RatelimitNamespace{}, fault.Wrap(err,
+						fault.Code(codes.Data.RatelimitNamespace.Duplicate.URN()),
+						fault.Internal("namespace already exists"),
+						fault.Public(fmt.Sprintf("A namespace with name %q already exists in this workspace.", name)),
+					)
+				}
+				return openapi.RatelimitNamespace{}, fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database failed to rename ratelimit namespace"),
+					fault.Public("The database is unavailable."),
+				)
+			}
+		}
+
+		algorithm := namespace.Algorithm
+		refillRate := namespace.RefillRate
+		if req.Algorithm != nil || req.RefillRate != nil {
+			if req.Algorithm != nil {
+				algorithm = db.RatelimitNamespacesAlgorithm(*req.Algorithm)
+			}
+
+			refillRate = sql.NullInt32{} // nolint:exhaustruct
+			if req.RefillRate != nil {
+				refillRate = sql.NullInt32{Int32: int32(*req.RefillRate), Valid: true} // nolint:gosec
+			}
+
+			err = db.Query.UpdateRatelimitNamespaceAlgorithm(ctx, tx, db.UpdateRatelimitNamespaceAlgorithmParams{
+				Algorithm:  algorithm,
+				RefillRate: refillRate,
+				Now:        now,
+				ID:         namespace.ID,
+			})
+			if err != nil {
+				return openapi.RatelimitNamespace{}, fault.Wrap(err,
+					fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+					fault.Internal("database failed to update ratelimit namespace algorithm"),
+					fault.Public("The database is unavailable."),
+				)
+			}
+		}
+
+		err = h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID: auth.AuthorizedWorkspaceID,
+				Event:       auditlog.RatelimitNamespaceUpdateEvent,
+				Display:     fmt.Sprintf("Updated ratelimit namespace %s.", namespace.ID),
+				ActorID:     auth.Key.ID,
+				ActorType:   auditlog.RootKeyActor,
+				ActorName:   "root key",
+				ActorMeta:   map[string]any{},
+				RemoteIP:    s.Location(),
+				UserAgent:   s.UserAgent(),
+				Resources: []auditlog.AuditLogResource{
+					{
+						ID:          namespace.ID,
+						Name:        name,
+						DisplayName: name,
+						Type:        auditlog.RatelimitNamespaceResourceType,
+						Meta: map[string]any{
+							"previousName": namespace.Name,
+							"algorithm":    string(algorithm),
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			return openapi.RatelimitNamespace{}, err
+		}
+
+		h.RatelimitNamespaceCache.Remove(ctx,
+			cache.ScopedKey{
+				WorkspaceID: auth.AuthorizedWorkspaceID,
+				Key:         namespace.ID,
+			},
+			cache.ScopedKey{
+				WorkspaceID: auth.AuthorizedWorkspaceID,
+				Key:         namespace.Name,
+			},
+			cache.ScopedKey{
+				WorkspaceID: auth.AuthorizedWorkspaceID,
+				Key:         name,
+			},
+		)
+
+		result := openapi.RatelimitNamespace{
+			NamespaceId: namespace.ID,
+			Name:        name,
+			Algorithm:   openapi.RatelimitAlgorithm(algorithm),
+			RefillRate:  nil,
+		}
+		if refillRate.Valid {
+			result.RefillRate = ptr.P(int64(refillRate.Int32))
+		}
+
+		return result, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: updated,
+	})
+}
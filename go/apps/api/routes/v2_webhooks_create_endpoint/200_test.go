@@ -0,0 +1,65 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_webhooks_create_endpoint"
+	vaultv1 "github.com/unkeyed/unkey/go/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+)
+
+func TestCreateEndpointSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		Logger:    h.Logger,
+		DB:        h.DB,
+		Keys:      h.Keys,
+		Auditlogs: h.Auditlogs,
+		Vault:     h.Vault,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "webhook.*.create_endpoint")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{
+		Url:    "https://example.com/webhooks",
+		Events: []openapi.WebhookEvent{openapi.KeyExpiringSoon, openapi.KeyCreditsLow},
+	})
+	require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+	require.NotEmpty(t, res.Body.Data.EndpointId)
+	require.NotEmpty(t, res.Body.Data.Secret)
+
+	endpoint, err := db.Query.FindWebhookEndpointByID(ctx, h.DB.RO(), res.Body.Data.EndpointId)
+	require.NoError(t, err)
+	require.Equal(t, h.Resources().UserWorkspace.ID, endpoint.WorkspaceID)
+	require.Equal(t, "https://example.com/webhooks", endpoint.Url)
+	require.True(t, endpoint.Enabled)
+
+	var events []string
+	require.NoError(t, json.Unmarshal(endpoint.Events, &events))
+	require.Equal(t, []string{"key.expiring_soon", "key.credits_low"}, events)
+
+	// The secret is only stored encrypted
+	require.NotEqual(t, res.Body.Data.Secret, endpoint.Encrypted)
+	decrypted, err := h.Vault.Decrypt(ctx, &vaultv1.DecryptRequest{
+		Keyring:   endpoint.WorkspaceID,
+		Encrypted: endpoint.Encrypted,
+	})
+	require.NoError(t, err)
+	require.Equal(t, res.Body.Data.Secret, decrypted.GetPlaintext())
+}
@@ -0,0 +1,78 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_webhooks_create_endpoint"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+)
+
+func TestCreateEndpointBadRequest(t *testing.T) {
+	h := testutil.NewHarness(t)
+
+	route := &handler.Handler{
+		Logger:    h.Logger,
+		DB:        h.DB,
+		Keys:      h.Keys,
+		Auditlogs: h.Auditlogs,
+		Vault:     h.Vault,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "webhook.*.create_endpoint")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	t.Run("insecure url", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			Url:    "http://example.com/webhooks",
+			Events: []openapi.WebhookEvent{openapi.KeyExpired},
+		})
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+
+	for _, url := range []string{
+		"https://127.0.0.1/webhooks",
+		"https://10.0.0.1/webhooks",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/webhooks",
+		"https://[::ffff:192.168.0.1]/webhooks",
+		"https://localhost/webhooks",
+	} {
+		t.Run(fmt.Sprintf("private url %s", url), func(t *testing.T) {
+			res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+				Url:    url,
+				Events: []openapi.WebhookEvent{openapi.KeyExpired},
+			})
+			require.Equal(t, 400, res.Status)
+			require.NotNil(t, res.Body.Error)
+		})
+	}
+
+	t.Run("no events", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			Url:    "https://example.com/webhooks",
+			Events: []openapi.WebhookEvent{},
+		})
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		res := testutil.CallRoute[handler.Request, openapi.BadRequestErrorResponse](h, route, headers, handler.Request{
+			Url:    "https://example.com/webhooks",
+			Events: []openapi.WebhookEvent{"key.unknown"},
+		})
+		require.Equal(t, 400, res.Status)
+		require.NotNil(t, res.Body.Error)
+	})
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	vaultv1 "github.com/unkeyed/unkey/go/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/go/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/pkg/auditlog"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+	"github.com/unkeyed/unkey/go/pkg/vault"
+	"github.com/unkeyed/unkey/go/pkg/webhook"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2WebhooksCreateEndpointRequestBody
+type Response = openapi.V2WebhooksCreateEndpointResponseBody
+
+// Handler implements zen.Route interface for the v2 webhooks create endpoint endpoint
+type Handler struct {
+	// Services as public fields
+	Logger    logging.Logger
+	DB        db.Database
+	Keys      keys.KeyService
+	Auditlogs auditlogs.AuditLogService
+	Vault     *vault.Service
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/webhooks.createEndpoint"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	auth, emit, err := h.Keys.GetRootKey(ctx, s)
+	defer emit()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = auth.VerifyRootKey(ctx, keys.WithPermissions(rbac.T(rbac.Tuple{
+		ResourceType: rbac.Webhook,
+		ResourceID:   "*",
+		Action:       rbac.CreateEndpoint,
+	})))
+	if err != nil {
+		return err
+	}
+
+	// The secret is stored encrypted, so deliveries can sign with it later
+	if h.Vault == nil {
+		return fault.New("vault missing",
+			fault.Code(codes.App.Precondition.PreconditionFailed.URN()),
+			fault.Public("Vault hasn't been set up."),
+		)
+	}
+
+	u, err := url.Parse(req.Url)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fault.New("invalid webhook url",
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("invalid webhook url"), fault.Public("The url must be a valid https URL."),
+		)
+	}
+
+	// Deliveries are sent from inside our network, they must not reach
+	// internal services
+	err = webhook.ValidateURL(ctx, net.DefaultResolver, req.Url)
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("webhook url is not public"), fault.Public("The url must resolve to a public IP address."),
+		)
+	}
+
+	events, err := json.Marshal(req.Events)
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Validation.InvalidInput.URN()),
+			fault.Internal("unable to marshal events"), fault.Public("We're unable to marshal the events."),
+		)
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.UnexpectedError.URN()),
+			fault.Internal("unable to generate webhook secret"), fault.Public("We're unable to generate a secret."),
+		)
+	}
+
+	encryption, err := h.Vault.Encrypt(ctx, &vaultv1.EncryptRequest{
+		Keyring: auth.AuthorizedWorkspaceID,
+		Data:    secret,
+	})
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("vault error"), fault.Public("Failed to encrypt webhook secret in vault."),
+		)
+	}
+
+	endpointID := uid.New(uid.WebhookEndpointPrefix)
+
+	err = db.Tx(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		err := db.Query.InsertWebhookEndpoint(ctx, tx, db.InsertWebhookEndpointParams{
+			ID:              endpointID,
+			WorkspaceID:     auth.AuthorizedWorkspaceID,
+			Url:             req.Url,
+			Events:          events,
+			Encrypted:       encryption.GetEncrypted(),
+			EncryptionKeyID: encryption.GetKeyId(),
+			CreatedAt:       time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to insert webhook endpoint"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		return h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID: auth.AuthorizedWorkspaceID,
+				Event:       auditlog.WebhookCreateEvent,
+				Display:     fmt.Sprintf("Created webhook endpoint %s for %s.", endpointID, u.Host),
+				ActorID:     auth.Key.ID,
+				ActorType:   auditlog.RootKeyActor,
+				ActorName:   "root key",
+				ActorMeta:   map[string]any{},
+				RemoteIP:    s.Location(),
+				UserAgent:   s.UserAgent(),
+				Resources: []auditlog.AuditLogResource{
+					{
+						ID:          endpointID,
+						Name:        req.Url,
+						DisplayName: req.Url,
+						Type:        auditlog.WebhookResourceType,
+						Meta:        nil,
+					},
+				},
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2WebhooksCreateEndpointResponseData{
+			EndpointId: endpointID,
+			Secret:     secret,
+		},
+	})
+}
@@ -0,0 +1,60 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_webhooks_delete_endpoint"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestDeleteEndpointForbidden(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	endpointID := uid.New(uid.WebhookEndpointPrefix)
+	err := db.Query.InsertWebhookEndpoint(ctx, h.DB.RW(), db.InsertWebhookEndpointParams{
+		ID:              endpointID,
+		WorkspaceID:     h.Resources().UserWorkspace.ID,
+		Url:             "https://example.com/webhooks",
+		Events:          []byte(`["key.expired"]`),
+		Encrypted:       "encrypted",
+		EncryptionKeyID: "dek",
+		CreatedAt:       time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	route := &handler.Handler{
+		Logger:    h.Logger,
+		DB:        h.DB,
+		Keys:      h.Keys,
+		Auditlogs: h.Auditlogs,
+	}
+
+	h.Register(route)
+
+	// Permission for a different endpoint
+	rootKey := h.CreateRootKey(h.Resources().UserWorkspace.ID, "webhook.whep_other.delete_endpoint")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	res := testutil.CallRoute[handler.Request, openapi.ForbiddenErrorResponse](h, route, headers, handler.Request{
+		EndpointId: endpointID,
+	})
+	require.Equal(t, 403, res.Status)
+	require.NotNil(t, res.Body.Error)
+
+	endpoint, err := db.Query.FindWebhookEndpointByID(ctx, h.DB.RO(), endpointID)
+	require.NoError(t, err)
+	require.False(t, endpoint.DeletedAtM.Valid)
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	"github.com/unkeyed/unkey/go/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/pkg/auditlog"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2WebhooksDeleteEndpointRequestBody
+type Response = openapi.V2WebhooksDeleteEndpointResponseBody
+
+// Handler implements zen.Route interface for the v2 webhooks delete endpoint endpoint
+type Handler struct {
+	// Services as public fields
+	Logger    logging.Logger
+	DB        db.Database
+	Keys      keys.KeyService
+	Auditlogs auditlogs.AuditLogService
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/webhooks.deleteEndpoint"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	auth, emit, err := h.Keys.GetRootKey(ctx, s)
+	defer emit()
+	if err != nil {
+		return err
+	}
+
+	req, err := zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = db.Tx(ctx, h.DB.RW(), func(ctx context.Context, tx db.DBTX) error {
+		endpoint, err := db.Query.FindWebhookEndpointByID(ctx, tx, req.EndpointId)
+		if err != nil {
+			if db.IsNotFound(err) {
+				return fault.New("webhook endpoint not found",
+					fault.Code(codes.Data.Webhook.NotFound.URN()),
+					fault.Internal("webhook endpoint not found"),
+					fault.Public("This webhook endpoint does not exist."),
+				)
+			}
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to find webhook endpoint"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		// Endpoints of other workspaces are reported as missing, so their ids
+		// can't be probed
+		if endpoint.WorkspaceID != auth.AuthorizedWorkspaceID || endpoint.DeletedAtM.Valid {
+			return fault.New("webhook endpoint not found",
+				fault.Code(codes.Data.Webhook.NotFound.URN()),
+				fault.Internal("webhook endpoint belongs to another workspace or was deleted"),
+				fault.Public("This webhook endpoint does not exist."),
+			)
+		}
+
+		err = auth.VerifyRootKey(ctx, keys.WithPermissions(rbac.Or(
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Webhook,
+				ResourceID:   endpoint.ID,
+				Action:       rbac.DeleteEndpoint,
+			}),
+			rbac.T(rbac.Tuple{
+				ResourceType: rbac.Webhook,
+				ResourceID:   "*",
+				Action:       rbac.DeleteEndpoint,
+			}),
+		)))
+		if err != nil {
+			return err
+		}
+
+		err = db.Query.SoftDeleteWebhookEndpoint(ctx, tx, db.SoftDeleteWebhookEndpointParams{
+			ID:  endpoint.ID,
+			Now: sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()},
+		})
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+				fault.Internal("database failed to soft delete webhook endpoint"),
+				fault.Public("The database is unavailable."),
+			)
+		}
+
+		return h.Auditlogs.Insert(ctx, tx, []auditlog.AuditLog{
+			{
+				WorkspaceID: auth.AuthorizedWorkspaceID,
+				Event:       auditlog.WebhookDeleteEvent,
+				Display:     fmt.Sprintf("Deleted webhook endpoint %s.", endpoint.ID),
+				ActorID:     auth.Key.ID,
+				ActorType:   auditlog.RootKeyActor,
+				ActorName:   "root key",
+				ActorMeta:   map[string]any{},
+				RemoteIP:    s.Location(),
+				UserAgent:   s.UserAgent(),
+				Resources: []auditlog.AuditLogResource{
+					{
+						ID:          endpoint.ID,
+						Name:        endpoint.Url,
+						DisplayName: endpoint.Url,
+						Type:        auditlog.WebhookResourceType,
+						Meta:        nil,
+					},
+				},
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.JSON(http.StatusOK, Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: openapi.V2WebhooksDeleteEndpointResponseData{},
+	})
+}
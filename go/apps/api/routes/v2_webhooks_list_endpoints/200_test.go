@@ -0,0 +1,77 @@
+package handler_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	handler "github.com/unkeyed/unkey/go/apps/api/routes/v2_webhooks_list_endpoints"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/testutil"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestListEndpointsSuccessfully(t *testing.T) {
+	ctx := context.Background()
+	h := testutil.NewHarness(t)
+
+	workspaceID := h.Resources().UserWorkspace.ID
+
+	endpointID := uid.New(uid.WebhookEndpointPrefix)
+	err := db.Query.InsertWebhookEndpoint(ctx, h.DB.RW(), db.InsertWebhookEndpointParams{
+		ID:              endpointID,
+		WorkspaceID:     workspaceID,
+		Url:             "https://example.com/webhooks",
+		Events:          []byte(`["key.expired","key.delete"]`),
+		Encrypted:       "encrypted",
+		EncryptionKeyID: "dek",
+		CreatedAt:       time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	// Deleted endpoints are not listed
+	deletedID := uid.New(uid.WebhookEndpointPrefix)
+	err = db.Query.InsertWebhookEndpoint(ctx, h.DB.RW(), db.InsertWebhookEndpointParams{
+		ID:              deletedID,
+		WorkspaceID:     workspaceID,
+		Url:             "https://example.com/deleted",
+		Events:          []byte(`["key.expired"]`),
+		Encrypted:       "encrypted",
+		EncryptionKeyID: "dek",
+		CreatedAt:       time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+	err = db.Query.SoftDeleteWebhookEndpoint(ctx, h.DB.RW(), db.SoftDeleteWebhookEndpointParams{
+		ID:  deletedID,
+		Now: sql.NullInt64{Valid: true, Int64: time.Now().UnixMilli()},
+	})
+	require.NoError(t, err)
+
+	route := &handler.Handler{
+		Logger: h.Logger,
+		DB:     h.DB,
+		Keys:   h.Keys,
+	}
+
+	h.Register(route)
+
+	rootKey := h.CreateRootKey(workspaceID, "webhook.*.read_endpoint")
+
+	headers := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {fmt.Sprintf("Bearer %s", rootKey)},
+	}
+
+	res := testutil.CallRoute[handler.Request, handler.Response](h, route, headers, handler.Request{})
+	require.Equal(t, 200, res.Status, "expected 200, received: %#v", res)
+	require.Len(t, res.Body.Data, 1)
+	require.Equal(t, endpointID, res.Body.Data[0].EndpointId)
+	require.Equal(t, "https://example.com/webhooks", res.Body.Data[0].Url)
+	require.Equal(t, []openapi.WebhookEvent{openapi.KeyExpired, openapi.KeyDelete}, res.Body.Data[0].Events)
+	require.True(t, res.Body.Data[0].Enabled)
+}
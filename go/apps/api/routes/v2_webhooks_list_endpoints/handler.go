@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/unkeyed/unkey/go/apps/api/openapi"
+	"github.com/unkeyed/unkey/go/internal/services/keys"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/rbac"
+	"github.com/unkeyed/unkey/go/pkg/zen"
+)
+
+type Request = openapi.V2WebhooksListEndpointsRequestBody
+type Response = openapi.V2WebhooksListEndpointsResponseBody
+
+// Handler implements zen.Route interface for the v2 webhooks list endpoints endpoint
+type Handler struct {
+	// Services as public fields
+	Logger logging.Logger
+	DB     db.Database
+	Keys   keys.KeyService
+}
+
+// Method returns the HTTP method this route responds to
+func (h *Handler) Method() string {
+	return "POST"
+}
+
+// Path returns the URL path pattern this route matches
+func (h *Handler) Path() string {
+	return "/v2/webhooks.listEndpoints"
+}
+
+// Handle processes the HTTP request
+func (h *Handler) Handle(ctx context.Context, s *zen.Session) error {
+	auth, emit, err := h.Keys.GetRootKey(ctx, s)
+	defer emit()
+	if err != nil {
+		return err
+	}
+
+	_, err = zen.BindBody[Request](s)
+	if err != nil {
+		return err
+	}
+
+	err = auth.VerifyRootKey(ctx, keys.WithPermissions(rbac.T(rbac.Tuple{
+		ResourceType: rbac.Webhook,
+		ResourceID:   "*",
+		Action:       rbac.ReadEndpoint,
+	})))
+	if err != nil {
+		return err
+	}
+
+	endpoints, err := db.Query.ListWebhookEndpointsByWorkspaceID(ctx, h.DB.RO(), auth.AuthorizedWorkspaceID)
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.App.Internal.ServiceUnavailable.URN()),
+			fault.Internal("database failed to list webhook endpoints"),
+			fault.Public("The database is unavailable."),
+		)
+	}
+
+	responseBody := Response{
+		Meta: openapi.Meta{
+			RequestId: s.RequestID(),
+		},
+		Data: make([]openapi.WebhookEndpoint, len(endpoints)),
+	}
+
+	for i, endpoint := range endpoints {
+		events := []openapi.WebhookEvent{}
+		err = json.Unmarshal(endpoint.Events, &events)
+		if err != nil {
+			return fault.Wrap(err,
+				fault.Code(codes.App.Internal.UnexpectedError.URN()),
+				fault.Internal("unable to unmarshal webhook endpoint events"),
+				fault.Public("We're unable to read the events of this endpoint."),
+			)
+		}
+
+		responseBody.Data[i] = openapi.WebhookEndpoint{
+			EndpointId: endpoint.ID,
+			Url:        endpoint.Url,
+			Events:     events,
+			Enabled:    endpoint.Enabled,
+			CreatedAt:  endpoint.CreatedAtM,
+		}
+	}
+
+	return s.JSON(http.StatusOK, responseBody)
+}
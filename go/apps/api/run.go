@@ -13,6 +13,9 @@ import (
 	"github.com/unkeyed/unkey/go/internal/services/caches"
 	"github.com/unkeyed/unkey/go/internal/services/keys"
 	"github.com/unkeyed/unkey/go/internal/services/ratelimit"
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/cache/invalidation"
+	"github.com/unkeyed/unkey/go/pkg/cache/l2"
 	"github.com/unkeyed/unkey/go/pkg/clickhouse"
 	"github.com/unkeyed/unkey/go/pkg/clock"
 	"github.com/unkeyed/unkey/go/pkg/counter"
@@ -132,9 +135,36 @@ func Run(ctx context.Context, cfg Config) error {
 		}
 	}
 
+	invalidations, err := invalidation.NewRedis(invalidation.RedisConfig{
+		RedisURL: cfg.RedisUrl,
+		Channel:  "",
+		Logger:   logger,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create cache invalidation broadcaster: %w", err)
+	}
+
+	shutdowns.Register(invalidations.Close)
+
+	var cacheL2 cache.L2
+	if len(cfg.CacheL2Resources) > 0 {
+		redisL2, closeL2, l2Err := l2.NewRedis(l2.RedisConfig{
+			RedisURL: cfg.RedisUrl,
+			Logger:   logger,
+		})
+		if l2Err != nil {
+			return fmt.Errorf("unable to create cache l2: %w", l2Err)
+		}
+		shutdowns.Register(closeL2)
+		cacheL2 = redisL2
+	}
+
 	caches, err := caches.New(caches.Config{
-		Logger: logger,
-		Clock:  clk,
+		Logger:        logger,
+		Clock:         clk,
+		Invalidations: invalidations,
+		L2:            cacheL2,
+		L2Resources:   cfg.CacheL2Resources,
 	})
 	if err != nil {
 		return fmt.Errorf("unable to create caches: %w", err)
@@ -196,14 +226,8 @@ func Run(ctx context.Context, cfg Config) error {
 	shutdowns.Register(ctr.Close)
 
 	var vaultSvc *vault.Service
-	if len(cfg.VaultMasterKeys) > 0 && cfg.VaultS3 != nil {
-		vaultStorage, err := storage.NewS3(storage.S3Config{
-			Logger:            logger,
-			S3URL:             cfg.VaultS3.URL,
-			S3Bucket:          cfg.VaultS3.Bucket,
-			S3AccessKeyID:     cfg.VaultS3.AccessKeyID,
-			S3AccessKeySecret: cfg.VaultS3.AccessKeySecret,
-		})
+	if len(cfg.VaultMasterKeys) > 0 && (cfg.VaultS3 != nil || cfg.VaultFilesystem != nil || cfg.VaultMySQL != nil) {
+		vaultStorage, err := newVaultStorage(cfg, logger)
 		if err != nil {
 			return fmt.Errorf("unable to create vault storage: %w", err)
 		}
@@ -261,3 +285,29 @@ func Run(ctx context.Context, cfg Config) error {
 	logger.Info("API server shut down successfully")
 	return nil
 }
+
+// newVaultStorage creates the configured vault storage.
+func newVaultStorage(cfg Config, logger logging.Logger) (storage.Storage, error) {
+	switch {
+	case cfg.VaultS3 != nil:
+		return storage.NewS3(storage.S3Config{
+			Logger:            logger,
+			S3URL:             cfg.VaultS3.URL,
+			S3Bucket:          cfg.VaultS3.Bucket,
+			S3AccessKeyID:     cfg.VaultS3.AccessKeyID,
+			S3AccessKeySecret: cfg.VaultS3.AccessKeySecret,
+		})
+	case cfg.VaultFilesystem != nil:
+		return storage.NewFilesystem(storage.FilesystemConfig{
+			Logger: logger,
+			Path:   cfg.VaultFilesystem.Path,
+		})
+	case cfg.VaultMySQL != nil:
+		return storage.NewMySQL(storage.MySQLConfig{
+			Logger: logger,
+			DSN:    cfg.VaultMySQL.DSN,
+		})
+	default:
+		return nil, fmt.Errorf("no vault storage configured")
+	}
+}
@@ -1,6 +1,8 @@
 package ctrl
 
 import (
+	"time"
+
 	"github.com/unkeyed/unkey/go/pkg/assert"
 	"github.com/unkeyed/unkey/go/pkg/clock"
 	"github.com/unkeyed/unkey/go/pkg/tls"
@@ -30,6 +32,10 @@ type Config struct {
 	DatabasePartition string
 	DatabaseHydra     string
 
+	// RedisUrl is the Redis connection string used by the usage limiter.
+	// Optional, when set refilled keys have their credit counters updated in Redis.
+	RedisUrl string
+
 	// --- OpenTelemetry configuration ---
 
 	// Enable sending otel data to the collector endpoint for metrics, traces, and logs
@@ -52,7 +58,29 @@ type Config struct {
 
 	// --- Vault Configuration ---
 	VaultMasterKeys []string
+
+	// The storage of the vault's encrypted data encryption keys, at most one
+	// may be set. Vault is disabled if none is set. It must be the storage
+	// the API uses, otherwise ctrl can not decrypt the webhook secrets the
+	// API encrypted.
 	VaultS3         *storage.S3Config
+	VaultFilesystem *storage.FilesystemConfig
+	VaultMySQL      *storage.MySQLConfig
+
+	// VaultDEKRotationInterval is the age after which the data encryption keys
+	// of vault keyrings are rotated and encrypted keys re-encrypted.
+	// Rotation is disabled if zero.
+	VaultDEKRotationInterval time.Duration
+
+	// --- Webhook Configuration ---
+
+	// WebhookExpiringWindow is how long before its expiration a key is
+	// reported to webhook endpoints as expiring soon.
+	WebhookExpiringWindow time.Duration
+
+	// WebhookCreditsThreshold is the number of remaining credits at which a
+	// key is reported to webhook endpoints as running low.
+	WebhookCreditsThreshold int
 }
 
 func (c Config) Validate() error {
@@ -68,5 +96,31 @@ func (c Config) Validate() error {
 		}
 	}
 
-	return nil
+	if c.VaultFilesystem != nil {
+		err := assert.NotEmpty(c.VaultFilesystem.Path, "vault filesystem path is empty")
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.VaultMySQL != nil {
+		err := assert.NotEmpty(c.VaultMySQL.DSN, "vault mysql dsn is empty")
+		if err != nil {
+			return err
+		}
+	}
+
+	vaultStorages := 0
+	for _, configured := range []bool{c.VaultS3 != nil, c.VaultFilesystem != nil, c.VaultMySQL != nil} {
+		if configured {
+			vaultStorages++
+		}
+	}
+
+	return assert.All(
+		assert.LessOrEqual(vaultStorages, 1, "only one vault storage can be configured"),
+		assert.GreaterOrEqual(c.VaultDEKRotationInterval, 0, "vault dek rotation interval must not be negative"),
+		assert.Greater(c.WebhookExpiringWindow, 0, "webhook expiring window must be positive"),
+		assert.Greater(c.WebhookCreditsThreshold, 0, "webhook credits threshold must be positive"),
+	)
 }
@@ -5,17 +5,26 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"connectrpc.com/connect"
 	"github.com/unkeyed/unkey/go/apps/ctrl/services/acme"
 	"github.com/unkeyed/unkey/go/apps/ctrl/services/acme/providers"
 	"github.com/unkeyed/unkey/go/apps/ctrl/services/ctrl"
+	"github.com/unkeyed/unkey/go/apps/ctrl/services/dekrotation"
 	"github.com/unkeyed/unkey/go/apps/ctrl/services/deployment"
+	"github.com/unkeyed/unkey/go/apps/ctrl/services/keyrefill"
 	"github.com/unkeyed/unkey/go/apps/ctrl/services/openapi"
+	"github.com/unkeyed/unkey/go/apps/ctrl/services/routing"
+	"github.com/unkeyed/unkey/go/apps/ctrl/services/webhooks"
+	"github.com/unkeyed/unkey/go/apps/ctrl/services/workflow"
 	deployTLS "github.com/unkeyed/unkey/go/deploy/pkg/tls"
 	"github.com/unkeyed/unkey/go/gen/proto/ctrl/v1/ctrlv1connect"
 	"github.com/unkeyed/unkey/go/gen/proto/metal/vmprovisioner/v1/vmprovisionerv1connect"
+	"github.com/unkeyed/unkey/go/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/go/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/go/pkg/counter"
 	"github.com/unkeyed/unkey/go/pkg/db"
 	"github.com/unkeyed/unkey/go/pkg/hydra"
 	"github.com/unkeyed/unkey/go/pkg/otel"
@@ -70,14 +79,8 @@ func Run(ctx context.Context, cfg Config) error {
 	}
 
 	var vaultSvc *vault.Service
-	if len(cfg.VaultMasterKeys) > 0 && cfg.VaultS3 != nil {
-		vaultStorage, err := storage.NewS3(storage.S3Config{
-			Logger:            logger,
-			S3URL:             cfg.VaultS3.S3URL,
-			S3Bucket:          cfg.VaultS3.S3Bucket,
-			S3AccessKeyID:     cfg.VaultS3.S3AccessKeyID,
-			S3AccessKeySecret: cfg.VaultS3.S3AccessKeySecret,
-		})
+	if len(cfg.VaultMasterKeys) > 0 && (cfg.VaultS3 != nil || cfg.VaultFilesystem != nil || cfg.VaultMySQL != nil) {
+		vaultStorage, err := newVaultStorage(cfg, logger)
 		if err != nil {
 			return fmt.Errorf("unable to create vault storage: %w", err)
 		}
@@ -192,6 +195,108 @@ func Run(ctx context.Context, cfg Config) error {
 		return fmt.Errorf("unable to register deployment workflow: %w", err)
 	}
 
+	provisionRegionWorkflow := deployment.NewProvisionRegionWorkflow(partitionDB, logger, metaldClient)
+	err = hydra.RegisterWorkflow(hydraWorker, provisionRegionWorkflow)
+	if err != nil {
+		return fmt.Errorf("unable to register provision region workflow: %w", err)
+	}
+
+	// The usage limiter is only used to update the credits of refilled keys,
+	// without redis there are no counters that could go stale.
+	var usageLimiter usagelimiter.Service
+	if cfg.RedisUrl != "" {
+		ctr, ctrErr := counter.NewRedis(counter.RedisConfig{
+			RedisURL: cfg.RedisUrl,
+			Logger:   logger,
+		})
+		if ctrErr != nil {
+			return fmt.Errorf("unable to create counter: %w", ctrErr)
+		}
+		shutdowns.Register(ctr.Close)
+
+		usageLimiter, err = usagelimiter.NewRedisWithCounter(usagelimiter.RedisConfig{
+			DB:      database,
+			Logger:  logger,
+			Counter: ctr,
+			TTL:     0,
+		})
+	} else {
+		usageLimiter, err = usagelimiter.New(usagelimiter.Config{
+			DB:     database,
+			Logger: logger,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to create usage limiter: %w", err)
+	}
+	shutdowns.Register(usageLimiter.Close)
+
+	keyRefillWorkflow := keyrefill.NewKeyRefill(keyrefill.KeyRefillConfig{
+		DB:     database,
+		Logger: logger,
+		Auditlogs: auditlogs.New(auditlogs.Config{
+			DB:     database,
+			Logger: logger,
+		}),
+		UsageLimiter: usageLimiter,
+		BatchSize:    0,
+	})
+	err = hydra.RegisterWorkflow(hydraWorker, keyRefillWorkflow)
+	if err != nil {
+		return fmt.Errorf("unable to register key refill workflow: %w", err)
+	}
+
+	var dekRotationWorkflow *dekrotation.DEKRotation
+	if vaultSvc != nil && cfg.VaultDEKRotationInterval > 0 {
+		dekRotationWorkflow = dekrotation.NewDEKRotation(dekrotation.DEKRotationConfig{
+			DB:               database,
+			Logger:           logger,
+			Vault:            vaultSvc,
+			Interval:         cfg.VaultDEKRotationInterval,
+			KeyringBatchSize: 0,
+			BatchSize:        0,
+		})
+		err = hydra.RegisterWorkflow(hydraWorker, dekRotationWorkflow)
+		if err != nil {
+			return fmt.Errorf("unable to register dek rotation workflow: %w", err)
+		}
+	}
+
+	// Webhook secrets are encrypted, without vault nothing can be signed
+	var keyLifecycleWorkflow *webhooks.KeyLifecycle
+	if vaultSvc != nil {
+		webhookDeliveryWorkflow, deliveryErr := webhooks.NewDelivery(webhooks.DeliveryConfig{
+			DB:            database,
+			Logger:        logger,
+			Vault:         vaultSvc,
+			HTTPClient:    nil,
+			RetrySchedule: nil,
+		})
+		if deliveryErr != nil {
+			return fmt.Errorf("unable to create webhook delivery workflow: %w", deliveryErr)
+		}
+		err = hydra.RegisterWorkflow(hydraWorker, webhookDeliveryWorkflow)
+		if err != nil {
+			return fmt.Errorf("unable to register webhook delivery workflow: %w", err)
+		}
+
+		keyLifecycleWorkflow = webhooks.NewKeyLifecycle(webhooks.KeyLifecycleConfig{
+			DB:                 database,
+			Logger:             logger,
+			Engine:             hydraEngine,
+			Delivery:           webhookDeliveryWorkflow,
+			ExpiringWindow:     cfg.WebhookExpiringWindow,
+			CreditsThreshold:   int32(cfg.WebhookCreditsThreshold), // nolint:gosec
+			Lookback:           0,
+			WorkspaceBatchSize: 0,
+			BatchSize:          0,
+		})
+		err = hydra.RegisterWorkflow(hydraWorker, keyLifecycleWorkflow)
+		if err != nil {
+			return fmt.Errorf("unable to register key lifecycle webhook workflow: %w", err)
+		}
+	}
+
 	// Create the connect handler
 	mux := http.NewServeMux()
 
@@ -205,6 +310,15 @@ func Run(ctx context.Context, cfg Config) error {
 		HydraEngine: hydraEngine,
 		Logger:      logger,
 	})))
+	mux.Handle(ctrlv1connect.NewRoutingServiceHandler(routing.New(routing.Config{
+		DB:          database,
+		PartitionDB: partitionDB,
+		Logger:      logger,
+	})))
+	mux.Handle(ctrlv1connect.NewWorkflowServiceHandler(workflow.New(workflow.Config{
+		HydraEngine: hydraEngine,
+		Logger:      logger,
+	})))
 
 	// Configure server
 	addr := fmt.Sprintf(":%d", cfg.HttpPort)
@@ -315,6 +429,8 @@ func Run(ctx context.Context, cfg Config) error {
 					hydra.WithMaxAttempts(24),
 					hydra.WithTimeout(25*time.Hour),
 					hydra.WithRetryBackoff(1*time.Hour),
+					// The challenge stays executable until the workflow picked it up
+					hydra.WithIdempotencyKey(strconv.FormatUint(challenge.ID, 10)),
 				)
 				if err != nil {
 					logger.Error("Failed to start workflow", "error", err)
@@ -333,6 +449,97 @@ func Run(ctx context.Context, cfg Config) error {
 		}
 	}()
 
+	go func() {
+		logger.Info("Starting key refill cron")
+
+		// Runs hourly instead of once at midnight, so a missed run is caught up
+		// later that day. Keys are only refilled once per day regardless.
+		cronErr := hydraEngine.RegisterCron("0 * * * *", "start-key-refills", func(ctx context.Context, payload hydra.CronPayload) error {
+			executionID, err := hydraEngine.StartWorkflow(ctx, keyRefillWorkflow.Name(),
+				keyrefill.KeyRefillRequest{
+					Time: payload.ScheduledAt,
+				},
+				hydra.WithMaxAttempts(5),
+				hydra.WithTimeout(1*time.Hour),
+				hydra.WithRetryBackoff(5*time.Minute),
+			)
+			if err != nil {
+				logger.Error("Failed to start key refill workflow", "error", err)
+				return err
+			}
+
+			logger.Info("Key refill workflow started", "executionID", executionID)
+			return nil
+		})
+
+		if cronErr != nil {
+			logger.Error("Failed to register key refill cron job", "error", cronErr)
+			return
+		}
+	}()
+
+	if dekRotationWorkflow != nil {
+		go func() {
+			logger.Info("Starting dek rotation cron")
+
+			// Runs daily, keyrings are only rotated once their latest key is
+			// older than the rotation interval.
+			cronErr := hydraEngine.RegisterCron("0 3 * * *", "start-dek-rotations", func(ctx context.Context, payload hydra.CronPayload) error {
+				executionID, err := hydraEngine.StartWorkflow(ctx, dekRotationWorkflow.Name(),
+					dekrotation.DEKRotationRequest{
+						Time: payload.ScheduledAt,
+					},
+					hydra.WithMaxAttempts(5),
+					hydra.WithTimeout(6*time.Hour),
+					hydra.WithRetryBackoff(15*time.Minute),
+				)
+				if err != nil {
+					logger.Error("Failed to start dek rotation workflow", "error", err)
+					return err
+				}
+
+				logger.Info("DEK rotation workflow started", "executionID", executionID)
+				return nil
+			})
+
+			if cronErr != nil {
+				logger.Error("Failed to register dek rotation cron job", "error", cronErr)
+				return
+			}
+		}()
+	}
+
+	if keyLifecycleWorkflow != nil {
+		go func() {
+			logger.Info("Starting key lifecycle webhook cron")
+
+			// Events are deduplicated, so runs may overlap with the lookback of
+			// previous runs.
+			cronErr := hydraEngine.RegisterCron("*/5 * * * *", "start-key-lifecycle-webhooks", func(ctx context.Context, payload hydra.CronPayload) error {
+				executionID, err := hydraEngine.StartWorkflow(ctx, keyLifecycleWorkflow.Name(),
+					webhooks.KeyLifecycleRequest{
+						Time: payload.ScheduledAt,
+					},
+					hydra.WithMaxAttempts(3),
+					hydra.WithTimeout(30*time.Minute),
+					hydra.WithRetryBackoff(time.Minute),
+				)
+				if err != nil {
+					logger.Error("Failed to start key lifecycle webhook workflow", "error", err)
+					return err
+				}
+
+				logger.Info("Key lifecycle webhook workflow started", "executionID", executionID)
+				return nil
+			})
+
+			if cronErr != nil {
+				logger.Error("Failed to register key lifecycle webhook cron job", "error", cronErr)
+				return
+			}
+		}()
+	}
+
 	// Start Hydra worker
 	go func() {
 		logger.Info("Starting Hydra workflow worker")
@@ -353,3 +560,29 @@ func Run(ctx context.Context, cfg Config) error {
 	logger.Info("Ctrl server shut down successfully")
 	return nil
 }
+
+// newVaultStorage creates the configured vault storage.
+func newVaultStorage(cfg Config, logger logging.Logger) (storage.Storage, error) {
+	switch {
+	case cfg.VaultS3 != nil:
+		return storage.NewS3(storage.S3Config{
+			Logger:            logger,
+			S3URL:             cfg.VaultS3.S3URL,
+			S3Bucket:          cfg.VaultS3.S3Bucket,
+			S3AccessKeyID:     cfg.VaultS3.S3AccessKeyID,
+			S3AccessKeySecret: cfg.VaultS3.S3AccessKeySecret,
+		})
+	case cfg.VaultFilesystem != nil:
+		return storage.NewFilesystem(storage.FilesystemConfig{
+			Logger: logger,
+			Path:   cfg.VaultFilesystem.Path,
+		})
+	case cfg.VaultMySQL != nil:
+		return storage.NewMySQL(storage.MySQLConfig{
+			Logger: logger,
+			DSN:    cfg.VaultMySQL.DSN,
+		})
+	default:
+		return nil, fmt.Errorf("no vault storage configured")
+	}
+}
@@ -0,0 +1,365 @@
+package dekrotation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	vaultv1 "github.com/unkeyed/unkey/go/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/vault"
+)
+
+const (
+	// defaultInterval is the age after which the latest data encryption key
+	// of a keyring is rotated.
+	defaultInterval = 90 * 24 * time.Hour
+
+	// defaultKeyringBatchSize is the number of keyrings rotated per step.
+	defaultKeyringBatchSize = 100
+
+	// defaultBatchSize is the number of encrypted keys re-encrypted per step.
+	defaultBatchSize = 1000
+)
+
+// DEKRotation rotates the data encryption keys of all vault keyrings and
+// moves the encrypted keys to the latest one.
+//
+// Every run re-encrypts the data encryption keys with the newest key
+// encryption key, and creates a new data encryption key for keyrings whose
+// latest key is older than the interval. Afterwards the rows of
+// encrypted_keys are re-encrypted with the latest data encryption key of
+// their workspace's keyring, and a report of the key versions that are still
+// in use is logged.
+//
+// Older data encryption keys are never deleted, data encrypted elsewhere can
+// still be decrypted with them.
+type DEKRotation struct {
+	db               db.Database
+	logger           logging.Logger
+	vault            *vault.Service
+	interval         time.Duration
+	keyringBatchSize int
+	batchSize        int
+}
+
+type DEKRotationConfig struct {
+	DB     db.Database
+	Logger logging.Logger
+	Vault  *vault.Service
+
+	// Interval is the age after which the latest data encryption key of a
+	// keyring is rotated, defaults to 90 days.
+	Interval time.Duration
+
+	// KeyringBatchSize is the number of keyrings rotated per step, defaults
+	// to 100.
+	KeyringBatchSize int
+
+	// BatchSize is the number of encrypted keys re-encrypted per step,
+	// defaults to 1000.
+	BatchSize int
+}
+
+// NewDEKRotation creates a new DEK rotation workflow instance
+func NewDEKRotation(config DEKRotationConfig) *DEKRotation {
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	keyringBatchSize := config.KeyringBatchSize
+	if keyringBatchSize <= 0 {
+		keyringBatchSize = defaultKeyringBatchSize
+	}
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	return &DEKRotation{
+		db:               config.DB,
+		logger:           config.Logger,
+		vault:            config.Vault,
+		interval:         interval,
+		keyringBatchSize: keyringBatchSize,
+		batchSize:        batchSize,
+	}
+}
+
+// Name returns the workflow name for registration
+func (w *DEKRotation) Name() string {
+	return "dek_rotation"
+}
+
+// DEKRotationRequest defines the input for the DEK rotation workflow
+type DEKRotationRequest struct {
+	// Time is the unix milli timestamp the rotation is performed for.
+	// It is part of the payload so retries do not rotate keys twice.
+	Time int64 `json:"time"`
+}
+
+// ReEncryptBatch is the progress of the re-encryption after a step.
+type ReEncryptBatch struct {
+	// Cursor is the id of the last key of the batch.
+	Cursor string `json:"cursor"`
+
+	// Keys is the number of keys in the batch.
+	Keys int `json:"keys"`
+
+	// ReEncrypted is the number of keys that were re-encrypted, keys already
+	// using the latest data encryption key are skipped.
+	ReEncrypted int `json:"reencrypted"`
+
+	// Failed is the number of keys that could not be re-encrypted.
+	Failed int `json:"failed"`
+}
+
+// UsageReport lists the key versions that are still in use after a rotation.
+type UsageReport struct {
+	// KEKs maps the ids of key encryption keys to the number of data
+	// encryption keys encrypted with them.
+	KEKs map[string]int `json:"keks"`
+
+	// OutdatedDEKs maps the ids of data encryption keys that are not the
+	// latest of their keyring to the number of encrypted keys still using
+	// them.
+	OutdatedDEKs map[string]int64 `json:"outdatedDeks"`
+
+	// LatestKeys is the number of encrypted keys using the latest data
+	// encryption key of their keyring.
+	LatestKeys int64 `json:"latestKeys"`
+}
+
+// Run rotates the keyrings and re-encrypts the encrypted keys in batches,
+// every batch is a separate step so a retry continues where it failed.
+func (w *DEKRotation) Run(ctx hydra.WorkflowContext, req *DEKRotationRequest) error {
+	now := time.UnixMilli(req.Time)
+
+	rings, err := hydra.Step(ctx, "list-keyrings", func(stepCtx context.Context) ([]string, error) {
+		return w.vault.ListKeyrings(stepCtx)
+	})
+	if err != nil {
+		w.logger.Error("failed to list keyrings", "error", err)
+		return err
+	}
+
+	w.logger.Info("starting dek rotation", "keyrings", len(rings))
+
+	rotated := 0
+	for batch := 0; batch*w.keyringBatchSize < len(rings); batch++ {
+		start := batch * w.keyringBatchSize
+		end := min(start+w.keyringBatchSize, len(rings))
+
+		n, rotateErr := hydra.Step(ctx, fmt.Sprintf("rotate-keyrings-%d", batch), func(stepCtx context.Context) (int, error) {
+			return w.rotateKeyrings(stepCtx, rings[start:end], now)
+		})
+		if rotateErr != nil {
+			w.logger.Error("failed to rotate keyrings", "error", rotateErr)
+			return rotateErr
+		}
+		rotated += n
+	}
+
+	cursor := ""
+	reEncrypted := 0
+	failed := 0
+	for batch := 0; ; batch++ {
+		progress, reEncryptErr := hydra.Step(ctx, fmt.Sprintf("reencrypt-keys-%d", batch), func(stepCtx context.Context) (ReEncryptBatch, error) {
+			return w.reEncryptKeys(stepCtx, cursor)
+		})
+		if reEncryptErr != nil {
+			w.logger.Error("failed to re-encrypt keys", "error", reEncryptErr)
+			return reEncryptErr
+		}
+
+		reEncrypted += progress.ReEncrypted
+		failed += progress.Failed
+		cursor = progress.Cursor
+
+		if progress.Keys < w.batchSize {
+			break
+		}
+	}
+
+	report, err := hydra.Step(ctx, "usage-report", func(stepCtx context.Context) (UsageReport, error) {
+		return w.usageReport(stepCtx, rings)
+	})
+	if err != nil {
+		w.logger.Error("failed to create usage report", "error", err)
+		return err
+	}
+
+	w.logger.Info("dek rotation completed",
+		"rotated", rotated,
+		"reencrypted", reEncrypted,
+		"failed", failed,
+		"keks", report.KEKs,
+		"outdatedDeks", report.OutdatedDEKs,
+		"latestKeys", report.LatestKeys,
+	)
+
+	return nil
+}
+
+// rotateKeyrings re-encrypts the data encryption keys of the keyrings with the
+// newest key encryption key, and creates a new data encryption key for those
+// whose latest key was created before the interval. It returns how many
+// keyrings got a new data encryption key.
+//
+// Keyrings that were rotated by a previous attempt of this step are skipped,
+// their latest key is newer than the interval.
+func (w *DEKRotation) rotateKeyrings(ctx context.Context, rings []string, now time.Time) (int, error) {
+	rotateBefore := now.Add(-w.interval).UnixMilli()
+
+	rotated := 0
+	for _, ring := range rings {
+		err := w.vault.RollKeyring(ctx, ring)
+		if err != nil {
+			return 0, fmt.Errorf("unable to roll keyring %s: %w", ring, err)
+		}
+
+		deks, err := w.vault.ListDEKs(ctx, ring)
+		if err != nil {
+			return 0, fmt.Errorf("unable to list deks of keyring %s: %w", ring, err)
+		}
+
+		due := true
+		for _, dek := range deks {
+			if dek.Latest && dek.CreatedAt >= rotateBefore {
+				due = false
+			}
+		}
+		if !due {
+			continue
+		}
+
+		dekID, err := w.vault.RotateDEK(ctx, ring)
+		if err != nil {
+			return 0, fmt.Errorf("unable to rotate dek of keyring %s: %w", ring, err)
+		}
+
+		w.logger.Info("rotated dek", "keyring", ring, "dekId", dekID)
+		rotated++
+	}
+
+	return rotated, nil
+}
+
+// reEncryptKeys re-encrypts the encrypted keys after the cursor that do not
+// use the latest data encryption key of their keyring.
+//
+// A key that is updated while it is re-encrypted keeps the new value, the
+// update only applies if the ciphertext did not change. Keys that can not be
+// decrypted are logged and skipped, so they do not block the rotation.
+func (w *DEKRotation) reEncryptKeys(ctx context.Context, cursor string) (ReEncryptBatch, error) {
+	keys, err := db.Query.ListKeyEncryptions(ctx, w.db.RO(), db.ListKeyEncryptionsParams{
+		IDCursor: cursor,
+		Limit:    int32(w.batchSize), // nolint:gosec
+	})
+	if err != nil {
+		return ReEncryptBatch{}, fmt.Errorf("unable to list encrypted keys: %w", err)
+	}
+
+	progress := ReEncryptBatch{
+		Cursor:      cursor,
+		Keys:        len(keys),
+		ReEncrypted: 0,
+		Failed:      0,
+	}
+
+	latest := make(map[string]string)
+	for _, key := range keys {
+		progress.Cursor = key.KeyID
+
+		latestID, ok := latest[key.WorkspaceID]
+		if !ok {
+			latestID, err = w.vault.LatestDEK(ctx, key.WorkspaceID)
+			if err != nil {
+				return ReEncryptBatch{}, fmt.Errorf("unable to get latest dek of keyring %s: %w", key.WorkspaceID, err)
+			}
+			latest[key.WorkspaceID] = latestID
+		}
+
+		if key.EncryptionKeyID == latestID {
+			continue
+		}
+
+		decrypted, err := w.vault.Decrypt(ctx, &vaultv1.DecryptRequest{
+			Keyring:   key.WorkspaceID,
+			Encrypted: key.Encrypted,
+		})
+		if err != nil {
+			w.logger.Error("unable to decrypt key", "keyId", key.KeyID, "error", err)
+			progress.Failed++
+			continue
+		}
+
+		encrypted, err := w.vault.Encrypt(ctx, &vaultv1.EncryptRequest{
+			Keyring: key.WorkspaceID,
+			Data:    decrypted.GetPlaintext(),
+		})
+		if err != nil {
+			return ReEncryptBatch{}, fmt.Errorf("unable to encrypt key %s: %w", key.KeyID, err)
+		}
+
+		err = db.Query.UpdateKeyEncryption(ctx, w.db.RW(), db.UpdateKeyEncryptionParams{
+			Encrypted:         encrypted.GetEncrypted(),
+			EncryptionKeyID:   encrypted.GetKeyId(),
+			UpdatedAt:         sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+			KeyID:             key.KeyID,
+			PreviousEncrypted: key.Encrypted,
+		})
+		if err != nil {
+			return ReEncryptBatch{}, fmt.Errorf("unable to update key %s: %w", key.KeyID, err)
+		}
+
+		progress.ReEncrypted++
+	}
+
+	return progress, nil
+}
+
+// usageReport collects the key encryption keys used by the keyrings and the
+// data encryption keys used by encrypted keys.
+func (w *DEKRotation) usageReport(ctx context.Context, rings []string) (UsageReport, error) {
+	report := UsageReport{
+		KEKs:         make(map[string]int),
+		OutdatedDEKs: make(map[string]int64),
+		LatestKeys:   0,
+	}
+
+	latest := make(map[string]bool)
+	for _, ring := range rings {
+		deks, err := w.vault.ListDEKs(ctx, ring)
+		if err != nil {
+			return UsageReport{}, fmt.Errorf("unable to list deks of keyring %s: %w", ring, err)
+		}
+
+		for _, dek := range deks {
+			report.KEKs[dek.EncryptionKeyID]++
+			if dek.Latest {
+				latest[dek.ID] = true
+			}
+		}
+	}
+
+	counts, err := db.Query.CountKeyEncryptionsByEncryptionKeyID(ctx, w.db.RO())
+	if err != nil {
+		return UsageReport{}, fmt.Errorf("unable to count encrypted keys: %w", err)
+	}
+
+	for _, count := range counts {
+		if latest[count.EncryptionKeyID] {
+			report.LatestKeys += count.Total
+			continue
+		}
+		report.OutdatedDEKs[count.EncryptionKeyID] = count.Total
+	}
+
+	return report, nil
+}
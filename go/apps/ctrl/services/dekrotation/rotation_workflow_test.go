@@ -0,0 +1,162 @@
+package dekrotation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	vaultv1 "github.com/unkeyed/unkey/go/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/testutil/containers"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+	"github.com/unkeyed/unkey/go/pkg/vault"
+	"github.com/unkeyed/unkey/go/pkg/vault/keys"
+	"github.com/unkeyed/unkey/go/pkg/vault/storage"
+)
+
+func TestDEKRotationReEncryptsKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	logger := logging.NewNoop()
+
+	mysqlCfg := containers.MySQL(t)
+	mysqlCfg.DBName = "unkey"
+	database, err := db.New(db.Config{
+		PrimaryDSN:  mysqlCfg.FormatDSN(),
+		ReadOnlyDSN: "",
+		Logger:      logger,
+	})
+	require.NoError(t, err)
+	defer database.Close()
+
+	vaultStorage, err := storage.NewMemory(storage.MemoryConfig{
+		Logger: logger,
+	})
+	require.NoError(t, err)
+
+	_, masterKey, err := keys.GenerateMasterKey()
+	require.NoError(t, err)
+
+	vaultSvc, err := vault.New(vault.Config{
+		Logger:     logger,
+		Storage:    vaultStorage,
+		MasterKeys: []string{masterKey},
+	})
+	require.NoError(t, err)
+
+	hydraCfg := containers.MySQL(t)
+	hydraCfg.DBName = "hydra"
+	engine, err := hydra.New(hydra.Config{
+		DSN:        hydraCfg.FormatDSN(),
+		Namespace:  fmt.Sprintf("test_%s", uid.New(uid.Prefix("test"))),
+		Logger:     logger,
+		Marshaller: hydra.NewJSONMarshaller(),
+	})
+	require.NoError(t, err)
+
+	worker, err := hydra.NewWorker(engine, hydra.WorkerConfig{
+		Concurrency:       1,
+		PollInterval:      100 * time.Millisecond,
+		HeartbeatInterval: time.Second,
+		ClaimTimeout:      10 * time.Second,
+	})
+	require.NoError(t, err)
+
+	workflow := NewDEKRotation(DEKRotationConfig{
+		DB:               database,
+		Logger:           logger,
+		Vault:            vaultSvc,
+		Interval:         time.Hour,
+		KeyringBatchSize: 1,
+		BatchSize:        2,
+	})
+	require.NoError(t, hydra.RegisterWorkflow(worker, workflow))
+	require.NoError(t, worker.Start(ctx))
+	defer worker.Shutdown(ctx)
+
+	// Two workspaces with a few encrypted keys each, more than fit in a batch
+	plaintexts := map[string]string{}
+	workspaceIDs := []string{uid.New(uid.WorkspacePrefix), uid.New(uid.WorkspacePrefix)}
+	oldDEKs := map[string]string{}
+	for _, workspaceID := range workspaceIDs {
+		for i := range 3 {
+			keyID := uid.New(uid.KeyPrefix)
+			plaintext := fmt.Sprintf("%s_%d", workspaceID, i)
+			encrypted, encryptErr := vaultSvc.Encrypt(ctx, &vaultv1.EncryptRequest{
+				Keyring: workspaceID,
+				Data:    plaintext,
+			})
+			require.NoError(t, encryptErr)
+
+			require.NoError(t, db.Query.InsertKeyEncryption(ctx, database.RW(), db.InsertKeyEncryptionParams{
+				WorkspaceID:     workspaceID,
+				KeyID:           keyID,
+				Encrypted:       encrypted.GetEncrypted(),
+				EncryptionKeyID: encrypted.GetKeyId(),
+				CreatedAt:       time.Now().UnixMilli(),
+			}))
+
+			plaintexts[keyID] = plaintext
+			oldDEKs[workspaceID] = encrypted.GetKeyId()
+		}
+	}
+
+	// Pretend the keys were created more than an interval ago
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), DEKRotationRequest{
+		Time: time.Now().Add(2 * time.Hour).UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		execution, getErr := store.Query.GetWorkflow(ctx, engine.GetDB(), store.GetWorkflowParams{
+			ID:        executionID,
+			Namespace: engine.GetNamespace(),
+		})
+		return getErr == nil && execution.Status == store.WorkflowExecutionsStatusCompleted
+	}, 30*time.Second, 100*time.Millisecond)
+
+	for _, workspaceID := range workspaceIDs {
+		latest, latestErr := vaultSvc.LatestDEK(ctx, workspaceID)
+		require.NoError(t, latestErr)
+		require.NotEqual(t, oldDEKs[workspaceID], latest, "the dek of %s must be rotated", workspaceID)
+	}
+
+	for keyID, plaintext := range plaintexts {
+		row, findErr := db.Query.FindKeyEncryptionByKeyID(ctx, database.RO(), keyID)
+		require.NoError(t, findErr)
+
+		latest, latestErr := vaultSvc.LatestDEK(ctx, row.WorkspaceID)
+		require.NoError(t, latestErr)
+		require.Equal(t, latest, row.EncryptionKeyID)
+		require.True(t, row.UpdatedAt.Valid)
+
+		decrypted, decryptErr := vaultSvc.Decrypt(ctx, &vaultv1.DecryptRequest{
+			Keyring:   row.WorkspaceID,
+			Encrypted: row.Encrypted,
+		})
+		require.NoError(t, decryptErr)
+		require.Equal(t, plaintext, decrypted.GetPlaintext())
+	}
+
+	steps, err := engine.ListSteps(ctx, executionID)
+	require.NoError(t, err)
+
+	var report UsageReport
+	for _, step := range steps {
+		if step.StepName == "usage-report" {
+			require.NoError(t, hydra.NewJSONMarshaller().Unmarshal(step.OutputData, &report))
+		}
+	}
+	require.Empty(t, report.OutdatedDEKs)
+	require.Equal(t, int64(len(plaintexts)), report.LatestKeys)
+}
@@ -3,6 +3,8 @@ package deployment
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -26,7 +28,8 @@ func limitString(s string, maxRunes int) string {
 // CreateVersion creates a new deployment version record and kicks off the deployment workflow.
 // It validates workspace/project, normalizes git metadata (branch fallback, commit fields),
 // and persists the deployment in "pending" state. Workflow failures are logged but do not
-// fail creation to allow retries.
+// fail creation to allow retries. Requests with the idempotency key of an earlier request
+// of the project return the version that request created.
 
 func (s *Service) CreateVersion(
 	ctx context.Context,
@@ -85,6 +88,23 @@ func (s *Service) CreateVersion(
 		}
 	}
 
+	// Retried requests return the version of the first request with the same key
+	idempotencyKey := ""
+	if req.Msg.GetIdempotencyKey() != "" {
+		idempotencyKey = req.Msg.GetProjectId() + ":" + req.Msg.GetIdempotencyKey()
+
+		existing, found, findErr := s.findIdempotentVersion(ctx, idempotencyKey)
+		if findErr != nil {
+			return nil, connect.NewError(connect.CodeInternal, findErr)
+		}
+		if found {
+			s.logger.Info("returning version of idempotent request",
+				"deployment_id", existing.GetVersionId(),
+				"project_id", req.Msg.GetProjectId())
+			return connect.NewResponse(existing), nil
+		}
+	}
+
 	// Determine environment (default to preview)
 	// TODO: Add environment field to CreateVersionRequest proto
 	environment := db.DeploymentsEnvironmentPreview
@@ -93,6 +113,10 @@ func (s *Service) CreateVersion(
 	deploymentID := uid.New("deployment")
 	now := time.Now().UnixMilli()
 
+	if idempotencyKey == "" {
+		idempotencyKey = deploymentID
+	}
+
 	// Sanitize input values before persisting
 	gitCommitSha := req.Msg.GetGitCommitSha()
 	gitCommitMessage := limitString(req.Msg.GetGitCommitMessage(), 10240)
@@ -147,6 +171,7 @@ func (s *Service) CreateVersion(
 		hydra.WithMaxAttempts(3),
 		hydra.WithTimeout(25*time.Minute),
 		hydra.WithRetryBackoff(1*time.Minute),
+		hydra.WithIdempotencyKey(idempotencyKey),
 	)
 	if err != nil {
 		s.logger.Error("failed to start deployment workflow",
@@ -159,6 +184,27 @@ func (s *Service) CreateVersion(
 			"execution_id", executionID)
 	}
 
+	// A concurrent request with the same key may have started its workflow
+	// first, in which case this version is never deployed
+	if err == nil && req.Msg.GetIdempotencyKey() != "" {
+		existing, found, findErr := s.findIdempotentVersion(ctx, idempotencyKey)
+		if findErr != nil {
+			return nil, connect.NewError(connect.CodeInternal, findErr)
+		}
+		if found && existing.GetVersionId() != deploymentID {
+			err = db.Query.UpdateDeploymentStatus(ctx, s.db.RW(), db.UpdateDeploymentStatusParams{
+				ID:        deploymentID,
+				Status:    db.DeploymentsStatusFailed,
+				UpdatedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+			})
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+
+			return connect.NewResponse(existing), nil
+		}
+	}
+
 	res := connect.NewResponse(&ctrlv1.CreateVersionResponse{
 		VersionId: deploymentID,
 		Status:    ctrlv1.VersionStatus_VERSION_STATUS_PENDING,
@@ -166,3 +212,31 @@ func (s *Service) CreateVersion(
 
 	return res, nil
 }
+
+// findIdempotentVersion returns the version whose deployment workflow was
+// started with the idempotency key, and whether there is one.
+func (s *Service) findIdempotentVersion(ctx context.Context, idempotencyKey string) (*ctrlv1.CreateVersionResponse, bool, error) {
+	execution, err := s.hydraEngine.FindExecutionByIdempotencyKey(ctx, "deployment", idempotencyKey)
+	if err != nil {
+		if errors.Is(err, hydra.ErrExecutionNotFound) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var deployReq DeployRequest
+	err = json.Unmarshal(execution.InputData, &deployReq)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode deployment workflow input: %w", err)
+	}
+
+	deployment, err := db.Query.FindDeploymentById(ctx, s.db.RO(), deployReq.DeploymentID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load deployment %s: %w", deployReq.DeploymentID, err)
+	}
+
+	return &ctrlv1.CreateVersionResponse{
+		VersionId: deployment.ID,
+		Status:    convertDbStatusToProto(string(deployment.Status)),
+	}, true, nil
+}
@@ -3,14 +3,13 @@ package deployment
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"time"
 
-	"connectrpc.com/connect"
-	vmprovisionerv1 "github.com/unkeyed/unkey/go/gen/proto/metal/vmprovisioner/v1"
 	"github.com/unkeyed/unkey/go/gen/proto/metal/vmprovisioner/v1/vmprovisionerv1connect"
 	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
 	"github.com/unkeyed/unkey/go/pkg/db"
@@ -55,6 +54,8 @@ type DeployRequest struct {
 	DeploymentID string `json:"deployment_id"`
 	DockerImage  string `json:"docker_image"`
 	Hostname     string `json:"hostname"`
+	// Regions to deploy to in parallel, defaults to us-east-1
+	Regions []string `json:"regions,omitempty"`
 }
 
 // BuildInfo holds build metadata from initialization step
@@ -197,59 +198,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 7: Create VM (network call to metald)
-	createResult, err := hydra.Step(ctx, "metald-create-vm", func(stepCtx context.Context) (*vmprovisionerv1.CreateVmResponse, error) {
-		w.logger.Info("creating VM for deployment", "deployment_id", req.DeploymentID, "docker_image", req.DockerImage, "workspace_id", req.WorkspaceID, "project_id", req.ProjectID)
-
-		// Create VM configuration for Docker backend
-		vmConfig := &vmprovisionerv1.VmConfig{
-			Cpu: &vmprovisionerv1.CpuConfig{
-				VcpuCount: 1,
-			},
-			Memory: &vmprovisionerv1.MemoryConfig{
-				SizeBytes: 536870912, // 512MB
-			},
-			Boot: &vmprovisionerv1.BootConfig{
-				KernelPath: "/boot/vmlinux",
-				InitrdPath: "/boot/initrd",
-				KernelArgs: "console=ttyS0 quiet",
-			},
-			Storage: []*vmprovisionerv1.StorageDevice{{
-				Id:   "root",
-				Path: "/dev/vda",
-			}},
-			Metadata: map[string]string{
-				"docker_image":  req.DockerImage,
-				"exposed_ports": "8080/tcp",
-				"env_vars":      "PORT=8080",
-				"version_id":    req.DeploymentID,
-				"workspace_id":  req.WorkspaceID,
-				"project_id":    req.ProjectID,
-				"created_by":    "deploy-workflow",
-			},
-		}
-
-		// Make real metald CreateVm call
-		resp, err := w.metaldClient.CreateVm(stepCtx, connect.NewRequest(&vmprovisionerv1.CreateVmRequest{
-			Config: vmConfig,
-		}))
-		if err != nil {
-			w.logger.Error("metald CreateVm call failed", "error", err, "docker_image", req.DockerImage)
-			return nil, fmt.Errorf("failed to create VM: %w", err)
-		}
-
-		w.logger.Info("VM created successfully", "vm_id", resp.Msg.VmId, "state", resp.Msg.State.String(), "docker_image", req.DockerImage)
-
-		return resp.Msg, nil
-	})
-	if err != nil {
-		w.logger.Error("VM creation failed", "error", err, "deployment_id", req.DeploymentID)
-		return err
-	}
-
-	w.logger.Info("VM creation completed", "vm_id", createResult.VmId, "state", createResult.State.String())
-
-	// Step 8: Log building rootfs
+	// Step 7: Log building rootfs
 	err = hydra.StepVoid(ctx, "log-building-rootfs", func(stepCtx context.Context) error {
 		return db.Query.InsertDeploymentStep(stepCtx, w.db.RW(), db.InsertDeploymentStepParams{
 			DeploymentID: req.DeploymentID,
@@ -264,7 +213,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 9: Log uploading rootfs
+	// Step 8: Log uploading rootfs
 	err = hydra.StepVoid(ctx, "log-uploading-rootfs", func(stepCtx context.Context) error {
 		return db.Query.InsertDeploymentStep(stepCtx, w.db.RW(), db.InsertDeploymentStepParams{
 			DeploymentID: req.DeploymentID,
@@ -279,7 +228,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 10: Update build status to succeeded
+	// Step 9: Update build status to succeeded
 	_, err = hydra.Step(ctx, "update-build-succeeded", func(stepCtx context.Context) (*struct{}, error) {
 		w.logger.Info("updating build status to succeeded", "build_id", buildID)
 		successErr := db.Query.UpdateBuildSucceeded(stepCtx, w.db.RW(), db.UpdateBuildSucceededParams{
@@ -297,7 +246,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 11: Log creating VM
+	// Step 10: Log creating VM
 	err = hydra.StepVoid(ctx, "log-creating-vm", func(stepCtx context.Context) error {
 		return db.Query.InsertDeploymentStep(stepCtx, w.db.RW(), db.InsertDeploymentStepParams{
 			DeploymentID: req.DeploymentID,
@@ -312,7 +261,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 12: Update version status to deploying
+	// Step 11: Update version status to deploying
 	_, err = hydra.Step(ctx, "update-version-deploying", func(stepCtx context.Context) (*struct{}, error) {
 		w.logger.Info("starting deployment", "deployment_id", req.DeploymentID)
 
@@ -331,116 +280,66 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 13: Skip VM status polling for Docker backend (VM is immediately ready)
-	w.logger.Info("skipping VM status polling for Docker backend", "vm_id", createResult.VmId)
-
-	// Step 14: Boot VM (network call to metald)
-	_, err = hydra.Step(ctx, "metald-boot-vm", func(stepCtx context.Context) (*vmprovisionerv1.BootVmResponse, error) {
-		w.logger.Info("booting VM", "vm_id", createResult.VmId)
-
-		// Make real metald BootVm call
-		resp, err := w.metaldClient.BootVm(stepCtx, connect.NewRequest(&vmprovisionerv1.BootVmRequest{
-			VmId: createResult.VmId,
-		}))
+	// Step 12: Provision all regions in parallel, each in its own child workflow
+	regions := deployRegions(req.Regions)
+	children := make([]string, 0, len(regions))
+	for _, region := range regions {
+		childID, err := hydra.ChildWorkflow(ctx, "provision-"+region, "provision-region", &ProvisionRegionRequest{
+			WorkspaceID:  req.WorkspaceID,
+			ProjectID:    req.ProjectID,
+			DeploymentID: req.DeploymentID,
+			DockerImage:  req.DockerImage,
+			Region:       region,
+		})
 		if err != nil {
-			w.logger.Error("metald BootVm call failed", "error", err, "vm_id", createResult.VmId)
-			return nil, fmt.Errorf("failed to boot VM: %w", err)
+			w.logger.Error("failed to start region provisioning", "error", err, "region", region, "deployment_id", req.DeploymentID)
+			return err
 		}
-
-		if !resp.Msg.Success {
-			w.logger.Error("VM boot was not successful", "vm_id", createResult.VmId, "state", resp.Msg.State.String())
-			return nil, fmt.Errorf("VM boot was not successful, state: %s", resp.Msg.State.String())
-		}
-
-		w.logger.Info("VM booted successfully", "vm_id", createResult.VmId, "state", resp.Msg.State.String())
-		return resp.Msg, nil
-	})
-	if err != nil {
-		w.logger.Error("VM boot failed", "error", err, "vm_id", createResult.VmId)
-		return err
+		children = append(children, childID)
 	}
 
-	w.logger.Info("VM boot completed successfully", "vm_id", createResult.VmId)
-
-	// Step 15: Get VM info to retrieve port mappings
-	vmInfo, err := hydra.Step(ctx, "metald-get-vm-info", func(stepCtx context.Context) (*vmprovisionerv1.GetVmInfoResponse, error) {
-		w.logger.Info("getting VM info for port mappings", "vm_id", createResult.VmId)
-
-		resp, err := w.metaldClient.GetVmInfo(stepCtx, connect.NewRequest(&vmprovisionerv1.GetVmInfoRequest{
-			VmId: createResult.VmId,
-		}))
-		if err != nil {
-			w.logger.Error("metald GetVmInfo call failed", "error", err, "vm_id", createResult.VmId)
-			return nil, fmt.Errorf("failed to get VM info: %w", err)
-		}
-
-		if resp.Msg.NetworkInfo != nil {
-			w.logger.Info("VM info retrieved successfully", "vm_id", createResult.VmId, "port_mappings", len(resp.Msg.NetworkInfo.PortMappings))
-		} else {
-			w.logger.Warn("VM info retrieved but no network info", "vm_id", createResult.VmId)
-		}
-
-		return resp.Msg, nil
-	})
-	if err != nil {
-		w.logger.Error("failed to get VM info", "error", err, "vm_id", createResult.VmId)
-		return err
+	results, awaitErr := hydra.AwaitChildren[*RegionVM](ctx, children...)
+	if awaitErr != nil && !errors.Is(awaitErr, hydra.ErrChildWorkflowFailed) {
+		return awaitErr
 	}
 
-	// Step 16: Insert VM into partition database
-	err = hydra.StepVoid(ctx, "insert-vm-partition-db", func(stepCtx context.Context) error {
-		w.logger.Info("inserting VM into partition database", "vm_id", createResult.VmId, "deployment_id", req.DeploymentID)
-
-		// Validate partition DB connection before proceeding
-		if w.partitionDB == nil {
-			w.logger.Error("CRITICAL: partition database not initialized")
-			return fmt.Errorf("partition database not initialized")
+	// Step 13: Take over the VMs of all provisioned regions, so they are deleted
+	// if the deployment fails later on or a region could not be provisioned
+	vms, err := hydra.Step(ctx, "register-vms", func(stepCtx context.Context) ([]*RegionVM, error) {
+		provisioned := make([]*RegionVM, 0, len(results))
+		for _, vm := range results {
+			if vm != nil {
+				provisioned = append(provisioned, vm)
+			}
 		}
-
-		// Extract host port from VM info - find port mapping for container port 8080
-		var hostPort int32 = 8080 // default fallback
-		if vmInfo.NetworkInfo != nil && len(vmInfo.NetworkInfo.PortMappings) > 0 {
-			for _, portMapping := range vmInfo.NetworkInfo.PortMappings {
-				if portMapping.ContainerPort == 8080 {
-					hostPort = portMapping.HostPort
-					break
-				}
+		return provisioned, nil
+	}, hydra.WithCompensation(func(stepCtx context.Context, vms []*RegionVM) error {
+		for _, vm := range vms {
+			w.logger.Info("deleting VM of failed deployment", "vm_id", vm.VmID, "region", vm.Region, "deployment_id", req.DeploymentID)
+			if err := deleteVM(stepCtx, w.metaldClient, w.logger, vm.VmID); err != nil {
+				return err
 			}
 		}
-
-		// Create VM record in partition database
-		vmParams := partitiondb.UpsertVMParams{
-			ID:           createResult.VmId,
-			DeploymentID: req.DeploymentID,
-			Region:       "us-east-1",
-			PrivateIp: sql.NullString{
-				String: "127.0.0.1",
-				Valid:  true,
-			},
-			Port: sql.NullInt32{
-				Int32: hostPort,
-				Valid: true,
-			},
-			CpuMillicores: 1000,
-			MemoryMb:      512,
-			Status:        partitiondb.VmsStatusRunning,
-			HealthStatus:  partitiondb.VmsHealthStatusHealthy,
-		}
-
-		if err := partitiondb.Query.UpsertVM(stepCtx, w.partitionDB.RW(), vmParams); err != nil {
-			w.logger.Error("failed to create VM in partition DB", "error", err, "vm_id", createResult.VmId)
-			return fmt.Errorf("failed to create VM %s in partition DB: %w", createResult.VmId, err)
-		}
-
-		w.logger.Info("VM inserted into partition database successfully", "vm_id", createResult.VmId, "host_port", hostPort)
 		return nil
-	})
+	}))
 	if err != nil {
-		w.logger.Error("failed to insert VM into partition database", "error", err, "vm_id", createResult.VmId)
+		w.logger.Error("failed to register VMs", "error", err, "deployment_id", req.DeploymentID)
 		return err
 	}
 
-	// Step 17: Create/update gateway configuration
+	if awaitErr != nil {
+		w.logger.Error("region provisioning failed", "error", awaitErr, "deployment_id", req.DeploymentID)
+		return awaitErr
+	}
+
+	vmIDs := make([]string, len(vms))
+	for i, vm := range vms {
+		vmIDs[i] = vm.VmID
+	}
+
+	w.logger.Info("all regions provisioned", "deployment_id", req.DeploymentID, "vm_ids", vmIDs)
+
+	// Step 14: Create/update gateway configuration
 	err = hydra.StepVoid(ctx, "create-gateway-config", func(stepCtx context.Context) error {
 		// Only create gateway config if hostname is provided
 		if req.Hostname == "" {
@@ -457,29 +356,32 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		}
 
 		// Create VM protobuf objects for gateway config
-		vms := []*partitionv1.VM{
-			{
-				Id:     createResult.VmId,
-				Region: "us-east-1", // TODO: make this configurable
-			},
+		gatewayVMs := make([]*partitionv1.VM, len(vms))
+		for i, vm := range vms {
+			gatewayVMs[i] = &partitionv1.VM{
+				Id:     vm.VmID,
+				Region: vm.Region,
+			}
 		}
 
-		gatewayConfig := &partitionv1.GatewayConfig{
-			DeploymentId: req.DeploymentID,
-			IsEnabled:    true,
-			Vms:          vms,
+		// Keep everything configured on the hostname, a deploy only replaces
+		// the deployment it serves
+		var current *partitionv1.GatewayConfig
+		existing, err := partitiondb.Query.FindGatewayByHostname(stepCtx, w.partitionDB.RO(), req.Hostname)
+		if err != nil && !partitiondb.IsNotFound(err) {
+			w.logger.Error("failed to fetch existing gateway config", "error", err, "hostname", req.Hostname)
+			return fmt.Errorf("failed to fetch existing gateway config: %w", err)
 		}
-
-		// Only add AuthConfig if we have a KeyspaceID
-		if req.KeyspaceID != "" {
-			gatewayConfig.AuthConfig = &partitionv1.AuthConfig{
-				RequireApiKey:  true,
-				KeyspaceId:     req.KeyspaceID,
-				AllowAnonymous: false,
-				Enabled:        true,
+		if err == nil {
+			current = &partitionv1.GatewayConfig{}
+			if err := proto.Unmarshal(existing.Config, current); err != nil {
+				w.logger.Error("failed to unmarshal existing gateway config", "error", err, "hostname", req.Hostname)
+				return fmt.Errorf("failed to unmarshal existing gateway config: %w", err)
 			}
 		}
 
+		gatewayConfig := deployGatewayConfig(current, req.DeploymentID, gatewayVMs, req.KeyspaceID)
+
 		// Marshal protobuf to bytes
 		configBytes, err := proto.Marshal(gatewayConfig)
 		if err != nil {
@@ -497,7 +399,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 			w.logger.Error("failed to upsert gateway config", "error", err, "hostname", req.Hostname)
 			return fmt.Errorf("failed to upsert gateway config: %w", err)
 		}
-		w.logger.Info("gateway configuration created successfully", "hostname", req.Hostname, "vm_id", createResult.VmId)
+		w.logger.Info("gateway configuration created successfully", "hostname", req.Hostname, "vm_ids", vmIDs)
 		return nil
 	})
 	if err != nil {
@@ -505,12 +407,12 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 18: Log booting VM
+	// Step 15: Log booting VM
 	err = hydra.StepVoid(ctx, "log-booting-vm", func(stepCtx context.Context) error {
 		return db.Query.InsertDeploymentStep(stepCtx, w.db.RW(), db.InsertDeploymentStepParams{
 			DeploymentID: req.DeploymentID,
 			Status:       "booting_vm",
-			Message:      sql.NullString{String: fmt.Sprintf("VM booted successfully: %s", createResult.VmId), Valid: true},
+			Message:      sql.NullString{String: fmt.Sprintf("VMs booted successfully: %s", strings.Join(vmIDs, ", ")), Valid: true},
 			ErrorMessage: sql.NullString{String: "", Valid: false},
 			CreatedAt:    time.Now().UnixMilli(),
 		})
@@ -520,7 +422,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 19: Assign domains (create route entries)
+	// Step 16: Assign domains (create route entries)
 	assignedHostnames, err := hydra.Step(ctx, "assign-domains", func(stepCtx context.Context) ([]string, error) {
 		w.logger.Info("assigning domains to version", "deployment_id", req.DeploymentID)
 
@@ -566,16 +468,13 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		hostnames = append(hostnames, primaryHostname)
 		w.logger.Info("primary domain assigned successfully", "hostname", primaryHostname, "deployment_id", req.DeploymentID, "route_id", routeID)
 
-		// Add localhost:port hostname for development
-		w.logger.Info("checking for port mappings", "has_network_info", vmInfo.NetworkInfo != nil, "port_mappings_count", func() int {
-			if vmInfo.NetworkInfo != nil {
-				return len(vmInfo.NetworkInfo.PortMappings)
+		// Add localhost:port hostnames for development
+		for _, vm := range vms {
+			if vm.Info == nil || vm.Info.NetworkInfo == nil {
+				continue
 			}
-			return 0
-		}())
 
-		if vmInfo.NetworkInfo != nil && len(vmInfo.NetworkInfo.PortMappings) > 0 {
-			for _, portMapping := range vmInfo.NetworkInfo.PortMappings {
+			for _, portMapping := range vm.Info.NetworkInfo.PortMappings {
 				localhostHostname := fmt.Sprintf("localhost:%d", portMapping.HostPort)
 
 				// Create route entry for localhost:port
@@ -596,7 +495,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 				}
 
 				hostnames = append(hostnames, localhostHostname)
-				w.logger.Info("localhost domain assigned successfully", "hostname", localhostHostname, "deployment_id", req.DeploymentID, "route_id", localhostRouteID, "container_port", portMapping.ContainerPort, "host_port", portMapping.HostPort)
+				w.logger.Info("localhost domain assigned successfully", "hostname", localhostHostname, "deployment_id", req.DeploymentID, "route_id", localhostRouteID, "region", vm.Region, "container_port", portMapping.ContainerPort, "host_port", portMapping.HostPort)
 			}
 		}
 
@@ -667,7 +566,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 20: Log assigning domains
+	// Step 17: Log assigning domains
 	err = hydra.StepVoid(ctx, "log-assigning-domains", func(stepCtx context.Context) error {
 		var message string
 		if len(assignedHostnames) > 0 {
@@ -688,7 +587,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 21: Update version status to active
+	// Step 18: Update version status to active
 	_, err = hydra.Step(ctx, "update-version-active", func(stepCtx context.Context) (*DeploymentResult, error) {
 		completionTime := time.Now().UnixMilli()
 		w.logger.Info("updating deployment status to active", "deployment_id", req.DeploymentID, "completion_time", completionTime)
@@ -714,94 +613,40 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 22: Health check container (using host port mapping)
+	// Step 19: Health check the container of every region (using host port mapping)
 	err = hydra.StepVoid(ctx, "health-check-container", func(stepCtx context.Context) error {
-		if vmInfo.NetworkInfo == nil || len(vmInfo.NetworkInfo.PortMappings) == 0 {
-			return fmt.Errorf("no port mappings available for container health check")
-		}
-
-		// Find the port mapping for container port 8080
-		var hostPort int32
-		for _, portMapping := range vmInfo.NetworkInfo.PortMappings {
-			if portMapping.ContainerPort == 8080 {
-				hostPort = portMapping.HostPort
-				break
-			}
-		}
-
-		if hostPort == 0 {
-			return fmt.Errorf("no host port mapping found for container port 8080")
-		}
-
-		// Try multiple host addresses to reach the Docker host
-		// Prioritize Docker's magic domain names
-		hostAddresses := []string{
-			"host.docker.internal",    // Docker Desktop (Windows/Mac) and some Linux setups
-			"gateway.docker.internal", // Docker gateway
-			"172.17.0.1",              // Default Docker bridge gateway
-			"172.18.0.1",              // Alternative Docker bridge
-		}
-
 		client := &http.Client{Timeout: 10 * time.Second}
 
-		for _, hostAddr := range hostAddresses {
-			healthURL := fmt.Sprintf("http://%s:%d/v1/liveness", hostAddr, hostPort)
-			w.logger.Info("trying container health check", "url", healthURL, "host_port", hostPort, "deployment_id", req.DeploymentID)
-
-			resp, err := client.Get(healthURL)
-			if err != nil {
-				w.logger.Warn("health check failed for host address", "error", err, "host_addr", hostAddr, "deployment_id", req.DeploymentID)
-				continue
+		for _, vm := range vms {
+			hostPort := vm.hostPort()
+			if hostPort == 0 {
+				return fmt.Errorf("no host port mapping found for container port 8080 of VM %s", vm.VmID)
 			}
-			defer resp.Body.Close()
 
-			if resp.StatusCode == http.StatusOK {
-				w.logger.Info("container is healthy", "host_addr", hostAddr, "deployment_id", req.DeploymentID)
-				return nil
+			if !w.checkContainerHealth(client, hostPort, req.DeploymentID) {
+				return fmt.Errorf("health check of VM %s failed on all host addresses: %v", vm.VmID, dockerHostAddresses)
 			}
-
-			w.logger.Warn("health check returned non-200 status", "status", resp.StatusCode, "host_addr", hostAddr, "deployment_id", req.DeploymentID)
 		}
 
-		return fmt.Errorf("health check failed on all host addresses: %v", hostAddresses)
+		return nil
 	})
 	if err != nil {
 		w.logger.Error("container health check failed", "error", err, "deployment_id", req.DeploymentID)
 		// Don't fail the deployment, just skip OpenAPI scraping
 	}
 
-	// Step 23: Scrape OpenAPI spec from container (using host port mapping)
+	// Step 20: Scrape OpenAPI spec from container (using host port mapping)
 	openapiSpec, err := hydra.Step(ctx, "scrape-openapi-spec", func(stepCtx context.Context) (string, error) {
-		if vmInfo.NetworkInfo == nil || len(vmInfo.NetworkInfo.PortMappings) == 0 {
-			w.logger.Warn("no port mappings available for OpenAPI scraping", "deployment_id", req.DeploymentID)
-			return "", nil
-		}
-
-		// Find the port mapping for container port 8080
-		var hostPort int32
-		for _, portMapping := range vmInfo.NetworkInfo.PortMappings {
-			if portMapping.ContainerPort == 8080 {
-				hostPort = portMapping.HostPort
-				break
-			}
-		}
-
+		// Every region runs the same image, so any of them serves the spec
+		hostPort := vms[0].hostPort()
 		if hostPort == 0 {
 			w.logger.Warn("no host port mapping found for container port 8080", "deployment_id", req.DeploymentID)
 			return "", nil
 		}
 
-		// Try multiple host addresses to reach the Docker host
-		hostAddresses := []string{
-			"host.docker.internal",    // Docker Desktop (Windows/Mac) and some Linux setups
-			"gateway.docker.internal", // Docker gateway
-			"172.17.0.1",              // Default Docker bridge gateway
-			"172.18.0.1",              // Alternative Docker bridge
-		}
-
 		client := &http.Client{Timeout: 10 * time.Second}
 
-		for _, hostAddr := range hostAddresses {
+		for _, hostAddr := range dockerHostAddresses {
 			openapiURL := fmt.Sprintf("http://%s:%d/openapi.yaml", hostAddr, hostPort)
 			w.logger.Info("trying to scrape OpenAPI spec", "url", openapiURL, "host_port", hostPort, "deployment_id", req.DeploymentID)
 
@@ -828,14 +673,14 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 			return string(specBytes), nil
 		}
 
-		return "", fmt.Errorf("failed to scrape OpenAPI spec from all host addresses: %v", hostAddresses)
+		return "", fmt.Errorf("failed to scrape OpenAPI spec from all host addresses: %v", dockerHostAddresses)
 	})
 	if err != nil {
 		w.logger.Error("failed to scrape OpenAPI spec", "error", err, "deployment_id", req.DeploymentID)
 		return err
 	}
 
-	// Step 24: Update gateway config with OpenAPI spec
+	// Step 21: Update gateway config with OpenAPI spec
 	err = hydra.StepVoid(ctx, "update-gateway-config-openapi", func(stepCtx context.Context) error {
 		// Only update if we have both hostname and OpenAPI spec
 		if req.Hostname == "" || openapiSpec == "" {
@@ -895,7 +740,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		// Don't fail the deployment for this
 	}
 
-	// Step 25: Store OpenAPI spec in database
+	// Step 22: Store OpenAPI spec in database
 	err = hydra.StepVoid(ctx, "store-openapi-spec", func(stepCtx context.Context) error {
 		if openapiSpec == "" {
 			w.logger.Info("no OpenAPI spec to store", "deployment_id", req.DeploymentID)
@@ -921,7 +766,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	// Step 26: Log completed
+	// Step 23: Log completed
 	err = hydra.StepVoid(ctx, "log-completed", func(stepCtx context.Context) error {
 		return db.Query.InsertDeploymentStep(stepCtx, w.db.RW(), db.InsertDeploymentStepParams{
 			DeploymentID: req.DeploymentID,
@@ -936,7 +781,7 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 		return err
 	}
 
-	w.logger.Info("deployment workflow stage completed successfully", "deployment_id", req.DeploymentID, "vm_id", createResult.VmId)
+	w.logger.Info("deployment workflow stage completed successfully", "deployment_id", req.DeploymentID, "vm_ids", vmIDs)
 
 	w.logger.Info("deployment workflow completed",
 		"execution_id", ctx.ExecutionID(),
@@ -950,3 +795,56 @@ func (w *DeployWorkflow) Run(ctx hydra.WorkflowContext, req *DeployRequest) erro
 
 	return nil
 }
+
+// dockerHostAddresses are the addresses tried to reach the Docker host,
+// Docker's magic domain names first
+var dockerHostAddresses = []string{
+	"host.docker.internal",    // Docker Desktop (Windows/Mac) and some Linux setups
+	"gateway.docker.internal", // Docker gateway
+	"172.17.0.1",              // Default Docker bridge gateway
+	"172.18.0.1",              // Alternative Docker bridge
+}
+
+// deployRegions returns the regions to deploy to, without duplicates
+func deployRegions(regions []string) []string {
+	if len(regions) == 0 {
+		return []string{"us-east-1"}
+	}
+
+	unique := make([]string, 0, len(regions))
+	seen := make(map[string]bool, len(regions))
+	for _, region := range regions {
+		if seen[region] {
+			continue
+		}
+		seen[region] = true
+		unique = append(unique, region)
+	}
+
+	return unique
+}
+
+// checkContainerHealth reports whether the container behind the host port
+// is live on any of the Docker host addresses.
+func (w *DeployWorkflow) checkContainerHealth(client *http.Client, hostPort int32, deploymentID string) bool {
+	for _, hostAddr := range dockerHostAddresses {
+		healthURL := fmt.Sprintf("http://%s:%d/v1/liveness", hostAddr, hostPort)
+		w.logger.Info("trying container health check", "url", healthURL, "host_port", hostPort, "deployment_id", deploymentID)
+
+		resp, err := client.Get(healthURL)
+		if err != nil {
+			w.logger.Warn("health check failed for host address", "error", err, "host_addr", hostAddr, "deployment_id", deploymentID)
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusOK {
+			w.logger.Info("container is healthy", "host_addr", hostAddr, "deployment_id", deploymentID)
+			return true
+		}
+
+		w.logger.Warn("health check returned non-200 status", "status", resp.StatusCode, "host_addr", hostAddr, "deployment_id", deploymentID)
+	}
+
+	return false
+}
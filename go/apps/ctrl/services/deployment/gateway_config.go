@@ -0,0 +1,44 @@
+package deployment
+
+import (
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// deployGatewayConfig points the gateway config of a hostname at a new
+// deployment. Only the deployment fields change, everything configured on
+// the hostname, such as a traffic split, sticky sessions or rate limits,
+// carries over from the current config.
+//
+// If traffic is split, the new deployment takes over the share of the
+// previous primary deployment, the other targets keep their weights.
+func deployGatewayConfig(current *partitionv1.GatewayConfig, deploymentID string, vms []*partitionv1.VM, keyspaceID string) *partitionv1.GatewayConfig {
+	config := &partitionv1.GatewayConfig{}
+	if current != nil {
+		config = proto.CloneOf(current)
+	}
+
+	previousDeploymentID := config.GetDeploymentId()
+
+	config.IsEnabled = true
+	config.DeploymentId = deploymentID
+	config.Vms = vms
+
+	for _, target := range config.GetTargets() {
+		if target.GetDeploymentId() == previousDeploymentID {
+			target.DeploymentId = deploymentID
+			target.Vms = vms
+		}
+	}
+
+	if keyspaceID != "" {
+		config.AuthConfig = &partitionv1.AuthConfig{
+			RequireApiKey:  true,
+			KeyspaceId:     keyspaceID,
+			AllowAnonymous: false,
+			Enabled:        true,
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,69 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+)
+
+func TestDeployGatewayConfig(t *testing.T) {
+	t.Parallel()
+
+	vms := []*partitionv1.VM{{Id: "vm_new", Region: "us-east-1"}}
+
+	t.Run("new hostname", func(t *testing.T) {
+		t.Parallel()
+
+		config := deployGatewayConfig(nil, "d_new", vms, "ks_1")
+
+		require.True(t, config.GetIsEnabled())
+		require.Equal(t, "d_new", config.GetDeploymentId())
+		require.Len(t, config.GetVms(), 1)
+		require.Equal(t, "ks_1", config.GetAuthConfig().GetKeyspaceId())
+		require.Empty(t, config.GetTargets())
+	})
+
+	t.Run("keeps the hostname configuration", func(t *testing.T) {
+		t.Parallel()
+
+		oldVMs := []*partitionv1.VM{{Id: "vm_old", Region: "us-east-1"}}
+		canaryVMs := []*partitionv1.VM{{Id: "vm_canary", Region: "us-east-1"}}
+		current := &partitionv1.GatewayConfig{
+			IsEnabled:    true,
+			DeploymentId: "d_old",
+			Vms:          oldVMs,
+			Targets: []*partitionv1.DeploymentTarget{
+				{DeploymentId: "d_old", Weight: 90, Vms: oldVMs},
+				{DeploymentId: "d_canary", Weight: 10, Vms: canaryVMs},
+			},
+			StickyConfig: &partitionv1.StickyConfig{CookieName: "unkey_deployment"},
+			AuthConfig:   &partitionv1.AuthConfig{Enabled: true, KeyspaceId: "ks_old"},
+			RatelimitConfig: &partitionv1.RatelimitConfig{
+				Enabled:  true,
+				Limit:    100,
+				Duration: 60000,
+			},
+		}
+
+		config := deployGatewayConfig(current, "d_new", vms, "")
+
+		require.Equal(t, "d_new", config.GetDeploymentId())
+		require.Equal(t, "vm_new", config.GetVms()[0].GetId())
+
+		require.Len(t, config.GetTargets(), 2)
+		require.Equal(t, "d_new", config.GetTargets()[0].GetDeploymentId())
+		require.Equal(t, int32(90), config.GetTargets()[0].GetWeight())
+		require.Equal(t, "vm_new", config.GetTargets()[0].GetVms()[0].GetId())
+		require.Equal(t, "d_canary", config.GetTargets()[1].GetDeploymentId())
+		require.Equal(t, int32(10), config.GetTargets()[1].GetWeight())
+
+		require.Equal(t, "unkey_deployment", config.GetStickyConfig().GetCookieName())
+		require.Equal(t, "ks_old", config.GetAuthConfig().GetKeyspaceId())
+		require.Equal(t, int64(100), config.GetRatelimitConfig().GetLimit())
+
+		// The current config is not modified
+		require.Equal(t, "d_old", current.GetDeploymentId())
+		require.Equal(t, "d_old", current.GetTargets()[0].GetDeploymentId())
+	})
+}
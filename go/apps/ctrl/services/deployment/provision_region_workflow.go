@@ -0,0 +1,253 @@
+package deployment
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"connectrpc.com/connect"
+	vmprovisionerv1 "github.com/unkeyed/unkey/go/gen/proto/metal/vmprovisioner/v1"
+	"github.com/unkeyed/unkey/go/gen/proto/metal/vmprovisioner/v1/vmprovisionerv1connect"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	partitiondb "github.com/unkeyed/unkey/go/pkg/partition/db"
+)
+
+// ProvisionRegionWorkflow creates, boots and registers the VM of a deployment
+// in a single region. The deploy workflow runs one per region as a child
+// workflow, so regions are provisioned in parallel.
+type ProvisionRegionWorkflow struct {
+	partitionDB  db.Database
+	logger       logging.Logger
+	metaldClient vmprovisionerv1connect.VmServiceClient
+}
+
+// NewProvisionRegionWorkflow creates a new provision region workflow instance
+func NewProvisionRegionWorkflow(partitionDB db.Database, logger logging.Logger,
+	metaldClient vmprovisionerv1connect.VmServiceClient,
+) *ProvisionRegionWorkflow {
+	return &ProvisionRegionWorkflow{
+		partitionDB:  partitionDB,
+		logger:       logger,
+		metaldClient: metaldClient,
+	}
+}
+
+// Name returns the workflow name for registration
+func (w *ProvisionRegionWorkflow) Name() string {
+	return "provision-region"
+}
+
+// ProvisionRegionRequest defines the input for the provision region workflow
+type ProvisionRegionRequest struct {
+	WorkspaceID  string `json:"workspace_id"`
+	ProjectID    string `json:"project_id"`
+	DeploymentID string `json:"deployment_id"`
+	DockerImage  string `json:"docker_image"`
+	Region       string `json:"region"`
+}
+
+// RegionVM is the result of the provision region workflow
+type RegionVM struct {
+	Region string                             `json:"region"`
+	VmID   string                             `json:"vm_id"`
+	Info   *vmprovisionerv1.GetVmInfoResponse `json:"info"`
+}
+
+// hostPort returns the host port that container port 8080 is mapped to, or 0
+// if there is none.
+func (vm *RegionVM) hostPort() int32 {
+	if vm.Info == nil || vm.Info.NetworkInfo == nil {
+		return 0
+	}
+
+	for _, portMapping := range vm.Info.NetworkInfo.PortMappings {
+		if portMapping.ContainerPort == 8080 {
+			return portMapping.HostPort
+		}
+	}
+
+	return 0
+}
+
+// Run provisions the VM of a deployment in one region
+func (w *ProvisionRegionWorkflow) Run(ctx hydra.WorkflowContext, req *ProvisionRegionRequest) error {
+	w.logger.Info("starting provision region workflow",
+		"execution_id", ctx.ExecutionID(),
+		"deployment_id", req.DeploymentID,
+		"region", req.Region)
+
+	// Step 1: Create VM (network call to metald)
+	createResult, err := hydra.Step(ctx, "metald-create-vm", func(stepCtx context.Context) (*vmprovisionerv1.CreateVmResponse, error) {
+		w.logger.Info("creating VM for deployment", "deployment_id", req.DeploymentID, "region", req.Region, "docker_image", req.DockerImage, "workspace_id", req.WorkspaceID, "project_id", req.ProjectID)
+
+		// Create VM configuration for Docker backend
+		vmConfig := &vmprovisionerv1.VmConfig{
+			Cpu: &vmprovisionerv1.CpuConfig{
+				VcpuCount: 1,
+			},
+			Memory: &vmprovisionerv1.MemoryConfig{
+				SizeBytes: 536870912, // 512MB
+			},
+			Boot: &vmprovisionerv1.BootConfig{
+				KernelPath: "/boot/vmlinux",
+				InitrdPath: "/boot/initrd",
+				KernelArgs: "console=ttyS0 quiet",
+			},
+			Storage: []*vmprovisionerv1.StorageDevice{{
+				Id:   "root",
+				Path: "/dev/vda",
+			}},
+			Metadata: map[string]string{
+				"docker_image":  req.DockerImage,
+				"exposed_ports": "8080/tcp",
+				"env_vars":      "PORT=8080",
+				"version_id":    req.DeploymentID,
+				"workspace_id":  req.WorkspaceID,
+				"project_id":    req.ProjectID,
+				"region":        req.Region,
+				"created_by":    "deploy-workflow",
+			},
+		}
+
+		resp, err := w.metaldClient.CreateVm(stepCtx, connect.NewRequest(&vmprovisionerv1.CreateVmRequest{
+			Config: vmConfig,
+		}))
+		if err != nil {
+			w.logger.Error("metald CreateVm call failed", "error", err, "docker_image", req.DockerImage, "region", req.Region)
+			return nil, fmt.Errorf("failed to create VM: %w", err)
+		}
+
+		w.logger.Info("VM created successfully", "vm_id", resp.Msg.VmId, "state", resp.Msg.State.String(), "region", req.Region)
+
+		return resp.Msg, nil
+	}, hydra.WithCompensation(func(stepCtx context.Context, vm *vmprovisionerv1.CreateVmResponse) error {
+		// Don't leave the VM behind if a later step fails for good or the region is cancelled
+		w.logger.Info("deleting VM of failed region", "vm_id", vm.VmId, "deployment_id", req.DeploymentID, "region", req.Region)
+		return deleteVM(stepCtx, w.metaldClient, w.logger, vm.VmId)
+	}))
+	if err != nil {
+		w.logger.Error("VM creation failed", "error", err, "deployment_id", req.DeploymentID, "region", req.Region)
+		return err
+	}
+
+	// Step 2: Boot VM (network call to metald)
+	_, err = hydra.Step(ctx, "metald-boot-vm", func(stepCtx context.Context) (*vmprovisionerv1.BootVmResponse, error) {
+		w.logger.Info("booting VM", "vm_id", createResult.VmId, "region", req.Region)
+
+		resp, err := w.metaldClient.BootVm(stepCtx, connect.NewRequest(&vmprovisionerv1.BootVmRequest{
+			VmId: createResult.VmId,
+		}))
+		if err != nil {
+			w.logger.Error("metald BootVm call failed", "error", err, "vm_id", createResult.VmId)
+			return nil, fmt.Errorf("failed to boot VM: %w", err)
+		}
+
+		if !resp.Msg.Success {
+			w.logger.Error("VM boot was not successful", "vm_id", createResult.VmId, "state", resp.Msg.State.String())
+			return nil, fmt.Errorf("VM boot was not successful, state: %s", resp.Msg.State.String())
+		}
+
+		w.logger.Info("VM booted successfully", "vm_id", createResult.VmId, "state", resp.Msg.State.String())
+		return resp.Msg, nil
+	})
+	if err != nil {
+		w.logger.Error("VM boot failed", "error", err, "vm_id", createResult.VmId)
+		return err
+	}
+
+	// Step 3: Get VM info to retrieve port mappings
+	vmInfo, err := hydra.Step(ctx, "metald-get-vm-info", func(stepCtx context.Context) (*vmprovisionerv1.GetVmInfoResponse, error) {
+		w.logger.Info("getting VM info for port mappings", "vm_id", createResult.VmId)
+
+		resp, err := w.metaldClient.GetVmInfo(stepCtx, connect.NewRequest(&vmprovisionerv1.GetVmInfoRequest{
+			VmId: createResult.VmId,
+		}))
+		if err != nil {
+			w.logger.Error("metald GetVmInfo call failed", "error", err, "vm_id", createResult.VmId)
+			return nil, fmt.Errorf("failed to get VM info: %w", err)
+		}
+
+		if resp.Msg.NetworkInfo != nil {
+			w.logger.Info("VM info retrieved successfully", "vm_id", createResult.VmId, "port_mappings", len(resp.Msg.NetworkInfo.PortMappings))
+		} else {
+			w.logger.Warn("VM info retrieved but no network info", "vm_id", createResult.VmId)
+		}
+
+		return resp.Msg, nil
+	})
+	if err != nil {
+		w.logger.Error("failed to get VM info", "error", err, "vm_id", createResult.VmId)
+		return err
+	}
+
+	vm := &RegionVM{
+		Region: req.Region,
+		VmID:   createResult.VmId,
+		Info:   vmInfo,
+	}
+
+	// Step 4: Insert VM into partition database
+	err = hydra.StepVoid(ctx, "insert-vm-partition-db", func(stepCtx context.Context) error {
+		w.logger.Info("inserting VM into partition database", "vm_id", vm.VmID, "deployment_id", req.DeploymentID, "region", req.Region)
+
+		// Validate partition DB connection before proceeding
+		if w.partitionDB == nil {
+			w.logger.Error("CRITICAL: partition database not initialized")
+			return fmt.Errorf("partition database not initialized")
+		}
+
+		hostPort := vm.hostPort()
+		if hostPort == 0 {
+			hostPort = 8080 // default fallback
+		}
+
+		vmParams := partitiondb.UpsertVMParams{
+			ID:           vm.VmID,
+			DeploymentID: req.DeploymentID,
+			Region:       req.Region,
+			PrivateIp: sql.NullString{
+				String: "127.0.0.1",
+				Valid:  true,
+			},
+			Port: sql.NullInt32{
+				Int32: hostPort,
+				Valid: true,
+			},
+			CpuMillicores: 1000,
+			MemoryMb:      512,
+			Status:        partitiondb.VmsStatusRunning,
+			HealthStatus:  partitiondb.VmsHealthStatusHealthy,
+		}
+
+		if err := partitiondb.Query.UpsertVM(stepCtx, w.partitionDB.RW(), vmParams); err != nil {
+			w.logger.Error("failed to create VM in partition DB", "error", err, "vm_id", vm.VmID)
+			return fmt.Errorf("failed to create VM %s in partition DB: %w", vm.VmID, err)
+		}
+
+		w.logger.Info("VM inserted into partition database successfully", "vm_id", vm.VmID, "host_port", hostPort)
+		return nil
+	})
+	if err != nil {
+		w.logger.Error("failed to insert VM into partition database", "error", err, "vm_id", vm.VmID)
+		return err
+	}
+
+	return hydra.SetResult(ctx, vm)
+}
+
+// deleteVM deletes a VM in metald, a VM that is already gone counts as
+// deleted.
+func deleteVM(ctx context.Context, metaldClient vmprovisionerv1connect.VmServiceClient, logger logging.Logger, vmID string) error {
+	_, err := metaldClient.DeleteVm(ctx, connect.NewRequest(&vmprovisionerv1.DeleteVmRequest{
+		VmId:  vmID,
+		Force: true,
+	}))
+	if err != nil && connect.CodeOf(err) != connect.CodeNotFound {
+		logger.Error("metald DeleteVm call failed", "error", err, "vm_id", vmID)
+		return fmt.Errorf("failed to delete VM: %w", err)
+	}
+
+	return nil
+}
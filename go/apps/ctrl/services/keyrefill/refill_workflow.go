@@ -0,0 +1,244 @@
+package keyrefill
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/go/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/go/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/go/pkg/auditlog"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+const (
+	// defaultBatchSize is the number of keys refilled per step.
+	defaultBatchSize = 1000
+
+	// lastPossibleRefillDay is the highest refill_day a key can be configured with.
+	lastPossibleRefillDay = 31
+)
+
+// KeyRefill resets the remaining credits of all keys whose refill is due.
+//
+// Keys without a refill day are refilled every day, keys with a refill day are
+// refilled once a month. If the refill day does not exist in the current month,
+// for example the 31st in April, the key is refilled on the last day of the month.
+//
+// Cached verification rows of refilled keys are not touched, they expire by their
+// TTL. Verification only uses them to tell whether a key has credits at all, which
+// a refill never changes, the remaining credits come from the usage limiter.
+type KeyRefill struct {
+	db           db.Database
+	logger       logging.Logger
+	auditlogs    auditlogs.AuditLogService
+	usageLimiter usagelimiter.Service
+	batchSize    int
+}
+
+type KeyRefillConfig struct {
+	DB        db.Database
+	Logger    logging.Logger
+	Auditlogs auditlogs.AuditLogService
+
+	// UsageLimiter is used to update cached credit counters of refilled keys.
+	UsageLimiter usagelimiter.Service
+
+	// BatchSize is the number of keys refilled per step, defaults to 1000.
+	BatchSize int
+}
+
+// NewKeyRefill creates a new key refill workflow instance
+func NewKeyRefill(config KeyRefillConfig) *KeyRefill {
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	return &KeyRefill{
+		db:           config.DB,
+		logger:       config.Logger,
+		auditlogs:    config.Auditlogs,
+		usageLimiter: config.UsageLimiter,
+		batchSize:    batchSize,
+	}
+}
+
+// Name returns the workflow name for registration
+func (w *KeyRefill) Name() string {
+	return "key_refill"
+}
+
+// KeyRefillRequest defines the input for the key refill workflow
+type KeyRefillRequest struct {
+	// Time is the unix milli timestamp the refill is performed for.
+	// It is part of the payload so retries refill for the same day.
+	Time int64 `json:"time"`
+}
+
+// Run refills all due keys in batches, every batch is a separate step so a
+// failure does not refill already processed keys twice.
+func (w *KeyRefill) Run(ctx hydra.WorkflowContext, req *KeyRefillRequest) error {
+	now := time.UnixMilli(req.Time).UTC()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	untilDay := now.Day()
+	if now.Day() == daysInMonth(now) {
+		untilDay = lastPossibleRefillDay
+	}
+
+	w.logger.Info("starting key refill", "date", startOfDay.Format(time.DateOnly))
+
+	cursor := ""
+	total := 0
+	for batch := 0; ; batch++ {
+		keys, err := hydra.Step(ctx, fmt.Sprintf("find-keys-%d", batch), func(stepCtx context.Context) ([]db.ListKeysDueForRefillRow, error) {
+			return db.Query.ListKeysDueForRefill(stepCtx, w.db.RO(), db.ListKeysDueForRefillParams{
+				RefilledBefore: sql.NullTime{Time: startOfDay, Valid: true},
+				Today:          sql.NullInt16{Int16: int16(now.Day()), Valid: true}, // nolint:gosec
+				UntilDay:       sql.NullInt16{Int16: int16(untilDay), Valid: true},  // nolint:gosec
+				IDCursor:       cursor,
+				Limit:          int32(w.batchSize), // nolint:gosec
+			})
+		})
+		if err != nil {
+			w.logger.Error("failed to find keys due for refill", "error", err)
+			return err
+		}
+
+		if len(keys) == 0 {
+			break
+		}
+
+		refilled, err := hydra.Step(ctx, fmt.Sprintf("refill-keys-%d", batch), func(stepCtx context.Context) ([]db.LockKeysNotRefilledSinceRow, error) {
+			return w.refillKeys(stepCtx, keys, startOfDay)
+		})
+		if err != nil {
+			w.logger.Error("failed to refill keys", "error", err)
+			return err
+		}
+
+		// The usage limiter keeps remaining credits in redis, without updating
+		// them the key would keep using the credits from before the refill.
+		// This is a separate step after the commit, so redis is never refilled
+		// for keys the database didn't refill. If it fails, retries reuse the
+		// keys refilled by the previous step and only set the counters again.
+		_, err = hydra.Step(ctx, fmt.Sprintf("refill-credits-%d", batch), func(stepCtx context.Context) (int, error) {
+			for _, key := range refilled {
+				refillErr := w.usageLimiter.Refill(stepCtx, key.ID, key.RefillAmount.Int32)
+				if refillErr != nil {
+					return 0, fmt.Errorf("unable to refill credits of key %s: %w", key.ID, refillErr)
+				}
+			}
+			return len(refilled), nil
+		})
+		if err != nil {
+			w.logger.Error("failed to refill credits", "error", err)
+			return err
+		}
+
+		total += len(refilled)
+		cursor = keys[len(keys)-1].ID
+
+		if len(keys) < w.batchSize {
+			break
+		}
+	}
+
+	w.logger.Info("key refill completed", "date", startOfDay.Format(time.DateOnly), "refilled", total)
+
+	return nil
+}
+
+// refillKeys resets the credits of the given keys in a single transaction and
+// returns the keys that were refilled. Keys that were refilled by a previous
+// attempt of this step are skipped.
+func (w *KeyRefill) refillKeys(ctx context.Context, keys []db.ListKeysDueForRefillRow, startOfDay time.Time) ([]db.LockKeysNotRefilledSinceRow, error) {
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = key.ID
+	}
+
+	return db.TxWithResult(ctx, w.db.RW(), func(ctx context.Context, tx db.DBTX) ([]db.LockKeysNotRefilledSinceRow, error) {
+		due, err := db.Query.LockKeysNotRefilledSince(ctx, tx, db.LockKeysNotRefilledSinceParams{
+			Ids:            ids,
+			RefilledBefore: sql.NullTime{Time: startOfDay, Valid: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to lock keys: %w", err)
+		}
+
+		if len(due) == 0 {
+			return nil, nil
+		}
+
+		// Credits spent before this point are not replayed onto the refilled
+		// credits, so it has to be the actual time rather than the scheduled one.
+		refilledAt := time.Now()
+
+		dueIDs := make([]string, len(due))
+		for i, key := range due {
+			dueIDs[i] = key.ID
+		}
+
+		err = db.Query.UpdateManyKeysCreditsRefilled(ctx, tx, db.UpdateManyKeysCreditsRefilledParams{
+			Now: sql.NullTime{Time: refilledAt, Valid: true},
+			Ids: dueIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to refill keys: %w", err)
+		}
+
+		keysByID := make(map[string]db.ListKeysDueForRefillRow, len(keys))
+		for _, key := range keys {
+			keysByID[key.ID] = key
+		}
+
+		auditLogs := make([]auditlog.AuditLog, 0, len(due))
+		for _, refilled := range due {
+			key := keysByID[refilled.ID]
+			auditLogs = append(auditLogs, auditlog.AuditLog{
+				WorkspaceID: key.WorkspaceID,
+				Event:       auditlog.KeyUpdateEvent,
+				Display:     fmt.Sprintf("Refilled %s to %d", key.ID, refilled.RefillAmount.Int32),
+				ActorID:     "key_refill",
+				ActorName:   "key refill",
+				ActorMeta:   map[string]any{},
+				ActorType:   auditlog.SystemActor,
+				RemoteIP:    "",
+				UserAgent:   "",
+				Resources: []auditlog.AuditLogResource{
+					{
+						ID:          key.KeyAuthID,
+						Type:        auditlog.KeyAuthResourceType,
+						Name:        "",
+						DisplayName: "",
+						Meta:        nil,
+					},
+					{
+						ID:          key.ID,
+						Type:        auditlog.KeyResourceType,
+						Name:        key.Name.String,
+						DisplayName: key.Name.String,
+						Meta:        nil,
+					},
+				},
+			})
+		}
+
+		err = w.auditlogs.Insert(ctx, tx, auditLogs)
+		if err != nil {
+			return nil, fmt.Errorf("unable to insert audit logs: %w", err)
+		}
+
+		return due, nil
+	})
+}
+
+// daysInMonth returns the number of days in the month of t.
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,150 @@
+package keyrefill
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/internal/services/auditlogs"
+	"github.com/unkeyed/unkey/go/internal/services/usagelimiter"
+	"github.com/unkeyed/unkey/go/pkg/counter"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/testutil/containers"
+	"github.com/unkeyed/unkey/go/pkg/testutil/seed"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestKeyRefillRefillsOncePerDay(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	logger := logging.NewNoop()
+
+	mysqlCfg := containers.MySQL(t)
+	mysqlCfg.DBName = "unkey"
+	database, err := db.New(db.Config{
+		PrimaryDSN:  mysqlCfg.FormatDSN(),
+		ReadOnlyDSN: "",
+		Logger:      logger,
+	})
+	require.NoError(t, err)
+	defer database.Close()
+
+	ctr, err := counter.NewRedis(counter.RedisConfig{
+		RedisURL: containers.Redis(t),
+		Logger:   logger,
+	})
+	require.NoError(t, err)
+	defer ctr.Close()
+
+	usageLimiter, err := usagelimiter.NewRedisWithCounter(usagelimiter.RedisConfig{
+		DB:      database,
+		Logger:  logger,
+		Counter: ctr,
+		TTL:     0,
+	})
+	require.NoError(t, err)
+	defer usageLimiter.Close()
+
+	hydraCfg := containers.MySQL(t)
+	hydraCfg.DBName = "hydra"
+	engine, err := hydra.New(hydra.Config{
+		DSN:        hydraCfg.FormatDSN(),
+		Namespace:  fmt.Sprintf("test_%s", uid.New(uid.Prefix("test"))),
+		Logger:     logger,
+		Marshaller: hydra.NewJSONMarshaller(),
+	})
+	require.NoError(t, err)
+
+	worker, err := hydra.NewWorker(engine, hydra.WorkerConfig{
+		Concurrency:       1,
+		PollInterval:      100 * time.Millisecond,
+		HeartbeatInterval: time.Second,
+		ClaimTimeout:      10 * time.Second,
+	})
+	require.NoError(t, err)
+
+	workflow := NewKeyRefill(KeyRefillConfig{
+		DB:     database,
+		Logger: logger,
+		Auditlogs: auditlogs.New(auditlogs.Config{
+			DB:     database,
+			Logger: logger,
+		}),
+		UsageLimiter: usageLimiter,
+		BatchSize:    0,
+	})
+	require.NoError(t, hydra.RegisterWorkflow(worker, workflow))
+	require.NoError(t, worker.Start(ctx))
+	defer worker.Shutdown(ctx)
+
+	seeder := seed.New(t, database, nil)
+	workspace := seeder.CreateWorkspace(ctx)
+	api := seeder.CreateAPI(ctx, seed.CreateApiRequest{
+		WorkspaceID:   workspace.ID,
+		IpWhitelist:   "",
+		EncryptedKeys: false,
+		Name:          nil,
+		CreatedAt:     nil,
+		DefaultPrefix: nil,
+		DefaultBytes:  nil,
+	})
+	key := seeder.CreateKey(ctx, seed.CreateKeyRequest{
+		WorkspaceID:  workspace.ID,
+		KeyAuthID:    api.KeyAuthID.String,
+		Remaining:    ptr.P(int32(10)),
+		RefillAmount: ptr.P(int32(100)),
+	})
+
+	// Spend some credits so the usage limiter has a counter in redis
+	res, err := usageLimiter.Limit(ctx, usagelimiter.UsageRequest{KeyId: key.KeyID, Cost: 5})
+	require.NoError(t, err)
+	require.Equal(t, int32(5), res.Remaining)
+
+	runRefill := func(scheduledAt time.Time) {
+		executionID, startErr := engine.StartWorkflow(ctx, workflow.Name(), KeyRefillRequest{Time: scheduledAt.UnixMilli()})
+		require.NoError(t, startErr)
+
+		require.Eventually(t, func() bool {
+			execution, getErr := store.Query.GetWorkflow(ctx, engine.GetDB(), store.GetWorkflowParams{
+				ID:        executionID,
+				Namespace: engine.GetNamespace(),
+			})
+			return getErr == nil && execution.Status == store.WorkflowExecutionsStatusCompleted
+		}, 30*time.Second, 100*time.Millisecond)
+	}
+
+	now := time.Now()
+	runRefill(now)
+
+	credits, err := db.Query.FindKeyCredits(ctx, database.RO(), key.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, int32(100), credits.Int32)
+
+	// The cached counter was refilled as well
+	res, err = usageLimiter.Limit(ctx, usagelimiter.UsageRequest{KeyId: key.KeyID, Cost: 30})
+	require.NoError(t, err)
+	require.True(t, res.Valid)
+	require.Equal(t, int32(70), res.Remaining)
+
+	// A second run on the same day does not refill again
+	runRefill(now)
+
+	res, err = usageLimiter.Limit(ctx, usagelimiter.UsageRequest{KeyId: key.KeyID, Cost: 1})
+	require.NoError(t, err)
+	require.Equal(t, int32(69), res.Remaining)
+
+	logs, err := db.Query.FindAuditLogTargetByID(ctx, database.RO(), key.KeyID)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+}
@@ -0,0 +1,147 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	partitiondb "github.com/unkeyed/unkey/go/pkg/partition/db"
+	"google.golang.org/protobuf/proto"
+)
+
+// newGatewayConfig builds the gateway config of a hostname that routes all
+// traffic to deployment. Settings that belong to the hostname rather than the
+// deployment, like authentication and rate limits, are carried over from the
+// current config if there is one.
+func newGatewayConfig(current *partitionv1.GatewayConfig, deployment db.Deployment, vms []partitiondb.Vm) *partitionv1.GatewayConfig {
+	config := &partitionv1.GatewayConfig{
+		IsEnabled:        true,
+		DeploymentId:     deployment.ID,
+		WorkspaceId:      deployment.WorkspaceID,
+		ProjectId:        deployment.ProjectID,
+		Environment:      string(deployment.Environment),
+		Vms:              newVMs(vms),
+		Targets:          nil,
+		StickyConfig:     nil,
+		AuthConfig:       nil,
+		ValidationConfig: nil,
+		RatelimitConfig:  nil,
+		GitCommitSha:     deployment.GitCommitSha.String,
+		GitBranch:        deployment.GitBranch.String,
+	}
+
+	config.Targets = []*partitionv1.DeploymentTarget{{
+		DeploymentId: deployment.ID,
+		Weight:       100,
+		Vms:          config.Vms,
+	}}
+
+	if current != nil {
+		config.AuthConfig = current.GetAuthConfig()
+		config.RatelimitConfig = current.GetRatelimitConfig()
+		config.StickyConfig = current.GetStickyConfig()
+	}
+
+	// The spec belongs to the deployment, a rollback must not keep validating
+	// against the spec of the version it rolled back from.
+	if deployment.OpenapiSpec.Valid && deployment.OpenapiSpec.String != "" {
+		config.ValidationConfig = &partitionv1.ValidationConfig{
+			Enabled:     true,
+			OpenapiSpec: deployment.OpenapiSpec.String,
+		}
+	}
+
+	return config
+}
+
+// newSplitGatewayConfig sends weight percent of the traffic to the deployment
+// and the rest to the primary deployment of the current config. Everything
+// else stays as it is, the primary deployment still owns the hostname.
+func newSplitGatewayConfig(current *partitionv1.GatewayConfig, deploymentID string, vms []partitiondb.Vm, weight int32) *partitionv1.GatewayConfig {
+	config := proto.CloneOf(current)
+
+	config.Targets = []*partitionv1.DeploymentTarget{
+		{
+			DeploymentId: current.GetDeploymentId(),
+			Weight:       100 - weight,
+			Vms:          current.GetVms(),
+		},
+		{
+			DeploymentId: deploymentID,
+			Weight:       weight,
+			Vms:          newVMs(vms),
+		},
+	}
+
+	return config
+}
+
+// newStickyConfig returns nil for an empty sticky session, which turns
+// sticky assignment off.
+func newStickyConfig(session *ctrlv1.StickySession) *partitionv1.StickyConfig {
+	if session.GetCookieName() == "" && session.GetHeaderName() == "" {
+		return nil
+	}
+
+	return &partitionv1.StickyConfig{
+		CookieName: session.GetCookieName(),
+		HeaderName: session.GetHeaderName(),
+	}
+}
+
+// newRatelimitConfig returns nil for an empty rate limit, which turns rate
+// limiting off.
+func newRatelimitConfig(ratelimit *ctrlv1.Ratelimit) (*partitionv1.RatelimitConfig, error) {
+	if ratelimit.GetLimit() == 0 && ratelimit.GetDuration() == 0 {
+		return nil, nil
+	}
+
+	if ratelimit.GetLimit() <= 0 {
+		return nil, errors.New("ratelimit limit must be greater than 0")
+	}
+
+	if ratelimit.GetDuration() < 1000 {
+		return nil, errors.New("ratelimit duration must be at least 1000ms")
+	}
+
+	config := &partitionv1.RatelimitConfig{
+		Enabled:          true,
+		Limit:            ratelimit.GetLimit(),
+		Duration:         ratelimit.GetDuration(),
+		IdentifierSource: partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_IP,
+		IdentifierHeader: "",
+	}
+
+	switch ratelimit.GetIdentifier() {
+	case ctrlv1.RatelimitIdentifier_RATELIMIT_IDENTIFIER_UNSPECIFIED,
+		ctrlv1.RatelimitIdentifier_RATELIMIT_IDENTIFIER_IP:
+	case ctrlv1.RatelimitIdentifier_RATELIMIT_IDENTIFIER_KEY_ID:
+		config.IdentifierSource = partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_KEY_ID
+	case ctrlv1.RatelimitIdentifier_RATELIMIT_IDENTIFIER_IDENTITY:
+		config.IdentifierSource = partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_IDENTITY
+	case ctrlv1.RatelimitIdentifier_RATELIMIT_IDENTIFIER_HEADER:
+		if ratelimit.GetIdentifierHeader() == "" {
+			return nil, errors.New("ratelimit identifier_header is required to limit by header")
+		}
+		config.IdentifierSource = partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_HEADER
+		config.IdentifierHeader = ratelimit.GetIdentifierHeader()
+	default:
+		return nil, fmt.Errorf("unknown ratelimit identifier: %s", ratelimit.GetIdentifier())
+	}
+
+	return config, nil
+}
+
+func newVMs(vms []partitiondb.Vm) []*partitionv1.VM {
+	result := make([]*partitionv1.VM, len(vms))
+	for i, vm := range vms {
+		result[i] = &partitionv1.VM{
+			Id:     vm.ID,
+			Region: vm.Region,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,210 @@
+package routing
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	partitiondb "github.com/unkeyed/unkey/go/pkg/partition/db"
+)
+
+func TestNewGatewayConfig(t *testing.T) {
+	t.Parallel()
+
+	deployment := db.Deployment{
+		ID:           "d_new",
+		WorkspaceID:  "ws_1",
+		ProjectID:    "proj_1",
+		Environment:  db.DeploymentsEnvironmentProduction,
+		GitCommitSha: sql.NullString{String: "abc123", Valid: true},
+		GitBranch:    sql.NullString{String: "main", Valid: true},
+		OpenapiSpec:  sql.NullString{String: "openapi: 3.0.0", Valid: true},
+	}
+
+	vms := []partitiondb.Vm{
+		{ID: "vm_1", DeploymentID: "d_new", Region: "us-east-1"},
+		{ID: "vm_2", DeploymentID: "d_new", Region: "eu-west-1"},
+	}
+
+	t.Run("new hostname", func(t *testing.T) {
+		t.Parallel()
+
+		config := newGatewayConfig(nil, deployment, vms)
+
+		require.True(t, config.GetIsEnabled())
+		require.Equal(t, "d_new", config.GetDeploymentId())
+		require.Equal(t, "ws_1", config.GetWorkspaceId())
+		require.Equal(t, "proj_1", config.GetProjectId())
+		require.Equal(t, "production", config.GetEnvironment())
+		require.Equal(t, "abc123", config.GetGitCommitSha())
+		require.Equal(t, "main", config.GetGitBranch())
+		require.Nil(t, config.GetAuthConfig())
+
+		require.Len(t, config.GetVms(), 2)
+		require.Equal(t, "vm_1", config.GetVms()[0].GetId())
+		require.Equal(t, "us-east-1", config.GetVms()[0].GetRegion())
+		require.Equal(t, "vm_2", config.GetVms()[1].GetId())
+		require.Equal(t, "eu-west-1", config.GetVms()[1].GetRegion())
+
+		require.True(t, config.GetValidationConfig().GetEnabled())
+		require.Equal(t, "openapi: 3.0.0", config.GetValidationConfig().GetOpenapiSpec())
+
+		require.Len(t, config.GetTargets(), 1)
+		require.Equal(t, "d_new", config.GetTargets()[0].GetDeploymentId())
+		require.Equal(t, int32(100), config.GetTargets()[0].GetWeight())
+		require.Len(t, config.GetTargets()[0].GetVms(), 2)
+	})
+
+	t.Run("auth config is carried over", func(t *testing.T) {
+		t.Parallel()
+
+		current := &partitionv1.GatewayConfig{
+			DeploymentId: "d_old",
+			AuthConfig: &partitionv1.AuthConfig{
+				Enabled:        true,
+				RequireApiKey:  true,
+				KeyspaceId:     "ks_1",
+				AllowAnonymous: false,
+			},
+			ValidationConfig: &partitionv1.ValidationConfig{
+				Enabled:     true,
+				OpenapiSpec: "old spec",
+			},
+			StickyConfig: &partitionv1.StickyConfig{
+				CookieName: "unkey_version",
+			},
+			RatelimitConfig: &partitionv1.RatelimitConfig{
+				Enabled:  true,
+				Limit:    100,
+				Duration: 60_000,
+			},
+		}
+
+		config := newGatewayConfig(current, deployment, vms)
+
+		require.Equal(t, "d_new", config.GetDeploymentId())
+		require.Equal(t, "unkey_version", config.GetStickyConfig().GetCookieName())
+		require.Equal(t, "ks_1", config.GetAuthConfig().GetKeyspaceId())
+		require.True(t, config.GetAuthConfig().GetRequireApiKey())
+		require.Equal(t, int64(100), config.GetRatelimitConfig().GetLimit())
+		require.Equal(t, "openapi: 3.0.0", config.GetValidationConfig().GetOpenapiSpec())
+	})
+
+	t.Run("validation is dropped without a spec", func(t *testing.T) {
+		t.Parallel()
+
+		withoutSpec := deployment
+		withoutSpec.OpenapiSpec = sql.NullString{}
+
+		current := &partitionv1.GatewayConfig{
+			ValidationConfig: &partitionv1.ValidationConfig{
+				Enabled:     true,
+				OpenapiSpec: "old spec",
+			},
+		}
+
+		config := newGatewayConfig(current, withoutSpec, vms)
+		require.Nil(t, config.GetValidationConfig())
+	})
+}
+
+func TestNewSplitGatewayConfig(t *testing.T) {
+	t.Parallel()
+
+	current := &partitionv1.GatewayConfig{
+		IsEnabled:    true,
+		DeploymentId: "d_blue",
+		WorkspaceId:  "ws_1",
+		Vms:          []*partitionv1.VM{{Id: "vm_blue", Region: "us-east-1"}},
+		Targets: []*partitionv1.DeploymentTarget{
+			{DeploymentId: "d_blue", Weight: 100, Vms: []*partitionv1.VM{{Id: "vm_blue", Region: "us-east-1"}}},
+		},
+		ValidationConfig: &partitionv1.ValidationConfig{
+			Enabled:     true,
+			OpenapiSpec: "blue spec",
+		},
+	}
+
+	config := newSplitGatewayConfig(current, "d_green", []partitiondb.Vm{
+		{ID: "vm_green", DeploymentID: "d_green", Region: "eu-west-1"},
+	}, 10)
+
+	// The primary deployment still owns the hostname
+	require.Equal(t, "d_blue", config.GetDeploymentId())
+	require.Equal(t, "blue spec", config.GetValidationConfig().GetOpenapiSpec())
+
+	require.Len(t, config.GetTargets(), 2)
+	require.Equal(t, "d_blue", config.GetTargets()[0].GetDeploymentId())
+	require.Equal(t, int32(90), config.GetTargets()[0].GetWeight())
+	require.Equal(t, "vm_blue", config.GetTargets()[0].GetVms()[0].GetId())
+	require.Equal(t, "d_green", config.GetTargets()[1].GetDeploymentId())
+	require.Equal(t, int32(10), config.GetTargets()[1].GetWeight())
+	require.Equal(t, "vm_green", config.GetTargets()[1].GetVms()[0].GetId())
+
+	// The current config is left untouched
+	require.Len(t, current.GetTargets(), 1)
+}
+
+func TestNewStickyConfig(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, newStickyConfig(&ctrlv1.StickySession{}))
+
+	sticky := newStickyConfig(&ctrlv1.StickySession{HeaderName: "X-User-Id"})
+	require.Equal(t, "X-User-Id", sticky.GetHeaderName())
+	require.Empty(t, sticky.GetCookieName())
+}
+
+func TestNewRatelimitConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty ratelimit turns rate limiting off", func(t *testing.T) {
+		t.Parallel()
+
+		config, err := newRatelimitConfig(nil)
+		require.NoError(t, err)
+		require.Nil(t, config)
+	})
+
+	t.Run("limits by ip by default", func(t *testing.T) {
+		t.Parallel()
+
+		config, err := newRatelimitConfig(&ctrlv1.Ratelimit{Limit: 100, Duration: 60_000})
+		require.NoError(t, err)
+		require.True(t, config.GetEnabled())
+		require.Equal(t, int64(100), config.GetLimit())
+		require.Equal(t, int64(60_000), config.GetDuration())
+		require.Equal(t, partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_IP, config.GetIdentifierSource())
+	})
+
+	t.Run("limits by header", func(t *testing.T) {
+		t.Parallel()
+
+		config, err := newRatelimitConfig(&ctrlv1.Ratelimit{
+			Limit:            10,
+			Duration:         1000,
+			Identifier:       ctrlv1.RatelimitIdentifier_RATELIMIT_IDENTIFIER_HEADER,
+			IdentifierHeader: "X-User-Id",
+		})
+		require.NoError(t, err)
+		require.Equal(t, partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_HEADER, config.GetIdentifierSource())
+		require.Equal(t, "X-User-Id", config.GetIdentifierHeader())
+	})
+
+	t.Run("rejects invalid ratelimits", func(t *testing.T) {
+		t.Parallel()
+
+		for _, ratelimit := range []*ctrlv1.Ratelimit{
+			{Limit: 0, Duration: 60_000},
+			{Limit: -1, Duration: 60_000},
+			{Limit: 10, Duration: 500},
+			{Limit: 10, Duration: 60_000, Identifier: ctrlv1.RatelimitIdentifier_RATELIMIT_IDENTIFIER_HEADER},
+		} {
+			_, err := newRatelimitConfig(ratelimit)
+			require.Error(t, err, "%v", ratelimit)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+)
+
+// GetRoute returns the route of a hostname, including the environment of the
+// version it points at and its TLS certificate.
+func (s *Service) GetRoute(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.GetRouteRequest],
+) (*connect.Response[ctrlv1.GetRouteResponse], error) {
+	if req.Msg.GetHostname() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("hostname is required"))
+	}
+
+	route, err := db.Query.FindHostnameRouteByHostname(ctx, s.db.RO(), req.Msg.GetHostname())
+	if err != nil {
+		if db.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("route not found: %s", req.Msg.GetHostname()))
+		}
+
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load route: %w", err))
+	}
+
+	deployment, err := db.Query.FindDeploymentById(ctx, s.db.RO(), route.DeploymentID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load version %s: %w", route.DeploymentID, err))
+	}
+
+	isCustomDomain := false
+	domain, err := db.Query.FindDomainByDomain(ctx, s.db.RO(), route.Hostname)
+	if err != nil && !db.IsNotFound(err) {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load domain: %w", err))
+	}
+	if err == nil {
+		isCustomDomain = domain.Type == db.DomainsTypeCustom
+	}
+
+	protoRoute := newRoute(route, deployment.Environment, isCustomDomain)
+	s.withCertificate(ctx, protoRoute)
+	s.withWeight(ctx, protoRoute)
+
+	return connect.NewResponse(&ctrlv1.GetRouteResponse{
+		Route: protoRoute,
+	}), nil
+}
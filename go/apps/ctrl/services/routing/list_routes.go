@@ -0,0 +1,77 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// ListRoutes lists the routes of a project ordered by hostname. Routes of
+// preview versions are only included if requested or if the preview
+// environment is requested explicitly.
+func (s *Service) ListRoutes(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.ListRoutesRequest],
+) (*connect.Response[ctrlv1.ListRoutesResponse], error) {
+	if req.Msg.GetWorkspaceId() == "" || req.Msg.GetProjectId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("workspace_id and project_id are required"))
+	}
+
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	environment := req.Msg.GetEnvironmentId()
+	if environment == "" && !req.Msg.GetIncludePreview() {
+		environment = string(db.DeploymentsEnvironmentProduction)
+	}
+
+	rows, err := db.Query.ListHostnameRoutes(ctx, s.db.RO(), db.ListHostnameRoutesParams{
+		WorkspaceID:    req.Msg.GetWorkspaceId(),
+		ProjectID:      req.Msg.GetProjectId(),
+		HostnameCursor: req.Msg.GetPageToken(),
+		Environment:    environment,
+		Limit:          int32(pageSize + 1), // nolint:gosec
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list routes: %w", err))
+	}
+
+	nextPageToken := ""
+	if len(rows) > pageSize {
+		rows = rows[:pageSize]
+		nextPageToken = rows[pageSize-1].Hostname
+	}
+
+	routes := make([]*ctrlv1.Route, len(rows))
+	for i, row := range rows {
+		routes[i] = newRoute(db.HostnameRoute{
+			ID:           row.ID,
+			WorkspaceID:  row.WorkspaceID,
+			ProjectID:    row.ProjectID,
+			Hostname:     row.Hostname,
+			DeploymentID: row.DeploymentID,
+			IsEnabled:    row.IsEnabled,
+			CreatedAt:    row.CreatedAt,
+			UpdatedAt:    row.UpdatedAt,
+		}, row.Environment, row.DomainType.Valid && row.DomainType.DomainsType == db.DomainsTypeCustom)
+		s.withCertificate(ctx, routes[i])
+		s.withWeight(ctx, routes[i])
+	}
+
+	return connect.NewResponse(&ctrlv1.ListRoutesResponse{
+		Routes:        routes,
+		NextPageToken: nextPageToken,
+	}), nil
+}
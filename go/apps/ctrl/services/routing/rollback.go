@@ -0,0 +1,54 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Rollback points an existing route back at a previous version and ends any
+// traffic split. Unlike SetRoute it never creates a route, rolling back a
+// hostname that was never routed is almost certainly a typo.
+func (s *Service) Rollback(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.RollbackRequest],
+) (*connect.Response[ctrlv1.RollbackResponse], error) {
+	if req.Msg.GetHostname() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("hostname is required"))
+	}
+
+	if req.Msg.GetTargetVersionId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("target_version_id is required"))
+	}
+
+	_, err := db.Query.FindHostnameRouteByHostname(ctx, s.db.RO(), req.Msg.GetHostname())
+	if err != nil {
+		if db.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("route not found: %s", req.Msg.GetHostname()))
+		}
+
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load route: %w", err))
+	}
+
+	previousVersionID, err := s.switchRoute(ctx, switchRouteRequest{
+		hostname:      req.Msg.GetHostname(),
+		deploymentID:  req.Msg.GetTargetVersionId(),
+		weight:        100,
+		stickySession: nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&ctrlv1.RollbackResponse{
+		PreviousVersionId: previousVersionID,
+		NewVersionId:      req.Msg.GetTargetVersionId(),
+		EffectiveAt:       timestamppb.New(time.Now()),
+	}), nil
+}
@@ -0,0 +1,102 @@
+package routing
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	partitiondb "github.com/unkeyed/unkey/go/pkg/partition/db"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newRoute(route db.HostnameRoute, environment db.DeploymentsEnvironment, isCustomDomain bool) *ctrlv1.Route {
+	r := &ctrlv1.Route{
+		Hostname:             route.Hostname,
+		VersionId:            route.DeploymentID,
+		WorkspaceId:          route.WorkspaceID,
+		ProjectId:            route.ProjectID,
+		EnvironmentId:        string(environment),
+		Weight:               100,
+		IsCustomDomain:       isCustomDomain,
+		IsEnabled:            route.IsEnabled,
+		CreatedAt:            timestamppb.New(time.UnixMilli(route.CreatedAt)),
+		UpdatedAt:            nil,
+		HasCertificate:       false,
+		CertificateExpiresAt: nil,
+	}
+
+	if route.UpdatedAt.Valid {
+		r.UpdatedAt = timestamppb.New(time.UnixMilli(route.UpdatedAt.Int64))
+	}
+
+	return r
+}
+
+// withCertificate adds the TLS certificate info of the route's hostname.
+// Certificates are optional, failing to load one is logged and leaves the
+// route without certificate info.
+func (s *Service) withCertificate(ctx context.Context, route *ctrlv1.Route) {
+	certificate, err := partitiondb.Query.FindCertificateByHostname(ctx, s.partitionDB.RO(), route.GetHostname())
+	if err != nil {
+		if !partitiondb.IsNotFound(err) {
+			s.logger.Warn("failed to load certificate", "error", err, "hostname", route.GetHostname())
+		}
+
+		return
+	}
+
+	expiresAt, err := certificateExpiry(certificate.Certificate)
+	if err != nil {
+		s.logger.Warn("failed to parse certificate", "error", err, "hostname", route.GetHostname())
+		return
+	}
+
+	route.HasCertificate = true
+	route.CertificateExpiresAt = timestamppb.New(expiresAt)
+}
+
+// withWeight sets the share of the traffic the route's version receives, which
+// is below 100 while a canary runs.
+func (s *Service) withWeight(ctx context.Context, route *ctrlv1.Route) {
+	gateway, err := partitiondb.Query.FindGatewayByHostname(ctx, s.partitionDB.RO(), route.GetHostname())
+	if err != nil {
+		if !partitiondb.IsNotFound(err) {
+			s.logger.Warn("failed to load gateway config", "error", err, "hostname", route.GetHostname())
+		}
+
+		return
+	}
+
+	var config partitionv1.GatewayConfig
+	if err = proto.Unmarshal(gateway.Config, &config); err != nil {
+		s.logger.Warn("failed to unmarshal gateway config", "error", err, "hostname", route.GetHostname())
+		return
+	}
+
+	for _, target := range config.GetTargets() {
+		if target.GetDeploymentId() == route.GetVersionId() {
+			route.Weight = target.GetWeight()
+		}
+	}
+}
+
+func certificateExpiry(certPEM string) (time.Time, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return time.Time{}, errors.New("failed to decode PEM block")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return cert.NotAfter, nil
+}
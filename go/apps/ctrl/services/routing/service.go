@@ -0,0 +1,33 @@
+package routing
+
+import (
+	"github.com/unkeyed/unkey/go/gen/proto/ctrl/v1/ctrlv1connect"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+// Service implements the RoutingService. Routes are stored in the
+// hostname_routes table of the main database, every change is materialised
+// into the gateway config of the hostname in the partition database, which is
+// what the gateways read.
+type Service struct {
+	ctrlv1connect.UnimplementedRoutingServiceHandler
+	db          db.Database
+	partitionDB db.Database
+	logger      logging.Logger
+}
+
+type Config struct {
+	DB          db.Database
+	PartitionDB db.Database
+	Logger      logging.Logger
+}
+
+func New(cfg Config) *Service {
+	return &Service{
+		UnimplementedRoutingServiceHandler: ctrlv1connect.UnimplementedRoutingServiceHandler{},
+		db:                                 cfg.DB,
+		partitionDB:                        cfg.PartitionDB,
+		logger:                             cfg.Logger,
+	}
+}
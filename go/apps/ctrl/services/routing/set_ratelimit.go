@@ -0,0 +1,73 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+	partitiondb "github.com/unkeyed/unkey/go/pkg/partition/db"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetRatelimit configures the rate limit the gateways enforce for a hostname.
+// Only the rate limit of the gateway config changes, the route and everything
+// else the hostname is configured with stay as they are.
+func (s *Service) SetRatelimit(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.SetRatelimitRequest],
+) (*connect.Response[ctrlv1.SetRatelimitResponse], error) {
+	hostname := req.Msg.GetHostname()
+	if hostname == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("hostname is required"))
+	}
+
+	ratelimitConfig, err := newRatelimitConfig(req.Msg.GetRatelimit())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	gateway, err := partitiondb.Query.FindGatewayByHostname(ctx, s.partitionDB.RO(), hostname)
+	if err != nil {
+		if partitiondb.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("hostname %s has no gateway config", hostname))
+		}
+
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load gateway config: %w", err))
+	}
+
+	config := &partitionv1.GatewayConfig{}
+	if err = proto.Unmarshal(gateway.Config, config); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to unmarshal gateway config: %w", err))
+	}
+
+	config.RatelimitConfig = ratelimitConfig
+
+	configBytes, err := proto.Marshal(config)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to marshal gateway config: %w", err))
+	}
+
+	err = partitiondb.Query.UpsertGateway(ctx, s.partitionDB.RW(), partitiondb.UpsertGatewayParams{
+		Hostname: hostname,
+		Config:   configBytes,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to upsert gateway config: %w", err))
+	}
+
+	s.logger.Info("ratelimit updated",
+		"hostname", hostname,
+		"enabled", ratelimitConfig != nil,
+		"limit", ratelimitConfig.GetLimit(),
+		"duration", ratelimitConfig.GetDuration(),
+	)
+
+	return connect.NewResponse(&ctrlv1.SetRatelimitResponse{
+		EffectiveAt: timestamppb.New(time.Now()),
+	}), nil
+}
@@ -0,0 +1,53 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetRoute points a hostname at a version, creating the route if the hostname
+// has none yet. A weight below 100 sends that share of the traffic to the
+// version and keeps the rest on the version the route points at, which is how
+// canaries start. The gateways pick up the change as soon as their cached
+// config expires.
+func (s *Service) SetRoute(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.SetRouteRequest],
+) (*connect.Response[ctrlv1.SetRouteResponse], error) {
+	if req.Msg.GetHostname() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("hostname is required"))
+	}
+
+	if req.Msg.GetVersionId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("version_id is required"))
+	}
+
+	weight := req.Msg.GetWeight()
+	if weight == 0 {
+		weight = 100
+	}
+
+	if weight < 0 || weight > 100 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("weight must be between 0 and 100"))
+	}
+
+	previousVersionID, err := s.switchRoute(ctx, switchRouteRequest{
+		hostname:      req.Msg.GetHostname(),
+		deploymentID:  req.Msg.GetVersionId(),
+		weight:        weight,
+		stickySession: req.Msg.GetStickySession(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&ctrlv1.SetRouteResponse{
+		PreviousVersionId: previousVersionID,
+		EffectiveAt:       timestamppb.New(time.Now()),
+	}), nil
+}
@@ -0,0 +1,179 @@
+package routing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	partitiondb "github.com/unkeyed/unkey/go/pkg/partition/db"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+	"google.golang.org/protobuf/proto"
+)
+
+type switchRouteRequest struct {
+	hostname     string
+	deploymentID string
+	// weight is the share of the traffic the deployment receives, between 1
+	// and 100. Anything below 100 splits the traffic with the deployment the
+	// route currently points at.
+	weight int32
+	// stickySession replaces the sticky session of the hostname if set
+	stickySession *ctrlv1.StickySession
+}
+
+// switchRoute points hostname at the deployment and rewrites the gateway
+// config of the hostname. It returns the deployment the hostname pointed at
+// before, which is empty if the route did not exist yet.
+//
+// A split keeps the route on its current deployment, only the gateway config
+// knows about the canary until it receives all traffic.
+//
+// The gateway config lives in the partition database, which the route
+// transaction does not cover. It is written as the last statement of the
+// transaction, so a failed gateway write rolls the route back. If the commit
+// fails after the gateway write, the gateway already serves the new config
+// while the route still points at the previous deployment. Switching is
+// idempotent, the caller reconciles the two by retrying the same switch,
+// which rewrites the config and updates the route again.
+func (s *Service) switchRoute(ctx context.Context, req switchRouteRequest) (string, error) {
+	hostname := req.hostname
+
+	deployment, err := db.Query.FindDeploymentById(ctx, s.db.RO(), req.deploymentID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return "", connect.NewError(connect.CodeNotFound, fmt.Errorf("version not found: %s", req.deploymentID))
+		}
+
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load version: %w", err))
+	}
+
+	if deployment.Status != db.DeploymentsStatusActive {
+		return "", connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("version %s is %s, only active versions can receive traffic", deployment.ID, deployment.Status))
+	}
+
+	vms, err := partitiondb.Query.ListVMsByDeploymentId(ctx, s.partitionDB.RO(), deployment.ID)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load vms: %w", err))
+	}
+
+	if len(vms) == 0 {
+		return "", connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("version %s has no vms to route traffic to", deployment.ID))
+	}
+
+	var current *partitionv1.GatewayConfig
+	gateway, err := partitiondb.Query.FindGatewayByHostname(ctx, s.partitionDB.RO(), hostname)
+	if err != nil && !partitiondb.IsNotFound(err) {
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("failed to load gateway config: %w", err))
+	}
+	if err == nil {
+		current = &partitionv1.GatewayConfig{}
+		if err = proto.Unmarshal(gateway.Config, current); err != nil {
+			return "", connect.NewError(connect.CodeInternal, fmt.Errorf("failed to unmarshal gateway config: %w", err))
+		}
+	}
+
+	split := req.weight < 100
+	if split && (current == nil || current.GetDeploymentId() == deployment.ID) {
+		return "", connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("hostname %s has no other version to split traffic with", hostname))
+	}
+
+	var config *partitionv1.GatewayConfig
+	if split {
+		config = newSplitGatewayConfig(current, deployment.ID, vms, req.weight)
+	} else {
+		config = newGatewayConfig(current, deployment, vms)
+	}
+
+	if req.stickySession != nil {
+		config.StickyConfig = newStickyConfig(req.stickySession)
+	}
+
+	configBytes, err := proto.Marshal(config)
+	if err != nil {
+		return "", connect.NewError(connect.CodeInternal, fmt.Errorf("failed to marshal gateway config: %w", err))
+	}
+
+	previousDeploymentID, err := db.TxWithResult(ctx, s.db.RW(), func(ctx context.Context, tx db.DBTX) (string, error) {
+		now := time.Now().UnixMilli()
+
+		route, err := db.Query.FindHostnameRouteByHostname(ctx, tx, hostname)
+		if err != nil && !db.IsNotFound(err) {
+			return "", fmt.Errorf("failed to load route: %w", err)
+		}
+
+		if db.IsNotFound(err) && split {
+			return "", connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("hostname %s has no route to split traffic with", hostname))
+		}
+
+		if db.IsNotFound(err) {
+			err = db.Query.InsertHostnameRoute(ctx, tx, db.InsertHostnameRouteParams{
+				ID:           uid.New("route"),
+				WorkspaceID:  deployment.WorkspaceID,
+				ProjectID:    deployment.ProjectID,
+				Hostname:     hostname,
+				DeploymentID: deployment.ID,
+				IsEnabled:    true,
+				CreatedAt:    now,
+				UpdatedAt:    sql.NullInt64{Valid: true, Int64: now},
+			})
+			if err != nil {
+				return "", fmt.Errorf("failed to create route: %w", err)
+			}
+		} else {
+			if route.WorkspaceID != deployment.WorkspaceID || route.ProjectID != deployment.ProjectID {
+				return "", connect.NewError(connect.CodePermissionDenied,
+					fmt.Errorf("hostname %s belongs to a different project than version %s", hostname, deployment.ID))
+			}
+
+			if !split {
+				err = db.Query.UpdateHostnameRouteDeployment(ctx, tx, db.UpdateHostnameRouteDeploymentParams{
+					DeploymentID: deployment.ID,
+					UpdatedAt:    sql.NullInt64{Valid: true, Int64: now},
+					ID:           route.ID,
+				})
+				if err != nil {
+					return "", fmt.Errorf("failed to update route: %w", err)
+				}
+			}
+		}
+
+		// Not part of the transaction, see the doc comment for how the two
+		// writes are reconciled
+		err = partitiondb.Query.UpsertGateway(ctx, s.partitionDB.RW(), partitiondb.UpsertGatewayParams{
+			Hostname: hostname,
+			Config:   configBytes,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to upsert gateway config: %w", err)
+		}
+
+		return route.DeploymentID, nil
+	})
+	if err != nil {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			return "", connectErr
+		}
+
+		return "", connect.NewError(connect.CodeInternal, err)
+	}
+
+	s.logger.Info("route updated",
+		"hostname", hostname,
+		"deployment_id", deployment.ID,
+		"weight", req.weight,
+		"previous_deployment_id", previousDeploymentID,
+	)
+
+	return previousDeploymentID, nil
+}
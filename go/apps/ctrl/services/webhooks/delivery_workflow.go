@@ -0,0 +1,237 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	vaultv1 "github.com/unkeyed/unkey/go/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/vault"
+	"github.com/unkeyed/unkey/go/pkg/webhook"
+)
+
+const (
+	// defaultTimeout is how long an endpoint has to respond to a delivery.
+	defaultTimeout = 10 * time.Second
+
+	// maxResponseBytes is how much of a response is read, the body is only
+	// kept for debugging failed deliveries.
+	maxResponseBytes = 1024
+)
+
+// retrySchedule is how long to wait after each failed attempt. Every wait is
+// different, hydra names durable sleeps by their duration.
+var retrySchedule = []time.Duration{
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+	2 * time.Hour,
+	4 * time.Hour,
+	8 * time.Hour,
+}
+
+// Delivery sends a single event to a single endpoint.
+//
+// The payload is signed with the endpoint's secret, see the webhook package
+// for the signature format. Endpoints have to respond with a 2xx status,
+// anything else is retried with durable sleeps in between, so deliveries
+// keep retrying across restarts for about 15 hours. Endpoints that are
+// deleted or disabled in the meantime are skipped.
+type Delivery struct {
+	db         db.Database
+	logger     logging.Logger
+	vault      *vault.Service
+	httpClient *http.Client
+	schedule   []time.Duration
+}
+
+type DeliveryConfig struct {
+	DB     db.Database
+	Logger logging.Logger
+
+	// Vault decrypts the signing secrets of endpoints.
+	Vault *vault.Service
+
+	// HTTPClient sends the deliveries, defaults to a client with a 10 second
+	// timeout that only connects to public addresses, see
+	// webhook.NewHTTPClient.
+	HTTPClient *http.Client
+
+	// RetrySchedule is how long to wait after each failed attempt, the
+	// number of attempts is one more than its length. Every wait must be
+	// different. Defaults to 8 retries over about 15 hours.
+	RetrySchedule []time.Duration
+}
+
+// NewDelivery creates a new webhook delivery workflow instance.
+//
+// Returns an error if a wait of the retry schedule is not positive or used
+// twice.
+func NewDelivery(config DeliveryConfig) (*Delivery, error) {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = webhook.NewHTTPClient(defaultTimeout)
+	}
+
+	schedule := config.RetrySchedule
+	if schedule == nil {
+		schedule = retrySchedule
+	}
+
+	seen := make(map[time.Duration]struct{}, len(schedule))
+	for _, wait := range schedule {
+		if wait <= 0 {
+			return nil, fmt.Errorf("retry schedule waits must be positive, got %s", wait)
+		}
+		if _, ok := seen[wait]; ok {
+			return nil, fmt.Errorf("retry schedule waits must be different, %s is used twice", wait)
+		}
+		seen[wait] = struct{}{}
+	}
+
+	return &Delivery{
+		db:         config.DB,
+		logger:     config.Logger,
+		vault:      config.Vault,
+		httpClient: httpClient,
+		schedule:   schedule,
+	}, nil
+}
+
+// Name returns the workflow name for registration
+func (w *Delivery) Name() string {
+	return "webhook_delivery"
+}
+
+// DeliveryRequest defines the input for the webhook delivery workflow
+type DeliveryRequest struct {
+	EventID    string `json:"eventId"`
+	EndpointID string `json:"endpointId"`
+}
+
+// DeliveryAttempt is the outcome of a single attempt.
+type DeliveryAttempt struct {
+	// Skipped is true if the endpoint was deleted or disabled.
+	Skipped bool `json:"skipped"`
+
+	// StatusCode is the status the endpoint responded with, 0 if the request
+	// failed.
+	StatusCode int `json:"statusCode"`
+
+	// Error describes why the attempt failed, empty if it succeeded.
+	Error string `json:"error"`
+}
+
+// Run delivers the event, retrying until the endpoint accepts it or the
+// retry schedule is exhausted.
+//
+// Failed attempts are recorded as completed steps with their error, so a
+// replay does not send them again.
+func (w *Delivery) Run(ctx hydra.WorkflowContext, req *DeliveryRequest) error {
+	for attempt := 0; ; attempt++ {
+		result, err := hydra.Step(ctx, fmt.Sprintf("deliver-%d", attempt), func(stepCtx context.Context) (DeliveryAttempt, error) {
+			return w.deliver(stepCtx, req)
+		})
+		if err != nil {
+			w.logger.Error("failed to deliver webhook", "error", err)
+			return err
+		}
+
+		if result.Skipped {
+			w.logger.Info("skipped webhook delivery to removed endpoint",
+				"eventId", req.EventID,
+				"endpointId", req.EndpointID,
+			)
+			return nil
+		}
+
+		if result.Error == "" {
+			w.logger.Info("webhook delivered",
+				"eventId", req.EventID,
+				"endpointId", req.EndpointID,
+				"attempt", attempt,
+			)
+			return nil
+		}
+
+		if attempt >= len(w.schedule) {
+			w.logger.Warn("giving up webhook delivery",
+				"eventId", req.EventID,
+				"endpointId", req.EndpointID,
+				"statusCode", result.StatusCode,
+				"error", result.Error,
+			)
+			return fmt.Errorf("delivery of %s to %s failed after %d attempts: %s", req.EventID, req.EndpointID, attempt+1, result.Error)
+		}
+
+		err = hydra.Sleep(ctx, w.schedule[attempt])
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// deliver sends the event to the endpoint once. Errors of the endpoint are
+// returned as part of the attempt, errors reading the event, the endpoint or
+// its secret are returned as errors and retried by hydra.
+func (w *Delivery) deliver(ctx context.Context, req *DeliveryRequest) (DeliveryAttempt, error) {
+	endpoint, err := db.Query.FindWebhookEndpointByID(ctx, w.db.RO(), req.EndpointID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return DeliveryAttempt{Skipped: true, StatusCode: 0, Error: ""}, nil
+		}
+		return DeliveryAttempt{}, fmt.Errorf("unable to find endpoint: %w", err)
+	}
+
+	if endpoint.DeletedAtM.Valid || !endpoint.Enabled {
+		return DeliveryAttempt{Skipped: true, StatusCode: 0, Error: ""}, nil
+	}
+
+	event, err := db.Query.FindWebhookEventByID(ctx, w.db.RO(), req.EventID)
+	if err != nil {
+		return DeliveryAttempt{}, fmt.Errorf("unable to find event: %w", err)
+	}
+
+	secret, err := w.vault.Decrypt(ctx, &vaultv1.DecryptRequest{
+		Keyring:   endpoint.WorkspaceID,
+		Encrypted: endpoint.Encrypted,
+	})
+	if err != nil {
+		return DeliveryAttempt{}, fmt.Errorf("unable to decrypt secret: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(event.Payload))
+	if err != nil {
+		return DeliveryAttempt{Skipped: false, StatusCode: 0, Error: err.Error()}, nil
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(webhook.EventIDHeader, event.ID)
+	httpReq.Header.Set(webhook.SignatureHeader, webhook.Sign(secret.GetPlaintext(), time.Now(), event.Payload))
+
+	res, err := w.httpClient.Do(httpReq)
+	if err != nil {
+		return DeliveryAttempt{Skipped: false, StatusCode: 0, Error: err.Error()}, nil
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxResponseBytes))
+		return DeliveryAttempt{
+			Skipped:    false,
+			StatusCode: res.StatusCode,
+			Error:      fmt.Sprintf("endpoint responded with %d: %s", res.StatusCode, body),
+		}, nil
+	}
+
+	return DeliveryAttempt{Skipped: false, StatusCode: res.StatusCode, Error: ""}, nil
+}
@@ -0,0 +1,290 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	vaultv1 "github.com/unkeyed/unkey/go/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/testutil/containers"
+	"github.com/unkeyed/unkey/go/pkg/testutil/seed"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+	"github.com/unkeyed/unkey/go/pkg/vault"
+	"github.com/unkeyed/unkey/go/pkg/vault/keys"
+	"github.com/unkeyed/unkey/go/pkg/vault/storage"
+	"github.com/unkeyed/unkey/go/pkg/webhook"
+)
+
+func TestNewDeliveryValidatesRetrySchedule(t *testing.T) {
+	config := DeliveryConfig{
+		DB:            nil,
+		Logger:        logging.NewNoop(),
+		Vault:         nil,
+		HTTPClient:    nil,
+		RetrySchedule: nil,
+	}
+
+	delivery, err := NewDelivery(config)
+	require.NoError(t, err)
+	require.Equal(t, retrySchedule, delivery.schedule)
+
+	config.RetrySchedule = []time.Duration{time.Second, 2 * time.Second}
+	_, err = NewDelivery(config)
+	require.NoError(t, err)
+
+	// Sleeps are named by their duration, a repeated wait would replay the first one
+	config.RetrySchedule = []time.Duration{time.Second, 2 * time.Second, time.Second}
+	_, err = NewDelivery(config)
+	require.Error(t, err)
+
+	config.RetrySchedule = []time.Duration{time.Second, 0}
+	_, err = NewDelivery(config)
+	require.Error(t, err)
+
+	config.RetrySchedule = []time.Duration{-time.Second}
+	_, err = NewDelivery(config)
+	require.Error(t, err)
+}
+
+func TestDeliveryRetriesUntilAccepted(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// The endpoint rejects the first two attempts
+	var requests atomic.Int32
+	h := newDeliveryHarness(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}), []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 150 * time.Millisecond})
+
+	endpointID := h.createEndpoint(ctx, t, h.serverURL)
+	execution := h.deliver(ctx, t, endpointID)
+
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, execution.Status)
+	require.Equal(t, int32(3), requests.Load())
+}
+
+func TestDeliveryGivesUpAfterRetrySchedule(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var requests atomic.Int32
+	h := newDeliveryHarness(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}), []time.Duration{50 * time.Millisecond, 100 * time.Millisecond})
+
+	endpointID := h.createEndpoint(ctx, t, h.serverURL)
+	execution := h.deliver(ctx, t, endpointID)
+
+	require.Equal(t, store.WorkflowExecutionsStatusFailed, execution.Status)
+	require.Contains(t, execution.ErrorMessage.String, "failed after 3 attempts")
+	require.Equal(t, int32(3), requests.Load())
+}
+
+func TestDeliverySkipsRemovedEndpoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var requests atomic.Int32
+	h := newDeliveryHarness(ctx, t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}), []time.Duration{50 * time.Millisecond})
+
+	deleted := h.createEndpoint(ctx, t, h.serverURL)
+	require.NoError(t, db.Query.SoftDeleteWebhookEndpoint(ctx, h.database.RW(), db.SoftDeleteWebhookEndpointParams{
+		Now: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		ID:  deleted,
+	}))
+
+	execution := h.deliver(ctx, t, deleted)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, execution.Status)
+
+	// Endpoints that no longer exist are skipped as well
+	execution = h.deliver(ctx, t, uid.New(uid.WebhookEndpointPrefix))
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, execution.Status)
+
+	require.Equal(t, int32(0), requests.Load())
+}
+
+// deliveryHarness runs the delivery workflow against a real database and
+// hydra engine.
+type deliveryHarness struct {
+	database  db.Database
+	engine    *hydra.Engine
+	delivery  *Delivery
+	workspace string
+	eventID   string
+	encrypted *vaultv1.EncryptResponse
+	serverURL string
+}
+
+// newDeliveryHarness starts a worker running a delivery workflow that sends
+// to the handler and records a single event to deliver.
+func newDeliveryHarness(ctx context.Context, t *testing.T, handler http.Handler, schedule []time.Duration) *deliveryHarness {
+	t.Helper()
+
+	logger := logging.NewNoop()
+
+	mysqlCfg := containers.MySQL(t)
+	mysqlCfg.DBName = "unkey"
+	database, err := db.New(db.Config{
+		PrimaryDSN:  mysqlCfg.FormatDSN(),
+		ReadOnlyDSN: "",
+		Logger:      logger,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	vaultStorage, err := storage.NewMemory(storage.MemoryConfig{
+		Logger: logger,
+	})
+	require.NoError(t, err)
+
+	_, masterKey, err := keys.GenerateMasterKey()
+	require.NoError(t, err)
+
+	vaultSvc, err := vault.New(vault.Config{
+		Logger:     logger,
+		Storage:    vaultStorage,
+		MasterKeys: []string{masterKey},
+	})
+	require.NoError(t, err)
+
+	hydraCfg := containers.MySQL(t)
+	hydraCfg.DBName = "hydra"
+	engine, err := hydra.New(hydra.Config{
+		DSN:        hydraCfg.FormatDSN(),
+		Namespace:  fmt.Sprintf("test_%s", uid.New(uid.Prefix("test"))),
+		Logger:     logger,
+		Marshaller: hydra.NewJSONMarshaller(),
+	})
+	require.NoError(t, err)
+
+	worker, err := hydra.NewWorker(engine, hydra.WorkerConfig{
+		Concurrency:       2,
+		PollInterval:      100 * time.Millisecond,
+		HeartbeatInterval: time.Second,
+		ClaimTimeout:      10 * time.Second,
+	})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	delivery, err := NewDelivery(DeliveryConfig{
+		DB:            database,
+		Logger:        logger,
+		Vault:         vaultSvc,
+		HTTPClient:    server.Client(),
+		RetrySchedule: schedule,
+	})
+	require.NoError(t, err)
+	require.NoError(t, hydra.RegisterWorkflow(worker, delivery))
+	require.NoError(t, worker.Start(ctx))
+	t.Cleanup(func() { worker.Shutdown(context.Background()) })
+
+	workspace := seed.New(t, database, nil).CreateWorkspace(ctx)
+
+	secret, err := webhook.GenerateSecret()
+	require.NoError(t, err)
+
+	encrypted, err := vaultSvc.Encrypt(ctx, &vaultv1.EncryptRequest{
+		Keyring: workspace.ID,
+		Data:    secret,
+	})
+	require.NoError(t, err)
+
+	eventID := uid.New(uid.WebhookEventPrefix)
+	require.NoError(t, db.Query.InsertWebhookEvent(ctx, database.RW(), db.InsertWebhookEventParams{
+		ID:          eventID,
+		WorkspaceID: workspace.ID,
+		Event:       string(webhook.KeyExpiredEvent),
+		DedupeKey:   eventID,
+		Payload:     []byte(fmt.Sprintf(`{"id":%q,"event":%q}`, eventID, webhook.KeyExpiredEvent)),
+		CreatedAt:   time.Now().UnixMilli(),
+	}))
+
+	return &deliveryHarness{
+		database:  database,
+		engine:    engine,
+		delivery:  delivery,
+		workspace: workspace.ID,
+		eventID:   eventID,
+		encrypted: encrypted,
+		serverURL: server.URL,
+	}
+}
+
+// createEndpoint creates an endpoint for the url.
+func (h *deliveryHarness) createEndpoint(ctx context.Context, t *testing.T, url string) string {
+	t.Helper()
+
+	events, err := json.Marshal(webhook.Events)
+	require.NoError(t, err)
+
+	endpointID := uid.New(uid.WebhookEndpointPrefix)
+	require.NoError(t, db.Query.InsertWebhookEndpoint(ctx, h.database.RW(), db.InsertWebhookEndpointParams{
+		ID:              endpointID,
+		WorkspaceID:     h.workspace,
+		Url:             url,
+		Events:          events,
+		Encrypted:       h.encrypted.GetEncrypted(),
+		EncryptionKeyID: h.encrypted.GetKeyId(),
+		CreatedAt:       time.Now().UnixMilli(),
+	}))
+
+	return endpointID
+}
+
+// deliver runs the delivery workflow once, without hydra retrying it, and
+// waits for it to finish.
+func (h *deliveryHarness) deliver(ctx context.Context, t *testing.T, endpointID string) store.WorkflowExecution {
+	t.Helper()
+
+	executionID, err := h.engine.StartWorkflow(ctx, h.delivery.Name(), DeliveryRequest{
+		EventID:    h.eventID,
+		EndpointID: endpointID,
+	}, hydra.WithMaxAttempts(1))
+	require.NoError(t, err)
+
+	var execution store.WorkflowExecution
+	require.Eventually(t, func() bool {
+		var getErr error
+		execution, getErr = store.Query.GetWorkflow(ctx, h.engine.GetDB(), store.GetWorkflowParams{
+			ID:        executionID,
+			Namespace: h.engine.GetNamespace(),
+		})
+		return getErr == nil && (execution.Status == store.WorkflowExecutionsStatusCompleted ||
+			execution.Status == store.WorkflowExecutionsStatusFailed)
+	}, 30*time.Second, 100*time.Millisecond)
+
+	return execution
+}
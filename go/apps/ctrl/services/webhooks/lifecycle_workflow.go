@@ -0,0 +1,521 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/unkeyed/unkey/go/pkg/auditlog"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+	"github.com/unkeyed/unkey/go/pkg/webhook"
+)
+
+const (
+	// defaultExpiringWindow is how long before its expiration a key is
+	// reported as expiring soon.
+	defaultExpiringWindow = 7 * 24 * time.Hour
+
+	// defaultCreditsThreshold is the number of remaining credits at which a
+	// key is reported as running low.
+	defaultCreditsThreshold = 100
+
+	// defaultLookback is how far back expired keys and audit logs are
+	// scanned, it covers runs that were missed or failed.
+	defaultLookback = 24 * time.Hour
+
+	// defaultWorkspaceBatchSize is the number of workspaces scanned per step.
+	defaultWorkspaceBatchSize = 100
+
+	// defaultBatchSize is the number of rows read per query and the number
+	// of events dispatched per step.
+	defaultBatchSize = 1000
+)
+
+// KeyLifecycle records lifecycle events of keys in workspaces with webhook
+// endpoints and starts a delivery for every endpoint subscribed to them.
+//
+// Keys are scanned for upcoming and past expirations and for low or
+// exhausted credits, created and deleted keys are read from the audit log.
+// Events are recorded in webhook_events with a dedupe key that identifies
+// the occurrence, so overlapping runs never record an event twice:
+//
+//   - expirations are deduplicated by the key and its expiration, so changing
+//     the expiration reports the key again
+//   - credit events are deduplicated by the key and its last refill, so they
+//     are reported once per refill period
+//   - created and deleted keys are deduplicated by their audit log
+//
+// Credits are read from the database, which lags behind the usage limiter
+// by a few seconds.
+type KeyLifecycle struct {
+	db                 db.Database
+	logger             logging.Logger
+	engine             *hydra.Engine
+	delivery           *Delivery
+	expiringWindow     time.Duration
+	creditsThreshold   int32
+	lookback           time.Duration
+	workspaceBatchSize int
+	batchSize          int
+}
+
+type KeyLifecycleConfig struct {
+	DB     db.Database
+	Logger logging.Logger
+
+	// Engine starts the delivery workflows.
+	Engine *hydra.Engine
+
+	// Delivery is the workflow started for every endpoint subscribed to an
+	// event.
+	Delivery *Delivery
+
+	// ExpiringWindow is how long before its expiration a key is reported as
+	// expiring soon, defaults to 7 days.
+	ExpiringWindow time.Duration
+
+	// CreditsThreshold is the number of remaining credits at which a key is
+	// reported as running low, defaults to 100.
+	CreditsThreshold int32
+
+	// Lookback is how far back expired keys and audit logs are scanned,
+	// defaults to 24 hours.
+	Lookback time.Duration
+
+	// WorkspaceBatchSize is the number of workspaces scanned per step,
+	// defaults to 100.
+	WorkspaceBatchSize int
+
+	// BatchSize is the number of rows read per query and the number of events
+	// dispatched per step, defaults to 1000.
+	BatchSize int
+}
+
+// NewKeyLifecycle creates a new key lifecycle webhook workflow instance
+func NewKeyLifecycle(config KeyLifecycleConfig) *KeyLifecycle {
+	expiringWindow := config.ExpiringWindow
+	if expiringWindow <= 0 {
+		expiringWindow = defaultExpiringWindow
+	}
+
+	creditsThreshold := config.CreditsThreshold
+	if creditsThreshold <= 0 {
+		creditsThreshold = defaultCreditsThreshold
+	}
+
+	lookback := config.Lookback
+	if lookback <= 0 {
+		lookback = defaultLookback
+	}
+
+	workspaceBatchSize := config.WorkspaceBatchSize
+	if workspaceBatchSize <= 0 {
+		workspaceBatchSize = defaultWorkspaceBatchSize
+	}
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	return &KeyLifecycle{
+		db:                 config.DB,
+		logger:             config.Logger,
+		engine:             config.Engine,
+		delivery:           config.Delivery,
+		expiringWindow:     expiringWindow,
+		creditsThreshold:   creditsThreshold,
+		lookback:           lookback,
+		workspaceBatchSize: workspaceBatchSize,
+		batchSize:          batchSize,
+	}
+}
+
+// Name returns the workflow name for registration
+func (w *KeyLifecycle) Name() string {
+	return "key_lifecycle_webhooks"
+}
+
+// KeyLifecycleRequest defines the input for the key lifecycle webhook workflow
+type KeyLifecycleRequest struct {
+	// Time is the unix milli timestamp the keys are scanned for.
+	// It is part of the payload so retries scan the same window.
+	Time int64 `json:"time"`
+}
+
+// DispatchBatch is the progress of the dispatch after a step.
+type DispatchBatch struct {
+	// Cursor is the id of the last event of the batch.
+	Cursor string `json:"cursor"`
+
+	// Events is the number of events in the batch.
+	Events int `json:"events"`
+
+	// Deliveries is the number of delivery workflows started.
+	Deliveries int `json:"deliveries"`
+}
+
+// Run records the events of all workspaces with webhook endpoints and then
+// dispatches every event that was not dispatched yet, including those of
+// earlier runs that failed halfway.
+func (w *KeyLifecycle) Run(ctx hydra.WorkflowContext, req *KeyLifecycleRequest) error {
+	now := time.UnixMilli(req.Time)
+
+	w.logger.Info("starting key lifecycle webhooks", "time", now)
+
+	cursor := ""
+	scanned := 0
+	for batch := 0; ; batch++ {
+		workspaceIDs, err := hydra.Step(ctx, fmt.Sprintf("find-workspaces-%d", batch), func(stepCtx context.Context) ([]string, error) {
+			return db.Query.ListWorkspacesWithWebhookEndpoints(stepCtx, w.db.RO(), db.ListWorkspacesWithWebhookEndpointsParams{
+				WorkspaceIDCursor: cursor,
+				Limit:             int32(w.workspaceBatchSize), // nolint:gosec
+			})
+		})
+		if err != nil {
+			w.logger.Error("failed to find workspaces with webhook endpoints", "error", err)
+			return err
+		}
+
+		if len(workspaceIDs) == 0 {
+			break
+		}
+
+		n, err := hydra.Step(ctx, fmt.Sprintf("record-events-%d", batch), func(stepCtx context.Context) (int, error) {
+			return w.recordEvents(stepCtx, workspaceIDs, now)
+		})
+		if err != nil {
+			w.logger.Error("failed to record events", "error", err)
+			return err
+		}
+
+		scanned += n
+		cursor = workspaceIDs[len(workspaceIDs)-1]
+
+		if len(workspaceIDs) < w.workspaceBatchSize {
+			break
+		}
+	}
+
+	cursor = ""
+	dispatched := 0
+	deliveries := 0
+	for batch := 0; ; batch++ {
+		progress, err := hydra.Step(ctx, fmt.Sprintf("dispatch-events-%d", batch), func(stepCtx context.Context) (DispatchBatch, error) {
+			return w.dispatchEvents(stepCtx, cursor)
+		})
+		if err != nil {
+			w.logger.Error("failed to dispatch events", "error", err)
+			return err
+		}
+
+		dispatched += progress.Events
+		deliveries += progress.Deliveries
+		cursor = progress.Cursor
+
+		if progress.Events < w.batchSize {
+			break
+		}
+	}
+
+	w.logger.Info("key lifecycle webhooks completed",
+		"scanned", scanned,
+		"dispatched", dispatched,
+		"deliveries", deliveries,
+	)
+
+	return nil
+}
+
+// recordEvents records the lifecycle events of keys in the given workspaces
+// and returns how many events were found, including ones that were already
+// recorded before.
+func (w *KeyLifecycle) recordEvents(ctx context.Context, workspaceIDs []string, now time.Time) (int, error) {
+	found := 0
+
+	// Keys that expire within the window
+	for cursor := ""; ; {
+		keys, err := db.Query.ListKeysExpiringBetween(ctx, w.db.RO(), db.ListKeysExpiringBetweenParams{
+			WorkspaceIds:  workspaceIDs,
+			ExpiresAfter:  sql.NullTime{Time: now, Valid: true},
+			ExpiresBefore: sql.NullTime{Time: now.Add(w.expiringWindow), Valid: true},
+			IDCursor:      cursor,
+			Limit:         int32(w.batchSize), // nolint:gosec
+		})
+		if err != nil {
+			return 0, fmt.Errorf("unable to list expiring keys: %w", err)
+		}
+
+		for _, key := range keys {
+			expires := key.Expires.Time.UnixMilli()
+			err = w.record(ctx, webhook.Payload{
+				ID:          "",
+				Event:       webhook.KeyExpiringSoonEvent,
+				WorkspaceID: key.WorkspaceID,
+				Time:        now.UnixMilli(),
+				Data: webhook.KeyData{
+					KeyID:      key.ID,
+					KeySpaceID: key.KeyAuthID,
+					Name:       key.Name.String,
+					Expires:    ptr.P(expires),
+					Remaining:  nil,
+				},
+			}, fmt.Sprintf("%s:%d", key.ID, expires))
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		found += len(keys)
+		if len(keys) < w.batchSize {
+			break
+		}
+		cursor = keys[len(keys)-1].ID
+	}
+
+	// Keys that expired since the lookback
+	for cursor := ""; ; {
+		keys, err := db.Query.ListKeysExpiringBetween(ctx, w.db.RO(), db.ListKeysExpiringBetweenParams{
+			WorkspaceIds:  workspaceIDs,
+			ExpiresAfter:  sql.NullTime{Time: now.Add(-w.lookback), Valid: true},
+			ExpiresBefore: sql.NullTime{Time: now, Valid: true},
+			IDCursor:      cursor,
+			Limit:         int32(w.batchSize), // nolint:gosec
+		})
+		if err != nil {
+			return 0, fmt.Errorf("unable to list expired keys: %w", err)
+		}
+
+		for _, key := range keys {
+			expires := key.Expires.Time.UnixMilli()
+			err = w.record(ctx, webhook.Payload{
+				ID:          "",
+				Event:       webhook.KeyExpiredEvent,
+				WorkspaceID: key.WorkspaceID,
+				Time:        expires,
+				Data: webhook.KeyData{
+					KeyID:      key.ID,
+					KeySpaceID: key.KeyAuthID,
+					Name:       key.Name.String,
+					Expires:    ptr.P(expires),
+					Remaining:  nil,
+				},
+			}, fmt.Sprintf("%s:%d", key.ID, expires))
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		found += len(keys)
+		if len(keys) < w.batchSize {
+			break
+		}
+		cursor = keys[len(keys)-1].ID
+	}
+
+	// Keys running out of credits
+	for cursor := ""; ; {
+		keys, err := db.Query.ListKeysWithCreditsAtMost(ctx, w.db.RO(), db.ListKeysWithCreditsAtMostParams{
+			WorkspaceIds: workspaceIDs,
+			Credits:      sql.NullInt32{Int32: w.creditsThreshold, Valid: true},
+			IDCursor:     cursor,
+			Limit:        int32(w.batchSize), // nolint:gosec
+		})
+		if err != nil {
+			return 0, fmt.Errorf("unable to list keys with low credits: %w", err)
+		}
+
+		for _, key := range keys {
+			event := webhook.KeyCreditsLowEvent
+			if key.RemainingRequests.Int32 <= 0 {
+				event = webhook.KeyCreditsExhaustedEvent
+			}
+
+			lastRefill := int64(0)
+			if key.LastRefillAt.Valid {
+				lastRefill = key.LastRefillAt.Time.UnixMilli()
+			}
+
+			err = w.record(ctx, webhook.Payload{
+				ID:          "",
+				Event:       event,
+				WorkspaceID: key.WorkspaceID,
+				Time:        now.UnixMilli(),
+				Data: webhook.KeyData{
+					KeyID:      key.ID,
+					KeySpaceID: key.KeyAuthID,
+					Name:       key.Name.String,
+					Expires:    nil,
+					Remaining:  ptr.P(key.RemainingRequests.Int32),
+				},
+			}, fmt.Sprintf("%s:%d", key.ID, lastRefill))
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		found += len(keys)
+		if len(keys) < w.batchSize {
+			break
+		}
+		cursor = keys[len(keys)-1].ID
+	}
+
+	// Created and deleted keys
+	for cursor := ""; ; {
+		logs, err := db.Query.ListKeyAuditLogsSince(ctx, w.db.RO(), db.ListKeyAuditLogsSinceParams{
+			WorkspaceIds: workspaceIDs,
+			Events:       []string{string(auditlog.KeyCreateEvent), string(auditlog.KeyDeleteEvent)},
+			Since:        now.Add(-w.lookback).UnixMilli(),
+			IDCursor:     cursor,
+			Limit:        int32(w.batchSize), // nolint:gosec
+		})
+		if err != nil {
+			return 0, fmt.Errorf("unable to list key audit logs: %w", err)
+		}
+
+		for _, auditLog := range logs {
+			err = w.record(ctx, webhook.Payload{
+				ID:          "",
+				Event:       webhook.Event(auditLog.Event),
+				WorkspaceID: auditLog.WorkspaceID,
+				Time:        auditLog.Time,
+				Data: webhook.KeyData{
+					KeyID:      auditLog.KeyID,
+					KeySpaceID: auditLog.KeyAuthID.String,
+					Name:       auditLog.KeyName.String,
+					Expires:    nil,
+					Remaining:  nil,
+				},
+			}, auditLog.ID)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		found += len(logs)
+		if len(logs) < w.batchSize {
+			break
+		}
+		cursor = logs[len(logs)-1].ID
+	}
+
+	return found, nil
+}
+
+// record inserts the event unless it was recorded with the same dedupe key
+// before.
+func (w *KeyLifecycle) record(ctx context.Context, payload webhook.Payload, dedupeKey string) error {
+	payload.ID = uid.New(uid.WebhookEventPrefix)
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("unable to marshal payload: %w", err)
+	}
+
+	err = db.Query.InsertWebhookEvent(ctx, w.db.RW(), db.InsertWebhookEventParams{
+		ID:          payload.ID,
+		WorkspaceID: payload.WorkspaceID,
+		Event:       string(payload.Event),
+		DedupeKey:   dedupeKey,
+		Payload:     b,
+		CreatedAt:   time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to insert %s event: %w", payload.Event, err)
+	}
+
+	return nil
+}
+
+// dispatchEvents starts a delivery workflow for every endpoint subscribed to
+// the next batch of undispatched events, and marks them as dispatched.
+//
+// Deliveries are started with an idempotency key, if a previous attempt of
+// this step started some of them already they are not started again.
+func (w *KeyLifecycle) dispatchEvents(ctx context.Context, cursor string) (DispatchBatch, error) {
+	events, err := db.Query.ListUndispatchedWebhookEvents(ctx, w.db.RW(), db.ListUndispatchedWebhookEventsParams{
+		IDCursor: cursor,
+		Limit:    int32(w.batchSize), // nolint:gosec
+	})
+	if err != nil {
+		return DispatchBatch{}, fmt.Errorf("unable to list undispatched events: %w", err)
+	}
+
+	progress := DispatchBatch{
+		Cursor:     cursor,
+		Events:     len(events),
+		Deliveries: 0,
+	}
+
+	endpointsByWorkspace := map[string][]db.WebhookEndpoint{}
+	for _, event := range events {
+		endpoints, ok := endpointsByWorkspace[event.WorkspaceID]
+		if !ok {
+			endpoints, err = db.Query.ListWebhookEndpointsByWorkspaceID(ctx, w.db.RO(), event.WorkspaceID)
+			if err != nil {
+				return DispatchBatch{}, fmt.Errorf("unable to list endpoints of workspace %s: %w", event.WorkspaceID, err)
+			}
+			endpointsByWorkspace[event.WorkspaceID] = endpoints
+		}
+
+		for _, endpoint := range endpoints {
+			subscribed, subscribedErr := isSubscribed(endpoint, event.Event)
+			if subscribedErr != nil {
+				return DispatchBatch{}, subscribedErr
+			}
+			if !subscribed {
+				continue
+			}
+
+			_, err = w.engine.StartWorkflow(ctx, w.delivery.Name(),
+				DeliveryRequest{
+					EventID:    event.ID,
+					EndpointID: endpoint.ID,
+				},
+				hydra.WithMaxAttempts(3),
+				hydra.WithTimeout(24*time.Hour),
+				hydra.WithRetryBackoff(time.Minute),
+				hydra.WithIdempotencyKey(fmt.Sprintf("%s:%s", event.ID, endpoint.ID)),
+			)
+			if err != nil {
+				return DispatchBatch{}, fmt.Errorf("unable to start delivery of %s to %s: %w", event.ID, endpoint.ID, err)
+			}
+			progress.Deliveries++
+		}
+
+		err = db.Query.UpdateWebhookEventDispatched(ctx, w.db.RW(), db.UpdateWebhookEventDispatchedParams{
+			DispatchedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+			ID:           event.ID,
+		})
+		if err != nil {
+			return DispatchBatch{}, fmt.Errorf("unable to mark %s as dispatched: %w", event.ID, err)
+		}
+
+		progress.Cursor = event.ID
+	}
+
+	return progress, nil
+}
+
+// isSubscribed reports whether an enabled endpoint subscribed to the event.
+func isSubscribed(endpoint db.WebhookEndpoint, event string) (bool, error) {
+	if !endpoint.Enabled {
+		return false, nil
+	}
+
+	var events []string
+	err := json.Unmarshal(endpoint.Events, &events)
+	if err != nil {
+		return false, fmt.Errorf("unable to unmarshal events of endpoint %s: %w", endpoint.ID, err)
+	}
+
+	return slices.Contains(events, event), nil
+}
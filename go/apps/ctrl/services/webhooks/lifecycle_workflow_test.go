@@ -0,0 +1,253 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	vaultv1 "github.com/unkeyed/unkey/go/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/ptr"
+	"github.com/unkeyed/unkey/go/pkg/testutil/containers"
+	"github.com/unkeyed/unkey/go/pkg/testutil/seed"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+	"github.com/unkeyed/unkey/go/pkg/vault"
+	"github.com/unkeyed/unkey/go/pkg/vault/keys"
+	"github.com/unkeyed/unkey/go/pkg/vault/storage"
+	"github.com/unkeyed/unkey/go/pkg/webhook"
+)
+
+func TestKeyLifecycleDeliversSignedEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	logger := logging.NewNoop()
+
+	mysqlCfg := containers.MySQL(t)
+	mysqlCfg.DBName = "unkey"
+	database, err := db.New(db.Config{
+		PrimaryDSN:  mysqlCfg.FormatDSN(),
+		ReadOnlyDSN: "",
+		Logger:      logger,
+	})
+	require.NoError(t, err)
+	defer database.Close()
+
+	vaultStorage, err := storage.NewMemory(storage.MemoryConfig{
+		Logger: logger,
+	})
+	require.NoError(t, err)
+
+	_, masterKey, err := keys.GenerateMasterKey()
+	require.NoError(t, err)
+
+	vaultSvc, err := vault.New(vault.Config{
+		Logger:     logger,
+		Storage:    vaultStorage,
+		MasterKeys: []string{masterKey},
+	})
+	require.NoError(t, err)
+
+	hydraCfg := containers.MySQL(t)
+	hydraCfg.DBName = "hydra"
+	engine, err := hydra.New(hydra.Config{
+		DSN:        hydraCfg.FormatDSN(),
+		Namespace:  fmt.Sprintf("test_%s", uid.New(uid.Prefix("test"))),
+		Logger:     logger,
+		Marshaller: hydra.NewJSONMarshaller(),
+	})
+	require.NoError(t, err)
+
+	worker, err := hydra.NewWorker(engine, hydra.WorkerConfig{
+		Concurrency:       4,
+		PollInterval:      100 * time.Millisecond,
+		HeartbeatInterval: time.Second,
+		ClaimTimeout:      10 * time.Second,
+	})
+	require.NoError(t, err)
+
+	secret, err := webhook.GenerateSecret()
+	require.NoError(t, err)
+
+	// The endpoint rejects the first delivery, which has to be retried
+	var mu sync.Mutex
+	requests := 0
+	received := map[webhook.Event][]webhook.Payload{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, readErr := io.ReadAll(r.Body)
+		if readErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		verifyErr := webhook.Verify(secret, r.Header.Get(webhook.SignatureHeader), body, time.Now(), time.Minute)
+		if verifyErr != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var payload webhook.Payload
+		if json.Unmarshal(body, &payload) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received[payload.Event] = append(received[payload.Event], payload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	delivery, err := NewDelivery(DeliveryConfig{
+		DB:            database,
+		Logger:        logger,
+		Vault:         vaultSvc,
+		HTTPClient:    server.Client(),
+		RetrySchedule: []time.Duration{100 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	workflow := NewKeyLifecycle(KeyLifecycleConfig{
+		DB:                 database,
+		Logger:             logger,
+		Engine:             engine,
+		Delivery:           delivery,
+		ExpiringWindow:     7 * 24 * time.Hour,
+		CreditsThreshold:   10,
+		Lookback:           time.Hour,
+		WorkspaceBatchSize: 1,
+		BatchSize:          2,
+	})
+	require.NoError(t, hydra.RegisterWorkflow(worker, delivery))
+	require.NoError(t, hydra.RegisterWorkflow(worker, workflow))
+	require.NoError(t, worker.Start(ctx))
+	defer worker.Shutdown(ctx)
+
+	seeder := seed.New(t, database, nil)
+	workspace := seeder.CreateWorkspace(ctx)
+	api := seeder.CreateAPI(ctx, seed.CreateApiRequest{
+		WorkspaceID:   workspace.ID,
+		IpWhitelist:   "",
+		EncryptedKeys: false,
+		Name:          nil,
+		CreatedAt:     nil,
+		DefaultPrefix: nil,
+		DefaultBytes:  nil,
+	})
+
+	now := time.Now()
+	expiring := seeder.CreateKey(ctx, seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeyAuthID:   api.KeyAuthID.String,
+		Expires:     ptr.P(now.Add(48 * time.Hour)),
+	})
+	expired := seeder.CreateKey(ctx, seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeyAuthID:   api.KeyAuthID.String,
+		Expires:     ptr.P(now.Add(-time.Minute)),
+	})
+	low := seeder.CreateKey(ctx, seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeyAuthID:   api.KeyAuthID.String,
+		Remaining:   ptr.P(int32(5)),
+	})
+	exhausted := seeder.CreateKey(ctx, seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeyAuthID:   api.KeyAuthID.String,
+		Remaining:   ptr.P(int32(0)),
+	})
+
+	// Neither expiring nor low on credits
+	seeder.CreateKey(ctx, seed.CreateKeyRequest{
+		WorkspaceID: workspace.ID,
+		KeyAuthID:   api.KeyAuthID.String,
+		Remaining:   ptr.P(int32(1000)),
+		Expires:     ptr.P(now.Add(30 * 24 * time.Hour)),
+	})
+
+	encrypted, err := vaultSvc.Encrypt(ctx, &vaultv1.EncryptRequest{
+		Keyring: workspace.ID,
+		Data:    secret,
+	})
+	require.NoError(t, err)
+
+	events, err := json.Marshal(webhook.Events)
+	require.NoError(t, err)
+	require.NoError(t, db.Query.InsertWebhookEndpoint(ctx, database.RW(), db.InsertWebhookEndpointParams{
+		ID:              uid.New(uid.WebhookEndpointPrefix),
+		WorkspaceID:     workspace.ID,
+		Url:             server.URL,
+		Events:          events,
+		Encrypted:       encrypted.GetEncrypted(),
+		EncryptionKeyID: encrypted.GetKeyId(),
+		CreatedAt:       now.UnixMilli(),
+	}))
+
+	run := func() string {
+		executionID, startErr := engine.StartWorkflow(ctx, workflow.Name(), KeyLifecycleRequest{Time: now.UnixMilli()})
+		require.NoError(t, startErr)
+
+		require.Eventually(t, func() bool {
+			execution, getErr := store.Query.GetWorkflow(ctx, engine.GetDB(), store.GetWorkflowParams{
+				ID:        executionID,
+				Namespace: engine.GetNamespace(),
+			})
+			return getErr == nil && execution.Status == store.WorkflowExecutionsStatusCompleted
+		}, 30*time.Second, 100*time.Millisecond)
+
+		return executionID
+	}
+
+	run()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 4
+	}, 30*time.Second, 100*time.Millisecond)
+
+	mu.Lock()
+	require.Len(t, received[webhook.KeyExpiringSoonEvent], 1)
+	require.Equal(t, expiring.KeyID, received[webhook.KeyExpiringSoonEvent][0].Data.KeyID)
+	require.Len(t, received[webhook.KeyExpiredEvent], 1)
+	require.Equal(t, expired.KeyID, received[webhook.KeyExpiredEvent][0].Data.KeyID)
+	require.Len(t, received[webhook.KeyCreditsLowEvent], 1)
+	require.Equal(t, low.KeyID, received[webhook.KeyCreditsLowEvent][0].Data.KeyID)
+	require.Equal(t, int32(5), *received[webhook.KeyCreditsLowEvent][0].Data.Remaining)
+	require.Len(t, received[webhook.KeyCreditsExhaustedEvent], 1)
+	require.Equal(t, exhausted.KeyID, received[webhook.KeyCreditsExhaustedEvent][0].Data.KeyID)
+	mu.Unlock()
+
+	// A second run finds the same keys, but does not record them again
+	executionID := run()
+
+	steps, err := engine.ListSteps(ctx, executionID)
+	require.NoError(t, err)
+
+	var dispatched DispatchBatch
+	for _, step := range steps {
+		if step.StepName == "dispatch-events-0" {
+			require.NoError(t, hydra.NewJSONMarshaller().Unmarshal(step.OutputData, &dispatched))
+		}
+	}
+	require.Equal(t, 0, dispatched.Events)
+}
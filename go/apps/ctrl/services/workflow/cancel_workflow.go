@@ -0,0 +1,41 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+)
+
+// CancelWorkflow cancels a workflow execution and its children. Running
+// executions are stopped by their worker before the next step, so the
+// returned workflow may still be running with cancel_requested_at set.
+func (s *Service) CancelWorkflow(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.CancelWorkflowRequest],
+) (*connect.Response[ctrlv1.CancelWorkflowResponse], error) {
+	executionID := req.Msg.GetExecutionId()
+	if executionID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("execution_id is required"))
+	}
+
+	err := s.hydraEngine.Cancel(ctx, executionID, req.Msg.GetReason())
+	if err != nil {
+		return nil, connectError(err, "cancel workflow")
+	}
+
+	s.logger.Info("workflow cancelled",
+		"execution_id", executionID,
+		"reason", req.Msg.GetReason(),
+	)
+
+	execution, err := s.hydraEngine.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, connectError(err, "load workflow")
+	}
+
+	return connect.NewResponse(&ctrlv1.CancelWorkflowResponse{
+		Workflow: newWorkflow(execution),
+	}), nil
+}
@@ -0,0 +1,95 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+)
+
+func newWorkflow(e store.WorkflowExecution) *ctrlv1.Workflow {
+	return &ctrlv1.Workflow{
+		ExecutionId:       e.ID,
+		WorkflowName:      e.WorkflowName,
+		Status:            convertStatusToProto(e.Status),
+		ErrorMessage:      e.ErrorMessage.String,
+		MaxAttempts:       e.MaxAttempts,
+		RemainingAttempts: e.RemainingAttempts,
+		ParentExecutionId: e.ParentExecutionID.String,
+		CreatedAt:         e.CreatedAt,
+		StartedAt:         e.StartedAt.Int64,
+		CompletedAt:       e.CompletedAt.Int64,
+		NextRetryAt:       e.NextRetryAt.Int64,
+		SleepUntil:        e.SleepUntil.Int64,
+		CancelRequestedAt: e.CancelRequestedAt.Int64,
+	}
+}
+
+func newWorkflowStep(s store.WorkflowStep) *ctrlv1.WorkflowStep {
+	return &ctrlv1.WorkflowStep{
+		Name:              s.StepName,
+		Status:            string(s.Status),
+		ErrorMessage:      s.ErrorMessage.String,
+		RemainingAttempts: s.RemainingAttempts,
+		StartedAt:         s.StartedAt.Int64,
+		CompletedAt:       s.CompletedAt.Int64,
+	}
+}
+
+func convertStatusToProto(status store.WorkflowExecutionsStatus) ctrlv1.WorkflowStatus {
+	switch status {
+	case store.WorkflowExecutionsStatusPending:
+		return ctrlv1.WorkflowStatus_WORKFLOW_STATUS_PENDING
+	case store.WorkflowExecutionsStatusRunning:
+		return ctrlv1.WorkflowStatus_WORKFLOW_STATUS_RUNNING
+	case store.WorkflowExecutionsStatusSleeping:
+		return ctrlv1.WorkflowStatus_WORKFLOW_STATUS_SLEEPING
+	case store.WorkflowExecutionsStatusCompleted:
+		return ctrlv1.WorkflowStatus_WORKFLOW_STATUS_COMPLETED
+	case store.WorkflowExecutionsStatusFailed:
+		return ctrlv1.WorkflowStatus_WORKFLOW_STATUS_FAILED
+	case store.WorkflowExecutionsStatusCancelled:
+		return ctrlv1.WorkflowStatus_WORKFLOW_STATUS_CANCELLED
+	case store.WorkflowExecutionsStatusTerminated:
+		return ctrlv1.WorkflowStatus_WORKFLOW_STATUS_TERMINATED
+	default:
+		return ctrlv1.WorkflowStatus_WORKFLOW_STATUS_UNSPECIFIED
+	}
+}
+
+func convertStatusFromProto(status ctrlv1.WorkflowStatus) store.WorkflowExecutionsStatus {
+	switch status {
+	case ctrlv1.WorkflowStatus_WORKFLOW_STATUS_PENDING:
+		return store.WorkflowExecutionsStatusPending
+	case ctrlv1.WorkflowStatus_WORKFLOW_STATUS_RUNNING:
+		return store.WorkflowExecutionsStatusRunning
+	case ctrlv1.WorkflowStatus_WORKFLOW_STATUS_SLEEPING:
+		return store.WorkflowExecutionsStatusSleeping
+	case ctrlv1.WorkflowStatus_WORKFLOW_STATUS_COMPLETED:
+		return store.WorkflowExecutionsStatusCompleted
+	case ctrlv1.WorkflowStatus_WORKFLOW_STATUS_FAILED:
+		return store.WorkflowExecutionsStatusFailed
+	case ctrlv1.WorkflowStatus_WORKFLOW_STATUS_CANCELLED:
+		return store.WorkflowExecutionsStatusCancelled
+	case ctrlv1.WorkflowStatus_WORKFLOW_STATUS_TERMINATED:
+		return store.WorkflowExecutionsStatusTerminated
+	default:
+		return ""
+	}
+}
+
+// connectError maps errors of the hydra engine to connect error codes.
+func connectError(err error, action string) error {
+	switch {
+	case errors.Is(err, hydra.ErrExecutionNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, hydra.ErrExecutionFinished),
+		errors.Is(err, hydra.ErrExecutionNotRetryable):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	default:
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to %s: %w", action, err))
+	}
+}
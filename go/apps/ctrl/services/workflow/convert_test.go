@@ -0,0 +1,36 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+)
+
+func TestConvertStatusRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	statuses := []store.WorkflowExecutionsStatus{
+		store.WorkflowExecutionsStatusPending,
+		store.WorkflowExecutionsStatusRunning,
+		store.WorkflowExecutionsStatusSleeping,
+		store.WorkflowExecutionsStatusCompleted,
+		store.WorkflowExecutionsStatusFailed,
+		store.WorkflowExecutionsStatusCancelled,
+		store.WorkflowExecutionsStatusTerminated,
+	}
+
+	for _, status := range statuses {
+		t.Run(string(status), func(t *testing.T) {
+			t.Parallel()
+
+			protoStatus := convertStatusToProto(status)
+			require.NotEqual(t, ctrlv1.WorkflowStatus_WORKFLOW_STATUS_UNSPECIFIED, protoStatus)
+			require.Equal(t, status, convertStatusFromProto(protoStatus))
+		})
+	}
+
+	// No filter
+	require.Equal(t, store.WorkflowExecutionsStatus(""), convertStatusFromProto(ctrlv1.WorkflowStatus_WORKFLOW_STATUS_UNSPECIFIED))
+}
@@ -0,0 +1,28 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+)
+
+// GetWorkflow returns a workflow execution by ID.
+func (s *Service) GetWorkflow(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.GetWorkflowRequest],
+) (*connect.Response[ctrlv1.GetWorkflowResponse], error) {
+	if req.Msg.GetExecutionId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("execution_id is required"))
+	}
+
+	execution, err := s.hydraEngine.GetExecution(ctx, req.Msg.GetExecutionId())
+	if err != nil {
+		return nil, connectError(err, "load workflow")
+	}
+
+	return connect.NewResponse(&ctrlv1.GetWorkflowResponse{
+		Workflow: newWorkflow(execution),
+	}), nil
+}
@@ -0,0 +1,33 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+)
+
+// ListWorkflowSteps returns the step history of a workflow execution.
+func (s *Service) ListWorkflowSteps(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.ListWorkflowStepsRequest],
+) (*connect.Response[ctrlv1.ListWorkflowStepsResponse], error) {
+	if req.Msg.GetExecutionId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("execution_id is required"))
+	}
+
+	steps, err := s.hydraEngine.ListSteps(ctx, req.Msg.GetExecutionId())
+	if err != nil {
+		return nil, connectError(err, "list workflow steps")
+	}
+
+	protoSteps := make([]*ctrlv1.WorkflowStep, len(steps))
+	for i, step := range steps {
+		protoSteps[i] = newWorkflowStep(step)
+	}
+
+	return connect.NewResponse(&ctrlv1.ListWorkflowStepsResponse{
+		Steps: protoSteps,
+	}), nil
+}
@@ -0,0 +1,53 @@
+package workflow
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// ListWorkflows lists workflow executions newest first, optionally filtered
+// by status and workflow name.
+func (s *Service) ListWorkflows(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.ListWorkflowsRequest],
+) (*connect.Response[ctrlv1.ListWorkflowsResponse], error) {
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	executions, err := s.hydraEngine.ListExecutions(ctx, hydra.ListExecutionsRequest{
+		Status:       convertStatusFromProto(req.Msg.GetStatus()),
+		WorkflowName: req.Msg.GetWorkflowName(),
+		Cursor:       req.Msg.GetPageToken(),
+		Limit:        pageSize,
+	})
+	if err != nil {
+		return nil, connectError(err, "list workflows")
+	}
+
+	// A full page means there may be more
+	nextPageToken := ""
+	if len(executions) == pageSize {
+		nextPageToken = executions[pageSize-1].ID
+	}
+
+	workflows := make([]*ctrlv1.Workflow, len(executions))
+	for i, execution := range executions {
+		workflows[i] = newWorkflow(execution)
+	}
+
+	return connect.NewResponse(&ctrlv1.ListWorkflowsResponse{
+		Workflows:     workflows,
+		NextPageToken: nextPageToken,
+	}), nil
+}
@@ -0,0 +1,37 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+)
+
+// RetryWorkflow queues a failed, cancelled or terminated workflow execution
+// again. It resumes after its last completed step.
+func (s *Service) RetryWorkflow(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.RetryWorkflowRequest],
+) (*connect.Response[ctrlv1.RetryWorkflowResponse], error) {
+	executionID := req.Msg.GetExecutionId()
+	if executionID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("execution_id is required"))
+	}
+
+	err := s.hydraEngine.Retry(ctx, executionID)
+	if err != nil {
+		return nil, connectError(err, "retry workflow")
+	}
+
+	s.logger.Info("workflow retried", "execution_id", executionID)
+
+	execution, err := s.hydraEngine.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, connectError(err, "load workflow")
+	}
+
+	return connect.NewResponse(&ctrlv1.RetryWorkflowResponse{
+		Workflow: newWorkflow(execution),
+	}), nil
+}
@@ -0,0 +1,29 @@
+package workflow
+
+import (
+	"github.com/unkeyed/unkey/go/gen/proto/ctrl/v1/ctrlv1connect"
+	"github.com/unkeyed/unkey/go/pkg/hydra"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+// Service implements the WorkflowService. It lets operators inspect hydra
+// workflow executions of the control plane, such as deployments, and cancel,
+// terminate or retry them.
+type Service struct {
+	ctrlv1connect.UnimplementedWorkflowServiceHandler
+	hydraEngine *hydra.Engine
+	logger      logging.Logger
+}
+
+type Config struct {
+	HydraEngine *hydra.Engine
+	Logger      logging.Logger
+}
+
+func New(cfg Config) *Service {
+	return &Service{
+		UnimplementedWorkflowServiceHandler: ctrlv1connect.UnimplementedWorkflowServiceHandler{},
+		hydraEngine:                         cfg.HydraEngine,
+		logger:                              cfg.Logger,
+	}
+}
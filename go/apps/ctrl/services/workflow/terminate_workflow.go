@@ -0,0 +1,40 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+)
+
+// TerminateWorkflow stops a workflow execution and its children immediately
+// by revoking the lease of the worker that runs it.
+func (s *Service) TerminateWorkflow(
+	ctx context.Context,
+	req *connect.Request[ctrlv1.TerminateWorkflowRequest],
+) (*connect.Response[ctrlv1.TerminateWorkflowResponse], error) {
+	executionID := req.Msg.GetExecutionId()
+	if executionID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("execution_id is required"))
+	}
+
+	err := s.hydraEngine.Terminate(ctx, executionID, req.Msg.GetReason())
+	if err != nil {
+		return nil, connectError(err, "terminate workflow")
+	}
+
+	s.logger.Warn("workflow terminated",
+		"execution_id", executionID,
+		"reason", req.Msg.GetReason(),
+	)
+
+	execution, err := s.hydraEngine.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, connectError(err, "load workflow")
+	}
+
+	return connect.NewResponse(&ctrlv1.TerminateWorkflowResponse{
+		Workflow: newWorkflow(execution),
+	}), nil
+}
@@ -2,9 +2,11 @@ package gateway_proxy
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/unkeyed/unkey/go/apps/gw/server"
 	"github.com/unkeyed/unkey/go/apps/gw/services/auth"
+	"github.com/unkeyed/unkey/go/apps/gw/services/limiter"
 	"github.com/unkeyed/unkey/go/apps/gw/services/proxy"
 	"github.com/unkeyed/unkey/go/apps/gw/services/routing"
 	"github.com/unkeyed/unkey/go/apps/gw/services/validation"
@@ -12,6 +14,7 @@ import (
 	"github.com/unkeyed/unkey/go/pkg/fault"
 	"github.com/unkeyed/unkey/go/pkg/otel/logging"
 	"github.com/unkeyed/unkey/go/pkg/otel/tracing"
+	"github.com/unkeyed/unkey/go/pkg/prometheus/metrics"
 )
 
 // Handler implements the main gateway proxy functionality.
@@ -22,6 +25,7 @@ type Handler struct {
 	Proxy          proxy.Proxy
 	Auth           auth.Authenticator
 	Validator      validation.Validator
+	Limiter        limiter.Limiter
 }
 
 // Handle processes all HTTPS requests for the gateway.
@@ -58,8 +62,32 @@ func (h *Handler) Handle(ctx context.Context, sess *server.Session) error {
 		return err
 	}
 
-	// Select an available VM for this gateway
-	targetURL, err := h.RoutingService.SelectVM(ctx, config)
+	// Handle rate limiting if configured, after authentication so requests
+	// can be limited by their key
+	if h.Limiter != nil {
+		err = h.Limiter.Limit(ctx, sess, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Pick the deployment that serves this request, traffic may be split
+	// between several deployments
+	target, err := h.RoutingService.SelectTarget(config, req)
+	if err != nil {
+		return fault.Wrap(err,
+			fault.Code(codes.Gateway.Routing.VMSelectionFailed.URN()),
+			fault.Internal("failed to select deployment"),
+			fault.Public("Service temporarily unavailable"),
+		)
+	}
+
+	if cookie := routing.StickyCookie(config, target); cookie != nil {
+		http.SetCookie(sess.ResponseWriter(), cookie)
+	}
+
+	// Select an available VM of the deployment
+	targetURL, err := h.RoutingService.SelectVM(ctx, config, target, nil)
 	if err != nil {
 		return fault.Wrap(err,
 			fault.Code(codes.Gateway.Routing.VMSelectionFailed.URN()),
@@ -70,6 +98,23 @@ func (h *Handler) Handle(ctx context.Context, sess *server.Session) error {
 
 	// Forward the request using the proxy service
 	err = h.Proxy.Forward(ctx, *targetURL, sess.ResponseWriter(), req)
+
+	// Requests that never reached the VM are sent to another one once, as
+	// long as sending them twice can't do harm
+	if err != nil && proxy.IsRetryable(err) && isRetryableRequest(req) {
+		retryURL, selectErr := h.RoutingService.SelectVM(ctx, config, target, []string{targetURL.Host})
+		if selectErr == nil {
+			metrics.GatewayRetriesTotal.WithLabelValues(target.GetDeploymentId()).Inc()
+			h.Logger.Debug("retrying request on another vm",
+				"deployment_id", target.GetDeploymentId(),
+				"failed", targetURL.Host,
+				"retry", retryURL.Host,
+			)
+
+			err = h.Proxy.Forward(ctx, *retryURL, sess.ResponseWriter(), req)
+		}
+	}
+
 	if err != nil {
 		return fault.Wrap(err,
 			fault.Code(codes.Gateway.Proxy.ProxyForwardFailed.URN()),
@@ -80,3 +125,19 @@ func (h *Handler) Handle(ctx context.Context, sess *server.Session) error {
 
 	return nil
 }
+
+// isRetryableRequest reports whether req may be sent to the backend twice.
+// Only idempotent methods without a body qualify, the body has already been
+// consumed by the first attempt.
+func isRetryableRequest(req *http.Request) bool {
+	if req.ContentLength != 0 {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
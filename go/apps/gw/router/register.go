@@ -8,6 +8,7 @@ import (
 	"github.com/unkeyed/unkey/go/apps/gw/router/gateway_proxy"
 	"github.com/unkeyed/unkey/go/apps/gw/server"
 	"github.com/unkeyed/unkey/go/apps/gw/services/auth"
+	"github.com/unkeyed/unkey/go/apps/gw/services/limiter"
 	"github.com/unkeyed/unkey/go/apps/gw/services/proxy"
 	"github.com/unkeyed/unkey/go/apps/gw/services/routing"
 )
@@ -50,6 +51,7 @@ func Register(srv *server.Server, svc *Services, region string, serverType Serve
 	// Random defaults which can be fine-tuned later
 	proxyService, err := proxy.New(proxy.Config{
 		Logger:              svc.Logger,
+		Health:              svc.Health,
 		MaxIdleConns:        transport.MaxIdleConns,
 		IdleConnTimeout:     "90s",
 		TLSHandshakeTimeout: "10s",
@@ -69,6 +71,15 @@ func Register(srv *server.Server, svc *Services, region string, serverType Serve
 		return err
 	}
 
+	limiterService, err := limiter.New(limiter.Config{
+		Logger:    svc.Logger,
+		Ratelimit: svc.Ratelimit,
+	})
+	if err != nil {
+		svc.Logger.Error("failed to create limiter service", "error", err.Error())
+		return err
+	}
+
 	// Create the main proxy handler that handles all gateway requests
 	proxyHandler := &gateway_proxy.Handler{
 		Logger:         svc.Logger,
@@ -76,6 +87,7 @@ func Register(srv *server.Server, svc *Services, region string, serverType Serve
 		Proxy:          proxyService,
 		Auth:           authService,
 		Validator:      svc.Validation,
+		Limiter:        limiterService,
 	}
 
 	// Create a mux for routing
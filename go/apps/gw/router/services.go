@@ -2,6 +2,7 @@ package router
 
 import (
 	"github.com/unkeyed/unkey/go/apps/gw/services/certmanager"
+	"github.com/unkeyed/unkey/go/apps/gw/services/health"
 	"github.com/unkeyed/unkey/go/apps/gw/services/routing"
 	"github.com/unkeyed/unkey/go/apps/gw/services/validation"
 	"github.com/unkeyed/unkey/go/gen/proto/ctrl/v1/ctrlv1connect"
@@ -16,6 +17,7 @@ type Services struct {
 	Logger         logging.Logger
 	CertManager    certmanager.Service
 	RoutingService routing.Service
+	Health         health.Checker        // For passive outlier detection in the proxy
 	Validation     validation.Validator  // For OpenAPI request validation
 	ClickHouse     clickhouse.ClickHouse // For metrics middleware
 	Keys           keys.KeyService
@@ -13,6 +13,7 @@ import (
 	"github.com/unkeyed/unkey/go/apps/gw/server"
 	"github.com/unkeyed/unkey/go/apps/gw/services/caches"
 	"github.com/unkeyed/unkey/go/apps/gw/services/certmanager"
+	"github.com/unkeyed/unkey/go/apps/gw/services/health"
 	"github.com/unkeyed/unkey/go/apps/gw/services/routing"
 	"github.com/unkeyed/unkey/go/apps/gw/services/validation"
 	"github.com/unkeyed/unkey/go/gen/proto/ctrl/v1/ctrlv1connect"
@@ -209,11 +210,32 @@ func Run(ctx context.Context, cfg Config) error {
 	}
 	shutdowns.Register(keySvc.Close)
 
+	// Create health checker for active health checks and outlier ejection
+	// of upstream VMs
+	healthChecker, err := health.New(health.Config{
+		Logger:              logger,
+		Clock:               clk,
+		Client:              nil,
+		Path:                "",
+		Interval:            0,
+		Timeout:             0,
+		UnhealthyThreshold:  0,
+		HealthyThreshold:    0,
+		ConsecutiveFailures: 0,
+		BaseEjectionTime:    0,
+		MaxEjectionTime:     0,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create health checker: %w", err)
+	}
+	shutdowns.Register(healthChecker.Close)
+
 	// Create routing service with partitioned database
 	routingService, err := routing.New(routing.Config{
 		DB:                 partitionedDB,
 		Logger:             logger,
 		Clock:              clk,
+		Health:             healthChecker,
 		GatewayConfigCache: caches.GatewayConfig,
 		VMCache:            caches.VM,
 	})
@@ -270,10 +292,11 @@ func Run(ctx context.Context, cfg Config) error {
 		Logger:         logger,
 		CertManager:    certManager,
 		RoutingService: routingService,
+		Health:         healthChecker,
 		Validation:     validationService,
 		ClickHouse:     ch,
 		Keys:           keySvc,
-		Ratelimit:      nil,
+		Ratelimit:      rlSvc,
 		MainDomain:     cfg.MainDomain,
 		AcmeClient:     acmeClient,
 	}
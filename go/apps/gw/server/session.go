@@ -25,11 +25,24 @@ type Session struct {
 	// This can be extracted from headers or authentication.
 	WorkspaceID string
 
+	// The key and its identity that authenticated the request, if the
+	// gateway authenticates requests.
+	KeyID      string
+	IdentityID string
+
 	requestBody    []byte
 	responseStatus int
 	responseBody   []byte
 }
 
+// NewSession creates a session for a request that is not served by the
+// server, for example to test services that operate on sessions.
+func NewSession(w http.ResponseWriter, r *http.Request) *Session {
+	s := &Session{}
+	s.init(w, r)
+	return s
+}
+
 // init initializes the session with a new request and response writer.
 func (s *Session) init(w http.ResponseWriter, r *http.Request) {
 	s.requestID = uid.New(uid.RequestPrefix)
@@ -37,6 +50,8 @@ func (s *Session) init(w http.ResponseWriter, r *http.Request) {
 	s.w = w
 	s.r = r
 	s.WorkspaceID = ""
+	s.KeyID = ""
+	s.IdentityID = ""
 }
 
 // RequestID returns the unique request ID for this session.
@@ -151,6 +166,8 @@ func (s *Session) reset() {
 	s.w = nil
 	s.r = nil
 	s.WorkspaceID = ""
+	s.KeyID = ""
+	s.IdentityID = ""
 	s.requestBody = nil
 	s.responseStatus = 0
 	s.responseBody = nil
@@ -163,5 +163,8 @@ func (a *authenticator) verifyAPIKey(ctx context.Context, sess *server.Session,
 		}
 	}
 
+	sess.KeyID = key.Key.ID
+	sess.IdentityID = key.Key.IdentityID.String
+
 	return nil
 }
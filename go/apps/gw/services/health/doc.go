@@ -0,0 +1,8 @@
+// Package health tracks the health of the gateway's upstream VMs.
+//
+// VMs are probed actively over HTTP and judged passively by the outcome of
+// the requests proxied to them. A VM that fails several probes, or several
+// requests in a row, is ejected and receives no traffic until it recovers.
+// Passive ejections last longer every time a VM is ejected again, so a
+// flapping VM doesn't keep failing requests.
+package health
@@ -0,0 +1,314 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/unkeyed/unkey/go/pkg/assert"
+	"github.com/unkeyed/unkey/go/pkg/clock"
+	"github.com/unkeyed/unkey/go/pkg/prometheus/metrics"
+)
+
+// unwatchAfter is how long a VM keeps being probed after it was last
+// selected. VMs of retired deployments drop out after this.
+const unwatchAfter = 5 * time.Minute
+
+type upstream struct {
+	deploymentID string
+	lastWatched  time.Time
+
+	// active health checks
+	checkFailures  int
+	checkSuccesses int
+	unhealthy      bool
+
+	// passive outlier detection
+	failures     int
+	ejections    int
+	ejectedUntil time.Time
+}
+
+type checker struct {
+	config Config
+
+	mu        sync.Mutex
+	upstreams map[string]*upstream
+
+	// deployments that have an ejected VM gauge, so it can be reset once
+	// the deployment is gone
+	gauges map[string]bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+var _ Checker = (*checker)(nil)
+
+// New creates a health checker and starts its active health checks.
+func New(config Config) (*checker, error) {
+	if err := assert.All(
+		assert.NotNilAndNotZero(config.Logger, "Logger is required"),
+	); err != nil {
+		return nil, err
+	}
+
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+	if config.Path == "" {
+		config.Path = "/health"
+	}
+	if config.Interval <= 0 {
+		config.Interval = 10 * time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 2 * time.Second
+	}
+	if config.UnhealthyThreshold <= 0 {
+		config.UnhealthyThreshold = 3
+	}
+	if config.HealthyThreshold <= 0 {
+		config.HealthyThreshold = 2
+	}
+	if config.ConsecutiveFailures <= 0 {
+		config.ConsecutiveFailures = 5
+	}
+	if config.BaseEjectionTime <= 0 {
+		config.BaseEjectionTime = 30 * time.Second
+	}
+	if config.MaxEjectionTime <= 0 {
+		config.MaxEjectionTime = 5 * time.Minute
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: config.Timeout}
+	}
+
+	c := &checker{
+		config:    config,
+		mu:        sync.Mutex{},
+		upstreams: make(map[string]*upstream),
+		gauges:    make(map[string]bool),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	go c.run()
+
+	return c, nil
+}
+
+func (c *checker) Watch(deploymentID string, addr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u, ok := c.upstreams[addr]
+	if !ok {
+		u = &upstream{
+			deploymentID:   deploymentID,
+			lastWatched:    time.Time{},
+			checkFailures:  0,
+			checkSuccesses: 0,
+			unhealthy:      false,
+			failures:       0,
+			ejections:      0,
+			ejectedUntil:   time.Time{},
+		}
+		c.upstreams[addr] = u
+	}
+
+	u.deploymentID = deploymentID
+	u.lastWatched = c.config.Clock.Now()
+}
+
+func (c *checker) IsAvailable(addr string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u, ok := c.upstreams[addr]
+	if !ok {
+		return true
+	}
+
+	return c.available(u)
+}
+
+func (c *checker) available(u *upstream) bool {
+	return !u.unhealthy && !c.config.Clock.Now().Before(u.ejectedUntil)
+}
+
+func (c *checker) ReportSuccess(addr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u, ok := c.upstreams[addr]
+	if !ok {
+		return
+	}
+
+	u.failures = 0
+	if c.available(u) {
+		u.ejections = 0
+	}
+}
+
+func (c *checker) ReportFailure(addr string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u, ok := c.upstreams[addr]
+	if !ok {
+		return
+	}
+
+	u.failures++
+	if u.failures < c.config.ConsecutiveFailures || !c.available(u) {
+		return
+	}
+
+	ejectionTime := c.config.BaseEjectionTime << min(u.ejections, 10)
+	ejectionTime = min(ejectionTime, c.config.MaxEjectionTime)
+
+	u.ejections++
+	u.failures = 0
+	u.ejectedUntil = c.config.Clock.Now().Add(ejectionTime)
+
+	metrics.GatewayEjectionsTotal.WithLabelValues(u.deploymentID, "outlier").Inc()
+	c.config.Logger.Warn("ejecting vm after consecutive failures",
+		"addr", addr,
+		"deployment_id", u.deploymentID,
+		"ejection_time", ejectionTime.String(),
+	)
+}
+
+func (c *checker) Close() error {
+	close(c.stop)
+	<-c.done
+
+	return nil
+}
+
+func (c *checker) run() {
+	defer close(c.done)
+
+	ticker := c.config.Clock.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C():
+			c.checkAll()
+		}
+	}
+}
+
+// checkAll probes every watched VM once and updates the ejected VM gauges.
+func (c *checker) checkAll() {
+	c.mu.Lock()
+	now := c.config.Clock.Now()
+	targets := make(map[string]string, len(c.upstreams))
+	for addr, u := range c.upstreams {
+		if now.Sub(u.lastWatched) > unwatchAfter {
+			delete(c.upstreams, addr)
+			continue
+		}
+		targets[addr] = u.deploymentID
+	}
+	c.mu.Unlock()
+
+	wg := sync.WaitGroup{}
+	for addr, deploymentID := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.record(addr, deploymentID, c.probe(addr))
+		}()
+	}
+	wg.Wait()
+
+	c.updateGauges()
+}
+
+// probe sends one health check and reports whether the VM is healthy.
+func (c *checker) probe(addr string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+c.config.Path, nil)
+	if err != nil {
+		return false
+	}
+
+	res, err := c.config.Client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+
+	return res.StatusCode < http.StatusInternalServerError
+}
+
+func (c *checker) record(addr string, deploymentID string, healthy bool) {
+	result := "healthy"
+	if !healthy {
+		result = "unhealthy"
+	}
+	metrics.GatewayHealthChecksTotal.WithLabelValues(deploymentID, result).Inc()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u, ok := c.upstreams[addr]
+	if !ok {
+		return
+	}
+
+	if healthy {
+		u.checkFailures = 0
+		u.checkSuccesses++
+		if u.unhealthy && u.checkSuccesses >= c.config.HealthyThreshold {
+			u.unhealthy = false
+			c.config.Logger.Info("vm passed health checks again", "addr", addr, "deployment_id", deploymentID)
+		}
+
+		return
+	}
+
+	u.checkSuccesses = 0
+	u.checkFailures++
+	if !u.unhealthy && u.checkFailures >= c.config.UnhealthyThreshold {
+		u.unhealthy = true
+		metrics.GatewayEjectionsTotal.WithLabelValues(deploymentID, "health_check").Inc()
+		c.config.Logger.Warn("ejecting vm after failed health checks", "addr", addr, "deployment_id", deploymentID)
+	}
+}
+
+func (c *checker) updateGauges() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ejected := make(map[string]int)
+	for _, u := range c.upstreams {
+		if _, ok := ejected[u.deploymentID]; !ok {
+			ejected[u.deploymentID] = 0
+		}
+		if !c.available(u) {
+			ejected[u.deploymentID]++
+		}
+	}
+
+	for deploymentID := range c.gauges {
+		if _, ok := ejected[deploymentID]; !ok {
+			metrics.GatewayEjectedVMs.DeleteLabelValues(deploymentID)
+			delete(c.gauges, deploymentID)
+		}
+	}
+
+	for deploymentID, count := range ejected {
+		metrics.GatewayEjectedVMs.WithLabelValues(deploymentID).Set(float64(count))
+		c.gauges[deploymentID] = true
+	}
+}
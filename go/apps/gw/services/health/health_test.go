@@ -0,0 +1,141 @@
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/clock"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+func newTestChecker(t *testing.T, clk clock.Clock) *checker {
+	t.Helper()
+
+	c, err := New(Config{
+		Logger:              logging.NewNoop(),
+		Clock:               clk,
+		Interval:            time.Hour,
+		UnhealthyThreshold:  2,
+		HealthyThreshold:    2,
+		ConsecutiveFailures: 3,
+		BaseEjectionTime:    10 * time.Second,
+		MaxEjectionTime:     30 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Close())
+	})
+
+	return c
+}
+
+func TestOutlierEjection(t *testing.T) {
+	t.Parallel()
+
+	clk := clock.NewTestClock()
+	c := newTestChecker(t, clk)
+
+	const addr = "10.0.0.1:8080"
+	c.Watch("d_123", addr)
+
+	t.Run("unknown VMs are available", func(t *testing.T) {
+		require.True(t, c.IsAvailable("10.0.0.2:8080"))
+		c.ReportFailure("10.0.0.2:8080")
+		require.True(t, c.IsAvailable("10.0.0.2:8080"))
+	})
+
+	t.Run("a success resets the consecutive failures", func(t *testing.T) {
+		c.ReportFailure(addr)
+		c.ReportFailure(addr)
+		c.ReportSuccess(addr)
+		c.ReportFailure(addr)
+		c.ReportFailure(addr)
+		require.True(t, c.IsAvailable(addr))
+	})
+
+	t.Run("consecutive failures eject the VM", func(t *testing.T) {
+		c.ReportFailure(addr)
+		require.False(t, c.IsAvailable(addr))
+
+		clk.Tick(9 * time.Second)
+		require.False(t, c.IsAvailable(addr))
+
+		clk.Tick(time.Second)
+		require.True(t, c.IsAvailable(addr))
+	})
+
+	t.Run("ejection time doubles up to the maximum", func(t *testing.T) {
+		for _, ejectionTime := range []time.Duration{20 * time.Second, 30 * time.Second, 30 * time.Second} {
+			for range 3 {
+				c.ReportFailure(addr)
+			}
+			require.False(t, c.IsAvailable(addr))
+
+			clk.Tick(ejectionTime - time.Second)
+			require.False(t, c.IsAvailable(addr))
+
+			clk.Tick(time.Second)
+			require.True(t, c.IsAvailable(addr))
+		}
+	})
+
+	t.Run("a success after the ejection resets the backoff", func(t *testing.T) {
+		c.ReportSuccess(addr)
+		for range 3 {
+			c.ReportFailure(addr)
+		}
+
+		clk.Tick(10 * time.Second)
+		require.True(t, c.IsAvailable(addr))
+	})
+}
+
+func TestActiveHealthChecks(t *testing.T) {
+	t.Parallel()
+
+	healthy := atomic.Bool{}
+	healthy.Store(true)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/health", r.URL.Path)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	clk := clock.NewTestClock()
+	c := newTestChecker(t, clk)
+
+	addr := strings.TrimPrefix(srv.URL, "http://")
+	c.Watch("d_123", addr)
+
+	c.checkAll()
+	require.True(t, c.IsAvailable(addr), "any answer below 500 is healthy")
+
+	healthy.Store(false)
+	c.checkAll()
+	require.True(t, c.IsAvailable(addr))
+	c.checkAll()
+	require.False(t, c.IsAvailable(addr))
+
+	healthy.Store(true)
+	c.checkAll()
+	require.False(t, c.IsAvailable(addr))
+	c.checkAll()
+	require.True(t, c.IsAvailable(addr))
+
+	// VMs that are no longer selected stop being probed
+	clk.Tick(unwatchAfter + time.Second)
+	c.checkAll()
+	c.mu.Lock()
+	require.Empty(t, c.upstreams)
+	c.mu.Unlock()
+}
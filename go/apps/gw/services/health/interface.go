@@ -0,0 +1,74 @@
+package health
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/unkeyed/unkey/go/pkg/clock"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+// Checker tracks the health of upstream VMs, identified by their address.
+type Checker interface {
+	// Watch starts active health checks of the VM at addr, unless it is
+	// watched already. VMs that are not watched again for a while stop
+	// being probed.
+	Watch(deploymentID string, addr string)
+
+	// IsAvailable reports whether the VM at addr may receive traffic.
+	// Unknown VMs are available.
+	IsAvailable(addr string) bool
+
+	// ReportSuccess records a request that the VM at addr answered.
+	ReportSuccess(addr string)
+
+	// ReportFailure records a request that the VM at addr failed, either by
+	// not answering or by answering with a server error.
+	ReportFailure(addr string)
+
+	// Close stops the active health checks.
+	Close() error
+}
+
+// Config holds configuration for the health checker.
+type Config struct {
+	// Logger for debugging and monitoring
+	Logger logging.Logger
+
+	// Clock for ejection timing, defaults to the system clock
+	Clock clock.Clock
+
+	// Client sends the health checks, defaults to a client with Timeout
+	Client *http.Client
+
+	// Path requested by health checks, defaults to /health. Any answer
+	// other than a server error counts as healthy, so apps without a health
+	// endpoint are still checked for being reachable.
+	Path string
+
+	// Interval between health checks of a VM, defaults to 10s
+	Interval time.Duration
+
+	// Timeout of a single health check, defaults to 2s
+	Timeout time.Duration
+
+	// UnhealthyThreshold is the number of consecutive failed health checks
+	// that eject a VM, defaults to 3
+	UnhealthyThreshold int
+
+	// HealthyThreshold is the number of consecutive successful health checks
+	// that bring an ejected VM back, defaults to 2
+	HealthyThreshold int
+
+	// ConsecutiveFailures is the number of consecutive failed requests that
+	// eject a VM, defaults to 5
+	ConsecutiveFailures int
+
+	// BaseEjectionTime is how long a VM is ejected for failing requests the
+	// first time. It doubles with every further ejection up to
+	// MaxEjectionTime. Defaults to 30s.
+	BaseEjectionTime time.Duration
+
+	// MaxEjectionTime caps the ejection time, defaults to 5m
+	MaxEjectionTime time.Duration
+}
@@ -0,0 +1,5 @@
+// Package limiter enforces the rate limit of a gateway config on incoming
+// requests, so deployed apps are protected without calling the ratelimit API
+// themselves. Requests are limited by IP, key, identity or a header and every
+// limited response carries the RateLimit-* headers.
+package limiter
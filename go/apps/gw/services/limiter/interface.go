@@ -0,0 +1,27 @@
+package limiter
+
+import (
+	"context"
+
+	"github.com/unkeyed/unkey/go/apps/gw/server"
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+	"github.com/unkeyed/unkey/go/internal/services/ratelimit"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+// Limiter defines the interface for gateway rate limiting.
+type Limiter interface {
+	// Limit checks the request against the rate limit of the config and sets
+	// the RateLimit-* response headers.
+	// Returns nil if the request is allowed or no rate limit is configured.
+	Limit(ctx context.Context, sess *server.Session, config *partitionv1.GatewayConfig) error
+}
+
+// Config holds configuration for the limiter.
+type Config struct {
+	// Logger for debugging and monitoring
+	Logger logging.Logger
+
+	// Ratelimit service that keeps the counters
+	Ratelimit ratelimit.Service
+}
@@ -0,0 +1,142 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/unkeyed/unkey/go/apps/gw/server"
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+	"github.com/unkeyed/unkey/go/internal/services/ratelimit"
+	"github.com/unkeyed/unkey/go/pkg/assert"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/otel/tracing"
+)
+
+type limiter struct {
+	logger    logging.Logger
+	ratelimit ratelimit.Service
+}
+
+var _ Limiter = (*limiter)(nil)
+
+// New creates a new limiter with the given configuration.
+func New(config Config) (Limiter, error) {
+	if err := assert.All(
+		assert.NotNilAndNotZero(config.Logger, "Logger is required"),
+		assert.NotNilAndNotZero(config.Ratelimit, "Ratelimit service is required"),
+	); err != nil {
+		return nil, err
+	}
+
+	return &limiter{
+		logger:    config.Logger,
+		ratelimit: config.Ratelimit,
+	}, nil
+}
+
+// Limit enforces the rate limit of the config on the request.
+func (l *limiter) Limit(ctx context.Context, sess *server.Session, config *partitionv1.GatewayConfig) error {
+	ctx, span := tracing.Start(ctx, "limiter.Limit")
+	defer span.End()
+
+	rl := config.GetRatelimitConfig()
+	if rl == nil || !rl.GetEnabled() {
+		return nil
+	}
+
+	if rl.GetLimit() <= 0 || rl.GetDuration() <= 0 {
+		l.logger.Warn("ignoring invalid ratelimit config",
+			"requestId", sess.RequestID(),
+			"deployment_id", config.GetDeploymentId(),
+			"limit", rl.GetLimit(),
+			"duration", rl.GetDuration(),
+		)
+
+		return nil
+	}
+
+	res, err := l.ratelimit.Ratelimit(ctx, ratelimit.RatelimitRequest{
+		Identifier: identifier(sess, config),
+		Limit:      rl.GetLimit(),
+		Duration:   time.Duration(rl.GetDuration()) * time.Millisecond,
+		Cost:       1,
+		Time:       time.Time{},
+		Algorithm:  ratelimit.SlidingWindow,
+		RefillRate: 0,
+	})
+	if err != nil {
+		// Fail open, a broken rate limiter must not take the app down with it
+		l.logger.Error("failed to ratelimit",
+			"requestId", sess.RequestID(),
+			"deployment_id", config.GetDeploymentId(),
+			"error", err.Error(),
+		)
+
+		return nil
+	}
+
+	resetSeconds := resetIn(res.Reset, time.Now())
+
+	header := sess.ResponseWriter().Header()
+	header.Set("RateLimit-Limit", strconv.FormatInt(res.Limit, 10))
+	header.Set("RateLimit-Remaining", strconv.FormatInt(res.Remaining, 10))
+	header.Set("RateLimit-Reset", strconv.FormatInt(resetSeconds, 10))
+
+	if !res.Success {
+		header.Set("Retry-After", strconv.FormatInt(resetSeconds, 10))
+
+		return fault.New("gateway rate limit exceeded",
+			fault.Code(codes.Gateway.Auth.RateLimited.URN()),
+			fault.Public("Rate limit exceeded"),
+		)
+	}
+
+	return nil
+}
+
+// identifier returns the rate limit identifier of the request. Identifiers are
+// scoped to the project, so two projects never share a counter.
+func identifier(sess *server.Session, config *partitionv1.GatewayConfig) string {
+	rl := config.GetRatelimitConfig()
+
+	source := "ip"
+	value := sess.Location()
+
+	switch rl.GetIdentifierSource() {
+	case partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_KEY_ID:
+		if sess.KeyID != "" {
+			source, value = "key", sess.KeyID
+		}
+	case partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_IDENTITY:
+		// Keys without an identity are limited on their own
+		if sess.IdentityID != "" {
+			source, value = "identity", sess.IdentityID
+		} else if sess.KeyID != "" {
+			source, value = "key", sess.KeyID
+		}
+	case partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_HEADER:
+		if name := rl.GetIdentifierHeader(); name != "" && sess.Request().Header.Get(name) != "" {
+			source, value = "header", sess.Request().Header.Get(name)
+		}
+	case partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_UNSPECIFIED,
+		partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_IP:
+	}
+
+	return fmt.Sprintf("gw:%s:%s:%s", config.GetProjectId(), source, value)
+}
+
+// resetIn returns the whole seconds until reset, rounded up so clients never
+// retry too early.
+func resetIn(reset time.Time, now time.Time) int64 {
+	seconds := reset.Sub(now).Seconds()
+	if seconds <= 0 {
+		return 0
+	}
+
+	return int64(math.Ceil(seconds))
+}
@@ -0,0 +1,288 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/apps/gw/server"
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+	"github.com/unkeyed/unkey/go/internal/services/ratelimit"
+	"github.com/unkeyed/unkey/go/pkg/codes"
+	"github.com/unkeyed/unkey/go/pkg/fault"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+// fakeRatelimit records every request and answers with a fixed response.
+type fakeRatelimit struct {
+	requests []ratelimit.RatelimitRequest
+	response ratelimit.RatelimitResponse
+	err      error
+}
+
+var _ ratelimit.Service = (*fakeRatelimit)(nil)
+
+func (f *fakeRatelimit) Ratelimit(_ context.Context, req ratelimit.RatelimitRequest) (ratelimit.RatelimitResponse, error) {
+	f.requests = append(f.requests, req)
+	return f.response, f.err
+}
+
+func (f *fakeRatelimit) RatelimitMany(ctx context.Context, reqs []ratelimit.RatelimitRequest) ([]ratelimit.RatelimitResponse, error) {
+	responses := make([]ratelimit.RatelimitResponse, len(reqs))
+	for i, req := range reqs {
+		res, err := f.Ratelimit(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = res
+	}
+
+	return responses, nil
+}
+
+func newTestLimiter(t *testing.T, rl *fakeRatelimit) Limiter {
+	t.Helper()
+
+	l, err := New(Config{
+		Logger:    logging.NewNoop(),
+		Ratelimit: rl,
+	})
+	require.NoError(t, err)
+
+	return l
+}
+
+func newTestSession(t *testing.T) (*server.Session, *httptest.ResponseRecorder) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "192.0.2.1:4711"
+
+	return server.NewSession(w, r), w
+}
+
+func newTestConfig(rl *partitionv1.RatelimitConfig) *partitionv1.GatewayConfig {
+	return &partitionv1.GatewayConfig{
+		ProjectId:       "proj_1",
+		DeploymentId:    "dep_1",
+		RatelimitConfig: rl,
+	}
+}
+
+func TestLimit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no ratelimit config", func(t *testing.T) {
+		t.Parallel()
+
+		rl := &fakeRatelimit{}
+		sess, _ := newTestSession(t)
+
+		err := newTestLimiter(t, rl).Limit(context.Background(), sess, newTestConfig(nil))
+		require.NoError(t, err)
+		require.Empty(t, rl.requests)
+	})
+
+	t.Run("disabled ratelimit config", func(t *testing.T) {
+		t.Parallel()
+
+		rl := &fakeRatelimit{}
+		sess, _ := newTestSession(t)
+
+		err := newTestLimiter(t, rl).Limit(context.Background(), sess, newTestConfig(&partitionv1.RatelimitConfig{
+			Enabled:  false,
+			Limit:    10,
+			Duration: 60_000,
+		}))
+		require.NoError(t, err)
+		require.Empty(t, rl.requests)
+	})
+
+	t.Run("invalid ratelimit config", func(t *testing.T) {
+		t.Parallel()
+
+		rl := &fakeRatelimit{}
+		sess, _ := newTestSession(t)
+
+		err := newTestLimiter(t, rl).Limit(context.Background(), sess, newTestConfig(&partitionv1.RatelimitConfig{
+			Enabled:  true,
+			Limit:    0,
+			Duration: 60_000,
+		}))
+		require.NoError(t, err)
+		require.Empty(t, rl.requests)
+	})
+
+	t.Run("allowed request sets headers", func(t *testing.T) {
+		t.Parallel()
+
+		rl := &fakeRatelimit{response: ratelimit.RatelimitResponse{
+			Limit:     10,
+			Remaining: 9,
+			Reset:     time.Now().Add(30 * time.Second),
+			Success:   true,
+		}}
+		sess, w := newTestSession(t)
+
+		err := newTestLimiter(t, rl).Limit(context.Background(), sess, newTestConfig(&partitionv1.RatelimitConfig{
+			Enabled:  true,
+			Limit:    10,
+			Duration: 60_000,
+		}))
+		require.NoError(t, err)
+
+		require.Len(t, rl.requests, 1)
+		require.Equal(t, "gw:proj_1:ip:192.0.2.1", rl.requests[0].Identifier)
+		require.Equal(t, int64(10), rl.requests[0].Limit)
+		require.Equal(t, time.Minute, rl.requests[0].Duration)
+
+		require.Equal(t, "10", w.Header().Get("RateLimit-Limit"))
+		require.Equal(t, "9", w.Header().Get("RateLimit-Remaining"))
+		require.Equal(t, "30", w.Header().Get("RateLimit-Reset"))
+		require.Empty(t, w.Header().Get("Retry-After"))
+	})
+
+	t.Run("denied request returns rate limited", func(t *testing.T) {
+		t.Parallel()
+
+		rl := &fakeRatelimit{response: ratelimit.RatelimitResponse{
+			Limit:     10,
+			Remaining: 0,
+			Reset:     time.Now().Add(5 * time.Second),
+			Success:   false,
+		}}
+		sess, w := newTestSession(t)
+
+		err := newTestLimiter(t, rl).Limit(context.Background(), sess, newTestConfig(&partitionv1.RatelimitConfig{
+			Enabled:  true,
+			Limit:    10,
+			Duration: 60_000,
+		}))
+		require.Error(t, err)
+
+		code, ok := fault.GetCode(err)
+		require.True(t, ok)
+		require.Equal(t, codes.Gateway.Auth.RateLimited.URN(), code)
+
+		require.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+		require.Equal(t, "5", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("fails open when the ratelimit service errors", func(t *testing.T) {
+		t.Parallel()
+
+		rl := &fakeRatelimit{err: errors.New("redis is down")}
+		sess, w := newTestSession(t)
+
+		err := newTestLimiter(t, rl).Limit(context.Background(), sess, newTestConfig(&partitionv1.RatelimitConfig{
+			Enabled:  true,
+			Limit:    10,
+			Duration: 60_000,
+		}))
+		require.NoError(t, err)
+		require.Len(t, rl.requests, 1)
+		require.Empty(t, w.Header().Get("RateLimit-Limit"))
+	})
+}
+
+func TestIdentifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		source     partitionv1.RatelimitIdentifierSource
+		header     string
+		keyID      string
+		identityID string
+		headers    map[string]string
+		want       string
+	}{
+		{
+			name:   "unspecified uses the ip",
+			source: partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_UNSPECIFIED,
+			want:   "gw:proj_1:ip:192.0.2.1",
+		},
+		{
+			name:    "ip prefers True-Client-Ip",
+			source:  partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_IP,
+			headers: map[string]string{"True-Client-Ip": "198.51.100.7"},
+			want:    "gw:proj_1:ip:198.51.100.7",
+		},
+		{
+			name:   "key id",
+			source: partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_KEY_ID,
+			keyID:  "key_1",
+			want:   "gw:proj_1:key:key_1",
+		},
+		{
+			name:   "key id without a key falls back to the ip",
+			source: partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_KEY_ID,
+			want:   "gw:proj_1:ip:192.0.2.1",
+		},
+		{
+			name:       "identity",
+			source:     partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_IDENTITY,
+			keyID:      "key_1",
+			identityID: "id_1",
+			want:       "gw:proj_1:identity:id_1",
+		},
+		{
+			name:   "identity without an identity falls back to the key",
+			source: partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_IDENTITY,
+			keyID:  "key_1",
+			want:   "gw:proj_1:key:key_1",
+		},
+		{
+			name:    "header",
+			source:  partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_HEADER,
+			header:  "X-Tenant",
+			headers: map[string]string{"X-Tenant": "acme"},
+			want:    "gw:proj_1:header:acme",
+		},
+		{
+			name:   "missing header falls back to the ip",
+			source: partitionv1.RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_HEADER,
+			header: "X-Tenant",
+			want:   "gw:proj_1:ip:192.0.2.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sess, _ := newTestSession(t)
+			for k, v := range tt.headers {
+				sess.Request().Header.Set(k, v)
+			}
+			sess.KeyID = tt.keyID
+			sess.IdentityID = tt.identityID
+
+			config := newTestConfig(&partitionv1.RatelimitConfig{
+				Enabled:          true,
+				Limit:            10,
+				Duration:         60_000,
+				IdentifierSource: tt.source,
+				IdentifierHeader: tt.header,
+			})
+
+			require.Equal(t, tt.want, identifier(sess, config))
+		})
+	}
+}
+
+func TestResetIn(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	require.Equal(t, int64(0), resetIn(now.Add(-time.Second), now))
+	require.Equal(t, int64(0), resetIn(now, now))
+	require.Equal(t, int64(1), resetIn(now.Add(10*time.Millisecond), now))
+	require.Equal(t, int64(2), resetIn(now.Add(1500*time.Millisecond), now))
+	require.Equal(t, int64(60), resetIn(now.Add(time.Minute), now))
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/unkeyed/unkey/go/apps/gw/services/health"
 	"github.com/unkeyed/unkey/go/pkg/otel/logging"
 )
 
@@ -19,6 +20,10 @@ type Config struct {
 	// Logger for debugging and monitoring
 	Logger logging.Logger
 
+	// Health receives the outcome of every forwarded request for outlier
+	// detection, optional
+	Health health.Checker
+
 	// MaxIdleConns is the maximum number of idle connections to keep open.
 	MaxIdleConns int
 
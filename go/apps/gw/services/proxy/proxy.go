@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/unkeyed/unkey/go/apps/gw/services/health"
 	"github.com/unkeyed/unkey/go/pkg/codes"
 	"github.com/unkeyed/unkey/go/pkg/fault"
 	"github.com/unkeyed/unkey/go/pkg/otel/logging"
@@ -19,6 +20,7 @@ var _ Proxy = (*proxy)(nil)
 
 type proxy struct {
 	logger    logging.Logger
+	health    health.Checker
 	transport *http.Transport
 }
 
@@ -59,6 +61,7 @@ func New(config Config) (Proxy, error) {
 
 	return &proxy{
 		logger:    config.Logger,
+		health:    config.Health,
 		transport: transport,
 	}, nil
 }
@@ -74,6 +77,17 @@ func (p *proxy) classifyProxyError(err error) error {
 		)
 	}
 
+	// Check for errors while connecting, including dial timeouts. The request
+	// never reached the backend.
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return fault.Wrap(err,
+			fault.Code(codes.Gateway.Proxy.ServiceUnavailable.URN()),
+			fault.Internal("unable to connect to backend service"),
+			fault.Public("The service is currently unavailable"),
+		)
+	}
+
 	// Check for net.Error timeout (handles most timeout cases)
 	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
 		return fault.Wrap(err,
@@ -110,6 +124,14 @@ func (p *proxy) classifyProxyError(err error) error {
 	)
 }
 
+// IsRetryable reports whether err, as returned by Forward, means the request
+// never reached the backend, so it is safe to send it to another one.
+func IsRetryable(err error) bool {
+	code, ok := fault.GetCode(err)
+
+	return ok && code == codes.Gateway.Proxy.ServiceUnavailable.URN()
+}
+
 // Forward implements the Proxy interface.
 func (p *proxy) Forward(ctx context.Context, target url.URL, w http.ResponseWriter, r *http.Request) error {
 	var err error
@@ -145,7 +167,23 @@ func (p *proxy) Forward(ctx context.Context, target url.URL, w http.ResponseWrit
 			}
 		},
 		Transport: p.transport,
+		ModifyResponse: func(res *http.Response) error {
+			if p.health != nil {
+				if res.StatusCode >= http.StatusInternalServerError {
+					p.health.ReportFailure(target.Host)
+				} else {
+					p.health.ReportSuccess(target.Host)
+				}
+			}
+
+			return nil
+		},
 		ErrorHandler: func(w http.ResponseWriter, r *http.Request, pErr error) {
+			// A client that went away says nothing about the backend
+			if p.health != nil && !errors.Is(pErr, context.Canceled) {
+				p.health.ReportFailure(target.Host)
+			}
+
 			if p.logger != nil {
 				p.logger.Error("proxy error",
 					"error", pErr.Error(),
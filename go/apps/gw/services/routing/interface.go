@@ -2,8 +2,10 @@ package routing
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 
+	"github.com/unkeyed/unkey/go/apps/gw/services/health"
 	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
 	"github.com/unkeyed/unkey/go/pkg/cache"
 	"github.com/unkeyed/unkey/go/pkg/clock"
@@ -16,8 +18,14 @@ type Service interface {
 	// GetTargetByHost finds gateway configuration based on the request host
 	GetConfig(ctx context.Context, host string) (*partitionv1.GatewayConfig, error)
 
-	// SelectVM picks an available VM from the gateway's VM list
-	SelectVM(ctx context.Context, config *partitionv1.GatewayConfig) (*url.URL, error)
+	// SelectTarget picks the deployment that serves the request. If the config
+	// splits traffic between deployments, the choice is weighted and sticky
+	// if the config asks for it.
+	SelectTarget(config *partitionv1.GatewayConfig, req *http.Request) (*partitionv1.DeploymentTarget, error)
+
+	// SelectVM picks an available VM from the target's VM list. VMs whose
+	// host is in exclude are skipped, so retries land on another VM.
+	SelectVM(ctx context.Context, config *partitionv1.GatewayConfig, target *partitionv1.DeploymentTarget, exclude []string) (*url.URL, error)
 }
 
 // Config holds configuration for the routing service.
@@ -26,6 +34,10 @@ type Config struct {
 	Logger logging.Logger
 	Clock  clock.Clock
 
+	// Health tracks ejected VMs, VMs are only filtered by their database
+	// status if it is nil
+	Health health.Checker
+
 	GatewayConfigCache cache.Cache[string, *partitionv1.GatewayConfig]
 	VMCache            cache.Cache[string, db.Vm]
 }
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"math/rand"
 	"net/url"
+	"slices"
 
+	"github.com/unkeyed/unkey/go/apps/gw/services/health"
 	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
 	"github.com/unkeyed/unkey/go/internal/services/caches"
 	"github.com/unkeyed/unkey/go/pkg/assert"
@@ -21,6 +23,7 @@ import (
 type service struct {
 	db     db.Database
 	logger logging.Logger
+	health health.Checker
 
 	gatewayConfigCache cache.Cache[string, *partitionv1.GatewayConfig]
 	vmCache            cache.Cache[string, db.Vm]
@@ -42,6 +45,7 @@ func New(config Config) (*service, error) {
 	return &service{
 		db:                 config.DB,
 		logger:             config.Logger,
+		health:             config.Health,
 		gatewayConfigCache: config.GatewayConfigCache,
 		vmCache:            config.VMCache,
 	}, nil
@@ -90,50 +94,86 @@ func (s *service) GetConfig(ctx context.Context, host string) (*partitionv1.Gate
 	return config, nil
 }
 
-// SelectVM picks an available VM from the gateway's VM list using simple round-robin.
-func (s *service) SelectVM(ctx context.Context, config *partitionv1.GatewayConfig) (*url.URL, error) {
-	if !config.IsEnabled {
-		return nil, fmt.Errorf("gateway %s is disabled", config.DeploymentId)
+// SelectVM picks a random running VM of the target deployment.
+//
+// VMs that the health checker ejected are skipped. If every VM is ejected,
+// all running VMs are used anyway: sending traffic to a VM that might have
+// recovered is better than failing every request.
+func (s *service) SelectVM(ctx context.Context, config *partitionv1.GatewayConfig, target *partitionv1.DeploymentTarget, exclude []string) (*url.URL, error) {
+	if !config.GetIsEnabled() {
+		return nil, fmt.Errorf("gateway %s is disabled", config.GetDeploymentId())
 	}
 
-	if len(config.Vms) == 0 {
-		return nil, fmt.Errorf("no VMs available for gateway %s", config.DeploymentId)
+	if len(target.GetVms()) == 0 {
+		return nil, fmt.Errorf("no VMs available for deployment %s", target.GetDeploymentId())
 	}
 
-	availableVms := make([]db.Vm, 0)
-	for _, vm := range config.Vms {
-		vm, hit, err := s.vmCache.SWR(ctx, vm.Id, func(ctx context.Context) (db.Vm, error) {
-			// refactor: this is bad BAD, we should really add a getMany method to the cache
-			return db.Query.FindVMById(ctx, s.db.RO(), vm.Id)
-		}, caches.DefaultFindFirstOp)
+	vmIDs := make([]string, len(target.GetVms()))
+	for i, vm := range target.GetVms() {
+		vmIDs[i] = vm.GetId()
+	}
 
+	// VMs that do not exist are cached as null and left out of vms
+	vms, _, err := s.vmCache.SWRMany(ctx, vmIDs, func(ctx context.Context, ids []string) (map[string]db.Vm, error) {
+		rows, err := db.Query.FindVMsByIds(ctx, s.db.RO(), ids)
 		if err != nil {
-			if db.IsNotFound(err) {
-				continue
-			}
-
 			return nil, err
 		}
 
-		if hit == cache.Null {
+		found := make(map[string]db.Vm, len(rows))
+		for _, row := range rows {
+			found[row.ID] = row
+		}
+		return found, nil
+	}, caches.DefaultFindFirstOp)
+	if err != nil {
+		return nil, err
+	}
+
+	runningVms := make([]string, 0)
+	availableVms := make([]string, 0)
+	for _, vmID := range vmIDs {
+		vm, ok := vms[vmID]
+		if !ok {
 			continue
 		}
 
-		if vm.Status != db.VmsStatusRunning {
+		if vm.Status != db.VmsStatusRunning || vm.HealthStatus == db.VmsHealthStatusUnhealthy {
 			continue
 		}
 
-		availableVms = append(availableVms, vm)
+		host := fmt.Sprintf("%s:%d", vm.PrivateIp.String, vm.Port.Int32)
+		if slices.Contains(exclude, host) {
+			continue
+		}
+
+		runningVms = append(runningVms, host)
+
+		if s.health != nil {
+			s.health.Watch(target.GetDeploymentId(), host)
+			if !s.health.IsAvailable(host) {
+				continue
+			}
+		}
+
+		availableVms = append(availableVms, host)
 	}
 
 	if len(availableVms) == 0 {
-		return nil, fmt.Errorf("no available VMs for gateway %s", config.DeploymentId)
+		if len(runningVms) == 0 {
+			return nil, fmt.Errorf("no available VMs for deployment %s", target.GetDeploymentId())
+		}
+
+		s.logger.Warn("all VMs of deployment are ejected, ignoring health",
+			"deployment_id", target.GetDeploymentId(),
+		)
+		availableVms = runningVms
 	}
 
 	// select random VM
 	selectedVM := availableVms[rand.Intn(len(availableVms))]
 
-	fullUrl := fmt.Sprintf("http://%s:%d", selectedVM.PrivateIp.String, selectedVM.Port.Int32)
+	fullUrl := "http://" + selectedVM
 
 	targetURL, err := url.Parse(fullUrl)
 	if err != nil {
@@ -0,0 +1,101 @@
+package routing
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net/http"
+	"time"
+
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+)
+
+// stickyCookieMaxAge is how long a client stays on the deployment it was
+// assigned to, the cookie is refreshed on every response.
+const stickyCookieMaxAge = 24 * time.Hour
+
+// SelectTarget picks the deployment that serves req.
+//
+// A client that carries the sticky cookie of a deployment that still receives
+// traffic stays on it. Otherwise the sticky header, if present, is hashed onto
+// the weights so the same value always lands on the same deployment for as
+// long as the weights don't change. Everyone else is assigned randomly,
+// proportional to the weights.
+func (s *service) SelectTarget(config *partitionv1.GatewayConfig, req *http.Request) (*partitionv1.DeploymentTarget, error) {
+	targets := config.GetTargets()
+
+	// Configs written before traffic splitting send everything to the
+	// primary deployment.
+	if len(targets) == 0 {
+		return &partitionv1.DeploymentTarget{
+			DeploymentId: config.GetDeploymentId(),
+			Weight:       100,
+			Vms:          config.GetVms(),
+		}, nil
+	}
+
+	total := 0
+	for _, target := range targets {
+		total += int(max(target.GetWeight(), 0))
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("no deployment receives traffic for gateway %s", config.GetDeploymentId())
+	}
+
+	sticky := config.GetStickyConfig()
+
+	if name := sticky.GetCookieName(); name != "" {
+		if cookie, err := req.Cookie(name); err == nil {
+			for _, target := range targets {
+				if target.GetDeploymentId() == cookie.Value && target.GetWeight() > 0 {
+					return target, nil
+				}
+			}
+		}
+	}
+
+	if name := sticky.GetHeaderName(); name != "" {
+		if value := req.Header.Get(name); value != "" {
+			h := fnv.New32a()
+			_, _ = h.Write([]byte(value))
+
+			return pickWeighted(targets, int(h.Sum32()%uint32(total))), nil // nolint:gosec
+		}
+	}
+
+	return pickWeighted(targets, rand.Intn(total)), nil
+}
+
+// pickWeighted returns the target whose share of the cumulative weights
+// contains n, where 0 <= n < sum of weights.
+func pickWeighted(targets []*partitionv1.DeploymentTarget, n int) *partitionv1.DeploymentTarget {
+	for _, target := range targets {
+		weight := int(max(target.GetWeight(), 0))
+		if n < weight {
+			return target
+		}
+		n -= weight
+	}
+
+	return targets[len(targets)-1]
+}
+
+// StickyCookie returns the cookie that keeps the client on target, or nil if
+// the config doesn't split traffic or doesn't use a sticky cookie.
+func StickyCookie(config *partitionv1.GatewayConfig, target *partitionv1.DeploymentTarget) *http.Cookie {
+	name := config.GetStickyConfig().GetCookieName()
+	if name == "" || len(config.GetTargets()) < 2 {
+		return nil
+	}
+
+	return &http.Cookie{
+		Name:     name,
+		Value:    target.GetDeploymentId(),
+		Path:     "/",
+		MaxAge:   int(stickyCookieMaxAge.Seconds()),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
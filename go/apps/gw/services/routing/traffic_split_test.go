@@ -0,0 +1,154 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	partitionv1 "github.com/unkeyed/unkey/go/gen/proto/partition/v1"
+)
+
+func TestSelectTarget(t *testing.T) {
+	t.Parallel()
+
+	s := &service{}
+
+	splitConfig := func(sticky *partitionv1.StickyConfig) *partitionv1.GatewayConfig {
+		return &partitionv1.GatewayConfig{
+			IsEnabled:    true,
+			DeploymentId: "d_blue",
+			Targets: []*partitionv1.DeploymentTarget{
+				{DeploymentId: "d_blue", Weight: 90},
+				{DeploymentId: "d_green", Weight: 10},
+			},
+			StickyConfig: sticky,
+		}
+	}
+
+	t.Run("configs without targets route to the primary deployment", func(t *testing.T) {
+		t.Parallel()
+
+		config := &partitionv1.GatewayConfig{
+			DeploymentId: "d_blue",
+			Vms:          []*partitionv1.VM{{Id: "vm_1", Region: "us-east-1"}},
+		}
+
+		target, err := s.SelectTarget(config, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.NoError(t, err)
+		require.Equal(t, "d_blue", target.GetDeploymentId())
+		require.Len(t, target.GetVms(), 1)
+	})
+
+	t.Run("traffic is split by weight", func(t *testing.T) {
+		t.Parallel()
+
+		config := splitConfig(nil)
+
+		counts := map[string]int{}
+		for range 10_000 {
+			target, err := s.SelectTarget(config, httptest.NewRequest(http.MethodGet, "/", nil))
+			require.NoError(t, err)
+			counts[target.GetDeploymentId()]++
+		}
+
+		require.InDelta(t, 9_000, counts["d_blue"], 300)
+		require.InDelta(t, 1_000, counts["d_green"], 300)
+	})
+
+	t.Run("targets without weight receive no traffic", func(t *testing.T) {
+		t.Parallel()
+
+		config := splitConfig(nil)
+		config.Targets[1].Weight = 0
+
+		for range 1_000 {
+			target, err := s.SelectTarget(config, httptest.NewRequest(http.MethodGet, "/", nil))
+			require.NoError(t, err)
+			require.Equal(t, "d_blue", target.GetDeploymentId())
+		}
+	})
+
+	t.Run("sticky cookie keeps the client on its deployment", func(t *testing.T) {
+		t.Parallel()
+
+		config := splitConfig(&partitionv1.StickyConfig{CookieName: "unkey_deployment"})
+
+		for range 100 {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.AddCookie(&http.Cookie{Name: "unkey_deployment", Value: "d_green"})
+
+			target, err := s.SelectTarget(config, req)
+			require.NoError(t, err)
+			require.Equal(t, "d_green", target.GetDeploymentId())
+		}
+	})
+
+	t.Run("sticky cookie of a retired deployment is ignored", func(t *testing.T) {
+		t.Parallel()
+
+		config := splitConfig(&partitionv1.StickyConfig{CookieName: "unkey_deployment"})
+		config.Targets[1].Weight = 0
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "unkey_deployment", Value: "d_green"})
+
+		target, err := s.SelectTarget(config, req)
+		require.NoError(t, err)
+		require.Equal(t, "d_blue", target.GetDeploymentId())
+	})
+
+	t.Run("sticky header always picks the same deployment", func(t *testing.T) {
+		t.Parallel()
+
+		config := splitConfig(&partitionv1.StickyConfig{HeaderName: "X-User-Id"})
+
+		for _, user := range []string{"user_1", "user_2", "user_3", "user_4"} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-User-Id", user)
+
+			first, err := s.SelectTarget(config, req)
+			require.NoError(t, err)
+
+			for range 100 {
+				target, err := s.SelectTarget(config, req)
+				require.NoError(t, err)
+				require.Equal(t, first.GetDeploymentId(), target.GetDeploymentId())
+			}
+		}
+	})
+
+	t.Run("no weights is an error", func(t *testing.T) {
+		t.Parallel()
+
+		config := splitConfig(nil)
+		config.Targets[0].Weight = 0
+		config.Targets[1].Weight = 0
+
+		_, err := s.SelectTarget(config, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Error(t, err)
+	})
+}
+
+func TestStickyCookie(t *testing.T) {
+	t.Parallel()
+
+	target := &partitionv1.DeploymentTarget{DeploymentId: "d_green", Weight: 10}
+
+	require.Nil(t, StickyCookie(&partitionv1.GatewayConfig{
+		Targets:      []*partitionv1.DeploymentTarget{target},
+		StickyConfig: &partitionv1.StickyConfig{CookieName: "unkey_deployment"},
+	}, target), "no cookie without a split")
+
+	require.Nil(t, StickyCookie(&partitionv1.GatewayConfig{
+		Targets: []*partitionv1.DeploymentTarget{{DeploymentId: "d_blue", Weight: 90}, target},
+	}, target), "no cookie without a cookie name")
+
+	cookie := StickyCookie(&partitionv1.GatewayConfig{
+		Targets:      []*partitionv1.DeploymentTarget{{DeploymentId: "d_blue", Weight: 90}, target},
+		StickyConfig: &partitionv1.StickyConfig{CookieName: "unkey_deployment"},
+	}, target)
+	require.NotNil(t, cookie)
+	require.Equal(t, "unkey_deployment", cookie.Name)
+	require.Equal(t, "d_green", cookie.Value)
+}
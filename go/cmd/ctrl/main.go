@@ -2,6 +2,7 @@ package ctrl
 
 import (
 	"context"
+	"time"
 
 	"github.com/unkeyed/unkey/go/apps/ctrl"
 	"github.com/unkeyed/unkey/go/pkg/cli"
@@ -39,6 +40,8 @@ var Cmd = &cli.Command{
 			cli.Required(), cli.EnvVar("UNKEY_DATABASE_PARTITION")),
 		cli.String("database-hydra", "MySQL connection string for hydra database. Required for all deployments. Example: user:pass@host:3306/hydra?parseTime=true",
 			cli.Required(), cli.EnvVar("UNKEY_DATABASE_HYDRA")),
+		cli.String("redis-url", "Redis connection string for distributed counters. Used to update key credits after refills. Example: redis://localhost:6379",
+			cli.EnvVar("UNKEY_REDIS_URL")),
 
 		// Observability
 		cli.Bool("otel", "Enable OpenTelemetry tracing and metrics",
@@ -71,6 +74,18 @@ var Cmd = &cli.Command{
 			cli.EnvVar("UNKEY_VAULT_S3_ACCESS_KEY_ID")),
 		cli.String("vault-s3-access-key-secret", "S3 secret access key",
 			cli.EnvVar("UNKEY_VAULT_S3_ACCESS_KEY_SECRET")),
+		cli.String("vault-filesystem-path", "Directory to store vault keys in, instead of S3. Must be the storage the API uses. Example: /var/lib/unkey/vault",
+			cli.EnvVar("UNKEY_VAULT_FILESYSTEM_PATH")),
+		cli.String("vault-mysql-dsn", "MySQL connection string to store vault keys in, instead of S3. Must be the storage the API uses. Example: user:pass@tcp(localhost:3306)/vault",
+			cli.EnvVar("UNKEY_VAULT_MYSQL_DSN")),
+		cli.Int("vault-dek-rotation-days", "Age in days after which the data encryption keys of vault keyrings are rotated and encrypted keys re-encrypted. Set to 0 to disable rotation. Default: 90",
+			cli.Default(90), cli.EnvVar("UNKEY_VAULT_DEK_ROTATION_DAYS")),
+
+		// Webhook Configuration
+		cli.Int("webhook-expiring-days", "Days before its expiration a key is reported to webhook endpoints as expiring soon. Default: 7",
+			cli.Default(7), cli.EnvVar("UNKEY_WEBHOOK_EXPIRING_DAYS")),
+		cli.Int("webhook-credits-threshold", "Remaining credits at which a key is reported to webhook endpoints as running low. Default: 100",
+			cli.Default(100), cli.EnvVar("UNKEY_WEBHOOK_CREDITS_THRESHOLD")),
 	},
 	Action: action,
 }
@@ -103,6 +118,20 @@ func action(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
+	var vaultFilesystemConfig *storage.FilesystemConfig
+	if cmd.String("vault-filesystem-path") != "" {
+		vaultFilesystemConfig = &storage.FilesystemConfig{
+			Path: cmd.String("vault-filesystem-path"),
+		}
+	}
+
+	var vaultMySQLConfig *storage.MySQLConfig
+	if cmd.String("vault-mysql-dsn") != "" {
+		vaultMySQLConfig = &storage.MySQLConfig{
+			DSN: cmd.String("vault-mysql-dsn"),
+		}
+	}
+
 	config := ctrl.Config{
 		// Basic configuration
 		Platform:   cmd.String("platform"),
@@ -115,6 +144,7 @@ func action(ctx context.Context, cmd *cli.Command) error {
 		DatabasePrimary:   cmd.String("database-primary"),
 		DatabasePartition: cmd.String("database-partition"),
 		DatabaseHydra:     cmd.String("database-hydra"),
+		RedisUrl:          cmd.String("redis-url"),
 
 		// Observability
 		OtelEnabled:           cmd.Bool("otel"),
@@ -131,6 +161,14 @@ func action(ctx context.Context, cmd *cli.Command) error {
 		// Vault configuration
 		VaultMasterKeys: cmd.StringSlice("vault-master-keys"),
 		VaultS3:         vaultS3Config,
+		VaultFilesystem: vaultFilesystemConfig,
+		VaultMySQL:      vaultMySQLConfig,
+
+		VaultDEKRotationInterval: time.Duration(cmd.Int("vault-dek-rotation-days")) * 24 * time.Hour,
+
+		// Webhook configuration
+		WebhookExpiringWindow:   time.Duration(cmd.Int("webhook-expiring-days")) * 24 * time.Hour,
+		WebhookCreditsThreshold: cmd.Int("webhook-credits-threshold"),
 
 		// Common
 		Clock: clock.New(),
@@ -143,6 +143,10 @@ DEPLOYMENT PROCESS:
 4. Create deployment version on Unkey platform
 5. Monitor deployment status until active
 
+AVAILABLE COMMANDS:
+- list: List deployment workflows and their status
+- cancel: Cancel a stuck deployment workflow
+
 EXAMPLES:
 unkey deploy --init                           # Initialize new project configuration
 unkey deploy --init --config=./my-project    # Initialize with custom location
@@ -153,9 +157,15 @@ unkey deploy --workspace-id=ws_production_123 # Override workspace from config f
 unkey deploy --context=./api                 # Deploy with custom build context
 unkey deploy --skip-push                     # Local development (build only, no push)
 unkey deploy --docker-image=ghcr.io/user/app:v1.0.0 # Deploy pre-built image
-unkey deploy --verbose                       # Verbose output for debugging`,
+unkey deploy --verbose                       # Verbose output for debugging
+unkey deploy list --status=running           # List running deployment workflows
+unkey deploy cancel wf_abc123                # Cancel a stuck deployment workflow`,
 	Flags:  DeployFlags,
 	Action: DeployAction,
+	Commands: []*cli.Command{
+		listCmd,
+		cancelCmd,
+	},
 }
 
 func DeployAction(ctx context.Context, cmd *cli.Command) error {
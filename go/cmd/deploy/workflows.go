@@ -0,0 +1,190 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	ctrlv1 "github.com/unkeyed/unkey/go/gen/proto/ctrl/v1"
+	"github.com/unkeyed/unkey/go/gen/proto/ctrl/v1/ctrlv1connect"
+	"github.com/unkeyed/unkey/go/pkg/cli"
+)
+
+// deploymentWorkflowName is the name the control plane registers its
+// deployment workflow with
+const deploymentWorkflowName = "deployment"
+
+var workflowFlags = []cli.Flag{
+	cli.String("control-plane-url", "Control plane URL", cli.Default(DefaultControlPlaneURL)),
+	cli.String("auth-token", "Control plane auth token", cli.Default(DefaultAuthToken)),
+}
+
+var listCmd = &cli.Command{
+	Name:  "list",
+	Usage: "List deployment workflows",
+	Description: `List the workflows running deployments on the control plane, newest first.
+
+Use it to find deployments that are stuck or failed, and their execution IDs to cancel them.
+
+EXAMPLES:
+unkey deploy list                             # List recent deployment workflows
+unkey deploy list --status=running            # Only list running deployments
+unkey deploy list --limit=10                  # List the 10 most recent deployments`,
+	Flags: append([]cli.Flag{
+		cli.String("status", "Only list workflows with this status: pending, running, sleeping, completed, failed, cancelled or terminated"),
+		cli.Int("limit", "Maximum number of workflows to list", cli.Default(20)),
+	}, workflowFlags...),
+	Action: listAction,
+}
+
+var cancelCmd = &cli.Command{
+	Name:  "cancel",
+	Usage: "Cancel a deployment workflow",
+	Description: `Cancel a deployment workflow by its execution ID.
+
+A running deployment stops before its next step, and the VMs it already created are deleted.
+
+EXAMPLES:
+unkey deploy cancel wf_abc123                            # Cancel a deployment workflow
+unkey deploy cancel wf_abc123 --reason="wrong image"     # Cancel with a reason`,
+	Flags: append([]cli.Flag{
+		cli.String("reason", "Why the deployment is cancelled", cli.Default("cancelled from the CLI")),
+	}, workflowFlags...),
+	Action: cancelAction,
+}
+
+// WorkflowClient inspects and cancels deployment workflows on the control plane
+type WorkflowClient struct {
+	client    ctrlv1connect.WorkflowServiceClient
+	authToken string
+}
+
+// NewWorkflowClient creates a new workflow client
+func NewWorkflowClient(controlPlaneURL, authToken string) *WorkflowClient {
+	return &WorkflowClient{
+		client:    ctrlv1connect.NewWorkflowServiceClient(&http.Client{}, controlPlaneURL),
+		authToken: authToken,
+	}
+}
+
+// ListDeployments lists deployment workflows, newest first
+func (c *WorkflowClient) ListDeployments(ctx context.Context, status ctrlv1.WorkflowStatus, limit int) ([]*ctrlv1.Workflow, error) {
+	req := connect.NewRequest(&ctrlv1.ListWorkflowsRequest{
+		Status:       status,
+		WorkflowName: deploymentWorkflowName,
+		PageSize:     int32(limit),
+		PageToken:    "",
+	})
+	req.Header().Set("Authorization", "Bearer "+c.authToken)
+
+	resp, err := c.client.ListWorkflows(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg.GetWorkflows(), nil
+}
+
+// Cancel cancels a workflow execution
+func (c *WorkflowClient) Cancel(ctx context.Context, executionID, reason string) (*ctrlv1.Workflow, error) {
+	req := connect.NewRequest(&ctrlv1.CancelWorkflowRequest{
+		ExecutionId: executionID,
+		Reason:      reason,
+	})
+	req.Header().Set("Authorization", "Bearer "+c.authToken)
+
+	resp, err := c.client.CancelWorkflow(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg.GetWorkflow(), nil
+}
+
+func listAction(ctx context.Context, cmd *cli.Command) error {
+	status, err := parseWorkflowStatus(cmd.String("status"))
+	if err != nil {
+		return err
+	}
+
+	limit := cmd.Int("limit")
+	if limit <= 0 {
+		return fmt.Errorf("limit must be positive, got %d", limit)
+	}
+
+	client := NewWorkflowClient(cmd.String("control-plane-url"), cmd.String("auth-token"))
+	workflows, err := client.ListDeployments(ctx, status, limit)
+	if err != nil {
+		return fmt.Errorf("failed to list deployment workflows: %w", err)
+	}
+
+	if len(workflows) == 0 {
+		fmt.Println("No deployment workflows found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EXECUTION ID\tSTATUS\tCREATED\tERROR")
+	for _, workflow := range workflows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			workflow.GetExecutionId(),
+			formatWorkflowStatus(workflow),
+			time.UnixMilli(workflow.GetCreatedAt()).Format(time.DateTime),
+			workflow.GetErrorMessage(),
+		)
+	}
+
+	return w.Flush()
+}
+
+func cancelAction(ctx context.Context, cmd *cli.Command) error {
+	args := cmd.Args()
+	if len(args) < 1 {
+		return fmt.Errorf("execution ID required")
+	}
+
+	client := NewWorkflowClient(cmd.String("control-plane-url"), cmd.String("auth-token"))
+	workflow, err := client.Cancel(ctx, args[0], cmd.String("reason"))
+	if err != nil {
+		return fmt.Errorf("failed to cancel deployment workflow: %w", err)
+	}
+
+	if workflow.GetStatus() == ctrlv1.WorkflowStatus_WORKFLOW_STATUS_CANCELLED {
+		fmt.Printf("Deployment workflow %s cancelled\n", workflow.GetExecutionId())
+	} else {
+		fmt.Printf("Deployment workflow %s will stop before its next step\n", workflow.GetExecutionId())
+	}
+
+	return nil
+}
+
+// parseWorkflowStatus parses a status name such as "failed", an empty name
+// matches every status.
+func parseWorkflowStatus(name string) (ctrlv1.WorkflowStatus, error) {
+	if name == "" {
+		return ctrlv1.WorkflowStatus_WORKFLOW_STATUS_UNSPECIFIED, nil
+	}
+
+	value, ok := ctrlv1.WorkflowStatus_value["WORKFLOW_STATUS_"+strings.ToUpper(name)]
+	if !ok || value == int32(ctrlv1.WorkflowStatus_WORKFLOW_STATUS_UNSPECIFIED) {
+		return ctrlv1.WorkflowStatus_WORKFLOW_STATUS_UNSPECIFIED, fmt.Errorf("unknown workflow status %q", name)
+	}
+
+	return ctrlv1.WorkflowStatus(value), nil
+}
+
+// formatWorkflowStatus returns the lowercase status of a workflow and whether
+// its cancellation was requested.
+func formatWorkflowStatus(workflow *ctrlv1.Workflow) string {
+	status := strings.ToLower(strings.TrimPrefix(workflow.GetStatus().String(), "WORKFLOW_STATUS_"))
+	if workflow.GetCancelRequestedAt() > 0 && workflow.GetCompletedAt() == 0 {
+		status += " (cancelling)"
+	}
+
+	return status
+}
@@ -0,0 +1,32 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unkeyed/unkey/go/pkg/cli"
+)
+
+var Cmd = &cli.Command{
+	Name:  "vault",
+	Usage: "Administer the vault",
+	Description: `Administer the vault that stores the encrypted data encryption keys of Unkey services.
+
+AVAILABLE COMMANDS:
+- migrate: Copy the vault's objects from one storage to another
+
+EXAMPLES:
+unkey vault migrate --help                       # Show the options of the migration`,
+	Commands: []*cli.Command{
+		migrateCmd,
+	},
+	Action: vaultAction,
+}
+
+func vaultAction(ctx context.Context, cmd *cli.Command) error {
+	fmt.Println("Available commands:")
+	fmt.Println("  migrate  - Copy the vault's objects from one storage to another")
+	fmt.Println()
+	fmt.Println("Use 'unkey vault <command> --help' for command-specific options")
+	return nil
+}
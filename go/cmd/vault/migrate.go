@@ -0,0 +1,104 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unkeyed/unkey/go/pkg/cli"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/vault/storage"
+)
+
+var migrateCmd = &cli.Command{
+	Name:  "migrate",
+	Usage: "Copy the vault's objects from one storage to another",
+	Description: `Copy all objects of the vault from one storage to another, for example to move a self-hosted deployment from S3 to the local filesystem.
+
+Objects are copied as they are, they stay encrypted with the same master keys. Objects that already exist in the destination with the same content are skipped, so an interrupted migration can be restarted. Stop the services using the vault, or run the migration twice, as keys created during the migration might be missed.
+
+STORAGES:
+- s3: an S3 compatible bucket, configured with the --<side>-s3-* flags
+- filesystem: a local directory, configured with --<side>-filesystem-path
+- mysql: a MySQL database, configured with --<side>-mysql-dsn
+
+EXAMPLES:
+unkey vault migrate --from s3 --from-s3-url http://localhost:3902 --from-s3-bucket vault --from-s3-access-key-id ... --from-s3-access-key-secret ... --to filesystem --to-filesystem-path /var/lib/unkey/vault
+unkey vault migrate --from filesystem --from-filesystem-path /var/lib/unkey/vault --to mysql --to-mysql-dsn "user:pass@tcp(localhost:3306)/vault"`,
+	Flags: append(append([]cli.Flag{
+		cli.String("prefix", "Only copy objects whose key starts with the prefix. Default: all objects"),
+	}, storageFlags("from")...), storageFlags("to")...),
+	Action: migrate,
+}
+
+// storageFlags returns the flags configuring the source or destination.
+func storageFlags(side string) []cli.Flag {
+	return []cli.Flag{
+		cli.String(side, fmt.Sprintf("Storage to copy %s: s3, filesystem or mysql", side), cli.Required()),
+		cli.String(side+"-s3-url", "S3 compatible endpoint URL"),
+		cli.String(side+"-s3-bucket", "S3 bucket name"),
+		cli.String(side+"-s3-access-key-id", "S3 access key ID"),
+		cli.String(side+"-s3-access-key-secret", "S3 secret access key"),
+		cli.String(side+"-filesystem-path", "Directory the objects are stored in"),
+		cli.String(side+"-mysql-dsn", "MySQL connection string of the database the objects are stored in"),
+	}
+}
+
+func newStorage(cmd *cli.Command, side string, logger logging.Logger) (storage.Storage, error) {
+	switch kind := cmd.String(side); kind {
+	case "s3":
+		for _, flag := range []string{"-s3-url", "-s3-bucket", "-s3-access-key-id", "-s3-access-key-secret"} {
+			if cmd.String(side+flag) == "" {
+				return nil, fmt.Errorf("--%s%s is required", side, flag)
+			}
+		}
+		return storage.NewS3(storage.S3Config{
+			S3URL:             cmd.String(side + "-s3-url"),
+			S3Bucket:          cmd.String(side + "-s3-bucket"),
+			S3AccessKeyID:     cmd.String(side + "-s3-access-key-id"),
+			S3AccessKeySecret: cmd.String(side + "-s3-access-key-secret"),
+			Logger:            logger,
+		})
+	case "filesystem":
+		path := cmd.String(side + "-filesystem-path")
+		if path == "" {
+			return nil, fmt.Errorf("--%s-filesystem-path is required", side)
+		}
+		return storage.NewFilesystem(storage.FilesystemConfig{
+			Path:   path,
+			Logger: logger,
+		})
+	case "mysql":
+		dsn := cmd.String(side + "-mysql-dsn")
+		if dsn == "" {
+			return nil, fmt.Errorf("--%s-mysql-dsn is required", side)
+		}
+		return storage.NewMySQL(storage.MySQLConfig{
+			DSN:    dsn,
+			Logger: logger,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage %q for --%s, use s3, filesystem or mysql", kind, side)
+	}
+}
+
+func migrate(ctx context.Context, cmd *cli.Command) error {
+	logger := logging.New()
+
+	from, err := newStorage(cmd, "from", logger)
+	if err != nil {
+		return cli.Exit("Failed to create source storage: "+err.Error(), 1)
+	}
+
+	to, err := newStorage(cmd, "to", logger)
+	if err != nil {
+		return cli.Exit("Failed to create destination storage: "+err.Error(), 1)
+	}
+
+	copied, err := storage.Copy(ctx, from, to, cmd.String("prefix"))
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Migration failed after copying %d objects: %s", copied, err.Error()), 1)
+	}
+
+	logger.Info("vault migration completed", "copied", copied)
+	return nil
+}
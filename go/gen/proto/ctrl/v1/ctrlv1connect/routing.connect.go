@@ -42,15 +42,19 @@ const (
 	RoutingServiceListRoutesProcedure = "/ctrl.v1.RoutingService/ListRoutes"
 	// RoutingServiceRollbackProcedure is the fully-qualified name of the RoutingService's Rollback RPC.
 	RoutingServiceRollbackProcedure = "/ctrl.v1.RoutingService/Rollback"
+	// RoutingServiceSetRatelimitProcedure is the fully-qualified name of the RoutingService's
+	// SetRatelimit RPC.
+	RoutingServiceSetRatelimitProcedure = "/ctrl.v1.RoutingService/SetRatelimit"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	routingServiceServiceDescriptor          = v1.File_proto_ctrl_v1_routing_proto.Services().ByName("RoutingService")
-	routingServiceSetRouteMethodDescriptor   = routingServiceServiceDescriptor.Methods().ByName("SetRoute")
-	routingServiceGetRouteMethodDescriptor   = routingServiceServiceDescriptor.Methods().ByName("GetRoute")
-	routingServiceListRoutesMethodDescriptor = routingServiceServiceDescriptor.Methods().ByName("ListRoutes")
-	routingServiceRollbackMethodDescriptor   = routingServiceServiceDescriptor.Methods().ByName("Rollback")
+	routingServiceServiceDescriptor            = v1.File_proto_ctrl_v1_routing_proto.Services().ByName("RoutingService")
+	routingServiceSetRouteMethodDescriptor     = routingServiceServiceDescriptor.Methods().ByName("SetRoute")
+	routingServiceGetRouteMethodDescriptor     = routingServiceServiceDescriptor.Methods().ByName("GetRoute")
+	routingServiceListRoutesMethodDescriptor   = routingServiceServiceDescriptor.Methods().ByName("ListRoutes")
+	routingServiceRollbackMethodDescriptor     = routingServiceServiceDescriptor.Methods().ByName("Rollback")
+	routingServiceSetRatelimitMethodDescriptor = routingServiceServiceDescriptor.Methods().ByName("SetRatelimit")
 )
 
 // RoutingServiceClient is a client for the ctrl.v1.RoutingService service.
@@ -63,6 +67,8 @@ type RoutingServiceClient interface {
 	ListRoutes(context.Context, *connect.Request[v1.ListRoutesRequest]) (*connect.Response[v1.ListRoutesResponse], error)
 	// Convenience method for rollback (just calls SetRoute internally)
 	Rollback(context.Context, *connect.Request[v1.RollbackRequest]) (*connect.Response[v1.RollbackResponse], error)
+	// Configure the rate limit the gateway enforces for a hostname
+	SetRatelimit(context.Context, *connect.Request[v1.SetRatelimitRequest]) (*connect.Response[v1.SetRatelimitResponse], error)
 }
 
 // NewRoutingServiceClient constructs a client for the ctrl.v1.RoutingService service. By default,
@@ -99,15 +105,22 @@ func NewRoutingServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(routingServiceRollbackMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		setRatelimit: connect.NewClient[v1.SetRatelimitRequest, v1.SetRatelimitResponse](
+			httpClient,
+			baseURL+RoutingServiceSetRatelimitProcedure,
+			connect.WithSchema(routingServiceSetRatelimitMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // routingServiceClient implements RoutingServiceClient.
 type routingServiceClient struct {
-	setRoute   *connect.Client[v1.SetRouteRequest, v1.SetRouteResponse]
-	getRoute   *connect.Client[v1.GetRouteRequest, v1.GetRouteResponse]
-	listRoutes *connect.Client[v1.ListRoutesRequest, v1.ListRoutesResponse]
-	rollback   *connect.Client[v1.RollbackRequest, v1.RollbackResponse]
+	setRoute     *connect.Client[v1.SetRouteRequest, v1.SetRouteResponse]
+	getRoute     *connect.Client[v1.GetRouteRequest, v1.GetRouteResponse]
+	listRoutes   *connect.Client[v1.ListRoutesRequest, v1.ListRoutesResponse]
+	rollback     *connect.Client[v1.RollbackRequest, v1.RollbackResponse]
+	setRatelimit *connect.Client[v1.SetRatelimitRequest, v1.SetRatelimitResponse]
 }
 
 // SetRoute calls ctrl.v1.RoutingService.SetRoute.
@@ -130,6 +143,11 @@ func (c *routingServiceClient) Rollback(ctx context.Context, req *connect.Reques
 	return c.rollback.CallUnary(ctx, req)
 }
 
+// SetRatelimit calls ctrl.v1.RoutingService.SetRatelimit.
+func (c *routingServiceClient) SetRatelimit(ctx context.Context, req *connect.Request[v1.SetRatelimitRequest]) (*connect.Response[v1.SetRatelimitResponse], error) {
+	return c.setRatelimit.CallUnary(ctx, req)
+}
+
 // RoutingServiceHandler is an implementation of the ctrl.v1.RoutingService service.
 type RoutingServiceHandler interface {
 	// Update routing for a hostname
@@ -140,6 +158,8 @@ type RoutingServiceHandler interface {
 	ListRoutes(context.Context, *connect.Request[v1.ListRoutesRequest]) (*connect.Response[v1.ListRoutesResponse], error)
 	// Convenience method for rollback (just calls SetRoute internally)
 	Rollback(context.Context, *connect.Request[v1.RollbackRequest]) (*connect.Response[v1.RollbackResponse], error)
+	// Configure the rate limit the gateway enforces for a hostname
+	SetRatelimit(context.Context, *connect.Request[v1.SetRatelimitRequest]) (*connect.Response[v1.SetRatelimitResponse], error)
 }
 
 // NewRoutingServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -172,6 +192,12 @@ func NewRoutingServiceHandler(svc RoutingServiceHandler, opts ...connect.Handler
 		connect.WithSchema(routingServiceRollbackMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	routingServiceSetRatelimitHandler := connect.NewUnaryHandler(
+		RoutingServiceSetRatelimitProcedure,
+		svc.SetRatelimit,
+		connect.WithSchema(routingServiceSetRatelimitMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/ctrl.v1.RoutingService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RoutingServiceSetRouteProcedure:
@@ -182,6 +208,8 @@ func NewRoutingServiceHandler(svc RoutingServiceHandler, opts ...connect.Handler
 			routingServiceListRoutesHandler.ServeHTTP(w, r)
 		case RoutingServiceRollbackProcedure:
 			routingServiceRollbackHandler.ServeHTTP(w, r)
+		case RoutingServiceSetRatelimitProcedure:
+			routingServiceSetRatelimitHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -206,3 +234,7 @@ func (UnimplementedRoutingServiceHandler) ListRoutes(context.Context, *connect.R
 func (UnimplementedRoutingServiceHandler) Rollback(context.Context, *connect.Request[v1.RollbackRequest]) (*connect.Response[v1.RollbackResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ctrl.v1.RoutingService.Rollback is not implemented"))
 }
+
+func (UnimplementedRoutingServiceHandler) SetRatelimit(context.Context, *connect.Request[v1.SetRatelimitRequest]) (*connect.Response[v1.SetRatelimitResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("ctrl.v1.RoutingService.SetRatelimit is not implemented"))
+}
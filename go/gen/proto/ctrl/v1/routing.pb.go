@@ -22,12 +22,72 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// What requests are rate limited by, defaults to the client IP. Requests
+// without the identifier are limited by their IP.
+type RatelimitIdentifier int32
+
+const (
+	RatelimitIdentifier_RATELIMIT_IDENTIFIER_UNSPECIFIED RatelimitIdentifier = 0
+	RatelimitIdentifier_RATELIMIT_IDENTIFIER_IP          RatelimitIdentifier = 1
+	RatelimitIdentifier_RATELIMIT_IDENTIFIER_KEY_ID      RatelimitIdentifier = 2
+	RatelimitIdentifier_RATELIMIT_IDENTIFIER_IDENTITY    RatelimitIdentifier = 3
+	RatelimitIdentifier_RATELIMIT_IDENTIFIER_HEADER      RatelimitIdentifier = 4
+)
+
+// Enum value maps for RatelimitIdentifier.
+var (
+	RatelimitIdentifier_name = map[int32]string{
+		0: "RATELIMIT_IDENTIFIER_UNSPECIFIED",
+		1: "RATELIMIT_IDENTIFIER_IP",
+		2: "RATELIMIT_IDENTIFIER_KEY_ID",
+		3: "RATELIMIT_IDENTIFIER_IDENTITY",
+		4: "RATELIMIT_IDENTIFIER_HEADER",
+	}
+	RatelimitIdentifier_value = map[string]int32{
+		"RATELIMIT_IDENTIFIER_UNSPECIFIED": 0,
+		"RATELIMIT_IDENTIFIER_IP":          1,
+		"RATELIMIT_IDENTIFIER_KEY_ID":      2,
+		"RATELIMIT_IDENTIFIER_IDENTITY":    3,
+		"RATELIMIT_IDENTIFIER_HEADER":      4,
+	}
+)
+
+func (x RatelimitIdentifier) Enum() *RatelimitIdentifier {
+	p := new(RatelimitIdentifier)
+	*p = x
+	return p
+}
+
+func (x RatelimitIdentifier) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RatelimitIdentifier) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ctrl_v1_routing_proto_enumTypes[0].Descriptor()
+}
+
+func (RatelimitIdentifier) Type() protoreflect.EnumType {
+	return &file_proto_ctrl_v1_routing_proto_enumTypes[0]
+}
+
+func (x RatelimitIdentifier) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RatelimitIdentifier.Descriptor instead.
+func (RatelimitIdentifier) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{0}
+}
+
 type SetRouteRequest struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
 	Hostname  string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
 	VersionId string                 `protobuf:"bytes,2,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
 	// Optional: for blue-green deployments
-	Weight        int32 `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"` // 0-100, defaults to 100 for full cutover
+	Weight int32 `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"` // 0-100, defaults to 100 for full cutover
+	// Optional: keeps clients on one version while traffic is split,
+	// defaults to the current sticky session of the hostname
+	StickySession *StickySession `protobuf:"bytes,4,opt,name=sticky_session,json=stickySession,proto3" json:"sticky_session,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,6 +143,67 @@ func (x *SetRouteRequest) GetWeight() int32 {
 	return 0
 }
 
+func (x *SetRouteRequest) GetStickySession() *StickySession {
+	if x != nil {
+		return x.StickySession
+	}
+	return nil
+}
+
+type StickySession struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cookie the gateway sets to remember the version of a client
+	CookieName string `protobuf:"bytes,1,opt,name=cookie_name,json=cookieName,proto3" json:"cookie_name,omitempty"`
+	// Request header whose value pins a client to a version, e.g. a user id
+	HeaderName    string `protobuf:"bytes,2,opt,name=header_name,json=headerName,proto3" json:"header_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StickySession) Reset() {
+	*x = StickySession{}
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StickySession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StickySession) ProtoMessage() {}
+
+func (x *StickySession) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StickySession.ProtoReflect.Descriptor instead.
+func (*StickySession) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StickySession) GetCookieName() string {
+	if x != nil {
+		return x.CookieName
+	}
+	return ""
+}
+
+func (x *StickySession) GetHeaderName() string {
+	if x != nil {
+		return x.HeaderName
+	}
+	return ""
+}
+
 type SetRouteResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	PreviousVersionId string                 `protobuf:"bytes,1,opt,name=previous_version_id,json=previousVersionId,proto3" json:"previous_version_id,omitempty"` // What was previously active
@@ -93,7 +214,7 @@ type SetRouteResponse struct {
 
 func (x *SetRouteResponse) Reset() {
 	*x = SetRouteResponse{}
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[1]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -105,7 +226,7 @@ func (x *SetRouteResponse) String() string {
 func (*SetRouteResponse) ProtoMessage() {}
 
 func (x *SetRouteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[1]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,7 +239,7 @@ func (x *SetRouteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetRouteResponse.ProtoReflect.Descriptor instead.
 func (*SetRouteResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{1}
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SetRouteResponse) GetPreviousVersionId() string {
@@ -144,7 +265,7 @@ type GetRouteRequest struct {
 
 func (x *GetRouteRequest) Reset() {
 	*x = GetRouteRequest{}
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[2]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -156,7 +277,7 @@ func (x *GetRouteRequest) String() string {
 func (*GetRouteRequest) ProtoMessage() {}
 
 func (x *GetRouteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[2]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -169,7 +290,7 @@ func (x *GetRouteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRouteRequest.ProtoReflect.Descriptor instead.
 func (*GetRouteRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{2}
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetRouteRequest) GetHostname() string {
@@ -188,7 +309,7 @@ type GetRouteResponse struct {
 
 func (x *GetRouteResponse) Reset() {
 	*x = GetRouteResponse{}
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[3]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -200,7 +321,7 @@ func (x *GetRouteResponse) String() string {
 func (*GetRouteResponse) ProtoMessage() {}
 
 func (x *GetRouteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[3]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -213,7 +334,7 @@ func (x *GetRouteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRouteResponse.ProtoReflect.Descriptor instead.
 func (*GetRouteResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{3}
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetRouteResponse) GetRoute() *Route {
@@ -239,7 +360,7 @@ type ListRoutesRequest struct {
 
 func (x *ListRoutesRequest) Reset() {
 	*x = ListRoutesRequest{}
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[4]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -251,7 +372,7 @@ func (x *ListRoutesRequest) String() string {
 func (*ListRoutesRequest) ProtoMessage() {}
 
 func (x *ListRoutesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[4]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -264,7 +385,7 @@ func (x *ListRoutesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRoutesRequest.ProtoReflect.Descriptor instead.
 func (*ListRoutesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{4}
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListRoutesRequest) GetWorkspaceId() string {
@@ -319,7 +440,7 @@ type ListRoutesResponse struct {
 
 func (x *ListRoutesResponse) Reset() {
 	*x = ListRoutesResponse{}
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[5]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -331,7 +452,7 @@ func (x *ListRoutesResponse) String() string {
 func (*ListRoutesResponse) ProtoMessage() {}
 
 func (x *ListRoutesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[5]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -344,7 +465,7 @@ func (x *ListRoutesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRoutesResponse.ProtoReflect.Descriptor instead.
 func (*ListRoutesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{5}
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListRoutesResponse) GetRoutes() []*Route {
@@ -384,7 +505,7 @@ type Route struct {
 
 func (x *Route) Reset() {
 	*x = Route{}
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[6]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -396,7 +517,7 @@ func (x *Route) String() string {
 func (*Route) ProtoMessage() {}
 
 func (x *Route) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[6]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -409,7 +530,7 @@ func (x *Route) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Route.ProtoReflect.Descriptor instead.
 func (*Route) Descriptor() ([]byte, []int) {
-	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{6}
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Route) GetHostname() string {
@@ -507,7 +628,7 @@ type RollbackRequest struct {
 
 func (x *RollbackRequest) Reset() {
 	*x = RollbackRequest{}
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[7]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -519,7 +640,7 @@ func (x *RollbackRequest) String() string {
 func (*RollbackRequest) ProtoMessage() {}
 
 func (x *RollbackRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[7]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -532,7 +653,7 @@ func (x *RollbackRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RollbackRequest.ProtoReflect.Descriptor instead.
 func (*RollbackRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{7}
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RollbackRequest) GetHostname() string {
@@ -560,7 +681,7 @@ type RollbackResponse struct {
 
 func (x *RollbackResponse) Reset() {
 	*x = RollbackResponse{}
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[8]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -572,7 +693,7 @@ func (x *RollbackResponse) String() string {
 func (*RollbackResponse) ProtoMessage() {}
 
 func (x *RollbackResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[8]
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -585,7 +706,7 @@ func (x *RollbackResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RollbackResponse.ProtoReflect.Descriptor instead.
 func (*RollbackResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{8}
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RollbackResponse) GetPreviousVersionId() string {
@@ -609,16 +730,191 @@ func (x *RollbackResponse) GetEffectiveAt() *timestamppb.Timestamp {
 	return nil
 }
 
+type SetRatelimitRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Hostname string                 `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// Rate limit enforced by the gateway on every request to the hostname,
+	// leaving it empty turns rate limiting off
+	Ratelimit     *Ratelimit `protobuf:"bytes,2,opt,name=ratelimit,proto3" json:"ratelimit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRatelimitRequest) Reset() {
+	*x = SetRatelimitRequest{}
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRatelimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRatelimitRequest) ProtoMessage() {}
+
+func (x *SetRatelimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRatelimitRequest.ProtoReflect.Descriptor instead.
+func (*SetRatelimitRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetRatelimitRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *SetRatelimitRequest) GetRatelimit() *Ratelimit {
+	if x != nil {
+		return x.Ratelimit
+	}
+	return nil
+}
+
+type Ratelimit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Requests allowed per window
+	Limit int64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Window duration in milliseconds
+	Duration   int64               `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	Identifier RatelimitIdentifier `protobuf:"varint,3,opt,name=identifier,proto3,enum=ctrl.v1.RatelimitIdentifier" json:"identifier,omitempty"`
+	// Header to read the identifier from, required for the header identifier
+	IdentifierHeader string `protobuf:"bytes,4,opt,name=identifier_header,json=identifierHeader,proto3" json:"identifier_header,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Ratelimit) Reset() {
+	*x = Ratelimit{}
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ratelimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ratelimit) ProtoMessage() {}
+
+func (x *Ratelimit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ratelimit.ProtoReflect.Descriptor instead.
+func (*Ratelimit) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Ratelimit) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Ratelimit) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Ratelimit) GetIdentifier() RatelimitIdentifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return RatelimitIdentifier_RATELIMIT_IDENTIFIER_UNSPECIFIED
+}
+
+func (x *Ratelimit) GetIdentifierHeader() string {
+	if x != nil {
+		return x.IdentifierHeader
+	}
+	return ""
+}
+
+type SetRatelimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EffectiveAt   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=effective_at,json=effectiveAt,proto3" json:"effective_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRatelimitResponse) Reset() {
+	*x = SetRatelimitResponse{}
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRatelimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRatelimitResponse) ProtoMessage() {}
+
+func (x *SetRatelimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_routing_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRatelimitResponse.ProtoReflect.Descriptor instead.
+func (*SetRatelimitResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_routing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetRatelimitResponse) GetEffectiveAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveAt
+	}
+	return nil
+}
+
 var File_proto_ctrl_v1_routing_proto protoreflect.FileDescriptor
 
 const file_proto_ctrl_v1_routing_proto_rawDesc = "" +
 	"\n" +
-	"\x1bproto/ctrl/v1/routing.proto\x12\actrl.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"d\n" +
+	"\x1bproto/ctrl/v1/routing.proto\x12\actrl.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa3\x01\n" +
 	"\x0fSetRouteRequest\x12\x1a\n" +
 	"\bhostname\x18\x01 \x01(\tR\bhostname\x12\x1d\n" +
 	"\n" +
 	"version_id\x18\x02 \x01(\tR\tversionId\x12\x16\n" +
-	"\x06weight\x18\x03 \x01(\x05R\x06weight\"\x81\x01\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\x12=\n" +
+	"\x0esticky_session\x18\x04 \x01(\v2\x16.ctrl.v1.StickySessionR\rstickySession\"Q\n" +
+	"\rStickySession\x12\x1f\n" +
+	"\vcookie_name\x18\x01 \x01(\tR\n" +
+	"cookieName\x12\x1f\n" +
+	"\vheader_name\x18\x02 \x01(\tR\n" +
+	"headerName\"\x81\x01\n" +
 	"\x10SetRouteResponse\x12.\n" +
 	"\x13previous_version_id\x18\x01 \x01(\tR\x11previousVersionId\x12=\n" +
 	"\feffective_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\veffectiveAt\"-\n" +
@@ -664,13 +960,32 @@ const file_proto_ctrl_v1_routing_proto_rawDesc = "" +
 	"\x10RollbackResponse\x12.\n" +
 	"\x13previous_version_id\x18\x01 \x01(\tR\x11previousVersionId\x12$\n" +
 	"\x0enew_version_id\x18\x02 \x01(\tR\fnewVersionId\x12=\n" +
-	"\feffective_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\veffectiveAt2\xa2\x02\n" +
+	"\feffective_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\veffectiveAt\"c\n" +
+	"\x13SetRatelimitRequest\x12\x1a\n" +
+	"\bhostname\x18\x01 \x01(\tR\bhostname\x120\n" +
+	"\tratelimit\x18\x02 \x01(\v2\x12.ctrl.v1.RatelimitR\tratelimit\"\xa8\x01\n" +
+	"\tRatelimit\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x03R\x05limit\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x03R\bduration\x12<\n" +
+	"\n" +
+	"identifier\x18\x03 \x01(\x0e2\x1c.ctrl.v1.RatelimitIdentifierR\n" +
+	"identifier\x12+\n" +
+	"\x11identifier_header\x18\x04 \x01(\tR\x10identifierHeader\"U\n" +
+	"\x14SetRatelimitResponse\x12=\n" +
+	"\feffective_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\veffectiveAt*\xbd\x01\n" +
+	"\x13RatelimitIdentifier\x12$\n" +
+	" RATELIMIT_IDENTIFIER_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17RATELIMIT_IDENTIFIER_IP\x10\x01\x12\x1f\n" +
+	"\x1bRATELIMIT_IDENTIFIER_KEY_ID\x10\x02\x12!\n" +
+	"\x1dRATELIMIT_IDENTIFIER_IDENTITY\x10\x03\x12\x1f\n" +
+	"\x1bRATELIMIT_IDENTIFIER_HEADER\x10\x042\xf1\x02\n" +
 	"\x0eRoutingService\x12A\n" +
 	"\bSetRoute\x12\x18.ctrl.v1.SetRouteRequest\x1a\x19.ctrl.v1.SetRouteResponse\"\x00\x12A\n" +
 	"\bGetRoute\x12\x18.ctrl.v1.GetRouteRequest\x1a\x19.ctrl.v1.GetRouteResponse\"\x00\x12G\n" +
 	"\n" +
 	"ListRoutes\x12\x1a.ctrl.v1.ListRoutesRequest\x1a\x1b.ctrl.v1.ListRoutesResponse\"\x00\x12A\n" +
-	"\bRollback\x12\x18.ctrl.v1.RollbackRequest\x1a\x19.ctrl.v1.RollbackResponse\"\x00B6Z4github.com/unkeyed/unkey/go/gen/proto/ctrl/v1;ctrlv1b\x06proto3"
+	"\bRollback\x12\x18.ctrl.v1.RollbackRequest\x1a\x19.ctrl.v1.RollbackResponse\"\x00\x12M\n" +
+	"\fSetRatelimit\x12\x1c.ctrl.v1.SetRatelimitRequest\x1a\x1d.ctrl.v1.SetRatelimitResponse\"\x00B6Z4github.com/unkeyed/unkey/go/gen/proto/ctrl/v1;ctrlv1b\x06proto3"
 
 var (
 	file_proto_ctrl_v1_routing_proto_rawDescOnce sync.Once
@@ -684,40 +999,52 @@ func file_proto_ctrl_v1_routing_proto_rawDescGZIP() []byte {
 	return file_proto_ctrl_v1_routing_proto_rawDescData
 }
 
-var file_proto_ctrl_v1_routing_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_ctrl_v1_routing_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_ctrl_v1_routing_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_proto_ctrl_v1_routing_proto_goTypes = []any{
-	(*SetRouteRequest)(nil),       // 0: ctrl.v1.SetRouteRequest
-	(*SetRouteResponse)(nil),      // 1: ctrl.v1.SetRouteResponse
-	(*GetRouteRequest)(nil),       // 2: ctrl.v1.GetRouteRequest
-	(*GetRouteResponse)(nil),      // 3: ctrl.v1.GetRouteResponse
-	(*ListRoutesRequest)(nil),     // 4: ctrl.v1.ListRoutesRequest
-	(*ListRoutesResponse)(nil),    // 5: ctrl.v1.ListRoutesResponse
-	(*Route)(nil),                 // 6: ctrl.v1.Route
-	(*RollbackRequest)(nil),       // 7: ctrl.v1.RollbackRequest
-	(*RollbackResponse)(nil),      // 8: ctrl.v1.RollbackResponse
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(RatelimitIdentifier)(0),      // 0: ctrl.v1.RatelimitIdentifier
+	(*SetRouteRequest)(nil),       // 1: ctrl.v1.SetRouteRequest
+	(*StickySession)(nil),         // 2: ctrl.v1.StickySession
+	(*SetRouteResponse)(nil),      // 3: ctrl.v1.SetRouteResponse
+	(*GetRouteRequest)(nil),       // 4: ctrl.v1.GetRouteRequest
+	(*GetRouteResponse)(nil),      // 5: ctrl.v1.GetRouteResponse
+	(*ListRoutesRequest)(nil),     // 6: ctrl.v1.ListRoutesRequest
+	(*ListRoutesResponse)(nil),    // 7: ctrl.v1.ListRoutesResponse
+	(*Route)(nil),                 // 8: ctrl.v1.Route
+	(*RollbackRequest)(nil),       // 9: ctrl.v1.RollbackRequest
+	(*RollbackResponse)(nil),      // 10: ctrl.v1.RollbackResponse
+	(*SetRatelimitRequest)(nil),   // 11: ctrl.v1.SetRatelimitRequest
+	(*Ratelimit)(nil),             // 12: ctrl.v1.Ratelimit
+	(*SetRatelimitResponse)(nil),  // 13: ctrl.v1.SetRatelimitResponse
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
 }
 var file_proto_ctrl_v1_routing_proto_depIdxs = []int32{
-	9,  // 0: ctrl.v1.SetRouteResponse.effective_at:type_name -> google.protobuf.Timestamp
-	6,  // 1: ctrl.v1.GetRouteResponse.route:type_name -> ctrl.v1.Route
-	6,  // 2: ctrl.v1.ListRoutesResponse.routes:type_name -> ctrl.v1.Route
-	9,  // 3: ctrl.v1.Route.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 4: ctrl.v1.Route.updated_at:type_name -> google.protobuf.Timestamp
-	9,  // 5: ctrl.v1.Route.certificate_expires_at:type_name -> google.protobuf.Timestamp
-	9,  // 6: ctrl.v1.RollbackResponse.effective_at:type_name -> google.protobuf.Timestamp
-	0,  // 7: ctrl.v1.RoutingService.SetRoute:input_type -> ctrl.v1.SetRouteRequest
-	2,  // 8: ctrl.v1.RoutingService.GetRoute:input_type -> ctrl.v1.GetRouteRequest
-	4,  // 9: ctrl.v1.RoutingService.ListRoutes:input_type -> ctrl.v1.ListRoutesRequest
-	7,  // 10: ctrl.v1.RoutingService.Rollback:input_type -> ctrl.v1.RollbackRequest
-	1,  // 11: ctrl.v1.RoutingService.SetRoute:output_type -> ctrl.v1.SetRouteResponse
-	3,  // 12: ctrl.v1.RoutingService.GetRoute:output_type -> ctrl.v1.GetRouteResponse
-	5,  // 13: ctrl.v1.RoutingService.ListRoutes:output_type -> ctrl.v1.ListRoutesResponse
-	8,  // 14: ctrl.v1.RoutingService.Rollback:output_type -> ctrl.v1.RollbackResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	2,  // 0: ctrl.v1.SetRouteRequest.sticky_session:type_name -> ctrl.v1.StickySession
+	14, // 1: ctrl.v1.SetRouteResponse.effective_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: ctrl.v1.GetRouteResponse.route:type_name -> ctrl.v1.Route
+	8,  // 3: ctrl.v1.ListRoutesResponse.routes:type_name -> ctrl.v1.Route
+	14, // 4: ctrl.v1.Route.created_at:type_name -> google.protobuf.Timestamp
+	14, // 5: ctrl.v1.Route.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 6: ctrl.v1.Route.certificate_expires_at:type_name -> google.protobuf.Timestamp
+	14, // 7: ctrl.v1.RollbackResponse.effective_at:type_name -> google.protobuf.Timestamp
+	12, // 8: ctrl.v1.SetRatelimitRequest.ratelimit:type_name -> ctrl.v1.Ratelimit
+	0,  // 9: ctrl.v1.Ratelimit.identifier:type_name -> ctrl.v1.RatelimitIdentifier
+	14, // 10: ctrl.v1.SetRatelimitResponse.effective_at:type_name -> google.protobuf.Timestamp
+	1,  // 11: ctrl.v1.RoutingService.SetRoute:input_type -> ctrl.v1.SetRouteRequest
+	4,  // 12: ctrl.v1.RoutingService.GetRoute:input_type -> ctrl.v1.GetRouteRequest
+	6,  // 13: ctrl.v1.RoutingService.ListRoutes:input_type -> ctrl.v1.ListRoutesRequest
+	9,  // 14: ctrl.v1.RoutingService.Rollback:input_type -> ctrl.v1.RollbackRequest
+	11, // 15: ctrl.v1.RoutingService.SetRatelimit:input_type -> ctrl.v1.SetRatelimitRequest
+	3,  // 16: ctrl.v1.RoutingService.SetRoute:output_type -> ctrl.v1.SetRouteResponse
+	5,  // 17: ctrl.v1.RoutingService.GetRoute:output_type -> ctrl.v1.GetRouteResponse
+	7,  // 18: ctrl.v1.RoutingService.ListRoutes:output_type -> ctrl.v1.ListRoutesResponse
+	10, // 19: ctrl.v1.RoutingService.Rollback:output_type -> ctrl.v1.RollbackResponse
+	13, // 20: ctrl.v1.RoutingService.SetRatelimit:output_type -> ctrl.v1.SetRatelimitResponse
+	16, // [16:21] is the sub-list for method output_type
+	11, // [11:16] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_proto_ctrl_v1_routing_proto_init() }
@@ -730,13 +1057,14 @@ func file_proto_ctrl_v1_routing_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_ctrl_v1_routing_proto_rawDesc), len(file_proto_ctrl_v1_routing_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   9,
+			NumEnums:      1,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_ctrl_v1_routing_proto_goTypes,
 		DependencyIndexes: file_proto_ctrl_v1_routing_proto_depIdxs,
+		EnumInfos:         file_proto_ctrl_v1_routing_proto_enumTypes,
 		MessageInfos:      file_proto_ctrl_v1_routing_proto_msgTypes,
 	}.Build()
 	File_proto_ctrl_v1_routing_proto = out.File
@@ -0,0 +1,1057 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: proto/ctrl/v1/workflow.proto
+
+package ctrlv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Workflow execution status enum
+type WorkflowStatus int32
+
+const (
+	WorkflowStatus_WORKFLOW_STATUS_UNSPECIFIED WorkflowStatus = 0
+	WorkflowStatus_WORKFLOW_STATUS_PENDING     WorkflowStatus = 1
+	WorkflowStatus_WORKFLOW_STATUS_RUNNING     WorkflowStatus = 2
+	WorkflowStatus_WORKFLOW_STATUS_SLEEPING    WorkflowStatus = 3
+	WorkflowStatus_WORKFLOW_STATUS_COMPLETED   WorkflowStatus = 4
+	WorkflowStatus_WORKFLOW_STATUS_FAILED      WorkflowStatus = 5
+	WorkflowStatus_WORKFLOW_STATUS_CANCELLED   WorkflowStatus = 6
+	WorkflowStatus_WORKFLOW_STATUS_TERMINATED  WorkflowStatus = 7
+)
+
+// Enum value maps for WorkflowStatus.
+var (
+	WorkflowStatus_name = map[int32]string{
+		0: "WORKFLOW_STATUS_UNSPECIFIED",
+		1: "WORKFLOW_STATUS_PENDING",
+		2: "WORKFLOW_STATUS_RUNNING",
+		3: "WORKFLOW_STATUS_SLEEPING",
+		4: "WORKFLOW_STATUS_COMPLETED",
+		5: "WORKFLOW_STATUS_FAILED",
+		6: "WORKFLOW_STATUS_CANCELLED",
+		7: "WORKFLOW_STATUS_TERMINATED",
+	}
+	WorkflowStatus_value = map[string]int32{
+		"WORKFLOW_STATUS_UNSPECIFIED": 0,
+		"WORKFLOW_STATUS_PENDING":     1,
+		"WORKFLOW_STATUS_RUNNING":     2,
+		"WORKFLOW_STATUS_SLEEPING":    3,
+		"WORKFLOW_STATUS_COMPLETED":   4,
+		"WORKFLOW_STATUS_FAILED":      5,
+		"WORKFLOW_STATUS_CANCELLED":   6,
+		"WORKFLOW_STATUS_TERMINATED":  7,
+	}
+)
+
+func (x WorkflowStatus) Enum() *WorkflowStatus {
+	p := new(WorkflowStatus)
+	*p = x
+	return p
+}
+
+func (x WorkflowStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkflowStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ctrl_v1_workflow_proto_enumTypes[0].Descriptor()
+}
+
+func (WorkflowStatus) Type() protoreflect.EnumType {
+	return &file_proto_ctrl_v1_workflow_proto_enumTypes[0]
+}
+
+func (x WorkflowStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkflowStatus.Descriptor instead.
+func (WorkflowStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{0}
+}
+
+type Workflow struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId  string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	WorkflowName string                 `protobuf:"bytes,2,opt,name=workflow_name,json=workflowName,proto3" json:"workflow_name,omitempty"`
+	Status       WorkflowStatus         `protobuf:"varint,3,opt,name=status,proto3,enum=ctrl.v1.WorkflowStatus" json:"status,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// Retries
+	MaxAttempts       int32 `protobuf:"varint,5,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	RemainingAttempts int32 `protobuf:"varint,6,opt,name=remaining_attempts,json=remainingAttempts,proto3" json:"remaining_attempts,omitempty"`
+	// Set for child workflows
+	ParentExecutionId string `protobuf:"bytes,7,opt,name=parent_execution_id,json=parentExecutionId,proto3" json:"parent_execution_id,omitempty"`
+	// Timestamps, Unix epoch milliseconds or 0 if not set
+	CreatedAt         int64 `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt         int64 `protobuf:"varint,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt       int64 `protobuf:"varint,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	NextRetryAt       int64 `protobuf:"varint,11,opt,name=next_retry_at,json=nextRetryAt,proto3" json:"next_retry_at,omitempty"`
+	SleepUntil        int64 `protobuf:"varint,12,opt,name=sleep_until,json=sleepUntil,proto3" json:"sleep_until,omitempty"`
+	CancelRequestedAt int64 `protobuf:"varint,13,opt,name=cancel_requested_at,json=cancelRequestedAt,proto3" json:"cancel_requested_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Workflow) Reset() {
+	*x = Workflow{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workflow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workflow) ProtoMessage() {}
+
+func (x *Workflow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workflow.ProtoReflect.Descriptor instead.
+func (*Workflow) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Workflow) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *Workflow) GetWorkflowName() string {
+	if x != nil {
+		return x.WorkflowName
+	}
+	return ""
+}
+
+func (x *Workflow) GetStatus() WorkflowStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WorkflowStatus_WORKFLOW_STATUS_UNSPECIFIED
+}
+
+func (x *Workflow) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Workflow) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Workflow) GetRemainingAttempts() int32 {
+	if x != nil {
+		return x.RemainingAttempts
+	}
+	return 0
+}
+
+func (x *Workflow) GetParentExecutionId() string {
+	if x != nil {
+		return x.ParentExecutionId
+	}
+	return ""
+}
+
+func (x *Workflow) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Workflow) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Workflow) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+func (x *Workflow) GetNextRetryAt() int64 {
+	if x != nil {
+		return x.NextRetryAt
+	}
+	return 0
+}
+
+func (x *Workflow) GetSleepUntil() int64 {
+	if x != nil {
+		return x.SleepUntil
+	}
+	return 0
+}
+
+func (x *Workflow) GetCancelRequestedAt() int64 {
+	if x != nil {
+		return x.CancelRequestedAt
+	}
+	return 0
+}
+
+type WorkflowStep struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status            string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // pending, running, completed or failed
+	ErrorMessage      string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	RemainingAttempts int32                  `protobuf:"varint,4,opt,name=remaining_attempts,json=remainingAttempts,proto3" json:"remaining_attempts,omitempty"`
+	// Timestamps, Unix epoch milliseconds or 0 if not set
+	StartedAt     int64 `protobuf:"varint,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   int64 `protobuf:"varint,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowStep) Reset() {
+	*x = WorkflowStep{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowStep) ProtoMessage() {}
+
+func (x *WorkflowStep) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowStep.ProtoReflect.Descriptor instead.
+func (*WorkflowStep) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WorkflowStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowStep) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WorkflowStep) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *WorkflowStep) GetRemainingAttempts() int32 {
+	if x != nil {
+		return x.RemainingAttempts
+	}
+	return 0
+}
+
+func (x *WorkflowStep) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *WorkflowStep) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+type GetWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkflowRequest) Reset() {
+	*x = GetWorkflowRequest{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkflowRequest) ProtoMessage() {}
+
+func (x *GetWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetWorkflowRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+type GetWorkflowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workflow      *Workflow              `protobuf:"bytes,1,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkflowResponse) Reset() {
+	*x = GetWorkflowResponse{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkflowResponse) ProtoMessage() {}
+
+func (x *GetWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*GetWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetWorkflowResponse) GetWorkflow() *Workflow {
+	if x != nil {
+		return x.Workflow
+	}
+	return nil
+}
+
+type ListWorkflowsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional filters
+	Status       WorkflowStatus `protobuf:"varint,1,opt,name=status,proto3,enum=ctrl.v1.WorkflowStatus" json:"status,omitempty"`
+	WorkflowName string         `protobuf:"bytes,2,opt,name=workflow_name,json=workflowName,proto3" json:"workflow_name,omitempty"`
+	// Pagination
+	PageSize      int32  `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // defaults to 100, at most 1000
+	PageToken     string `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowsRequest) Reset() {
+	*x = ListWorkflowsRequest{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowsRequest) ProtoMessage() {}
+
+func (x *ListWorkflowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowsRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkflowsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListWorkflowsRequest) GetStatus() WorkflowStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WorkflowStatus_WORKFLOW_STATUS_UNSPECIFIED
+}
+
+func (x *ListWorkflowsRequest) GetWorkflowName() string {
+	if x != nil {
+		return x.WorkflowName
+	}
+	return ""
+}
+
+func (x *ListWorkflowsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListWorkflowsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListWorkflowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workflows     []*Workflow            `protobuf:"bytes,1,rep,name=workflows,proto3" json:"workflows,omitempty"` // newest first
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowsResponse) Reset() {
+	*x = ListWorkflowsResponse{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowsResponse) ProtoMessage() {}
+
+func (x *ListWorkflowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowsResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkflowsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListWorkflowsResponse) GetWorkflows() []*Workflow {
+	if x != nil {
+		return x.Workflows
+	}
+	return nil
+}
+
+func (x *ListWorkflowsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListWorkflowStepsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowStepsRequest) Reset() {
+	*x = ListWorkflowStepsRequest{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowStepsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowStepsRequest) ProtoMessage() {}
+
+func (x *ListWorkflowStepsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowStepsRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkflowStepsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListWorkflowStepsRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+type ListWorkflowStepsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Steps         []*WorkflowStep        `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowStepsResponse) Reset() {
+	*x = ListWorkflowStepsResponse{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowStepsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowStepsResponse) ProtoMessage() {}
+
+func (x *ListWorkflowStepsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowStepsResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkflowStepsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListWorkflowStepsResponse) GetSteps() []*WorkflowStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type CancelWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelWorkflowRequest) Reset() {
+	*x = CancelWorkflowRequest{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelWorkflowRequest) ProtoMessage() {}
+
+func (x *CancelWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*CancelWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelWorkflowRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *CancelWorkflowRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelWorkflowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workflow      *Workflow              `protobuf:"bytes,1,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelWorkflowResponse) Reset() {
+	*x = CancelWorkflowResponse{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelWorkflowResponse) ProtoMessage() {}
+
+func (x *CancelWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*CancelWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelWorkflowResponse) GetWorkflow() *Workflow {
+	if x != nil {
+		return x.Workflow
+	}
+	return nil
+}
+
+type TerminateWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerminateWorkflowRequest) Reset() {
+	*x = TerminateWorkflowRequest{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateWorkflowRequest) ProtoMessage() {}
+
+func (x *TerminateWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*TerminateWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TerminateWorkflowRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *TerminateWorkflowRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type TerminateWorkflowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workflow      *Workflow              `protobuf:"bytes,1,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerminateWorkflowResponse) Reset() {
+	*x = TerminateWorkflowResponse{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateWorkflowResponse) ProtoMessage() {}
+
+func (x *TerminateWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*TerminateWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TerminateWorkflowResponse) GetWorkflow() *Workflow {
+	if x != nil {
+		return x.Workflow
+	}
+	return nil
+}
+
+type RetryWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExecutionId   string                 `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryWorkflowRequest) Reset() {
+	*x = RetryWorkflowRequest{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryWorkflowRequest) ProtoMessage() {}
+
+func (x *RetryWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*RetryWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RetryWorkflowRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+type RetryWorkflowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workflow      *Workflow              `protobuf:"bytes,1,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryWorkflowResponse) Reset() {
+	*x = RetryWorkflowResponse{}
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryWorkflowResponse) ProtoMessage() {}
+
+func (x *RetryWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ctrl_v1_workflow_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*RetryWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ctrl_v1_workflow_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RetryWorkflowResponse) GetWorkflow() *Workflow {
+	if x != nil {
+		return x.Workflow
+	}
+	return nil
+}
+
+var File_proto_ctrl_v1_workflow_proto protoreflect.FileDescriptor
+
+const file_proto_ctrl_v1_workflow_proto_rawDesc = "" +
+	"\n" +
+	"\x1cproto/ctrl/v1/workflow.proto\x12\actrl.v1\"\x80\x04\n" +
+	"\bWorkflow\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\x12#\n" +
+	"\rworkflow_name\x18\x02 \x01(\tR\fworkflowName\x12/\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x17.ctrl.v1.WorkflowStatusR\x06status\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12!\n" +
+	"\fmax_attempts\x18\x05 \x01(\x05R\vmaxAttempts\x12-\n" +
+	"\x12remaining_attempts\x18\x06 \x01(\x05R\x11remainingAttempts\x12.\n" +
+	"\x13parent_execution_id\x18\a \x01(\tR\x11parentExecutionId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\t \x01(\x03R\tstartedAt\x12!\n" +
+	"\fcompleted_at\x18\n" +
+	" \x01(\x03R\vcompletedAt\x12\"\n" +
+	"\rnext_retry_at\x18\v \x01(\x03R\vnextRetryAt\x12\x1f\n" +
+	"\vsleep_until\x18\f \x01(\x03R\n" +
+	"sleepUntil\x12.\n" +
+	"\x13cancel_requested_at\x18\r \x01(\x03R\x11cancelRequestedAt\"\xd0\x01\n" +
+	"\fWorkflowStep\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12-\n" +
+	"\x12remaining_attempts\x18\x04 \x01(\x05R\x11remainingAttempts\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\x03R\tstartedAt\x12!\n" +
+	"\fcompleted_at\x18\x06 \x01(\x03R\vcompletedAt\"7\n" +
+	"\x12GetWorkflowRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\"D\n" +
+	"\x13GetWorkflowResponse\x12-\n" +
+	"\bworkflow\x18\x01 \x01(\v2\x11.ctrl.v1.WorkflowR\bworkflow\"\xa8\x01\n" +
+	"\x14ListWorkflowsRequest\x12/\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x17.ctrl.v1.WorkflowStatusR\x06status\x12#\n" +
+	"\rworkflow_name\x18\x02 \x01(\tR\fworkflowName\x12\x1b\n" +
+	"\tpage_size\x18\n" +
+	" \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageToken\"p\n" +
+	"\x15ListWorkflowsResponse\x12/\n" +
+	"\tworkflows\x18\x01 \x03(\v2\x11.ctrl.v1.WorkflowR\tworkflows\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"=\n" +
+	"\x18ListWorkflowStepsRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\"H\n" +
+	"\x19ListWorkflowStepsResponse\x12+\n" +
+	"\x05steps\x18\x01 \x03(\v2\x15.ctrl.v1.WorkflowStepR\x05steps\"R\n" +
+	"\x15CancelWorkflowRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"G\n" +
+	"\x16CancelWorkflowResponse\x12-\n" +
+	"\bworkflow\x18\x01 \x01(\v2\x11.ctrl.v1.WorkflowR\bworkflow\"U\n" +
+	"\x18TerminateWorkflowRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"J\n" +
+	"\x19TerminateWorkflowResponse\x12-\n" +
+	"\bworkflow\x18\x01 \x01(\v2\x11.ctrl.v1.WorkflowR\bworkflow\"9\n" +
+	"\x14RetryWorkflowRequest\x12!\n" +
+	"\fexecution_id\x18\x01 \x01(\tR\vexecutionId\"F\n" +
+	"\x15RetryWorkflowResponse\x12-\n" +
+	"\bworkflow\x18\x01 \x01(\v2\x11.ctrl.v1.WorkflowR\bworkflow*\x83\x02\n" +
+	"\x0eWorkflowStatus\x12\x1f\n" +
+	"\x1bWORKFLOW_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17WORKFLOW_STATUS_PENDING\x10\x01\x12\x1b\n" +
+	"\x17WORKFLOW_STATUS_RUNNING\x10\x02\x12\x1c\n" +
+	"\x18WORKFLOW_STATUS_SLEEPING\x10\x03\x12\x1d\n" +
+	"\x19WORKFLOW_STATUS_COMPLETED\x10\x04\x12\x1a\n" +
+	"\x16WORKFLOW_STATUS_FAILED\x10\x05\x12\x1d\n" +
+	"\x19WORKFLOW_STATUS_CANCELLED\x10\x06\x12\x1e\n" +
+	"\x1aWORKFLOW_STATUS_TERMINATED\x10\a2\x92\x04\n" +
+	"\x0fWorkflowService\x12J\n" +
+	"\vGetWorkflow\x12\x1b.ctrl.v1.GetWorkflowRequest\x1a\x1c.ctrl.v1.GetWorkflowResponse\"\x00\x12P\n" +
+	"\rListWorkflows\x12\x1d.ctrl.v1.ListWorkflowsRequest\x1a\x1e.ctrl.v1.ListWorkflowsResponse\"\x00\x12\\\n" +
+	"\x11ListWorkflowSteps\x12!.ctrl.v1.ListWorkflowStepsRequest\x1a\".ctrl.v1.ListWorkflowStepsResponse\"\x00\x12S\n" +
+	"\x0eCancelWorkflow\x12\x1e.ctrl.v1.CancelWorkflowRequest\x1a\x1f.ctrl.v1.CancelWorkflowResponse\"\x00\x12\\\n" +
+	"\x11TerminateWorkflow\x12!.ctrl.v1.TerminateWorkflowRequest\x1a\".ctrl.v1.TerminateWorkflowResponse\"\x00\x12P\n" +
+	"\rRetryWorkflow\x12\x1d.ctrl.v1.RetryWorkflowRequest\x1a\x1e.ctrl.v1.RetryWorkflowResponse\"\x00B6Z4github.com/unkeyed/unkey/go/gen/proto/ctrl/v1;ctrlv1b\x06proto3"
+
+var (
+	file_proto_ctrl_v1_workflow_proto_rawDescOnce sync.Once
+	file_proto_ctrl_v1_workflow_proto_rawDescData []byte
+)
+
+func file_proto_ctrl_v1_workflow_proto_rawDescGZIP() []byte {
+	file_proto_ctrl_v1_workflow_proto_rawDescOnce.Do(func() {
+		file_proto_ctrl_v1_workflow_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_ctrl_v1_workflow_proto_rawDesc), len(file_proto_ctrl_v1_workflow_proto_rawDesc)))
+	})
+	return file_proto_ctrl_v1_workflow_proto_rawDescData
+}
+
+var file_proto_ctrl_v1_workflow_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_ctrl_v1_workflow_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_ctrl_v1_workflow_proto_goTypes = []any{
+	(WorkflowStatus)(0),               // 0: ctrl.v1.WorkflowStatus
+	(*Workflow)(nil),                  // 1: ctrl.v1.Workflow
+	(*WorkflowStep)(nil),              // 2: ctrl.v1.WorkflowStep
+	(*GetWorkflowRequest)(nil),        // 3: ctrl.v1.GetWorkflowRequest
+	(*GetWorkflowResponse)(nil),       // 4: ctrl.v1.GetWorkflowResponse
+	(*ListWorkflowsRequest)(nil),      // 5: ctrl.v1.ListWorkflowsRequest
+	(*ListWorkflowsResponse)(nil),     // 6: ctrl.v1.ListWorkflowsResponse
+	(*ListWorkflowStepsRequest)(nil),  // 7: ctrl.v1.ListWorkflowStepsRequest
+	(*ListWorkflowStepsResponse)(nil), // 8: ctrl.v1.ListWorkflowStepsResponse
+	(*CancelWorkflowRequest)(nil),     // 9: ctrl.v1.CancelWorkflowRequest
+	(*CancelWorkflowResponse)(nil),    // 10: ctrl.v1.CancelWorkflowResponse
+	(*TerminateWorkflowRequest)(nil),  // 11: ctrl.v1.TerminateWorkflowRequest
+	(*TerminateWorkflowResponse)(nil), // 12: ctrl.v1.TerminateWorkflowResponse
+	(*RetryWorkflowRequest)(nil),      // 13: ctrl.v1.RetryWorkflowRequest
+	(*RetryWorkflowResponse)(nil),     // 14: ctrl.v1.RetryWorkflowResponse
+}
+var file_proto_ctrl_v1_workflow_proto_depIdxs = []int32{
+	0,  // 0: ctrl.v1.Workflow.status:type_name -> ctrl.v1.WorkflowStatus
+	1,  // 1: ctrl.v1.GetWorkflowResponse.workflow:type_name -> ctrl.v1.Workflow
+	0,  // 2: ctrl.v1.ListWorkflowsRequest.status:type_name -> ctrl.v1.WorkflowStatus
+	1,  // 3: ctrl.v1.ListWorkflowsResponse.workflows:type_name -> ctrl.v1.Workflow
+	2,  // 4: ctrl.v1.ListWorkflowStepsResponse.steps:type_name -> ctrl.v1.WorkflowStep
+	1,  // 5: ctrl.v1.CancelWorkflowResponse.workflow:type_name -> ctrl.v1.Workflow
+	1,  // 6: ctrl.v1.TerminateWorkflowResponse.workflow:type_name -> ctrl.v1.Workflow
+	1,  // 7: ctrl.v1.RetryWorkflowResponse.workflow:type_name -> ctrl.v1.Workflow
+	3,  // 8: ctrl.v1.WorkflowService.GetWorkflow:input_type -> ctrl.v1.GetWorkflowRequest
+	5,  // 9: ctrl.v1.WorkflowService.ListWorkflows:input_type -> ctrl.v1.ListWorkflowsRequest
+	7,  // 10: ctrl.v1.WorkflowService.ListWorkflowSteps:input_type -> ctrl.v1.ListWorkflowStepsRequest
+	9,  // 11: ctrl.v1.WorkflowService.CancelWorkflow:input_type -> ctrl.v1.CancelWorkflowRequest
+	11, // 12: ctrl.v1.WorkflowService.TerminateWorkflow:input_type -> ctrl.v1.TerminateWorkflowRequest
+	13, // 13: ctrl.v1.WorkflowService.RetryWorkflow:input_type -> ctrl.v1.RetryWorkflowRequest
+	4,  // 14: ctrl.v1.WorkflowService.GetWorkflow:output_type -> ctrl.v1.GetWorkflowResponse
+	6,  // 15: ctrl.v1.WorkflowService.ListWorkflows:output_type -> ctrl.v1.ListWorkflowsResponse
+	8,  // 16: ctrl.v1.WorkflowService.ListWorkflowSteps:output_type -> ctrl.v1.ListWorkflowStepsResponse
+	10, // 17: ctrl.v1.WorkflowService.CancelWorkflow:output_type -> ctrl.v1.CancelWorkflowResponse
+	12, // 18: ctrl.v1.WorkflowService.TerminateWorkflow:output_type -> ctrl.v1.TerminateWorkflowResponse
+	14, // 19: ctrl.v1.WorkflowService.RetryWorkflow:output_type -> ctrl.v1.RetryWorkflowResponse
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_proto_ctrl_v1_workflow_proto_init() }
+func file_proto_ctrl_v1_workflow_proto_init() {
+	if File_proto_ctrl_v1_workflow_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_ctrl_v1_workflow_proto_rawDesc), len(file_proto_ctrl_v1_workflow_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_ctrl_v1_workflow_proto_goTypes,
+		DependencyIndexes: file_proto_ctrl_v1_workflow_proto_depIdxs,
+		EnumInfos:         file_proto_ctrl_v1_workflow_proto_enumTypes,
+		MessageInfos:      file_proto_ctrl_v1_workflow_proto_msgTypes,
+	}.Build()
+	File_proto_ctrl_v1_workflow_proto = out.File
+	file_proto_ctrl_v1_workflow_proto_goTypes = nil
+	file_proto_ctrl_v1_workflow_proto_depIdxs = nil
+}
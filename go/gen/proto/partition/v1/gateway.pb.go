@@ -21,6 +21,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// What a request is rate limited by. Requests that lack the identifier, like
+// unauthenticated requests when limiting by key, are limited by their IP.
+type RatelimitIdentifierSource int32
+
+const (
+	RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_UNSPECIFIED RatelimitIdentifierSource = 0
+	RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_IP          RatelimitIdentifierSource = 1
+	RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_KEY_ID      RatelimitIdentifierSource = 2
+	RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_IDENTITY    RatelimitIdentifierSource = 3
+	RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_HEADER      RatelimitIdentifierSource = 4
+)
+
+// Enum value maps for RatelimitIdentifierSource.
+var (
+	RatelimitIdentifierSource_name = map[int32]string{
+		0: "RATELIMIT_IDENTIFIER_SOURCE_UNSPECIFIED",
+		1: "RATELIMIT_IDENTIFIER_SOURCE_IP",
+		2: "RATELIMIT_IDENTIFIER_SOURCE_KEY_ID",
+		3: "RATELIMIT_IDENTIFIER_SOURCE_IDENTITY",
+		4: "RATELIMIT_IDENTIFIER_SOURCE_HEADER",
+	}
+	RatelimitIdentifierSource_value = map[string]int32{
+		"RATELIMIT_IDENTIFIER_SOURCE_UNSPECIFIED": 0,
+		"RATELIMIT_IDENTIFIER_SOURCE_IP":          1,
+		"RATELIMIT_IDENTIFIER_SOURCE_KEY_ID":      2,
+		"RATELIMIT_IDENTIFIER_SOURCE_IDENTITY":    3,
+		"RATELIMIT_IDENTIFIER_SOURCE_HEADER":      4,
+	}
+)
+
+func (x RatelimitIdentifierSource) Enum() *RatelimitIdentifierSource {
+	p := new(RatelimitIdentifierSource)
+	*p = x
+	return p
+}
+
+func (x RatelimitIdentifierSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RatelimitIdentifierSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_partition_v1_gateway_proto_enumTypes[0].Descriptor()
+}
+
+func (RatelimitIdentifierSource) Type() protoreflect.EnumType {
+	return &file_proto_partition_v1_gateway_proto_enumTypes[0]
+}
+
+func (x RatelimitIdentifierSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RatelimitIdentifierSource.Descriptor instead.
+func (RatelimitIdentifierSource) EnumDescriptor() ([]byte, []int) {
+	return file_proto_partition_v1_gateway_proto_rawDescGZIP(), []int{0}
+}
+
 // GatewayConfig contains all configuration needed for a hostname
 // including deployment metadata and middleware configurations
 type GatewayConfig struct {
@@ -33,9 +90,15 @@ type GatewayConfig struct {
 	ProjectId    string `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	Environment  string `protobuf:"bytes,5,opt,name=environment,proto3" json:"environment,omitempty"`
 	Vms          []*VM  `protobuf:"bytes,6,rep,name=vms,proto3" json:"vms,omitempty"`
+	// Traffic split between deployments for blue/green and canary deploys.
+	// The primary deployment above is always one of the targets. Configs
+	// without targets send all traffic to the primary deployment.
+	Targets      []*DeploymentTarget `protobuf:"bytes,7,rep,name=targets,proto3" json:"targets,omitempty"`
+	StickyConfig *StickyConfig       `protobuf:"bytes,8,opt,name=sticky_config,json=stickyConfig,proto3" json:"sticky_config,omitempty"`
 	// Middleware configurations
 	AuthConfig       *AuthConfig       `protobuf:"bytes,10,opt,name=auth_config,json=authConfig,proto3" json:"auth_config,omitempty"`
 	ValidationConfig *ValidationConfig `protobuf:"bytes,11,opt,name=validation_config,json=validationConfig,proto3" json:"validation_config,omitempty"`
+	RatelimitConfig  *RatelimitConfig  `protobuf:"bytes,12,opt,name=ratelimit_config,json=ratelimitConfig,proto3" json:"ratelimit_config,omitempty"`
 	// Deployment metadata
 	GitCommitSha  string `protobuf:"bytes,20,opt,name=git_commit_sha,json=gitCommitSha,proto3" json:"git_commit_sha,omitempty"`
 	GitBranch     string `protobuf:"bytes,21,opt,name=git_branch,json=gitBranch,proto3" json:"git_branch,omitempty"`
@@ -115,6 +178,20 @@ func (x *GatewayConfig) GetVms() []*VM {
 	return nil
 }
 
+func (x *GatewayConfig) GetTargets() []*DeploymentTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *GatewayConfig) GetStickyConfig() *StickyConfig {
+	if x != nil {
+		return x.StickyConfig
+	}
+	return nil
+}
+
 func (x *GatewayConfig) GetAuthConfig() *AuthConfig {
 	if x != nil {
 		return x.AuthConfig
@@ -129,6 +206,13 @@ func (x *GatewayConfig) GetValidationConfig() *ValidationConfig {
 	return nil
 }
 
+func (x *GatewayConfig) GetRatelimitConfig() *RatelimitConfig {
+	if x != nil {
+		return x.RatelimitConfig
+	}
+	return nil
+}
+
 func (x *GatewayConfig) GetGitCommitSha() string {
 	if x != nil {
 		return x.GitCommitSha
@@ -195,6 +279,124 @@ func (x *VM) GetRegion() string {
 	return ""
 }
 
+// A deployment receiving a share of a hostname's traffic
+type DeploymentTarget struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	// Relative share of the traffic, the weights of all targets add up to 100
+	Weight        int32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Vms           []*VM `protobuf:"bytes,3,rep,name=vms,proto3" json:"vms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentTarget) Reset() {
+	*x = DeploymentTarget{}
+	mi := &file_proto_partition_v1_gateway_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentTarget) ProtoMessage() {}
+
+func (x *DeploymentTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_partition_v1_gateway_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentTarget.ProtoReflect.Descriptor instead.
+func (*DeploymentTarget) Descriptor() ([]byte, []int) {
+	return file_proto_partition_v1_gateway_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DeploymentTarget) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *DeploymentTarget) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *DeploymentTarget) GetVms() []*VM {
+	if x != nil {
+		return x.Vms
+	}
+	return nil
+}
+
+// Sticky assignment keeps a client on the same deployment while traffic is
+// split. A known cookie takes precedence over the header.
+type StickyConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cookie the gateway sets to remember the deployment of a client
+	CookieName string `protobuf:"bytes,1,opt,name=cookie_name,json=cookieName,proto3" json:"cookie_name,omitempty"`
+	// Request header whose value is hashed to pick the deployment, e.g. a user id
+	HeaderName    string `protobuf:"bytes,2,opt,name=header_name,json=headerName,proto3" json:"header_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StickyConfig) Reset() {
+	*x = StickyConfig{}
+	mi := &file_proto_partition_v1_gateway_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StickyConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StickyConfig) ProtoMessage() {}
+
+func (x *StickyConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_partition_v1_gateway_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StickyConfig.ProtoReflect.Descriptor instead.
+func (*StickyConfig) Descriptor() ([]byte, []int) {
+	return file_proto_partition_v1_gateway_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StickyConfig) GetCookieName() string {
+	if x != nil {
+		return x.CookieName
+	}
+	return ""
+}
+
+func (x *StickyConfig) GetHeaderName() string {
+	if x != nil {
+		return x.HeaderName
+	}
+	return ""
+}
+
 // Authentication middleware configuration
 type AuthConfig struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -208,7 +410,7 @@ type AuthConfig struct {
 
 func (x *AuthConfig) Reset() {
 	*x = AuthConfig{}
-	mi := &file_proto_partition_v1_gateway_proto_msgTypes[2]
+	mi := &file_proto_partition_v1_gateway_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -220,7 +422,7 @@ func (x *AuthConfig) String() string {
 func (*AuthConfig) ProtoMessage() {}
 
 func (x *AuthConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_partition_v1_gateway_proto_msgTypes[2]
+	mi := &file_proto_partition_v1_gateway_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -233,7 +435,7 @@ func (x *AuthConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthConfig.ProtoReflect.Descriptor instead.
 func (*AuthConfig) Descriptor() ([]byte, []int) {
-	return file_proto_partition_v1_gateway_proto_rawDescGZIP(), []int{2}
+	return file_proto_partition_v1_gateway_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AuthConfig) GetRequireApiKey() bool {
@@ -275,7 +477,7 @@ type ValidationConfig struct {
 
 func (x *ValidationConfig) Reset() {
 	*x = ValidationConfig{}
-	mi := &file_proto_partition_v1_gateway_proto_msgTypes[3]
+	mi := &file_proto_partition_v1_gateway_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -287,7 +489,7 @@ func (x *ValidationConfig) String() string {
 func (*ValidationConfig) ProtoMessage() {}
 
 func (x *ValidationConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_partition_v1_gateway_proto_msgTypes[3]
+	mi := &file_proto_partition_v1_gateway_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,7 +502,7 @@ func (x *ValidationConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidationConfig.ProtoReflect.Descriptor instead.
 func (*ValidationConfig) Descriptor() ([]byte, []int) {
-	return file_proto_partition_v1_gateway_proto_rawDescGZIP(), []int{3}
+	return file_proto_partition_v1_gateway_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ValidationConfig) GetEnabled() bool {
@@ -317,11 +519,90 @@ func (x *ValidationConfig) GetOpenapiSpec() string {
 	return ""
 }
 
+// Rate limiting middleware configuration
+type RatelimitConfig struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Enabled bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Limit   int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Window duration in milliseconds
+	Duration         int64                     `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	IdentifierSource RatelimitIdentifierSource `protobuf:"varint,4,opt,name=identifier_source,json=identifierSource,proto3,enum=partition.v1.RatelimitIdentifierSource" json:"identifier_source,omitempty"`
+	// Header to read the identifier from, only used with the header source
+	IdentifierHeader string `protobuf:"bytes,5,opt,name=identifier_header,json=identifierHeader,proto3" json:"identifier_header,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RatelimitConfig) Reset() {
+	*x = RatelimitConfig{}
+	mi := &file_proto_partition_v1_gateway_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatelimitConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatelimitConfig) ProtoMessage() {}
+
+func (x *RatelimitConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_partition_v1_gateway_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatelimitConfig.ProtoReflect.Descriptor instead.
+func (*RatelimitConfig) Descriptor() ([]byte, []int) {
+	return file_proto_partition_v1_gateway_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RatelimitConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *RatelimitConfig) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RatelimitConfig) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *RatelimitConfig) GetIdentifierSource() RatelimitIdentifierSource {
+	if x != nil {
+		return x.IdentifierSource
+	}
+	return RatelimitIdentifierSource_RATELIMIT_IDENTIFIER_SOURCE_UNSPECIFIED
+}
+
+func (x *RatelimitConfig) GetIdentifierHeader() string {
+	if x != nil {
+		return x.IdentifierHeader
+	}
+	return ""
+}
+
 var File_proto_partition_v1_gateway_proto protoreflect.FileDescriptor
 
 const file_proto_partition_v1_gateway_proto_rawDesc = "" +
 	"\n" +
-	" proto/partition/v1/gateway.proto\x12\fpartition.v1\"\xa8\x03\n" +
+	" proto/partition/v1/gateway.proto\x12\fpartition.v1\"\xed\x04\n" +
 	"\rGatewayConfig\x12\x1d\n" +
 	"\n" +
 	"is_enabled\x18\x01 \x01(\bR\tisEnabled\x12#\n" +
@@ -330,17 +611,29 @@ const file_proto_partition_v1_gateway_proto_rawDesc = "" +
 	"\n" +
 	"project_id\x18\x04 \x01(\tR\tprojectId\x12 \n" +
 	"\venvironment\x18\x05 \x01(\tR\venvironment\x12\"\n" +
-	"\x03vms\x18\x06 \x03(\v2\x10.partition.v1.VMR\x03vms\x129\n" +
+	"\x03vms\x18\x06 \x03(\v2\x10.partition.v1.VMR\x03vms\x128\n" +
+	"\atargets\x18\a \x03(\v2\x1e.partition.v1.DeploymentTargetR\atargets\x12?\n" +
+	"\rsticky_config\x18\b \x01(\v2\x1a.partition.v1.StickyConfigR\fstickyConfig\x129\n" +
 	"\vauth_config\x18\n" +
 	" \x01(\v2\x18.partition.v1.AuthConfigR\n" +
 	"authConfig\x12K\n" +
-	"\x11validation_config\x18\v \x01(\v2\x1e.partition.v1.ValidationConfigR\x10validationConfig\x12$\n" +
+	"\x11validation_config\x18\v \x01(\v2\x1e.partition.v1.ValidationConfigR\x10validationConfig\x12H\n" +
+	"\x10ratelimit_config\x18\f \x01(\v2\x1d.partition.v1.RatelimitConfigR\x0fratelimitConfig\x12$\n" +
 	"\x0egit_commit_sha\x18\x14 \x01(\tR\fgitCommitSha\x12\x1d\n" +
 	"\n" +
 	"git_branch\x18\x15 \x01(\tR\tgitBranch\",\n" +
 	"\x02VM\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06region\x18\x02 \x01(\tR\x06region\"\x98\x01\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\"s\n" +
+	"\x10DeploymentTarget\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x05R\x06weight\x12\"\n" +
+	"\x03vms\x18\x03 \x03(\v2\x10.partition.v1.VMR\x03vms\"P\n" +
+	"\fStickyConfig\x12\x1f\n" +
+	"\vcookie_name\x18\x01 \x01(\tR\n" +
+	"cookieName\x12\x1f\n" +
+	"\vheader_name\x18\x02 \x01(\tR\n" +
+	"headerName\"\x98\x01\n" +
 	"\n" +
 	"AuthConfig\x12&\n" +
 	"\x0frequire_api_key\x18\x01 \x01(\bR\rrequireApiKey\x12\x1f\n" +
@@ -350,7 +643,19 @@ const file_proto_partition_v1_gateway_proto_rawDesc = "" +
 	"\aenabled\x18\x04 \x01(\bR\aenabled\"O\n" +
 	"\x10ValidationConfig\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12!\n" +
-	"\fopenapi_spec\x18\x02 \x01(\tR\vopenapiSpecB@Z>github.com/unkeyed/unkey/go/gen/proto/partition/v1;partitionv1b\x06proto3"
+	"\fopenapi_spec\x18\x02 \x01(\tR\vopenapiSpec\"\xe0\x01\n" +
+	"\x0fRatelimitConfig\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x1a\n" +
+	"\bduration\x18\x03 \x01(\x03R\bduration\x12T\n" +
+	"\x11identifier_source\x18\x04 \x01(\x0e2'.partition.v1.RatelimitIdentifierSourceR\x10identifierSource\x12+\n" +
+	"\x11identifier_header\x18\x05 \x01(\tR\x10identifierHeader*\xe6\x01\n" +
+	"\x19RatelimitIdentifierSource\x12+\n" +
+	"'RATELIMIT_IDENTIFIER_SOURCE_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eRATELIMIT_IDENTIFIER_SOURCE_IP\x10\x01\x12&\n" +
+	"\"RATELIMIT_IDENTIFIER_SOURCE_KEY_ID\x10\x02\x12(\n" +
+	"$RATELIMIT_IDENTIFIER_SOURCE_IDENTITY\x10\x03\x12&\n" +
+	"\"RATELIMIT_IDENTIFIER_SOURCE_HEADER\x10\x04B@Z>github.com/unkeyed/unkey/go/gen/proto/partition/v1;partitionv1b\x06proto3"
 
 var (
 	file_proto_partition_v1_gateway_proto_rawDescOnce sync.Once
@@ -364,22 +669,32 @@ func file_proto_partition_v1_gateway_proto_rawDescGZIP() []byte {
 	return file_proto_partition_v1_gateway_proto_rawDescData
 }
 
-var file_proto_partition_v1_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_partition_v1_gateway_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_partition_v1_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_proto_partition_v1_gateway_proto_goTypes = []any{
-	(*GatewayConfig)(nil),    // 0: partition.v1.GatewayConfig
-	(*VM)(nil),               // 1: partition.v1.VM
-	(*AuthConfig)(nil),       // 2: partition.v1.AuthConfig
-	(*ValidationConfig)(nil), // 3: partition.v1.ValidationConfig
+	(RatelimitIdentifierSource)(0), // 0: partition.v1.RatelimitIdentifierSource
+	(*GatewayConfig)(nil),          // 1: partition.v1.GatewayConfig
+	(*VM)(nil),                     // 2: partition.v1.VM
+	(*DeploymentTarget)(nil),       // 3: partition.v1.DeploymentTarget
+	(*StickyConfig)(nil),           // 4: partition.v1.StickyConfig
+	(*AuthConfig)(nil),             // 5: partition.v1.AuthConfig
+	(*ValidationConfig)(nil),       // 6: partition.v1.ValidationConfig
+	(*RatelimitConfig)(nil),        // 7: partition.v1.RatelimitConfig
 }
 var file_proto_partition_v1_gateway_proto_depIdxs = []int32{
-	1, // 0: partition.v1.GatewayConfig.vms:type_name -> partition.v1.VM
-	2, // 1: partition.v1.GatewayConfig.auth_config:type_name -> partition.v1.AuthConfig
-	3, // 2: partition.v1.GatewayConfig.validation_config:type_name -> partition.v1.ValidationConfig
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2, // 0: partition.v1.GatewayConfig.vms:type_name -> partition.v1.VM
+	3, // 1: partition.v1.GatewayConfig.targets:type_name -> partition.v1.DeploymentTarget
+	4, // 2: partition.v1.GatewayConfig.sticky_config:type_name -> partition.v1.StickyConfig
+	5, // 3: partition.v1.GatewayConfig.auth_config:type_name -> partition.v1.AuthConfig
+	6, // 4: partition.v1.GatewayConfig.validation_config:type_name -> partition.v1.ValidationConfig
+	7, // 5: partition.v1.GatewayConfig.ratelimit_config:type_name -> partition.v1.RatelimitConfig
+	2, // 6: partition.v1.DeploymentTarget.vms:type_name -> partition.v1.VM
+	0, // 7: partition.v1.RatelimitConfig.identifier_source:type_name -> partition.v1.RatelimitIdentifierSource
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_proto_partition_v1_gateway_proto_init() }
@@ -392,13 +707,14 @@ func file_proto_partition_v1_gateway_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_partition_v1_gateway_proto_rawDesc), len(file_proto_partition_v1_gateway_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_proto_partition_v1_gateway_proto_goTypes,
 		DependencyIndexes: file_proto_partition_v1_gateway_proto_depIdxs,
+		EnumInfos:         file_proto_partition_v1_gateway_proto_enumTypes,
 		MessageInfos:      file_proto_partition_v1_gateway_proto_msgTypes,
 	}.Build()
 	File_proto_partition_v1_gateway_proto = out.File
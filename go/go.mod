@@ -14,6 +14,7 @@ require (
 	github.com/go-acme/lego/v4 v4.25.2
 	github.com/go-redis/redis/v8 v8.11.5
 	github.com/go-sql-driver/mysql v1.9.2
+	github.com/jackc/pgx/v5 v5.7.4
 	github.com/lmittmann/tint v1.1.1
 	github.com/maypok86/otter v1.2.4
 	github.com/oapi-codegen/nullable v1.1.0
@@ -43,6 +44,7 @@ require (
 	golang.org/x/net v0.43.0
 	golang.org/x/text v0.28.0
 	google.golang.org/protobuf v1.36.8
+	modernc.org/sqlite v1.36.2
 )
 
 require (
@@ -97,7 +99,6 @@ require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
-	github.com/jackc/pgx/v5 v5.7.4 // indirect
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
@@ -174,7 +175,6 @@ require (
 	modernc.org/libc v1.61.13 // indirect
 	modernc.org/mathutil v1.7.1 // indirect
 	modernc.org/memory v1.9.1 // indirect
-	modernc.org/sqlite v1.36.2 // indirect
 )
 
 replace github.com/unkeyed/unkey/go/deploy/pkg/tls => ./deploy/pkg/tls
@@ -1,9 +1,12 @@
 package caches
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/cache/invalidation"
 	"github.com/unkeyed/unkey/go/pkg/cache/middleware"
 	"github.com/unkeyed/unkey/go/pkg/clock"
 	"github.com/unkeyed/unkey/go/pkg/db"
@@ -26,6 +29,9 @@ type Caches struct {
 	LiveApiByID cache.Cache[string, db.FindLiveApiByIDRow]
 }
 
+// resources are the names of all caches, they label metrics and traces.
+var resources = []string{"ratelimit_namespace", "verification_key_by_hash", "live_api_by_id"}
+
 // Config defines the configuration options for initializing caches.
 type Config struct {
 	// Logger is used for logging cache operations and errors.
@@ -33,12 +39,41 @@ type Config struct {
 
 	// Clock provides time functionality, allowing easier testing.
 	Clock clock.Clock
+
+	// Invalidations propagates removals to the caches of other nodes.
+	// Optional, if nil removals only affect the local caches.
+	Invalidations invalidation.Broadcaster
+
+	// L2 is a shared tier consulted before the origin on cache misses.
+	// Optional, it is only used by the caches listed in L2Resources.
+	L2 cache.L2
+
+	// L2Resources are the resource names of the caches using the L2,
+	// for example "verification_key_by_hash".
+	L2Resources []string
+}
+
+// l2For returns the L2 of a resource if it opted in.
+func (c Config) l2For(resource string) cache.L2 {
+	if c.L2 == nil || !slices.Contains(c.L2Resources, resource) {
+		return nil
+	}
+	return c.L2
+}
+
+// withMiddleware wraps a cache with the middlewares every cache uses.
+func withMiddleware[K comparable, V any](config Config, c cache.Cache[K, V], resource string) cache.Cache[K, V] {
+	if config.Invalidations != nil {
+		c = middleware.WithInvalidation(c, config.Invalidations, resource, config.Logger)
+	}
+	return middleware.WithTracing(c)
 }
 
 // New creates and initializes all cache instances with appropriate settings.
 //
 // It configures each cache with specific freshness/staleness windows, size limits,
-// resource names for tracing, and wraps them with tracing middleware.
+// resource names for tracing, and wraps them with tracing middleware. If
+// config.Invalidations is set, removals are broadcast to the other nodes.
 //
 // Parameters:
 //   - config: Configuration options including logger and clock implementations.
@@ -65,6 +100,12 @@ type Config struct {
 //	// Use the caches
 //	key, err := caches.KeyByHash.Get(ctx, "some-hash")
 func New(config Config) (Caches, error) {
+	for _, resource := range config.L2Resources {
+		if !slices.Contains(resources, resource) {
+			return Caches{}, fmt.Errorf("unknown cache resource %q, must be one of %v", resource, resources)
+		}
+	}
+
 	ratelimitNamespace, err := cache.New(cache.Config[cache.ScopedKey, db.FindRatelimitNamespace]{
 		Fresh:    time.Minute,
 		Stale:    24 * time.Hour,
@@ -72,6 +113,7 @@ func New(config Config) (Caches, error) {
 		MaxSize:  1_000_000,
 		Resource: "ratelimit_namespace",
 		Clock:    config.Clock,
+		L2:       config.l2For("ratelimit_namespace"),
 	})
 	if err != nil {
 		return Caches{}, err
@@ -84,6 +126,7 @@ func New(config Config) (Caches, error) {
 		MaxSize:  1_000_000,
 		Resource: "verification_key_by_hash",
 		Clock:    config.Clock,
+		L2:       config.l2For("verification_key_by_hash"),
 	})
 	if err != nil {
 		return Caches{}, err
@@ -96,14 +139,15 @@ func New(config Config) (Caches, error) {
 		MaxSize:  1_000_000,
 		Resource: "live_api_by_id",
 		Clock:    config.Clock,
+		L2:       config.l2For("live_api_by_id"),
 	})
 	if err != nil {
 		return Caches{}, err
 	}
 
 	return Caches{
-		RatelimitNamespace:    middleware.WithTracing(ratelimitNamespace),
-		LiveApiByID:           middleware.WithTracing(liveApiByID),
-		VerificationKeyByHash: middleware.WithTracing(verificationKeyByHash),
+		RatelimitNamespace:    withMiddleware(config, ratelimitNamespace, "ratelimit_namespace"),
+		LiveApiByID:           withMiddleware(config, liveApiByID, "live_api_by_id"),
+		VerificationKeyByHash: withMiddleware(config, verificationKeyByHash, "verification_key_by_hash"),
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/unkeyed/unkey/go/internal/services/caches"
@@ -66,14 +67,88 @@ func (s *service) Get(ctx context.Context, sess *zen.Session, rawKey string) (*K
 		return nil, emptyLog, fault.Wrap(err, fault.Internal("rawKey is empty"))
 	}
 
-	h := hash.Sha256(rawKey)
-	key, hit, err := s.keyCache.SWR(ctx, h, func(ctx context.Context) (db.FindKeyForVerificationRow, error) {
+	key, hit, err := s.findKey(ctx, hash.Sha256(rawKey))
+
+	return s.newVerifier(sess, key, hit, err)
+}
+
+// GetMany retrieves several keys at once and returns a KeyVerifier for every
+// raw key, in the same order. Every distinct key is only looked up once, but
+// each occurrence gets its own KeyVerifier, so a key that appears twice is
+// verified and logged twice.
+//
+// The returned functions log the verification of the key at the same index.
+// Callers must only call them for keys they verified, so keys of other
+// workspaces are never logged.
+func (s *service) GetMany(ctx context.Context, sess *zen.Session, rawKeys []string) ([]*KeyVerifier, []func(), error) {
+	ctx, span := tracing.Start(ctx, "keys.GetMany")
+	defer span.End()
+
+	hashes := make([]string, len(rawKeys))
+	lookups := make(map[string]*keyLookup)
+	for i, rawKey := range rawKeys {
+		err := assert.NotEmpty(rawKey)
+		if err != nil {
+			return nil, nil, fault.Wrap(err, fault.Internal(fmt.Sprintf("rawKey %d is empty", i)))
+		}
+
+		hashes[i] = hash.Sha256(rawKey)
+		lookups[hashes[i]] = nil
+	}
+
+	wg := sync.WaitGroup{}
+	mu := sync.Mutex{}
+	for h := range lookups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			key, hit, err := s.findKey(ctx, h)
+
+			mu.Lock()
+			lookups[h] = &keyLookup{key: key, hit: hit, err: err}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	verifiers := make([]*KeyVerifier, len(rawKeys))
+	logs := make([]func(), len(rawKeys))
+	for i, h := range hashes {
+		lookup := lookups[h]
+
+		kv, log, err := s.newVerifier(sess, lookup.key, lookup.hit, lookup.err)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		verifiers[i] = kv
+		logs[i] = log
+	}
+
+	return verifiers, logs, nil
+}
+
+// keyLookup is the result of loading a single key by its hash.
+type keyLookup struct {
+	key db.FindKeyForVerificationRow
+	hit cache.CacheHit
+	err error
+}
+
+// findKey loads a key by its hash, through the cache.
+func (s *service) findKey(ctx context.Context, h string) (db.FindKeyForVerificationRow, cache.CacheHit, error) {
+	return s.keyCache.SWR(ctx, h, func(ctx context.Context) (db.FindKeyForVerificationRow, error) {
 		// Use database retry with exponential backoff, skipping non-transient errors
 		return db.WithRetry(func() (db.FindKeyForVerificationRow, error) {
 			return db.Query.FindKeyForVerification(ctx, s.db.RO(), h)
 		})
 	}, caches.DefaultFindFirstOp)
+}
 
+// newVerifier builds the KeyVerifier for a loaded key and performs the basic
+// validation checks that don't depend on verification options.
+func (s *service) newVerifier(sess *zen.Session, key db.FindKeyForVerificationRow, hit cache.CacheHit, err error) (*KeyVerifier, func(), error) {
 	if err != nil {
 		if db.IsNotFound(err) {
 			// nolint:exhaustruct
@@ -86,3 +86,17 @@ func TestGet_EmptyString_Variants(t *testing.T) {
 		require.Contains(t, err.Error(), "rawKey is empty")
 	}
 }
+
+func TestGetMany_WithEmptyRawKey_ReturnsError(t *testing.T) {
+	t.Parallel()
+
+	s := &service{}
+	ctx := context.Background()
+
+	verifiers, logs, err := s.GetMany(ctx, nil, []string{"sk_123", ""})
+
+	require.Error(t, err)
+	require.Nil(t, verifiers)
+	require.Nil(t, logs)
+	require.Contains(t, err.Error(), "rawKey 1 is empty")
+}
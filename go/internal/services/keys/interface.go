@@ -12,6 +12,11 @@ type KeyService interface {
 	// Get retrieves a key and returns a KeyVerifier for validation
 	Get(ctx context.Context, sess *zen.Session, hash string) (*KeyVerifier, func(), error)
 
+	// GetMany retrieves several keys and returns a KeyVerifier for each of
+	// them, looking up every distinct key only once, along with a function
+	// per key that logs its verification
+	GetMany(ctx context.Context, sess *zen.Session, rawKeys []string) ([]*KeyVerifier, []func(), error)
+
 	// GetRootKey retrieves and validates a root key from the session
 	GetRootKey(ctx context.Context, sess *zen.Session) (*KeyVerifier, func(), error)
 
@@ -199,6 +199,8 @@ func (k *KeyVerifier) withRateLimits(ctx context.Context, specifiedLimits []open
 			Duration:   config.Duration,
 			Cost:       config.Cost,
 			Time:       time.Now(),
+			Algorithm:  ratelimit.SlidingWindow,
+			RefillRate: 0,
 		})
 		if err != nil {
 			k.logger.Error("Failed to ratelimit",
@@ -69,6 +69,22 @@ func (k *KeyVerifier) VerifyRootKey(ctx context.Context, opts ...VerifyOption) e
 	return k.ToFault()
 }
 
+// HasPermissions reports whether the key's permissions satisfy query. Unlike
+// verifying with WithPermissions, it doesn't change the key's status, so it
+// can be used to check a root key against several queries.
+func (k *KeyVerifier) HasPermissions(query rbac.PermissionQuery) (bool, error) {
+	if k.Status != StatusValid {
+		return false, nil
+	}
+
+	allowed, err := k.rBAC.EvaluatePermissions(query, k.Permissions)
+	if err != nil {
+		return false, err
+	}
+
+	return allowed.Valid, nil
+}
+
 // Verify performs key verification with the given options.
 // For root keys: returns fault errors for validation failures.
 // For normal keys: returns error only for system problems, check k.Valid and k.Status for validation results.
@@ -9,12 +9,14 @@ import (
 )
 
 // bucket maintains rate limit state for a specific identifier+limit+duration combination.
-// It stores a sliding window of request counts and manages the lifecycle of these windows.
+// Window based algorithms store request counts per window, token buckets store
+// the number of tokens left instead.
 //
-// Each bucket is uniquely identified by a triplet of:
+// Each bucket is uniquely identified by:
 //   - identifier: The rate limit subject (user ID, API key, etc)
 //   - limit: Maximum requests allowed in the duration
 //   - duration: Time window for the rate limit
+//   - algorithm: How requests are counted
 //
 // Example Usage:
 //
@@ -46,11 +48,24 @@ type bucket struct {
 	// strictUntil is when this bucket must sync with origin
 	// Used after rate limit exceeded to ensure consistency
 	strictUntil time.Time
+
+	// refillRate is the number of tokens added per duration
+	// Only used by token buckets
+	refillRate int64
+
+	// tokens is the number of tokens left in a token bucket
+	// Protected by mu
+	tokens float64
+
+	// tokensUpdatedAt is when tokens was last refilled
+	// Zero until the bucket has been synced with origin
+	// Protected by mu
+	tokensUpdatedAt time.Time
 }
 
 // bucketKey uniquely identifies a rate limit bucket by combining the
-// identifier, limit, and duration. This ensures separate tracking when
-// the same identifier has different rate limit configurations.
+// identifier, limit, duration and algorithm. This ensures separate tracking
+// when the same identifier has different rate limit configurations.
 //
 // Thread Safety:
 //   - Immutable after creation
@@ -62,6 +77,7 @@ type bucket struct {
 //	    identifier: "user-123",
 //	    limit:      100,
 //	    duration:   time.Minute,
+//	    algorithm:  SlidingWindow,
 //	}
 //	bucketID := key.toString()
 type bucketKey struct {
@@ -73,10 +89,42 @@ type bucketKey struct {
 
 	// duration is the time window for the rate limit
 	duration time.Duration
+
+	// algorithm is how requests are counted
+	algorithm Algorithm
+
+	// refillRate is the number of tokens added per duration, 0 unless the
+	// algorithm is TokenBucket
+	refillRate int64
+}
+
+// newBucketKey creates the key for a request. The request must have been
+// validated and defaults must have been applied.
+func newBucketKey(req RatelimitRequest) bucketKey {
+	key := bucketKey{
+		identifier: req.Identifier,
+		limit:      req.Limit,
+		duration:   req.Duration,
+		algorithm:  req.Algorithm,
+		refillRate: 0,
+	}
+	if req.Algorithm == TokenBucket {
+		key.refillRate = req.RefillRate
+	}
+	return key
 }
 
 func (b bucketKey) toString() string {
-	return fmt.Sprintf("%s-%d-%d", b.identifier, b.limit, b.duration.Milliseconds())
+	switch b.algorithm {
+	case FixedWindow:
+		return fmt.Sprintf("%s-%d-%d-%s", b.identifier, b.limit, b.duration.Milliseconds(), b.algorithm)
+	case TokenBucket:
+		return fmt.Sprintf("%s-%d-%d-%s-%d", b.identifier, b.limit, b.duration.Milliseconds(), b.algorithm, b.refillRate)
+	default:
+		// Sliding window keys predate the other algorithms and are not
+		// suffixed, so existing counters in redis remain valid.
+		return fmt.Sprintf("%s-%d-%d", b.identifier, b.limit, b.duration.Milliseconds())
+	}
 }
 
 // getOrCreateBucket retrieves a rate limiting bucket for the given key.
@@ -100,11 +148,14 @@ func (s *service) getOrCreateBucket(key bucketKey) (*bucket, bool) {
 	if !exists {
 		metrics.RatelimitBucketsCreated.Inc()
 		b = &bucket{
-			mu:          sync.RWMutex{},
-			limit:       key.limit,
-			duration:    key.duration,
-			windows:     make(map[int64]*window),
-			strictUntil: time.Time{},
+			mu:              sync.RWMutex{},
+			limit:           key.limit,
+			duration:        key.duration,
+			windows:         make(map[int64]*window),
+			strictUntil:     time.Time{},
+			refillRate:      key.refillRate,
+			tokens:          0,
+			tokensUpdatedAt: time.Time{},
 		}
 		s.buckets[key.toString()] = b
 	}
@@ -23,6 +23,13 @@ The sliding window implementation:
  3. Uses consistent hashing to route requests to origin nodes
  4. Propagates state changes asynchronously to maintain cluster-wide consistency
 
+Requests can select a different algorithm through RatelimitRequest.Algorithm:
+
+  - FixedWindow: Counts only the current window, resetting at every window boundary
+  - TokenBucket: Holds up to Limit tokens and refills RefillRate tokens per Duration,
+    allowing bursts while enforcing an average rate. Unsynced or exhausted buckets take
+    their tokens from the counter directly, all other decisions are made locally and replayed
+
 # Usage
 
 To create a new rate limiting service:
@@ -1,4 +1,5 @@
-// Package ratelimit provides distributed rate limiting functionality using a sliding window algorithm.
+// Package ratelimit provides distributed rate limiting functionality using sliding window,
+// fixed window and token bucket algorithms.
 package ratelimit
 
 import (
@@ -40,8 +41,43 @@ type Service interface {
 	//     Cost:       1,
 	//   })
 	Ratelimit(context.Context, RatelimitRequest) (RatelimitResponse, error)
+
+	// RatelimitMany checks multiple rate limits for a single action, for
+	// example a per-user, per-organisation and global limit. Tokens are only
+	// consumed if every limit passes, a rejection by one limit leaves all
+	// others untouched.
+	//
+	// Responses are returned in the same order as the requests. Each request
+	// must target a different bucket.
+	//
+	// Example Usage:
+	//   responses, err := svc.RatelimitMany(ctx, []RatelimitRequest{
+	//     {Identifier: "user-123", Limit: 10, Duration: time.Minute, Cost: 1},
+	//     {Identifier: "org-456", Limit: 1000, Duration: time.Minute, Cost: 1},
+	//   })
+	RatelimitMany(context.Context, []RatelimitRequest) ([]RatelimitResponse, error)
 }
 
+// Algorithm selects how a rate limit counts requests.
+type Algorithm string
+
+const (
+	// SlidingWindow counts the current window fully and the previous window
+	// weighted by how much of it still overlaps with the sliding window.
+	// This is the default when no algorithm is specified.
+	SlidingWindow Algorithm = "sliding_window"
+
+	// FixedWindow counts only the current window. The count resets at every
+	// window boundary, at the cost of allowing up to 2x Limit around a boundary.
+	// Windows are counted from the Unix epoch in multiples of Duration, they are
+	// not aligned to calendar months or any other anchor.
+	FixedWindow Algorithm = "fixed_window"
+
+	// TokenBucket allows bursts of up to Limit tokens and refills RefillRate
+	// tokens per Duration continuously.
+	TokenBucket Algorithm = "token_bucket"
+)
+
 // RatelimitRequest represents a request to check or consume rate limit tokens.
 // This is typically the first point of contact when a client wants to verify
 // if they are allowed to perform an action under the rate limit constraints.
@@ -92,6 +128,18 @@ type RatelimitRequest struct {
 	// Time of the request
 	// If not specified or zero, the ratelimiter will use its own clock.
 	Time time.Time
+
+	// Algorithm used to evaluate the rate limit.
+	// Defaults to SlidingWindow if not specified.
+	//
+	// For TokenBucket, Limit is the capacity of the bucket.
+	Algorithm Algorithm
+
+	// RefillRate is the number of tokens added to a token bucket per Duration.
+	// Only used by the TokenBucket algorithm.
+	//
+	// Must be >= 0. Defaults to Limit if not specified.
+	RefillRate int64
 }
 
 // RatelimitResponse contains the result of a rate limit check and the current state
@@ -115,6 +163,8 @@ type RatelimitResponse struct {
 	Remaining int64
 
 	// Reset is the Unix timestamp (in milliseconds) when the current window expires.
+	// For token buckets this is when the bucket is full again, or when enough
+	// tokens are available to retry if the request was denied.
 	// Clients can use this to:
 	//   - Display time until reset to users
 	//   - Implement automatic retry after window reset
@@ -130,6 +180,7 @@ type RatelimitResponse struct {
 	Success bool
 
 	// Current represents how many tokens have been consumed in this window.
+	// For token buckets this is the number of tokens missing from a full bucket.
 	// This is useful for:
 	//   - Monitoring and debugging
 	//   - Understanding usage patterns
@@ -14,7 +14,8 @@ import (
 // The janitor runs every minute and:
 // 1. Removes windows that are older than 3x their duration
 // 2. Removes buckets that have no remaining windows
-// 3. Updates metrics for monitoring
+// 3. Removes token buckets that have been full for at least one duration
+// 4. Updates metrics for monitoring
 //
 // Thread Safety:
 //   - Safe for concurrent access with rate limit operations
@@ -36,33 +37,49 @@ import (
 //   - Window removed by janitor after 3 minutes
 //   - Bucket removed when last window expires
 func (s *service) expireWindowsAndBuckets() {
+	repeat.Every(time.Minute, s.evictExpired)
+}
 
-	repeat.Every(time.Minute, func() {
-		s.bucketsMu.Lock()
-		defer s.bucketsMu.Unlock()
+// evictExpired removes expired windows and buckets once. It holds bucketsMu
+// while it locks each bucket in turn, so callers must never take bucketsMu
+// while holding a bucket lock.
+func (s *service) evictExpired() {
+	s.bucketsMu.Lock()
+	defer s.bucketsMu.Unlock()
 
-		windows := float64(0)
+	windows := float64(0)
 
-		for bucketID, bucket := range s.buckets {
-			bucket.mu.Lock()
-			for sequence, window := range bucket.windows {
-				if s.clock.Now().After(window.start.Add(3 * window.duration)) {
-					delete(bucket.windows, sequence)
-					metrics.RatelimitWindowsEvicted.Inc()
-				} else {
-					windows++
-				}
-			}
-			if len(bucket.windows) == 0 {
+	for bucketID, bucket := range s.buckets {
+		bucket.mu.Lock()
+
+		// Token buckets have no windows, once a bucket would have been
+		// refilled completely it is indistinguishable from a new one.
+		if bucket.refillRate > 0 {
+			if s.clock.Now().After(bucket.tokensAvailableAt(float64(bucket.limit)).Add(bucket.duration)) {
 				delete(s.buckets, bucketID)
 				metrics.RatelimitBucketsEvicted.Inc()
 			}
 
 			bucket.mu.Unlock()
+			continue
+		}
+
+		for sequence, window := range bucket.windows {
+			if s.clock.Now().After(window.start.Add(3 * window.duration)) {
+				delete(bucket.windows, sequence)
+				metrics.RatelimitWindowsEvicted.Inc()
+			} else {
+				windows++
+			}
+		}
+		if len(bucket.windows) == 0 {
+			delete(s.buckets, bucketID)
+			metrics.RatelimitBucketsEvicted.Inc()
 		}
 
-		metrics.RatelimitBuckets.Set(float64(len(s.buckets)))
-		metrics.RatelimitWindows.Set(windows)
-	})
+		bucket.mu.Unlock()
+	}
 
+	metrics.RatelimitBuckets.Set(float64(len(s.buckets)))
+	metrics.RatelimitWindows.Set(windows)
 }
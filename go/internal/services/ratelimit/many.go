@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/unkeyed/unkey/go/pkg/otel/tracing"
+	"github.com/unkeyed/unkey/go/pkg/prometheus/metrics"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RatelimitMany checks several rate limits for a single action and only
+// consumes tokens if all of them pass.
+//
+// All buckets are locked for the duration of the call, in a fixed order to
+// avoid deadlocks with concurrent calls, so no other request can take the
+// tokens between the check and the consumption on this node. Every bucket may
+// only appear once per call.
+//
+// The responses are in the same order as the requests. If any limit is
+// exceeded, no tokens are consumed and the responses of the limits that
+// passed describe the state as if the request had been admitted.
+//
+// Example:
+//
+//	res, err := svc.RatelimitMany(ctx, []RatelimitRequest{
+//	    {Identifier: "user-123", Limit: 10, Duration: time.Minute, Cost: 1},
+//	    {Identifier: "org-456", Limit: 100, Duration: time.Minute, Cost: 1},
+//	})
+func (s *service) RatelimitMany(ctx context.Context, reqs []RatelimitRequest) ([]RatelimitResponse, error) {
+	ctx, span := tracing.Start(ctx, "RatelimitMany")
+	defer span.End()
+
+	prepared := make([]RatelimitRequest, len(reqs))
+	keys := make([]bucketKey, len(reqs))
+	seen := make(map[string]int, len(reqs))
+	for i, req := range reqs {
+		req, err := s.prepare(req)
+		if err != nil {
+			return nil, err
+		}
+		prepared[i] = req
+
+		keys[i] = newBucketKey(req)
+		if j, ok := seen[keys[i].toString()]; ok {
+			return nil, fmt.Errorf("ratelimit requests %d and %d use the same bucket", j, i)
+		}
+		seen[keys[i].toString()] = i
+	}
+
+	// Resolve all buckets before locking any of them. getOrCreateBucket takes
+	// bucketsMu, which the janitor holds while it locks buckets, so taking it
+	// with a bucket locked could deadlock.
+	buckets := make([]*bucket, len(prepared))
+	for i := range prepared {
+		buckets[i], _ = s.getOrCreateBucket(keys[i])
+	}
+
+	// Lock buckets sorted by key, so concurrent calls can't lock them in
+	// opposite orders.
+	order := make([]int, len(prepared))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return keys[order[a]].toString() < keys[order[b]].toString()
+	})
+
+	for _, i := range order {
+		buckets[i].mu.Lock()
+		defer buckets[i].mu.Unlock()
+	}
+
+	responses := make([]RatelimitResponse, len(prepared))
+	decisionSources := make([]string, len(prepared))
+	passed := true
+	for i, req := range prepared {
+		if req.Algorithm == TokenBucket {
+			responses[i], decisionSources[i] = s.checkTokenBucket(ctx, keys[i], buckets[i], req)
+		} else {
+			responses[i], decisionSources[i] = s.checkWindows(ctx, keys[i], buckets[i], req)
+		}
+
+		if !responses[i].Success {
+			passed = false
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("requests", len(prepared)),
+		attribute.Bool("passed", passed),
+	)
+
+	for i, req := range prepared {
+		if !passed {
+			metrics.RatelimitDecision.WithLabelValues(decisionSources[i], "denied").Inc()
+			continue
+		}
+
+		if req.Algorithm == TokenBucket {
+			responses[i] = s.consumeTokenBucket(buckets[i], req)
+		} else {
+			responses[i] = s.consumeWindows(buckets[i], req, responses[i])
+		}
+
+		metrics.RatelimitDecision.WithLabelValues(decisionSources[i], "passed").Inc()
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,234 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/clock"
+	"github.com/unkeyed/unkey/go/pkg/counter"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+// memoryCounter is an in-memory counter.Counter, so the service can be tested
+// without Redis. Token bucket operations always admit the request.
+type memoryCounter struct {
+	mu     sync.Mutex
+	values map[string]int64
+}
+
+var _ counter.Counter = (*memoryCounter)(nil)
+
+func newMemoryCounter() *memoryCounter {
+	return &memoryCounter{mu: sync.Mutex{}, values: make(map[string]int64)}
+}
+
+func (c *memoryCounter) Increment(_ context.Context, key string, value int64, _ ...time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] += value
+	return c.values[key], nil
+}
+
+func (c *memoryCounter) Get(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.values[key], nil
+}
+
+func (c *memoryCounter) MultiGet(_ context.Context, keys []string) (map[string]int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values := make(map[string]int64, len(keys))
+	for _, key := range keys {
+		values[key] = c.values[key]
+	}
+
+	return values, nil
+}
+
+func (c *memoryCounter) Decrement(ctx context.Context, key string, value int64, ttl ...time.Duration) (int64, error) {
+	return c.Increment(ctx, key, -value, ttl...)
+}
+
+func (c *memoryCounter) DecrementIfExists(_ context.Context, key string, value int64) (int64, bool, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current, ok := c.values[key]
+	if !ok {
+		return 0, false, false, nil
+	}
+	if current < value {
+		return current, true, false, nil
+	}
+
+	c.values[key] = current - value
+	return c.values[key], true, true, nil
+}
+
+func (c *memoryCounter) SetIfNotExists(_ context.Context, key string, value int64, _ ...time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.values[key]; ok {
+		return false, nil
+	}
+
+	c.values[key] = value
+	return true, nil
+}
+
+func (c *memoryCounter) Set(_ context.Context, key string, value int64, _ ...time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values[key] = value
+	return nil
+}
+
+func (c *memoryCounter) TakeTokens(_ context.Context, _ string, capacity, _ int64, _ time.Duration, cost int64, _ time.Time) (float64, bool, error) {
+	return float64(capacity - cost), true, nil
+}
+
+func (c *memoryCounter) ChargeTokens(_ context.Context, _ string, capacity, _ int64, _ time.Duration, cost int64, _ time.Time) (float64, error) {
+	return float64(capacity - cost), nil
+}
+
+func (c *memoryCounter) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.values, key)
+	return nil
+}
+
+func (c *memoryCounter) Close() error {
+	return nil
+}
+
+func newTestService(t *testing.T) *service {
+	t.Helper()
+
+	s, err := New(Config{
+		Logger:  logging.NewNoop(),
+		Clock:   clock.New(),
+		Counter: newMemoryCounter(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+	})
+
+	return s
+}
+
+func TestRatelimitMany(t *testing.T) {
+	t.Parallel()
+
+	t.Run("consumes nothing if any limit is exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestService(t)
+		ctx := context.Background()
+
+		reqs := []RatelimitRequest{
+			{Identifier: "user", Limit: 1, Duration: time.Minute, Cost: 1},
+			{Identifier: "org", Limit: 10, Duration: time.Minute, Cost: 1},
+		}
+
+		res, err := s.RatelimitMany(ctx, reqs)
+		require.NoError(t, err)
+		require.True(t, res[0].Success)
+		require.True(t, res[1].Success)
+		require.Equal(t, int64(9), res[1].Remaining)
+
+		res, err = s.RatelimitMany(ctx, reqs)
+		require.NoError(t, err)
+		require.False(t, res[0].Success)
+
+		org, err := s.Ratelimit(ctx, RatelimitRequest{Identifier: "org", Limit: 10, Duration: time.Minute, Cost: 0})
+		require.NoError(t, err)
+		require.Equal(t, int64(9), org.Remaining)
+	})
+
+	t.Run("rejects the same bucket twice", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestService(t)
+
+		_, err := s.RatelimitMany(context.Background(), []RatelimitRequest{
+			{Identifier: "user", Limit: 10, Duration: time.Minute, Cost: 1},
+			{Identifier: "user", Limit: 10, Duration: time.Minute, Cost: 1},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("does not deadlock with the janitor", func(t *testing.T) {
+		t.Parallel()
+
+		s := newTestService(t)
+		ctx := context.Background()
+
+		identifiers := make([]string, 8)
+		for i := range identifiers {
+			identifiers[i] = fmt.Sprintf("identifier-%d", i)
+		}
+
+		done := make(chan struct{})
+		janitorDone := make(chan struct{})
+		go func() {
+			defer close(janitorDone)
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					s.evictExpired()
+				}
+			}
+		}()
+
+		wg := sync.WaitGroup{}
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for range 500 {
+					picked := rand.Perm(len(identifiers))[:3]
+					reqs := make([]RatelimitRequest, len(picked))
+					for i, j := range picked {
+						reqs[i] = RatelimitRequest{Identifier: identifiers[j], Limit: 1_000_000, Duration: time.Minute, Cost: 1}
+					}
+
+					_, err := s.RatelimitMany(ctx, reqs)
+					assert.NoError(t, err)
+				}
+			}()
+		}
+
+		finished := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(finished)
+		}()
+
+		select {
+		case <-finished:
+		case <-time.After(30 * time.Second):
+			t.Fatal("RatelimitMany deadlocked with the janitor")
+		}
+
+		close(done)
+		<-janitorDone
+	})
+}
@@ -59,15 +59,21 @@ func (s *service) syncWithOrigin(ctx context.Context, req RatelimitRequest) erro
 		return err
 	}
 
-	key := bucketKey{
-		identifier: req.Identifier,
-		limit:      req.Limit,
-		duration:   req.Duration,
-	}
+	key := newBucketKey(req)
 
 	bucket, _ := s.getOrCreateBucket(key)
 	bucket.mu.Lock()
 	defer bucket.mu.Unlock()
+
+	if req.Algorithm == TokenBucket {
+		err = s.syncTokenBucketWithOrigin(ctx, key, bucket, req)
+		if err != nil {
+			tracing.RecordError(span, err)
+		}
+
+		return err
+	}
+
 	currentWindow, _ := bucket.getCurrentWindow(req.Time)
 
 	newCounter, err := s.replayCircuitBreaker.Do(ctx, func(innerCtx context.Context) (int64, error) {
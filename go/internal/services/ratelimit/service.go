@@ -2,6 +2,7 @@ package ratelimit
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"github.com/unkeyed/unkey/go/pkg/assert"
@@ -84,6 +85,10 @@ type service struct {
 	// replayCircuitBreaker prevents cascading failures during peer communication
 	// Thread-safe internally
 	replayCircuitBreaker circuitbreaker.CircuitBreaker[int64]
+
+	// replayTokensCircuitBreaker does the same for token bucket replays
+	// Thread-safe internally
+	replayTokensCircuitBreaker circuitbreaker.CircuitBreaker[float64]
 }
 
 type Config struct {
@@ -142,7 +147,8 @@ func New(config Config) (*service, error) {
 			Capacity: 10_000,
 			Drop:     true,
 		}),
-		replayCircuitBreaker: circuitbreaker.New[int64]("replayRatelimitRequest"),
+		replayCircuitBreaker:       circuitbreaker.New[int64]("replayRatelimitRequest"),
+		replayTokensCircuitBreaker: circuitbreaker.New[float64]("replayTokenBucketRequest"),
 	}
 
 	s.expireWindowsAndBuckets()
@@ -171,7 +177,12 @@ func (s *service) calculateRateLimit(req RatelimitRequest, currentWindow, previo
 	// Pure sliding window calculation:
 	// - We count 100% of current window
 	// - We count a decreasing portion of previous window based on how far we are into current window
-	effectiveCount := currentWindow.counter + int64(float64(previousWindow.counter)*(1.0-windowElapsed))
+	//
+	// Fixed windows only count the current window.
+	effectiveCount := currentWindow.counter
+	if req.Algorithm != FixedWindow {
+		effectiveCount += int64(float64(previousWindow.counter) * (1.0 - windowElapsed))
+	}
 
 	effectiveCount += req.Cost
 
@@ -188,8 +199,9 @@ func (s *service) calculateRateLimit(req RatelimitRequest, currentWindow, previo
 }
 
 // Ratelimit checks if a request should be allowed under current rate limit constraints.
-// It implements a sliding window algorithm that considers both the current and previous
-// time windows to provide accurate rate limiting across a cluster of nodes.
+// By default it implements a sliding window algorithm that considers both the current and
+// previous time windows to provide accurate rate limiting across a cluster of nodes.
+// Fixed windows and token buckets are selected through the request's Algorithm.
 //
 // The method follows these steps:
 // 1. Validates request parameters
@@ -230,29 +242,94 @@ func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (Ratelimi
 	_, span := tracing.Start(ctx, "Ratelimit")
 	defer span.End()
 
+	req, err := s.prepare(req)
+	if err != nil {
+		return RatelimitResponse{}, err
+	}
+
+	key := newBucketKey(req)
+	span.SetAttributes(
+		attribute.String("key", key.toString()),
+		attribute.String("algorithm", string(req.Algorithm)),
+	)
+
+	b, _ := s.getOrCreateBucket(key)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if req.Algorithm == TokenBucket {
+		res, decisionSource := s.ratelimitTokenBucket(ctx, key, b, req)
+
+		span.SetAttributes(attribute.Bool("passed", res.Success))
+		if res.Success {
+			metrics.RatelimitDecision.WithLabelValues(decisionSource, "passed").Inc()
+		} else {
+			metrics.RatelimitDecision.WithLabelValues(decisionSource, "denied").Inc()
+		}
+
+		return res, nil
+	}
+
+	res, decisionSource := s.checkWindows(ctx, key, b, req)
+	if !res.Success {
+		span.SetAttributes(attribute.Bool("passed", false))
+		metrics.RatelimitDecision.WithLabelValues(decisionSource, "denied").Inc()
+
+		return res, nil
+	}
+
+	// If we get here, the request is allowed
+	res = s.consumeWindows(b, req, res)
+
+	span.SetAttributes(attribute.Bool("passed", true))
+	metrics.RatelimitDecision.WithLabelValues(decisionSource, "passed").Inc()
+
+	return res, nil
+}
+
+// prepare applies defaults to a request and validates it.
+func (s *service) prepare(req RatelimitRequest) (RatelimitRequest, error) {
 	if req.Time.IsZero() {
 		req.Time = s.clock.Now()
 	}
 
+	if req.Algorithm == "" {
+		req.Algorithm = SlidingWindow
+	}
+
+	if req.Algorithm == TokenBucket && req.RefillRate == 0 {
+		req.RefillRate = req.Limit
+	}
+
 	err := assert.All(
 		assert.NotEmpty(req.Identifier, "ratelimit identifier must not be empty"),
 		assert.Greater(req.Limit, 0, "ratelimit limit must be greater than zero"),
 		assert.GreaterOrEqual(req.Cost, 0, "ratelimit cost must not be negative"),
 		assert.GreaterOrEqual(req.Duration.Milliseconds(), 1000, "ratelimit duration must be at least 1s"),
 		assert.False(req.Time.IsZero(), "request time must not be zero"),
+		assert.True(
+			req.Algorithm == SlidingWindow || req.Algorithm == FixedWindow || req.Algorithm == TokenBucket,
+			fmt.Sprintf("ratelimit algorithm %q is not supported", req.Algorithm),
+		),
+		assert.GreaterOrEqual(req.RefillRate, 0, "ratelimit refill rate must not be negative"),
 	)
 	if err != nil {
-		return RatelimitResponse{}, err
+		return RatelimitRequest{}, err
 	}
 
-	key := bucketKey{req.Identifier, req.Limit, req.Duration}
-	span.SetAttributes(attribute.String("key", key.toString()))
-
-	b, _ := s.getOrCreateBucket(key)
-
-	b.mu.Lock()
-	defer b.mu.Unlock()
+	return req, nil
+}
 
+// checkWindows decides whether a sliding or fixed window request is within
+// its limit without consuming anything. The response describes the state as
+// if the request was admitted, call consumeWindows to actually admit it.
+//
+// Returns the response and whether the decision was made locally or by origin.
+//
+// Thread Safety:
+//   - Caller MUST hold bucket.mu lock
+func (s *service) checkWindows(ctx context.Context, key bucketKey, b *bucket, req RatelimitRequest) (RatelimitResponse, string) {
 	// Get current and previous windows
 	currentWindow, currentWindowExisted := b.getCurrentWindow(req.Time)
 	previousWindow, previousWindowExisted := b.getPreviousWindow(req.Time)
@@ -260,24 +337,23 @@ func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (Ratelimi
 	// track whether we were able to handle the request locally or if we had to call redis
 	decisionSource := "local"
 
+	// Fixed windows never look at the previous window
+	needsPreviousWindow := req.Algorithm == SlidingWindow
+
 	// First, try to make a decision based only on local data
-	if currentWindowExisted && previousWindowExisted {
+	if currentWindowExisted && (previousWindowExisted || !needsPreviousWindow) {
 		// Check if we can reject based on local data alone
 		exceeded, effectiveCount, remaining := s.calculateRateLimit(req, currentWindow, previousWindow)
 		if exceeded {
 			b.strictUntil = req.Time.Add(req.Duration)
 
-			// Record the denied request
-			span.SetAttributes(attribute.Bool("passed", false))
-			metrics.RatelimitDecision.WithLabelValues(decisionSource, "denied").Inc()
-
 			return RatelimitResponse{
 				Success:   false,
 				Remaining: remaining,
 				Reset:     currentWindow.start.Add(currentWindow.duration),
 				Limit:     req.Limit,
 				Current:   effectiveCount,
-			}, nil
+			}, decisionSource
 		}
 	}
 
@@ -297,7 +373,7 @@ func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (Ratelimi
 		}
 	}
 
-	if goToOrigin || !previousWindowExisted {
+	if needsPreviousWindow && (goToOrigin || !previousWindowExisted) {
 		decisionSource = "origin"
 		previousKey := counterKey(key, previousWindow.sequence)
 		res, err := s.counter.Get(ctx, previousKey)
@@ -317,34 +393,32 @@ func (s *service) Ratelimit(ctx context.Context, req RatelimitRequest) (Ratelimi
 	if exceeded {
 		// Set strictUntil to prevent further requests
 		b.strictUntil = req.Time.Add(req.Duration)
+	}
 
-		span.SetAttributes(attribute.Bool("passed", false))
-		metrics.RatelimitDecision.WithLabelValues(decisionSource, "denied").Inc()
+	return RatelimitResponse{
+		Success:   !exceeded,
+		Remaining: remaining,
+		Reset:     currentWindow.start.Add(currentWindow.duration),
+		Limit:     req.Limit,
+		Current:   effectiveCount,
+	}, decisionSource
+}
 
-		return RatelimitResponse{
-			Success:   false,
-			Remaining: remaining,
-			Reset:     currentWindow.start.Add(currentWindow.duration),
-			Limit:     req.Limit,
-			Current:   effectiveCount,
-		}, nil
-	}
+// consumeWindows admits a request that passed checkWindows by incrementing
+// the current window and buffering the request for async propagation.
+//
+// Thread Safety:
+//   - Caller MUST hold bucket.mu lock
+func (s *service) consumeWindows(b *bucket, req RatelimitRequest, res RatelimitResponse) RatelimitResponse {
+	currentWindow, _ := b.getCurrentWindow(req.Time)
 
-	// If we get here, the request is allowed
 	// Increment current window counter
 	currentWindow.counter += req.Cost
 
 	// Buffer the request for async propagation
 	s.replayBuffer.Buffer(req)
 
-	span.SetAttributes(attribute.Bool("passed", true))
-	metrics.RatelimitDecision.WithLabelValues(decisionSource, "passed").Inc()
+	res.Current = currentWindow.counter
 
-	return RatelimitResponse{
-		Success:   true,
-		Remaining: remaining,
-		Reset:     currentWindow.start.Add(currentWindow.duration),
-		Limit:     req.Limit,
-		Current:   currentWindow.counter,
-	}, nil
+	return res
 }
@@ -0,0 +1,206 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// refillTokens adds the tokens refilled since the last update, never exceeding
+// the bucket's limit.
+//
+// Thread Safety:
+//   - Caller MUST hold bucket.mu lock
+func (b *bucket) refillTokens(now time.Time) {
+	if !now.After(b.tokensUpdatedAt) {
+		return
+	}
+
+	refilled := float64(now.Sub(b.tokensUpdatedAt)) * float64(b.refillRate) / float64(b.duration)
+	b.tokens = math.Min(float64(b.limit), b.tokens+refilled)
+	b.tokensUpdatedAt = now
+}
+
+// tokensAvailableAt returns when the bucket will hold at least n tokens,
+// assuming no further tokens are consumed.
+//
+// Thread Safety:
+//   - Caller MUST hold bucket.mu lock
+func (b *bucket) tokensAvailableAt(n float64) time.Time {
+	missing := n - b.tokens
+	if missing <= 0 {
+		return b.tokensUpdatedAt
+	}
+
+	return b.tokensUpdatedAt.Add(time.Duration(math.Ceil(missing * float64(b.duration) / float64(b.refillRate))))
+}
+
+// ratelimitTokenBucket evaluates a request against a token bucket.
+//
+// Buckets that have not been synced yet, or were recently exhausted, take their
+// tokens directly from origin. Otherwise the decision is made locally and the
+// consumed tokens are replayed to origin asynchronously, just like windows.
+//
+// Returns the response and whether the decision was made locally or by origin.
+//
+// Thread Safety:
+//   - Caller MUST hold bucket.mu lock
+func (s *service) ratelimitTokenBucket(ctx context.Context, key bucketKey, b *bucket, req RatelimitRequest) (RatelimitResponse, string) {
+	decisionSource := "local"
+
+	synced := !b.tokensUpdatedAt.IsZero()
+	if !synced || req.Time.Before(b.strictUntil) {
+		decisionSource = "origin"
+
+		tokens, success, err := s.counter.TakeTokens(ctx, tokenBucketCounterKey(key), req.Limit, req.RefillRate, req.Duration, req.Cost, req.Time)
+		if err == nil {
+			b.tokens = tokens
+			if req.Time.After(b.tokensUpdatedAt) {
+				b.tokensUpdatedAt = req.Time
+			}
+
+			if !success {
+				b.strictUntil = req.Time.Add(req.Duration)
+			}
+
+			return s.tokenBucketResponse(b, req, success), decisionSource
+		}
+
+		s.logger.Error("unable to take tokens",
+			"key", tokenBucketCounterKey(key),
+			"error", err.Error(),
+		)
+
+		// Without origin we fall back to a local decision, a bucket we have
+		// never seen before is assumed to be full.
+		if !synced {
+			b.tokens = float64(req.Limit)
+			b.tokensUpdatedAt = req.Time
+		}
+	}
+
+	b.refillTokens(req.Time)
+
+	if b.tokens < float64(req.Cost) {
+		b.strictUntil = req.Time.Add(req.Duration)
+		return s.tokenBucketResponse(b, req, false), decisionSource
+	}
+
+	b.tokens -= float64(req.Cost)
+
+	// Buffer the request for async propagation
+	s.replayBuffer.Buffer(req)
+
+	return s.tokenBucketResponse(b, req, true), decisionSource
+}
+
+// checkTokenBucket decides whether a token bucket holds enough tokens for a
+// request without consuming any, neither locally nor at origin. Buckets that
+// have not been synced yet, or were recently exhausted, load their tokens from
+// origin first. Call consumeTokenBucket to actually admit the request.
+//
+// Returns the response and whether the decision was made locally or by origin.
+//
+// Thread Safety:
+//   - Caller MUST hold bucket.mu lock
+func (s *service) checkTokenBucket(ctx context.Context, key bucketKey, b *bucket, req RatelimitRequest) (RatelimitResponse, string) {
+	decisionSource := "local"
+
+	synced := !b.tokensUpdatedAt.IsZero()
+	if !synced || req.Time.Before(b.strictUntil) {
+		decisionSource = "origin"
+
+		// Taking zero tokens reads the bucket without changing it
+		tokens, _, err := s.counter.TakeTokens(ctx, tokenBucketCounterKey(key), req.Limit, req.RefillRate, req.Duration, 0, req.Time)
+		if err == nil {
+			b.tokens = tokens
+			if req.Time.After(b.tokensUpdatedAt) {
+				b.tokensUpdatedAt = req.Time
+			}
+		} else {
+			s.logger.Error("unable to read tokens",
+				"key", tokenBucketCounterKey(key),
+				"error", err.Error(),
+			)
+
+			if !synced {
+				b.tokens = float64(req.Limit)
+				b.tokensUpdatedAt = req.Time
+			}
+		}
+	}
+
+	b.refillTokens(req.Time)
+
+	if b.tokens < float64(req.Cost) {
+		b.strictUntil = req.Time.Add(req.Duration)
+		return s.tokenBucketResponse(b, req, false), decisionSource
+	}
+
+	return s.tokenBucketResponse(b, req, true), decisionSource
+}
+
+// consumeTokenBucket admits a request that passed checkTokenBucket and buffers
+// it for async propagation to origin.
+//
+// Thread Safety:
+//   - Caller MUST hold bucket.mu lock
+func (s *service) consumeTokenBucket(b *bucket, req RatelimitRequest) RatelimitResponse {
+	b.tokens -= float64(req.Cost)
+
+	// Buffer the request for async propagation
+	s.replayBuffer.Buffer(req)
+
+	return s.tokenBucketResponse(b, req, true)
+}
+
+// tokenBucketResponse builds the response from the bucket's current state.
+//
+// Thread Safety:
+//   - Caller MUST hold bucket.mu lock
+func (s *service) tokenBucketResponse(b *bucket, req RatelimitRequest, success bool) RatelimitResponse {
+	remaining := max(0, int64(math.Floor(b.tokens)))
+
+	reset := b.tokensAvailableAt(float64(req.Limit))
+	if !success {
+		reset = b.tokensAvailableAt(float64(req.Cost))
+	}
+
+	return RatelimitResponse{
+		Success:   success,
+		Remaining: remaining,
+		Reset:     reset,
+		Limit:     req.Limit,
+		Current:   req.Limit - remaining,
+	}
+}
+
+// syncTokenBucketWithOrigin replays tokens consumed by a local decision to
+// origin. The request was already admitted, so origin is charged even if it
+// does not have enough tokens left. If other nodes consumed tokens in the
+// meantime, origin has fewer tokens left than we do locally and we adopt its value.
+//
+// Thread Safety:
+//   - Caller MUST hold bucket.mu lock
+func (s *service) syncTokenBucketWithOrigin(ctx context.Context, key bucketKey, b *bucket, req RatelimitRequest) error {
+	tokens, err := s.replayTokensCircuitBreaker.Do(ctx, func(innerCtx context.Context) (float64, error) {
+		innerCtx, cancel := context.WithTimeout(innerCtx, 2*time.Second)
+		defer cancel()
+
+		return s.counter.ChargeTokens(innerCtx, tokenBucketCounterKey(key), req.Limit, req.RefillRate, req.Duration, req.Cost, req.Time)
+	})
+	if err != nil {
+		return err
+	}
+
+	b.refillTokens(req.Time)
+	b.tokens = math.Min(b.tokens, tokens)
+
+	// Origin went into debt, the cluster as a whole admitted too many
+	// requests. Stop making local decisions for a while.
+	if tokens < 0 {
+		b.strictUntil = req.Time.Add(req.Duration)
+	}
+
+	return nil
+}
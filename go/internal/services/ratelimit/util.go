@@ -5,3 +5,7 @@ import "fmt"
 func counterKey(b bucketKey, seq int64) string {
 	return fmt.Sprintf("%s:%d", b.toString(), seq)
 }
+
+func tokenBucketCounterKey(b bucketKey) string {
+	return fmt.Sprintf("%s:tokens", b.toString())
+}
@@ -8,6 +8,11 @@ type Service interface {
 	// If the given keyId has exceeded its usage limit, an error is returned.
 	Limit(ctx context.Context, req UsageRequest) (UsageResponse, error)
 
+	// Refill overwrites any cached credit state for the given keyId with remaining.
+	// Call this after the credits of a key were refilled in the database, so Limit
+	// does not keep spending the credits from before the refill.
+	Refill(ctx context.Context, keyId string, remaining int32) error
+
 	// Close gracefully shuts down the usage limiter service.
 	Close() error
 }
@@ -47,6 +47,11 @@ func (s *service) Limit(ctx context.Context, req UsageRequest) (UsageResponse, e
 	return UsageResponse{Valid: true, Remaining: max(0, remaining-req.Cost)}, nil
 }
 
+func (s *service) Refill(ctx context.Context, keyId string, remaining int32) error {
+	// Direct DB service reads credits on every request, there is nothing to refill
+	return nil
+}
+
 func (s *service) Close() error {
 	// Direct DB service has no resources to clean up
 	return nil
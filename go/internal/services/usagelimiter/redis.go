@@ -30,6 +30,10 @@ type CreditChange struct {
 
 	// Cost is the number of credits that we should deduct
 	Cost int32
+
+	// Time is when the credits were spent. Changes from before the key's last
+	// refill are not replayed, the refill already reset its credits.
+	Time time.Time
 }
 
 // RedisConfig holds configuration options for the Redis usage limiter.
@@ -186,7 +190,7 @@ func (s *counterService) Limit(ctx context.Context, req UsageRequest) (UsageResp
 	ctx, span := tracing.Start(ctx, "usagelimiter.counter.Limit")
 	defer span.End()
 
-	redisKey := fmt.Sprintf("credits:%s", req.KeyId)
+	redisKey := creditsKey(req.KeyId)
 
 	// Attempt decrement if key already exists in Redis
 	remaining, exists, success, err := s.counter.DecrementIfExists(ctx, redisKey, int64(req.Cost))
@@ -203,6 +207,24 @@ func (s *counterService) Limit(ctx context.Context, req UsageRequest) (UsageResp
 	return s.handleResult(req, remaining, success)
 }
 
+// Refill overwrites the Redis counter for the given key with the refilled credits.
+//
+// The counter is set rather than deleted: re-initializing it from the database
+// could read a replica that has not seen the refill yet. Credits that were spent
+// before the refill but are still waiting in a replay buffer are not deducted from
+// the refilled credits, see CreditChange.Time.
+func (s *counterService) Refill(ctx context.Context, keyId string, remaining int32) error {
+	ctx, span := tracing.Start(ctx, "usagelimiter.counter.Refill")
+	defer span.End()
+
+	return s.counter.Set(ctx, creditsKey(keyId), int64(remaining), s.ttl)
+}
+
+// creditsKey returns the Redis key holding the remaining credits of a key.
+func creditsKey(keyId string) string {
+	return fmt.Sprintf("credits:%s", keyId)
+}
+
 // handleResult processes the result of a decrement operation using an explicit success flag.
 // This eliminates ambiguity in determining whether the operation succeeded or failed.
 func (s *counterService) handleResult(req UsageRequest, remaining int64, success bool) (UsageResponse, error) {
@@ -211,6 +233,7 @@ func (s *counterService) handleResult(req UsageRequest, remaining int64, success
 		s.replayBuffer.Buffer(CreditChange{
 			KeyID: req.KeyId,
 			Cost:  req.Cost,
+			Time:  time.Now(),
 		})
 
 		metrics.UsagelimiterDecisions.WithLabelValues("redis", "allowed").Inc()
@@ -310,9 +333,10 @@ func (s *counterService) syncWithDB(ctx context.Context, change CreditChange) er
 	}()
 
 	_, err := s.dbCircuitBreaker.Do(ctx, func(ctx context.Context) (any, error) {
-		return nil, db.Query.UpdateKeyCreditsDecrement(ctx, s.db.RW(), db.UpdateKeyCreditsDecrementParams{
-			ID:      change.KeyID,
-			Credits: sql.NullInt32{Int32: change.Cost, Valid: true},
+		return nil, db.Query.UpdateKeyCreditsDecrementUnlessRefilled(ctx, s.db.RW(), db.UpdateKeyCreditsDecrementUnlessRefilledParams{
+			ID:        change.KeyID,
+			Credits:   sql.NullInt32{Int32: change.Cost, Valid: true},
+			ChargedAt: sql.NullTime{Time: change.Time, Valid: true},
 		})
 	})
 
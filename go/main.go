@@ -10,6 +10,7 @@ import (
 	"github.com/unkeyed/unkey/go/cmd/healthcheck"
 	"github.com/unkeyed/unkey/go/cmd/quotacheck"
 	"github.com/unkeyed/unkey/go/cmd/run"
+	"github.com/unkeyed/unkey/go/cmd/vault"
 	"github.com/unkeyed/unkey/go/cmd/version"
 	"github.com/unkeyed/unkey/go/pkg/cli"
 	versioncmd "github.com/unkeyed/unkey/go/pkg/version"
@@ -28,6 +29,7 @@ func main() {
 			healthcheck.Cmd,
 			quotacheck.Cmd,
 			gateway.Cmd,
+			vault.Cmd,
 		},
 	}
 
@@ -70,4 +70,8 @@ const (
 
 	// Audit log bucket events
 	AuditLogBucketCreateEvent AuditLogEvent = "auditLogBucket.create"
+
+	// Webhook endpoint events
+	WebhookCreateEvent AuditLogEvent = "webhook.create"
+	WebhookDeleteEvent AuditLogEvent = "webhook.delete"
 )
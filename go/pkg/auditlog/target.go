@@ -16,5 +16,6 @@ const (
 	RoleResourceType               AuditLogResourceType = "role"
 	VercelBindingResourceType      AuditLogResourceType = "vercelBinding"
 	VercelIntegrationResourceType  AuditLogResourceType = "vercelIntegration"
+	WebhookResourceType            AuditLogResourceType = "webhook"
 	WorkspaceResourceType          AuditLogResourceType = "workspace"
 )
@@ -23,6 +23,7 @@ type cache[K comparable, V any] struct {
 	logger   logging.Logger
 	resource string
 	clock    clock.Clock
+	l2       L2
 
 	revalidateC chan func()
 
@@ -47,6 +48,10 @@ type Config[K comparable, V any] struct {
 	Resource string
 
 	Clock clock.Clock
+
+	// L2 is an optional shared tier consulted by SWR before the origin.
+	// Writes and removals go through to it, clearing the cache does not.
+	L2 L2
 }
 
 var _ Cache[any, any] = (*cache[any, any])(nil)
@@ -80,6 +85,7 @@ func New[K comparable, V any](config Config[K, V]) (*cache[K, V], error) {
 		logger:            config.Logger,
 		resource:          config.Resource,
 		clock:             config.Clock,
+		l2:                config.L2,
 		revalidateC:       make(chan func(), 1000),
 		inflightMu:        sync.Mutex{},
 		inflightRefreshes: make(map[K]bool),
@@ -122,27 +128,74 @@ func (c *cache[K, V]) Get(ctx context.Context, key K) (value V, hit CacheHit) {
 	return value, Miss
 }
 
-func (c *cache[K, V]) SetNull(_ context.Context, key K) {
-	now := c.clock.Now()
-
+func (c *cache[K, V]) SetNull(ctx context.Context, key K) {
 	var v V
-	c.otter.Set(key, swrEntry[V]{
-		Value: v,
-		Fresh: now.Add(c.fresh),
-		Stale: now.Add(c.stale),
-		Hit:   Null,
-	})
+	e := c.set(key, v, Null)
+	c.setL2(ctx, key, e)
+}
+
+func (c *cache[K, V]) Set(ctx context.Context, key K, value V) {
+	e := c.set(key, value, Hit)
+	c.setL2(ctx, key, e)
 }
 
-func (c *cache[K, V]) Set(_ context.Context, key K, value V) {
+// set stores an entry in memory only.
+func (c *cache[K, V]) set(key K, value V, hit CacheHit) swrEntry[V] {
 	now := c.clock.Now()
 
-	c.otter.Set(key, swrEntry[V]{
+	e := swrEntry[V]{
 		Value: value,
 		Fresh: now.Add(c.fresh),
 		Stale: now.Add(c.stale),
-		Hit:   Hit,
-	})
+		Hit:   hit,
+	}
+	c.otter.Set(key, e)
+	return e
+}
+
+// setL2 writes an entry through to the L2. Failures are logged, the entry
+// is still served from memory.
+func (c *cache[K, V]) setL2(ctx context.Context, key K, e swrEntry[V]) {
+	if c.l2 == nil {
+		return
+	}
+
+	b, err := encodeEntry(e)
+	if err != nil {
+		c.logger.Warn("failed to write to l2", "resource", c.resource, "error", err.Error())
+		return
+	}
+
+	err = c.l2.Set(ctx, l2Key(c.resource, key), b, e.Stale.Sub(c.clock.Now()))
+	if err != nil {
+		c.logger.Warn("failed to write to l2", "resource", c.resource, "error", err.Error())
+	}
+}
+
+// getL2 returns the entry of a key from the L2 if it exists and is not
+// expired yet.
+func (c *cache[K, V]) getL2(ctx context.Context, key K) (swrEntry[V], bool) {
+	b, found, err := c.l2.Get(ctx, l2Key(c.resource, key))
+	if err != nil {
+		metrics.CacheReadsErrorsTotal.WithLabelValues(c.resource).Inc()
+		c.logger.Warn("failed to read from l2", "resource", c.resource, "error", err.Error())
+		return swrEntry[V]{}, false
+	}
+	if !found {
+		return swrEntry[V]{}, false
+	}
+
+	e, err := decodeEntry[V](b)
+	if err != nil {
+		metrics.CacheReadsErrorsTotal.WithLabelValues(c.resource).Inc()
+		c.logger.Warn("failed to read from l2", "resource", c.resource, "error", err.Error())
+		return swrEntry[V]{}, false
+	}
+
+	if !c.clock.Now().Before(e.Stale) {
+		return swrEntry[V]{}, false
+	}
+	return e, true
 }
 
 func (c *cache[K, V]) get(_ context.Context, key K) (swrEntry[V], bool) {
@@ -157,6 +210,19 @@ func (c *cache[K, V]) Remove(ctx context.Context, keys ...K) {
 	for _, key := range keys {
 		c.otter.Delete(key)
 	}
+
+	if c.l2 == nil || len(keys) == 0 {
+		return
+	}
+
+	l2Keys := make([]string, len(keys))
+	for i, key := range keys {
+		l2Keys[i] = l2Key(c.resource, key)
+	}
+	err := c.l2.Remove(ctx, l2Keys...)
+	if err != nil {
+		c.logger.Warn("failed to remove from l2", "resource", c.resource, "error", err.Error())
+	}
 }
 
 func (c *cache[K, V]) Dump(ctx context.Context) ([]byte, error) {
@@ -185,7 +251,8 @@ func (c *cache[K, V]) Restore(ctx context.Context, b []byte) error {
 	now := c.clock.Now()
 	for key, entry := range data {
 		if now.Before(entry.Fresh) || now.Before(entry.Stale) {
-			c.Set(ctx, key, entry.Value)
+			// The l2 is shared and does not need to be restored
+			c.set(key, entry.Value, Hit)
 		}
 		// If the entry is older than, we don't restore it
 	}
@@ -234,6 +301,49 @@ func (c *cache[K, V]) revalidate(
 	}
 }
 
+// lookup returns an entry that may still be served, from memory or else
+// from the l2. Entries found in the l2 are kept in memory afterwards.
+func (c *cache[K, V]) lookup(ctx context.Context, key K, now time.Time) (swrEntry[V], bool) {
+	e, ok := c.get(ctx, key)
+	if ok {
+		if now.Before(e.Stale) {
+			metrics.CacheTierReads.WithLabelValues(c.resource, "l1").Inc()
+			return e, true
+		}
+
+		// We have old data, that we should not serve anymore
+		c.otter.Delete(key)
+	}
+
+	if c.l2 == nil {
+		return swrEntry[V]{}, false
+	}
+
+	e, ok = c.getL2(ctx, key)
+	if !ok {
+		return swrEntry[V]{}, false
+	}
+
+	// Keep the deadlines of the l2 entry, so the entry does not live longer
+	// just because it moved between nodes
+	c.otter.Set(key, e)
+	metrics.CacheTierReads.WithLabelValues(c.resource, "l2").Inc()
+	return e, true
+}
+
+// revalidateInBackground queues a refresh of a stale entry.
+func (c *cache[K, V]) revalidateInBackground(
+	ctx context.Context,
+	key K, refreshFromOrigin func(context.Context) (V, error),
+	op func(error) Op,
+) {
+	c.revalidateC <- func() {
+		// If we don't uncancel the context, the revalidation will get canceled when
+		// the api response is returned
+		c.revalidate(context.WithoutCancel(ctx), key, refreshFromOrigin, op)
+	}
+}
+
 func (c *cache[K, V]) SWR(
 	ctx context.Context,
 	key K,
@@ -241,33 +351,18 @@ func (c *cache[K, V]) SWR(
 	op func(error) Op,
 ) (V, CacheHit, error) {
 	now := c.clock.Now()
-	e, ok := c.get(ctx, key)
+	e, ok := c.lookup(ctx, key, now)
 	if ok {
-		// Cache Hit
-
-		if now.Before(e.Fresh) {
-			// We have data and it's fresh, so we return it
-			return e.Value, e.Hit, nil
-		}
-
-		if now.Before(e.Stale) {
+		if !now.Before(e.Fresh) {
 			// We have data, but it's stale, so we refresh it in the background
 			// but return the current value
-
-			c.revalidateC <- func() {
-				// If we don't uncancel the context, the revalidation will get canceled when
-				// the api response is returned
-				c.revalidate(context.WithoutCancel(ctx), key, refreshFromOrigin, op)
-
-			}
-			return e.Value, e.Hit, nil
+			c.revalidateInBackground(ctx, key, refreshFromOrigin, op)
 		}
-
-		// We have old data, that we should not serve anymore
-		c.otter.Delete(key)
+		return e.Value, e.Hit, nil
 	}
 
 	// Cache Miss
+	metrics.CacheTierReads.WithLabelValues(c.resource, "origin").Inc()
 
 	// We have no data and need to go to the origin
 	v, err := refreshFromOrigin(ctx)
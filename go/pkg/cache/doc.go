@@ -42,4 +42,8 @@
 //	        return cache.Miss      // Mark as a transient error
 //	    },
 //	)
+//
+// A shared second tier can be configured with Config.L2, for example the
+// Redis implementation in pkg/cache/l2. SWR consults it on misses before
+// calling the origin, and writes and removals go through to it.
 package cache
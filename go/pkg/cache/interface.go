@@ -21,6 +21,20 @@ type Cache[K comparable, V any] interface {
 
 	SWR(ctx context.Context, key K, refreshFromOrigin func(ctx context.Context) (V, error), op func(error) Op) (value V, hit CacheHit, err error)
 
+	// GetMany returns the values of several keys, like Get.
+	// Keys that are not in the cache are Miss in hits and absent from values.
+	GetMany(ctx context.Context, keys []K) (values map[K]V, hits map[K]CacheHit)
+
+	// SWRMany is the bulk version of SWR. All keys the cache can not serve
+	// are loaded with a single call of refreshFromOrigin, which returns the
+	// values of the keys it found.
+	//
+	// Keys missing from the result of refreshFromOrigin do not exist in the
+	// origin, op is called with sql.ErrNoRows for them, matching the error of
+	// single row queries. If refreshFromOrigin fails, the cached values are
+	// returned together with the error.
+	SWRMany(ctx context.Context, keys []K, refreshFromOrigin func(ctx context.Context, keys []K) (map[K]V, error), op func(error) Op) (values map[K]V, hits map[K]CacheHit, err error)
+
 	// Dump returns a serialized representation of the cache.
 	Dump(ctx context.Context) ([]byte, error)
 
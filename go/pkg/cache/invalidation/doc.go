@@ -0,0 +1,18 @@
+/*
+Package invalidation broadcasts cache removals between nodes.
+
+Every API node holds its own in-memory caches, so removing an entry on one
+node leaves the other nodes serving it until it expires. A Broadcaster
+publishes the removed keys to every node of the cluster, where the
+invalidation middleware of pkg/cache/middleware removes them from the local
+cache as well.
+
+Two implementations are provided:
+  - NewRedis uses Redis pub/sub and is used in production
+  - NewMemory delivers events within the process and is used in tests
+
+Delivery is best effort. Events published while a node is disconnected from
+Redis are lost, so the fresh and stale windows of a cache still bound how
+long a node may serve an outdated entry.
+*/
+package invalidation
@@ -0,0 +1,39 @@
+package invalidation
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Event announces that keys were removed from a cache on one node.
+type Event struct {
+	// Resource is the name of the cache the keys were removed from, nodes
+	// only apply the event to their cache of the same resource.
+	Resource string `json:"resource"`
+
+	// Origin identifies the cache that published the event, so it can ignore
+	// its own events.
+	Origin string `json:"origin"`
+
+	// Keys are the removed keys, encoded as a JSON array.
+	Keys json.RawMessage `json:"keys"`
+}
+
+// Handler is called for every event of a subscribed resource.
+type Handler func(ctx context.Context, event Event)
+
+// Broadcaster distributes cache invalidation events between nodes.
+//
+// Concurrency: All methods are safe for concurrent use.
+type Broadcaster interface {
+	// Publish sends the event to all subscribers of its resource, including
+	// the ones of the publishing node.
+	Publish(ctx context.Context, event Event) error
+
+	// Subscribe registers a handler for the events of a resource. Handlers
+	// are called sequentially and must not block.
+	Subscribe(resource string, handler Handler)
+
+	// Close stops delivering events and releases all resources.
+	Close() error
+}
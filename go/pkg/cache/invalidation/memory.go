@@ -0,0 +1,57 @@
+package invalidation
+
+import (
+	"context"
+	"sync"
+)
+
+// memory delivers events to the subscribers within the same process.
+type memory struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	closed   bool
+}
+
+var _ Broadcaster = (*memory)(nil)
+
+// NewMemory creates a broadcaster that delivers events synchronously to all
+// subscribers in the process. Sharing one instance between several caches
+// simulates a cluster of nodes in tests.
+func NewMemory() Broadcaster {
+	return &memory{
+		mu:       sync.RWMutex{},
+		handlers: make(map[string][]Handler),
+		closed:   false,
+	}
+}
+
+func (m *memory) Publish(ctx context.Context, event Event) error {
+	m.mu.RLock()
+	if m.closed {
+		m.mu.RUnlock()
+		return nil
+	}
+	handlers := m.handlers[event.Resource]
+	m.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+	return nil
+}
+
+func (m *memory) Subscribe(resource string, handler Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.handlers[resource] = append(m.handlers[resource], handler)
+}
+
+func (m *memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	m.handlers = make(map[string][]Handler)
+	return nil
+}
@@ -0,0 +1,152 @@
+package invalidation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/unkeyed/unkey/go/pkg/assert"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+// DefaultChannel is the Redis channel events are published on, unless
+// configured otherwise.
+const DefaultChannel = "cache:invalidations"
+
+// redisBroadcaster publishes events on a Redis pub/sub channel that every
+// node subscribes to.
+type redisBroadcaster struct {
+	redis   *redis.Client
+	pubsub  *redis.PubSub
+	channel string
+	logger  logging.Logger
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+
+	done chan struct{}
+}
+
+var _ Broadcaster = (*redisBroadcaster)(nil)
+
+// RedisConfig holds configuration options for the Redis broadcaster.
+type RedisConfig struct {
+	// RedisURL is the connection URL for Redis.
+	// Format: redis://[[username][:password]@][host][:port][/database]
+	RedisURL string
+
+	// Channel is the pub/sub channel to use, defaults to DefaultChannel.
+	// Nodes only receive events of other nodes using the same channel.
+	Channel string
+
+	Logger logging.Logger
+}
+
+// NewRedis creates a broadcaster backed by Redis pub/sub and subscribes to
+// its channel.
+//
+// The subscription is reconnected automatically if the connection to Redis
+// is lost, events published in the meantime are not delivered.
+func NewRedis(config RedisConfig) (Broadcaster, error) {
+	err := assert.All(
+		assert.NotEmpty(config.RedisURL, "Redis URL must not be empty"),
+		assert.NotNil(config.Logger, "Logger must not be nil"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := config.Channel
+	if channel == "" {
+		channel = DefaultChannel
+	}
+
+	opts, err := redis.ParseURL(config.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	rdb := redis.NewClient(opts)
+
+	ctx := context.Background()
+	pubsub := rdb.Subscribe(ctx, channel)
+
+	// Wait for the subscription to be confirmed, so events published after
+	// this function returns are not missed
+	_, err = pubsub.Receive(ctx)
+	if err != nil {
+		_ = pubsub.Close()
+		_ = rdb.Close()
+		return nil, fmt.Errorf("failed to subscribe to redis channel: %w", err)
+	}
+
+	b := &redisBroadcaster{
+		redis:    rdb,
+		pubsub:   pubsub,
+		channel:  channel,
+		logger:   config.Logger.With("service", "cache_invalidation"),
+		mu:       sync.RWMutex{},
+		handlers: make(map[string][]Handler),
+		done:     make(chan struct{}),
+	}
+
+	go b.receive()
+
+	return b, nil
+}
+
+// receive dispatches messages of the channel to the handlers of their
+// resource until the subscription is closed.
+func (b *redisBroadcaster) receive() {
+	defer close(b.done)
+
+	ctx := context.Background()
+	for msg := range b.pubsub.Channel() {
+		var event Event
+		err := json.Unmarshal([]byte(msg.Payload), &event)
+		if err != nil {
+			b.logger.Warn("failed to decode cache invalidation event", "error", err.Error())
+			continue
+		}
+
+		b.mu.RLock()
+		handlers := b.handlers[event.Resource]
+		b.mu.RUnlock()
+
+		for _, handler := range handlers {
+			handler(ctx, event)
+		}
+	}
+}
+
+func (b *redisBroadcaster) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache invalidation event: %w", err)
+	}
+
+	err = b.redis.Publish(ctx, b.channel, payload).Err()
+	if err != nil {
+		return fmt.Errorf("failed to publish cache invalidation event: %w", err)
+	}
+	return nil
+}
+
+func (b *redisBroadcaster) Subscribe(resource string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[resource] = append(b.handlers[resource], handler)
+}
+
+func (b *redisBroadcaster) Close() error {
+	err := b.pubsub.Close()
+	<-b.done
+	if err != nil {
+		_ = b.redis.Close()
+		return fmt.Errorf("failed to close subscription: %w", err)
+	}
+	return b.redis.Close()
+}
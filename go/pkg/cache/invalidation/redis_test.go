@@ -0,0 +1,59 @@
+package invalidation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/testutil/containers"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestRedisBroadcaster(t *testing.T) {
+	ctx := context.Background()
+	redisURL := containers.Redis(t)
+
+	// Each test run uses its own channel, so concurrent runs do not interfere
+	channel := uid.New(uid.TestPrefix)
+
+	newBroadcaster := func() Broadcaster {
+		b, err := NewRedis(RedisConfig{
+			RedisURL: redisURL,
+			Channel:  channel,
+			Logger:   logging.NewNoop(),
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, b.Close()) })
+		return b
+	}
+
+	publisher := newBroadcaster()
+	subscriber := newBroadcaster()
+
+	received := make(chan Event, 10)
+	subscriber.Subscribe("keys", func(_ context.Context, event Event) {
+		received <- event
+	})
+	subscriber.Subscribe("apis", func(_ context.Context, event Event) {
+		t.Errorf("unexpected event for resource %s", event.Resource)
+	})
+
+	err := publisher.Publish(ctx, Event{
+		Resource: "keys",
+		Origin:   "ins_1",
+		Keys:     json.RawMessage(`["hash_1","hash_2"]`),
+	})
+	require.NoError(t, err)
+
+	select {
+	case event := <-received:
+		require.Equal(t, "keys", event.Resource)
+		require.Equal(t, "ins_1", event.Origin)
+		require.JSONEq(t, `["hash_1","hash_2"]`, string(event.Keys))
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not delivered")
+	}
+}
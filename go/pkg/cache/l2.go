@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"time"
+)
+
+func init() {
+	// Columns aggregated into JSON by MySQL are scanned into interface{}
+	// fields as []byte, which gob can only encode if the type is registered.
+	gob.Register([]byte(nil))
+}
+
+// L2 is a shared second tier behind the in-memory cache, consulted on misses
+// before going to the origin. It lets cold nodes pick up entries loaded by
+// other nodes instead of all of them querying the origin after a deploy.
+//
+// Values are opaque to the L2, the cache serializes entries together with
+// their fresh and stale deadlines using encoding/gob, so cached types must be
+// encodable by gob.
+//
+// Implementations must be safe for concurrent use.
+type L2 interface {
+	// Get returns the serialized entry of a key, or false if it does not exist.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores the serialized entry of a key until the ttl expires.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Remove deletes the entries of the given keys.
+	Remove(ctx context.Context, keys ...string) error
+}
+
+// l2Key returns the key an entry is stored under in the L2, keys of
+// different resources never collide.
+func l2Key[K comparable](resource string, key K) string {
+	if k, ok := any(key).(Key); ok {
+		return fmt.Sprintf("cache:%s:%s", resource, k.ToString())
+	}
+	return fmt.Sprintf("cache:%s:%v", resource, key)
+}
+
+func encodeEntry[V any](e swrEntry[V]) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeEntry[V any](b []byte) (swrEntry[V], error) {
+	var e swrEntry[V]
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&e)
+	if err != nil {
+		return e, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+	return e, nil
+}
@@ -0,0 +1,6 @@
+// Package l2 provides implementations of cache.L2, the shared tier behind
+// the in-memory caches of pkg/cache.
+//
+// NewRedis stores entries in Redis so all nodes of a cluster share them,
+// NewMemory keeps them in a map and is meant for tests.
+package l2
@@ -0,0 +1,70 @@
+package l2
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/clock"
+)
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// memory keeps entries in a map, expired entries are dropped when read.
+type memory struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	clock   clock.Clock
+}
+
+var _ cache.L2 = (*memory)(nil)
+
+// NewMemory creates an in-process L2. Sharing one instance between several
+// caches simulates nodes sharing a Redis L2 in tests.
+func NewMemory(clk clock.Clock) cache.L2 {
+	return &memory{
+		mu:      sync.Mutex{},
+		entries: make(map[string]memoryEntry),
+		clock:   clk,
+	}
+}
+
+func (m *memory) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !m.clock.Now().Before(e.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+func (m *memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = memoryEntry{
+		value:   value,
+		expires: m.clock.Now().Add(ttl),
+	}
+	return nil
+}
+
+func (m *memory) Remove(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.entries, key)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package l2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/unkeyed/unkey/go/pkg/assert"
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+// redisL2 stores entries as plain Redis strings that expire together with
+// the stale deadline of the entry.
+type redisL2 struct {
+	redis  *redis.Client
+	logger logging.Logger
+}
+
+var _ cache.L2 = (*redisL2)(nil)
+
+// RedisConfig holds configuration options for the Redis L2.
+type RedisConfig struct {
+	// RedisURL is the connection URL for Redis.
+	// Format: redis://[[username][:password]@][host][:port][/database]
+	RedisURL string
+
+	Logger logging.Logger
+}
+
+// NewRedis creates a Redis backed L2.
+//
+// The returned close function releases the connection pool and must be
+// called on shutdown.
+func NewRedis(config RedisConfig) (cache.L2, func() error, error) {
+	err := assert.All(
+		assert.NotEmpty(config.RedisURL, "Redis URL must not be empty"),
+		assert.NotNil(config.Logger, "Logger must not be nil"),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts, err := redis.ParseURL(config.RedisURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	rdb := redis.NewClient(opts)
+
+	_, err = rdb.Ping(context.Background()).Result()
+	if err != nil {
+		_ = rdb.Close()
+		return nil, nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	return &redisL2{redis: rdb, logger: config.Logger}, rdb.Close, nil
+}
+
+func (r *redisL2) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	b, err := r.redis.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get cache entry: %w", err)
+	}
+	return b, true, nil
+}
+
+func (r *redisL2) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	// A zero ttl would store the entry forever
+	if ttl <= 0 {
+		return nil
+	}
+
+	err := r.redis.Set(ctx, key, value, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set cache entry: %w", err)
+	}
+	return nil
+}
+
+func (r *redisL2) Remove(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	err := r.redis.Del(ctx, keys...).Err()
+	if err != nil {
+		return fmt.Errorf("failed to remove cache entries: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package cache_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/cache/l2"
+	"github.com/unkeyed/unkey/go/pkg/clock"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+func newL2Cache[K comparable, V any](t *testing.T, clk clock.Clock, tier cache.L2) cache.Cache[K, V] {
+	c, err := cache.New(cache.Config[K, V]{
+		Fresh:    time.Minute,
+		Stale:    time.Hour,
+		Logger:   logging.NewNoop(),
+		MaxSize:  100,
+		Resource: "test",
+		Clock:    clk,
+		L2:       tier,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func writeValue(err error) cache.Op {
+	if err != nil {
+		return cache.Noop
+	}
+	return cache.WriteValue
+}
+
+func TestL2SharesEntriesBetweenCaches(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewTestClock()
+	tier := l2.NewMemory(clk)
+
+	a := newL2Cache[string, string](t, clk, tier)
+	b := newL2Cache[string, string](t, clk, tier)
+
+	originCalls := 0
+	origin := func(context.Context) (string, error) {
+		originCalls++
+		return "value", nil
+	}
+
+	v, hit, err := a.SWR(ctx, "key", origin, writeValue)
+	require.NoError(t, err)
+	require.Equal(t, cache.Hit, hit)
+	require.Equal(t, "value", v)
+	require.Equal(t, 1, originCalls)
+
+	// The second cache is cold but finds the entry in the l2
+	v, hit, err = b.SWR(ctx, "key", origin, writeValue)
+	require.NoError(t, err)
+	require.Equal(t, cache.Hit, hit)
+	require.Equal(t, "value", v)
+	require.Equal(t, 1, originCalls)
+
+	// And keeps it in memory afterwards
+	v, hit = b.Get(ctx, "key")
+	require.Equal(t, cache.Hit, hit)
+	require.Equal(t, "value", v)
+
+	// Removals go through to the l2
+	a.Remove(ctx, "key")
+	c := newL2Cache[string, string](t, clk, tier)
+	_, _, err = c.SWR(ctx, "key", origin, writeValue)
+	require.NoError(t, err)
+	require.Equal(t, 2, originCalls)
+}
+
+func TestL2KeepsNullEntries(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewTestClock()
+	tier := l2.NewMemory(clk)
+
+	a := newL2Cache[string, string](t, clk, tier)
+	b := newL2Cache[string, string](t, clk, tier)
+
+	a.SetNull(ctx, "missing")
+
+	_, hit, err := b.SWR(ctx, "missing", func(context.Context) (string, error) {
+		t.Fatal("origin must not be called")
+		return "", nil
+	}, writeValue)
+	require.NoError(t, err)
+	require.Equal(t, cache.Null, hit)
+}
+
+func TestL2ExpiresWithStaleWindow(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewTestClock()
+	tier := l2.NewMemory(clk)
+
+	a := newL2Cache[string, string](t, clk, tier)
+	a.Set(ctx, "key", "value")
+
+	clk.Tick(2 * time.Hour)
+
+	b := newL2Cache[string, string](t, clk, tier)
+	v, _, err := b.SWR(ctx, "key", func(context.Context) (string, error) {
+		return "fresh", nil
+	}, writeValue)
+	require.NoError(t, err)
+	require.Equal(t, "fresh", v)
+}
+
+func TestL2SerializesVerificationKeys(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewTestClock()
+	tier := l2.NewMemory(clk)
+
+	a := newL2Cache[cache.ScopedKey, db.FindKeyForVerificationRow](t, clk, tier)
+	b := newL2Cache[cache.ScopedKey, db.FindKeyForVerificationRow](t, clk, tier)
+
+	key := cache.ScopedKey{WorkspaceID: "ws_1", Key: "hash"}
+	row := db.FindKeyForVerificationRow{
+		ID:          "key_1",
+		WorkspaceID: "ws_1",
+		Name:        sql.NullString{String: "name", Valid: true},
+		Expires:     sql.NullTime{Time: time.UnixMilli(1_700_000_000_000).UTC(), Valid: true},
+		Enabled:     true,
+		Roles:       []byte(`["admin"]`),
+		Permissions: []byte(`["api.*.read_key"]`),
+		Ratelimits:  nil,
+	}
+	a.Set(ctx, key, row)
+
+	got, hit, err := b.SWR(ctx, key, func(context.Context) (db.FindKeyForVerificationRow, error) {
+		t.Fatal("origin must not be called")
+		return db.FindKeyForVerificationRow{}, nil
+	}, writeValue)
+	require.NoError(t, err)
+	require.Equal(t, cache.Hit, hit)
+	require.Equal(t, row, got)
+}
@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/unkeyed/unkey/go/pkg/prometheus/metrics"
+)
+
+// errNotInOrigin is passed to op for keys that refreshFromOrigin of SWRMany
+// did not return.
+var errNotInOrigin = sql.ErrNoRows
+
+func (c *cache[K, V]) GetMany(ctx context.Context, keys []K) (map[K]V, map[K]CacheHit) {
+	values := make(map[K]V, len(keys))
+	hits := make(map[K]CacheHit, len(keys))
+	for _, key := range keys {
+		v, hit := c.Get(ctx, key)
+		hits[key] = hit
+		if hit == Hit {
+			values[key] = v
+		}
+	}
+	return values, hits
+}
+
+func (c *cache[K, V]) SWRMany(
+	ctx context.Context,
+	keys []K,
+	refreshFromOrigin func(context.Context, []K) (map[K]V, error),
+	op func(error) Op,
+) (map[K]V, map[K]CacheHit, error) {
+	now := c.clock.Now()
+
+	values := make(map[K]V, len(keys))
+	hits := make(map[K]CacheHit, len(keys))
+	stale := []K{}
+	missing := []K{}
+	for _, key := range keys {
+		if _, seen := hits[key]; seen {
+			continue
+		}
+
+		e, ok := c.lookup(ctx, key, now)
+		if !ok {
+			hits[key] = Miss
+			missing = append(missing, key)
+			continue
+		}
+
+		hits[key] = e.Hit
+		if e.Hit == Hit {
+			values[key] = e.Value
+		}
+		if !now.Before(e.Fresh) {
+			stale = append(stale, key)
+		}
+	}
+
+	if len(stale) > 0 {
+		c.revalidateC <- func() {
+			// If we don't uncancel the context, the revalidation will get canceled when
+			// the api response is returned
+			c.revalidateMany(context.WithoutCancel(ctx), stale, refreshFromOrigin, op)
+		}
+	}
+
+	if len(missing) == 0 {
+		return values, hits, nil
+	}
+
+	metrics.CacheTierReads.WithLabelValues(c.resource, "origin").Add(float64(len(missing)))
+
+	found, err := refreshFromOrigin(ctx, missing)
+	if err != nil {
+		return values, hits, err
+	}
+
+	for key, hit := range c.writeMany(ctx, missing, found, op) {
+		hits[key] = hit
+		if hit == Hit {
+			values[key] = found[key]
+		}
+	}
+
+	return values, hits, nil
+}
+
+// writeMany stores the result of a bulk origin call and returns the hit
+// status of every requested key.
+func (c *cache[K, V]) writeMany(ctx context.Context, keys []K, found map[K]V, op func(error) Op) map[K]CacheHit {
+	hits := make(map[K]CacheHit, len(keys))
+	for _, key := range keys {
+		v, ok := found[key]
+
+		var err error
+		if !ok {
+			err = errNotInOrigin
+		}
+
+		switch op(err) {
+		case WriteValue:
+			c.Set(ctx, key, v)
+			hits[key] = Hit
+		case WriteNull:
+			c.SetNull(ctx, key)
+			hits[key] = Null
+		default:
+			hits[key] = Miss
+		}
+	}
+	return hits
+}
+
+// revalidateMany refreshes stale entries with a single origin call, skipping
+// keys that are already being refreshed.
+func (c *cache[K, V]) revalidateMany(
+	ctx context.Context,
+	keys []K,
+	refreshFromOrigin func(context.Context, []K) (map[K]V, error),
+	op func(error) Op,
+) {
+	c.inflightMu.Lock()
+	claimed := make([]K, 0, len(keys))
+	for _, key := range keys {
+		if c.inflightRefreshes[key] {
+			continue
+		}
+		c.inflightRefreshes[key] = true
+		claimed = append(claimed, key)
+	}
+	c.inflightMu.Unlock()
+
+	if len(claimed) == 0 {
+		return
+	}
+
+	defer func() {
+		c.inflightMu.Lock()
+		for _, key := range claimed {
+			delete(c.inflightRefreshes, key)
+		}
+		c.inflightMu.Unlock()
+	}()
+
+	metrics.CacheRevalidations.WithLabelValues(c.resource).Add(float64(len(claimed)))
+	found, err := refreshFromOrigin(ctx, claimed)
+	if err != nil {
+		c.logger.Warn("failed to revalidate", "error", err.Error(), "keys", len(claimed))
+		return
+	}
+
+	c.writeMany(ctx, claimed, found, op)
+}
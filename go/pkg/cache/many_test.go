@@ -0,0 +1,116 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/clock"
+	"github.com/unkeyed/unkey/go/pkg/db"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+func findFirstOp(err error) cache.Op {
+	if err == nil {
+		return cache.WriteValue
+	}
+	if db.IsNotFound(err) {
+		return cache.WriteNull
+	}
+	return cache.Noop
+}
+
+func TestSWRMany(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewTestClock()
+
+	c, err := cache.New(cache.Config[string, string]{
+		Fresh:    time.Minute,
+		Stale:    5 * time.Minute,
+		Logger:   logging.NewNoop(),
+		MaxSize:  100,
+		Resource: "test",
+		Clock:    clk,
+	})
+	require.NoError(t, err)
+
+	origin := map[string]string{"a": "A", "b": "B", "c": "C"}
+	var calls atomic.Int32
+	var requested atomic.Value
+	refresh := func(_ context.Context, keys []string) (map[string]string, error) {
+		calls.Add(1)
+		requested.Store(keys)
+		found := map[string]string{}
+		for _, key := range keys {
+			if v, ok := origin[key]; ok {
+				found[key] = v
+			}
+		}
+		return found, nil
+	}
+
+	t.Run("misses are loaded with a single call", func(t *testing.T) {
+		c.Set(ctx, "a", "A")
+
+		values, hits, err := c.SWRMany(ctx, []string{"a", "b", "c", "missing", "b"}, refresh, findFirstOp)
+		require.NoError(t, err)
+		require.Equal(t, int32(1), calls.Load())
+		require.ElementsMatch(t, []string{"b", "c", "missing"}, requested.Load())
+		require.Equal(t, map[string]string{"a": "A", "b": "B", "c": "C"}, values)
+		require.Equal(t, map[string]cache.CacheHit{
+			"a":       cache.Hit,
+			"b":       cache.Hit,
+			"c":       cache.Hit,
+			"missing": cache.Null,
+		}, hits)
+	})
+
+	t.Run("cached keys and nulls do not call the origin", func(t *testing.T) {
+		calls.Store(0)
+
+		values, hits, err := c.SWRMany(ctx, []string{"a", "b", "missing"}, refresh, findFirstOp)
+		require.NoError(t, err)
+		require.Equal(t, int32(0), calls.Load())
+		require.Len(t, values, 2)
+		require.Equal(t, cache.Null, hits["missing"])
+
+		values, hits = c.GetMany(ctx, []string{"c", "unknown"})
+		require.Equal(t, map[string]string{"c": "C"}, values)
+		require.Equal(t, cache.Miss, hits["unknown"])
+	})
+
+	t.Run("stale keys are revalidated in the background", func(t *testing.T) {
+		calls.Store(0)
+		clk.Tick(2 * time.Minute)
+		origin["a"] = "A2"
+
+		values, _, err := c.SWRMany(ctx, []string{"a", "b"}, refresh, findFirstOp)
+		require.NoError(t, err)
+		require.Equal(t, "A", values["a"])
+
+		require.Eventually(t, func() bool {
+			v, _ := c.Get(ctx, "a")
+			return v == "A2"
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, int32(1), calls.Load())
+		require.ElementsMatch(t, []string{"a", "b"}, requested.Load())
+	})
+
+	t.Run("origin errors return the cached values", func(t *testing.T) {
+		failing := func(context.Context, []string) (map[string]string, error) {
+			return nil, errors.New("database is down")
+		}
+
+		values, hits, err := c.SWRMany(ctx, []string{"a", "new"}, failing, findFirstOp)
+		require.Error(t, err)
+		require.Equal(t, map[string]string{"a": "A2"}, values)
+		require.Equal(t, cache.Miss, hits["new"])
+
+		_, hit := c.Get(ctx, "new")
+		require.Equal(t, cache.Miss, hit)
+	})
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/cache/invalidation"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+type invalidationMiddleware[K comparable, V any] struct {
+	next        cache.Cache[K, V]
+	broadcaster invalidation.Broadcaster
+	resource    string
+	origin      string
+	logger      logging.Logger
+}
+
+// WithInvalidation propagates removals to the caches of the same resource on
+// other nodes.
+//
+// Keys removed from the cache are published on the broadcaster after they
+// were removed locally, and keys removed on other nodes are removed from the
+// wrapped cache without publishing them again. Keys are encoded as JSON, so K
+// must survive a round trip through encoding/json.
+func WithInvalidation[K comparable, V any](
+	c cache.Cache[K, V],
+	broadcaster invalidation.Broadcaster,
+	resource string,
+	logger logging.Logger,
+) cache.Cache[K, V] {
+	mw := &invalidationMiddleware[K, V]{
+		next:        c,
+		broadcaster: broadcaster,
+		resource:    resource,
+		origin:      uid.New(uid.InstancePrefix),
+		logger:      logger,
+	}
+
+	broadcaster.Subscribe(resource, mw.handle)
+
+	return mw
+}
+
+// handle removes the keys of events published by other nodes.
+func (mw *invalidationMiddleware[K, V]) handle(ctx context.Context, event invalidation.Event) {
+	if event.Origin == mw.origin {
+		return
+	}
+
+	var keys []K
+	err := json.Unmarshal(event.Keys, &keys)
+	if err != nil {
+		mw.logger.Warn("failed to decode invalidated cache keys",
+			"resource", mw.resource,
+			"error", err.Error(),
+		)
+		return
+	}
+
+	mw.next.Remove(ctx, keys...)
+}
+
+func (mw *invalidationMiddleware[K, V]) Get(ctx context.Context, key K) (V, cache.CacheHit) {
+	return mw.next.Get(ctx, key)
+}
+
+func (mw *invalidationMiddleware[K, V]) Set(ctx context.Context, key K, value V) {
+	mw.next.Set(ctx, key, value)
+}
+
+func (mw *invalidationMiddleware[K, V]) SetNull(ctx context.Context, key K) {
+	mw.next.SetNull(ctx, key)
+}
+
+func (mw *invalidationMiddleware[K, V]) Remove(ctx context.Context, keys ...K) {
+	mw.next.Remove(ctx, keys...)
+
+	if len(keys) == 0 {
+		return
+	}
+
+	b, err := json.Marshal(keys)
+	if err != nil {
+		mw.logger.Error("failed to encode invalidated cache keys",
+			"resource", mw.resource,
+			"error", err.Error(),
+		)
+		return
+	}
+
+	// The local removal already happened, other nodes catch up once their
+	// entries turn stale if the event can not be published
+	err = mw.broadcaster.Publish(ctx, invalidation.Event{
+		Resource: mw.resource,
+		Origin:   mw.origin,
+		Keys:     b,
+	})
+	if err != nil {
+		mw.logger.Error("failed to publish cache invalidation",
+			"resource", mw.resource,
+			"error", err.Error(),
+		)
+	}
+}
+
+func (mw *invalidationMiddleware[K, V]) GetMany(ctx context.Context, keys []K) (map[K]V, map[K]cache.CacheHit) {
+	return mw.next.GetMany(ctx, keys)
+}
+
+func (mw *invalidationMiddleware[K, V]) SWRMany(
+	ctx context.Context,
+	keys []K,
+	refreshFromOrigin func(ctx context.Context, keys []K) (map[K]V, error),
+	op func(err error) cache.Op,
+) (map[K]V, map[K]cache.CacheHit, error) {
+	// nolint:wrapcheck
+	return mw.next.SWRMany(ctx, keys, refreshFromOrigin, op)
+}
+
+func (mw *invalidationMiddleware[K, V]) Dump(ctx context.Context) ([]byte, error) {
+	// nolint:wrapcheck
+	return mw.next.Dump(ctx)
+}
+
+func (mw *invalidationMiddleware[K, V]) Restore(ctx context.Context, data []byte) error {
+	// nolint:wrapcheck
+	return mw.next.Restore(ctx, data)
+}
+
+func (mw *invalidationMiddleware[K, V]) Clear(ctx context.Context) {
+	mw.next.Clear(ctx)
+}
+
+func (mw *invalidationMiddleware[K, V]) SWR(
+	ctx context.Context,
+	key K,
+	refreshFromOrigin func(ctx context.Context) (V, error),
+	op func(err error) cache.Op,
+) (V, cache.CacheHit, error) {
+	// nolint:wrapcheck
+	return mw.next.SWR(ctx, key, refreshFromOrigin, op)
+}
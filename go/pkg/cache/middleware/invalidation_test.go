@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/unkeyed/unkey/go/pkg/cache"
+	"github.com/unkeyed/unkey/go/pkg/cache/invalidation"
+	"github.com/unkeyed/unkey/go/pkg/cache/middleware"
+	"github.com/unkeyed/unkey/go/pkg/clock"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+func newNode[K comparable](t *testing.T, broadcaster invalidation.Broadcaster, resource string) cache.Cache[K, string] {
+	c, err := cache.New(cache.Config[K, string]{
+		Fresh:    time.Minute,
+		Stale:    time.Hour,
+		Logger:   logging.NewNoop(),
+		MaxSize:  1000,
+		Resource: resource,
+		Clock:    clock.New(),
+	})
+	require.NoError(t, err)
+
+	return middleware.WithInvalidation(c, broadcaster, resource, logging.NewNoop())
+}
+
+func TestInvalidationPropagatesRemovals(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := invalidation.NewMemory()
+
+	a := newNode[string](t, broadcaster, "keys")
+	b := newNode[string](t, broadcaster, "keys")
+	other := newNode[string](t, broadcaster, "apis")
+
+	for _, c := range []cache.Cache[string, string]{a, b, other} {
+		c.Set(ctx, "k1", "v1")
+		c.Set(ctx, "k2", "v2")
+	}
+
+	a.Remove(ctx, "k1")
+
+	_, hit := a.Get(ctx, "k1")
+	require.Equal(t, cache.Miss, hit)
+	_, hit = b.Get(ctx, "k1")
+	require.Equal(t, cache.Miss, hit)
+
+	// Other keys and other resources are untouched
+	v, hit := b.Get(ctx, "k2")
+	require.Equal(t, cache.Hit, hit)
+	require.Equal(t, "v2", v)
+	_, hit = other.Get(ctx, "k1")
+	require.Equal(t, cache.Hit, hit)
+}
+
+func TestInvalidationScopedKeys(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := invalidation.NewMemory()
+
+	a := newNode[cache.ScopedKey](t, broadcaster, "namespaces")
+	b := newNode[cache.ScopedKey](t, broadcaster, "namespaces")
+
+	key := cache.ScopedKey{WorkspaceID: "ws_1", Key: "api-calls"}
+	sameKeyOtherWorkspace := cache.ScopedKey{WorkspaceID: "ws_2", Key: "api-calls"}
+	b.Set(ctx, key, "ns_1")
+	b.Set(ctx, sameKeyOtherWorkspace, "ns_2")
+
+	a.Remove(ctx, key)
+
+	_, hit := b.Get(ctx, key)
+	require.Equal(t, cache.Miss, hit)
+	_, hit = b.Get(ctx, sameKeyOtherWorkspace)
+	require.Equal(t, cache.Hit, hit)
+}
@@ -55,6 +55,34 @@ func (mw *tracingMiddleware[K, V]) Remove(ctx context.Context, keys ...K) {
 	mw.next.Remove(ctx, keys...)
 }
 
+func (mw *tracingMiddleware[K, V]) GetMany(ctx context.Context, keys []K) (map[K]V, map[K]cache.CacheHit) {
+	ctx, span := tracing.Start(ctx, "cache.GetMany")
+	defer span.End()
+	span.SetAttributes(attribute.Int("count", len(keys)))
+
+	values, hits := mw.next.GetMany(ctx, keys)
+	span.SetAttributes(attribute.Int("hits", len(values)))
+	return values, hits
+}
+
+func (mw *tracingMiddleware[K, V]) SWRMany(ctx context.Context, keys []K, refreshFromOrigin func(ctx context.Context, keys []K) (map[K]V, error), op func(err error) cache.Op) (map[K]V, map[K]cache.CacheHit, error) {
+	ctx, span := tracing.Start(ctx, "cache.SWRMany")
+	defer span.End()
+	span.SetAttributes(attribute.Int("count", len(keys)))
+
+	values, hits, err := mw.next.SWRMany(ctx, keys, func(innerCtx context.Context, missing []K) (map[K]V, error) {
+		innerCtx, innerSpan := tracing.Start(innerCtx, "refreshFromOrigin")
+		defer innerSpan.End()
+		innerSpan.SetAttributes(attribute.Int("count", len(missing)))
+		return refreshFromOrigin(innerCtx, missing)
+	}, op)
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+
+	return values, hits, err
+}
+
 func (mw *tracingMiddleware[K, V]) Dump(ctx context.Context) ([]byte, error) {
 	ctx, span := tracing.Start(ctx, "cache.Dump")
 	defer span.End()
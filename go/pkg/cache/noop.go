@@ -27,6 +27,22 @@ func (c *noopCache[K, V]) SWR(ctx context.Context, key K, refreshFromOrigin func
 	return v, Miss, nil
 }
 
+func (c *noopCache[K, V]) GetMany(ctx context.Context, keys []K) (map[K]V, map[K]CacheHit) {
+	hits := make(map[K]CacheHit, len(keys))
+	for _, key := range keys {
+		hits[key] = Miss
+	}
+	return map[K]V{}, hits
+}
+
+func (c *noopCache[K, V]) SWRMany(ctx context.Context, keys []K, refreshFromOrigin func(context.Context, []K) (map[K]V, error), op func(err error) Op) (map[K]V, map[K]CacheHit, error) {
+	hits := make(map[K]CacheHit, len(keys))
+	for _, key := range keys {
+		hits[key] = Miss
+	}
+	return map[K]V{}, hits, nil
+}
+
 func NewNoopCache[K comparable, V any]() Cache[K, V] {
 	return &noopCache[K, V]{}
 }
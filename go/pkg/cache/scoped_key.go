@@ -55,3 +55,10 @@ type ScopedKey struct {
 	// Different workspaces may have resources with the same key value.
 	Key string
 }
+
+// ToString returns the key in a form suitable for external stores such as
+// the L2 tier. Workspace IDs never contain a colon, so keys of different
+// workspaces can not collide.
+func (k ScopedKey) ToString() string {
+	return k.WorkspaceID + ":" + k.Key
+}
@@ -33,6 +33,10 @@ type Querier interface {
 
 	GetBillableVerifications(ctx context.Context, workspaceID string, year, month int) (int64, error)
 	GetBillableRatelimits(ctx context.Context, workspaceID string, year, month int) (int64, error)
+
+	// GetVerifications counts key verifications, grouped and filtered as
+	// requested, from the aggregate table of the requested granularity.
+	GetVerifications(ctx context.Context, req VerificationsRequest) ([]VerificationsRow, error)
 }
 
 type ClickHouse interface {
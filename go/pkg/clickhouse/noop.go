@@ -40,6 +40,11 @@ func (n *noop) GetBillableRatelimits(ctx context.Context, workspaceID string, ye
 	return 0, nil
 }
 
+// GetVerifications implements the Querier interface but always returns no rows.
+func (n *noop) GetVerifications(ctx context.Context, req VerificationsRequest) ([]VerificationsRow, error) {
+	return []VerificationsRow{}, nil
+}
+
 func (n *noop) Conn() ch.Conn {
 	return nil
 }
@@ -0,0 +1,254 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unkeyed/unkey/go/pkg/fault"
+)
+
+// Granularity is the size of the time buckets that verifications are
+// aggregated into. Every granularity is backed by its own aggregate table.
+type Granularity string
+
+const (
+	GranularityMinute Granularity = "minute"
+	GranularityHour   Granularity = "hour"
+	GranularityDay    Granularity = "day"
+	GranularityMonth  Granularity = "month"
+)
+
+// maxRanges bounds the time range that can be queried at each granularity,
+// so a single query can't return an unbounded number of buckets.
+var maxRanges = map[Granularity]time.Duration{
+	GranularityMinute: 24 * time.Hour,
+	GranularityHour:   31 * 24 * time.Hour,
+	GranularityDay:    3 * 366 * 24 * time.Hour,
+	GranularityMonth:  0, // unbounded
+}
+
+var verificationTables = map[Granularity]string{
+	GranularityMinute: "verifications.key_verifications_per_minute_v1",
+	GranularityHour:   "verifications.key_verifications_per_hour_v3",
+	GranularityDay:    "verifications.key_verifications_per_day_v3",
+	GranularityMonth:  "verifications.key_verifications_per_month_v3",
+}
+
+// PickGranularity returns the finest granularity that may be used to query
+// the time range between start and end.
+func PickGranularity(start, end time.Time) Granularity {
+	d := end.Sub(start)
+	for _, g := range []Granularity{GranularityMinute, GranularityHour, GranularityDay} {
+		if d <= maxRanges[g] {
+			return g
+		}
+	}
+
+	return GranularityMonth
+}
+
+// Validate returns an error if g is unknown or the time range between start
+// and end is too long to be queried at this granularity.
+func (g Granularity) Validate(start, end time.Time) error {
+	maxRange, ok := maxRanges[g]
+	if !ok {
+		return fmt.Errorf("unknown granularity %q", g)
+	}
+
+	if maxRange > 0 && end.Sub(start) > maxRange {
+		return fmt.Errorf("time range of %s is too long for %s granularity, the maximum is %s", end.Sub(start), g, maxRange)
+	}
+
+	return nil
+}
+
+// Truncate rounds t down to the start of its bucket.
+func (g Granularity) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+
+	switch g {
+	case GranularityMinute:
+		return t.Truncate(time.Minute)
+	case GranularityHour:
+		return t.Truncate(time.Hour)
+	case GranularityDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case GranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return t
+	}
+}
+
+// VerificationsGroupBy is a dimension that verifications can be grouped by.
+type VerificationsGroupBy string
+
+const (
+	GroupByTime    VerificationsGroupBy = "time"
+	GroupByOutcome VerificationsGroupBy = "outcome"
+	GroupByTag     VerificationsGroupBy = "tag"
+	GroupByKey     VerificationsGroupBy = "key"
+)
+
+// VerificationsRequest describes which verifications to count and how to
+// group them.
+type VerificationsRequest struct {
+	WorkspaceID string
+
+	// Start and End bound the time range, both inclusive. Start is rounded
+	// down to the start of its bucket.
+	Start time.Time
+	End   time.Time
+
+	// Granularity selects the aggregate table to query, use PickGranularity
+	// to find the finest one for the time range.
+	Granularity Granularity
+
+	// Optional filters, empty filters match everything.
+	KeySpaceID  string
+	KeyIDs      []string
+	IdentityIDs []string
+	Outcomes    []string
+	// Tags only matches verifications that carry all of these tags.
+	Tags []string
+
+	// GroupBy lists the dimensions to group by. Without any, a single row
+	// with the total count is returned. Grouping by tag counts every tag of a
+	// verification separately and omits verifications without tags.
+	GroupBy []VerificationsGroupBy
+
+	// Limit caps the number of rows, 0 means no limit.
+	Limit int
+}
+
+// VerificationsRow is a single group of verifications. Only the fields of
+// dimensions that were grouped by are set.
+type VerificationsRow struct {
+	Time    time.Time `ch:"time"`
+	Outcome string    `ch:"outcome"`
+	Tag     string    `ch:"tag"`
+	KeyID   string    `ch:"key_id"`
+	Count   int64     `ch:"count"`
+}
+
+// GetVerifications counts key verifications from the aggregate table that
+// matches the request's granularity.
+//
+// Example:
+//
+//	rows, err := ch.GetVerifications(ctx, clickhouse.VerificationsRequest{
+//	    WorkspaceID: "ws_123abc",
+//	    Start:       time.Now().Add(-24 * time.Hour),
+//	    End:         time.Now(),
+//	    Granularity: clickhouse.GranularityHour,
+//	    GroupBy:     []clickhouse.VerificationsGroupBy{clickhouse.GroupByTime, clickhouse.GroupByOutcome},
+//	})
+func (c *clickhouse) GetVerifications(ctx context.Context, req VerificationsRequest) ([]VerificationsRow, error) {
+	query, args, err := buildVerificationsQuery(req)
+	if err != nil {
+		return nil, fault.Wrap(err, fault.Internal("invalid verifications query"))
+	}
+
+	rows := []VerificationsRow{}
+	err = c.conn.Select(ctx, &rows, query, args...)
+	if err != nil {
+		return nil, fault.Wrap(err, fault.Internal("failed to query verifications"))
+	}
+
+	return rows, nil
+}
+
+// buildVerificationsQuery returns the SQL and its positional arguments for
+// req. Only values are passed as arguments, every identifier in the query
+// comes from a fixed set.
+func buildVerificationsQuery(req VerificationsRequest) (string, []any, error) {
+	table, ok := verificationTables[req.Granularity]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown granularity %q", req.Granularity)
+	}
+
+	columns := []string{}
+	groupBy := []string{}
+	orderBy := []string{}
+	arrayJoin := ""
+
+	for _, g := range req.GroupBy {
+		switch g {
+		case GroupByTime:
+			columns = append(columns, "time")
+			groupBy = append(groupBy, "time")
+			orderBy = append(orderBy, "time ASC")
+		case GroupByOutcome:
+			columns = append(columns, "outcome")
+			groupBy = append(groupBy, "outcome")
+		case GroupByTag:
+			arrayJoin = "ARRAY JOIN tags AS tag"
+			columns = append(columns, "tag")
+			groupBy = append(groupBy, "tag")
+		case GroupByKey:
+			columns = append(columns, "key_id")
+			groupBy = append(groupBy, "key_id")
+		default:
+			return "", nil, fmt.Errorf("unknown group by %q", g)
+		}
+	}
+
+	columns = append(columns, "sum(count) AS count")
+	orderBy = append(orderBy, "count DESC")
+
+	where := []string{
+		"workspace_id = ?",
+		"time >= ?",
+		"time <= ?",
+	}
+	args := []any{
+		req.WorkspaceID,
+		req.Granularity.Truncate(req.Start),
+		req.End.UTC(),
+	}
+
+	if req.KeySpaceID != "" {
+		where = append(where, "key_space_id = ?")
+		args = append(args, req.KeySpaceID)
+	}
+
+	if len(req.KeyIDs) > 0 {
+		where = append(where, "has(?, key_id)")
+		args = append(args, req.KeyIDs)
+	}
+
+	if len(req.IdentityIDs) > 0 {
+		where = append(where, "has(?, identity_id)")
+		args = append(args, req.IdentityIDs)
+	}
+
+	if len(req.Outcomes) > 0 {
+		where = append(where, "has(?, outcome)")
+		args = append(args, req.Outcomes)
+	}
+
+	if len(req.Tags) > 0 {
+		where = append(where, "hasAll(tags, ?)")
+		args = append(args, req.Tags)
+	}
+
+	query := strings.Builder{}
+	query.WriteString("SELECT " + strings.Join(columns, ", "))
+	query.WriteString(" FROM " + table)
+	if arrayJoin != "" {
+		query.WriteString(" " + arrayJoin)
+	}
+	query.WriteString(" WHERE " + strings.Join(where, " AND "))
+	if len(groupBy) > 0 {
+		query.WriteString(" GROUP BY " + strings.Join(groupBy, ", "))
+	}
+	query.WriteString(" ORDER BY " + strings.Join(orderBy, ", "))
+	if req.Limit > 0 {
+		query.WriteString(" LIMIT ?")
+		args = append(args, req.Limit)
+	}
+
+	return query.String(), args, nil
+}
@@ -0,0 +1,105 @@
+package clickhouse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickGranularity(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	require.Equal(t, GranularityMinute, PickGranularity(start, start.Add(time.Hour)))
+	require.Equal(t, GranularityMinute, PickGranularity(start, start.Add(24*time.Hour)))
+	require.Equal(t, GranularityHour, PickGranularity(start, start.Add(7*24*time.Hour)))
+	require.Equal(t, GranularityDay, PickGranularity(start, start.AddDate(0, 6, 0)))
+	require.Equal(t, GranularityMonth, PickGranularity(start, start.AddDate(5, 0, 0)))
+}
+
+func TestGranularityValidate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	require.NoError(t, GranularityMinute.Validate(start, start.Add(time.Hour)))
+	require.Error(t, GranularityMinute.Validate(start, start.Add(48*time.Hour)))
+	require.NoError(t, GranularityMonth.Validate(start, start.AddDate(10, 0, 0)))
+	require.Error(t, Granularity("week").Validate(start, start.Add(time.Hour)))
+}
+
+func TestGranularityTruncate(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+
+	require.Equal(t, time.Date(2025, 3, 14, 15, 9, 0, 0, time.UTC), GranularityMinute.Truncate(ts))
+	require.Equal(t, time.Date(2025, 3, 14, 15, 0, 0, 0, time.UTC), GranularityHour.Truncate(ts))
+	require.Equal(t, time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), GranularityDay.Truncate(ts))
+	require.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), GranularityMonth.Truncate(ts))
+}
+
+func TestBuildVerificationsQuery(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	t.Run("total count", func(t *testing.T) {
+		t.Parallel()
+
+		query, args, err := buildVerificationsQuery(VerificationsRequest{
+			WorkspaceID: "ws_123",
+			Start:       start,
+			End:         end,
+			Granularity: GranularityHour,
+		})
+		require.NoError(t, err)
+		require.Equal(t,
+			"SELECT sum(count) AS count FROM verifications.key_verifications_per_hour_v3 WHERE workspace_id = ? AND time >= ? AND time <= ? ORDER BY count DESC",
+			query,
+		)
+		require.Equal(t, []any{"ws_123", time.Date(2025, 3, 14, 15, 0, 0, 0, time.UTC), end}, args)
+	})
+
+	t.Run("filters and groups", func(t *testing.T) {
+		t.Parallel()
+
+		query, args, err := buildVerificationsQuery(VerificationsRequest{
+			WorkspaceID: "ws_123",
+			Start:       start,
+			End:         end,
+			Granularity: GranularityMinute,
+			KeySpaceID:  "ks_123",
+			KeyIDs:      []string{"key_1", "key_2"},
+			Outcomes:    []string{"VALID"},
+			Tags:        []string{"path=/v1"},
+			GroupBy:     []VerificationsGroupBy{GroupByTime, GroupByTag},
+			Limit:       100,
+		})
+		require.NoError(t, err)
+		require.Equal(t,
+			"SELECT time, tag, sum(count) AS count FROM verifications.key_verifications_per_minute_v1 ARRAY JOIN tags AS tag"+
+				" WHERE workspace_id = ? AND time >= ? AND time <= ? AND key_space_id = ? AND has(?, key_id) AND has(?, outcome) AND hasAll(tags, ?)"+
+				" GROUP BY time, tag ORDER BY time ASC, count DESC LIMIT ?",
+			query,
+		)
+		require.Len(t, args, 8)
+		require.Equal(t, 100, args[7])
+	})
+
+	t.Run("unknown group by", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := buildVerificationsQuery(VerificationsRequest{
+			WorkspaceID: "ws_123",
+			Start:       start,
+			End:         end,
+			Granularity: GranularityHour,
+			GroupBy:     []VerificationsGroupBy{"region"},
+		})
+		require.Error(t, err)
+	})
+}
@@ -84,6 +84,8 @@ const (
 
 	// NotFound indicates the requested rate limit namespace was not found.
 	UnkeyDataErrorsRatelimitNamespaceNotFound URN = "err:unkey:data:ratelimit_namespace_not_found"
+	// Duplicate indicates the requested rate limit namespace already exists.
+	UnkeyDataErrorsRatelimitNamespaceDuplicate URN = "err:unkey:data:ratelimit_namespace_already_exists"
 
 	// RatelimitOverride
 
@@ -102,6 +104,11 @@ const (
 	// NotFound indicates the requested audit log was not found.
 	UnkeyDataErrorsAuditLogNotFound URN = "err:unkey:data:audit_log_not_found"
 
+	// Webhook
+
+	// NotFound indicates the requested webhook endpoint was not found.
+	UnkeyDataErrorsWebhookNotFound URN = "err:unkey:data:webhook_not_found"
+
 	// ----------------
 	// UnkeyAppErrors
 	// ----------------
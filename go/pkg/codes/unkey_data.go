@@ -48,6 +48,9 @@ type dataKeyAuth struct {
 type dataRatelimitNamespace struct {
 	// NotFound indicates the requested rate limit namespace was not found.
 	NotFound Code
+
+	// Duplicate indicates the requested rate limit namespace already exists.
+	Duplicate Code
 }
 
 // dataRatelimitOverride defines errors related to rate limit override operations.
@@ -71,6 +74,12 @@ type dataAuditLog struct {
 	NotFound Code
 }
 
+// dataWebhook defines errors related to webhook endpoint operations.
+type dataWebhook struct {
+	// NotFound indicates the requested webhook endpoint was not found.
+	NotFound Code
+}
+
 // UnkeyDataErrors defines all data-related errors in the Unkey system.
 // These errors generally relate to CRUD operations on domain entities.
 type UnkeyDataErrors struct {
@@ -85,6 +94,7 @@ type UnkeyDataErrors struct {
 	RatelimitOverride  dataRatelimitOverride
 	Identity           dataIdentity
 	AuditLog           dataAuditLog
+	Webhook            dataWebhook
 }
 
 // Data contains all predefined data-related error codes.
@@ -118,7 +128,8 @@ var Data = UnkeyDataErrors{
 	},
 
 	RatelimitNamespace: dataRatelimitNamespace{
-		NotFound: Code{SystemUnkey, CategoryUnkeyData, "ratelimit_namespace_not_found"},
+		NotFound:  Code{SystemUnkey, CategoryUnkeyData, "ratelimit_namespace_not_found"},
+		Duplicate: Code{SystemUnkey, CategoryUnkeyData, "ratelimit_namespace_already_exists"},
 	},
 
 	RatelimitOverride: dataRatelimitOverride{
@@ -133,4 +144,8 @@ var Data = UnkeyDataErrors{
 	AuditLog: dataAuditLog{
 		NotFound: Code{SystemUnkey, CategoryUnkeyData, "audit_log_not_found"},
 	},
+
+	Webhook: dataWebhook{
+		NotFound: Code{SystemUnkey, CategoryUnkeyData, "webhook_not_found"},
+	},
 }
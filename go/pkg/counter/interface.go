@@ -127,6 +127,55 @@ type Counter interface {
 	//   - error: Any errors that occurred during the operation
 	SetIfNotExists(ctx context.Context, key string, value int64, ttl ...time.Duration) (bool, error)
 
+	// Set sets a counter to a specific value, overwriting any existing value.
+	// This is useful when the counter has to reflect a value that was changed
+	// outside of the counter, for example after resetting credits in the database.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and tracing
+	//   - key: Unique identifier for the counter
+	//   - value: Value to set the counter to
+	//   - ttl: Optional time-to-live duration for the counter
+	//
+	// Returns:
+	//   - error: Any errors that occurred during the operation
+	Set(ctx context.Context, key string, value int64, ttl ...time.Duration) error
+
+	// TakeTokens atomically refills and consumes tokens from a token bucket.
+	//
+	// The bucket starts full and gains refillRate tokens per interval, it never
+	// holds more than capacity tokens. Tokens are only consumed if at least cost
+	// tokens are available, a rejected request leaves the bucket unchanged.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and tracing
+	//   - key: Unique identifier for the bucket
+	//   - capacity: Maximum number of tokens the bucket can hold (must be > 0)
+	//   - refillRate: Number of tokens added per interval (must be > 0)
+	//   - interval: Duration over which refillRate tokens are added
+	//   - cost: Number of tokens to consume (must be >= 0)
+	//   - now: Time of the request, used to calculate the refill
+	//
+	// Returns:
+	//   - float64: Tokens left in the bucket after the operation, may be fractional
+	//   - bool: Whether the tokens were consumed
+	//   - error: Any errors that occurred during the operation
+	TakeTokens(ctx context.Context, key string, capacity, refillRate int64, interval time.Duration, cost int64, now time.Time) (float64, bool, error)
+
+	// ChargeTokens atomically refills and consumes tokens from a token bucket,
+	// like TakeTokens, but always consumes them. If fewer than cost tokens are
+	// available the bucket goes negative and pays the debt back through refills.
+	//
+	// This is used to account for tokens that were already granted elsewhere,
+	// for example by a local decision that is replayed later.
+	//
+	// Parameters are the same as for TakeTokens.
+	//
+	// Returns:
+	//   - float64: Tokens left in the bucket after the operation, may be negative
+	//   - error: Any errors that occurred during the operation
+	ChargeTokens(ctx context.Context, key string, capacity, refillRate int64, interval time.Duration, cost int64, now time.Time) (float64, error)
+
 	// Delete removes a counter key from the store.
 	// This is useful for forcing reinitialization or cleaning up stale data.
 	//
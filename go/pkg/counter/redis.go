@@ -44,11 +44,65 @@ const (
 		local newValue = redis.call('DECRBY', key, decrement)
 		return {newValue, 1, 1}  -- {new_decremented_value, existed=true, success=true}
 	`
+
+	// takeTokensScript is the Lua script for atomic token bucket operations.
+	// The bucket is stored as a hash of the remaining tokens and the time of
+	// the last refill in milliseconds. Missing buckets are treated as full.
+	//
+	// If force is set the tokens are consumed even if not enough are available,
+	// the bucket then goes negative and pays back the debt through refills.
+	//
+	// Tokens are returned as a string because redis truncates Lua numbers to
+	// integers, which would drop partially refilled tokens.
+	//
+	// Returns {tokens, success}
+	takeTokensScript = `
+		local key = KEYS[1]
+		local capacity = tonumber(ARGV[1])
+		local refillRate = tonumber(ARGV[2])
+		local interval = tonumber(ARGV[3])
+		local cost = tonumber(ARGV[4])
+		local now = tonumber(ARGV[5])
+		local force = tonumber(ARGV[6])
+
+		local state = redis.call('HMGET', key, 'tokens', 'updated')
+		local tokens = tonumber(state[1])
+		local updated = tonumber(state[2])
+		if tokens == nil or updated == nil then
+			tokens = capacity
+			updated = now
+		end
+
+		-- Requests may arrive slightly out of order, never refill backwards
+		if now > updated then
+			tokens = math.min(capacity, tokens + (now - updated) * refillRate / interval)
+			updated = now
+		end
+
+		local success = 0
+		if tokens >= cost then
+			success = 1
+		end
+		if success == 1 or force == 1 then
+			tokens = tokens - cost
+		end
+
+		-- Expire once the bucket would have been refilled completely, plus
+		-- one interval of headroom
+		local ttl = math.ceil((capacity - tokens) * interval / refillRate) + interval
+
+		redis.call('HSET', key, 'tokens', tostring(tokens), 'updated', updated)
+		redis.call('PEXPIRE', key, ttl)
+		return {tostring(tokens), success}
+	`
 )
 
 var (
 	// decrementIfExistsScriptCached is the cached script for atomic decrement operations
 	decrementIfExistsScriptCached = redis.NewScript(decrementIfExistsScript)
+
+	// takeTokensScriptCached is the cached script for token bucket operations
+	takeTokensScriptCached = redis.NewScript(takeTokensScript)
 )
 
 // redisCounter implements the Counter interface using Redis.
@@ -211,6 +265,19 @@ func (r *redisCounter) SetIfNotExists(ctx context.Context, key string, value int
 	return result.Val(), result.Err()
 }
 
+// Set sets a counter to a specific value, overwriting any existing value.
+func (r *redisCounter) Set(ctx context.Context, key string, value int64, ttl ...time.Duration) error {
+	ctx, span := tracing.Start(ctx, "RedisCounter.Set")
+	defer span.End()
+
+	var duration time.Duration
+	if len(ttl) > 0 {
+		duration = ttl[0]
+	}
+
+	return r.redis.Set(ctx, key, value, duration).Err()
+}
+
 // DecrementIfExists performs an atomic decrement operation.
 // Uses a cached Lua script (EVALSHA) to atomically check existence, verify sufficient
 // credits, and conditionally decrement. Returns the actual current counter value and
@@ -256,6 +323,84 @@ func (r *redisCounter) DecrementIfExists(ctx context.Context, key string, value
 	return actualValue, existedFlag == 1, successFlag == 1, nil
 }
 
+// TakeTokens atomically refills and consumes tokens from a token bucket.
+//
+// Uses a cached Lua script (EVALSHA) so the refill and the consumption happen
+// in a single round trip without races between concurrent callers. The bucket
+// expires once it would have been refilled completely, at which point it is
+// indistinguishable from a new bucket.
+func (r *redisCounter) TakeTokens(ctx context.Context, key string, capacity, refillRate int64, interval time.Duration, cost int64, now time.Time) (float64, bool, error) {
+	ctx, span := tracing.Start(ctx, "RedisCounter.TakeTokens")
+	defer span.End()
+
+	return r.runTakeTokens(ctx, key, capacity, refillRate, interval, cost, now, false)
+}
+
+// ChargeTokens atomically refills and consumes tokens from a token bucket,
+// even if that leaves the bucket with a negative balance.
+//
+// Uses the same Lua script as TakeTokens.
+func (r *redisCounter) ChargeTokens(ctx context.Context, key string, capacity, refillRate int64, interval time.Duration, cost int64, now time.Time) (float64, error) {
+	ctx, span := tracing.Start(ctx, "RedisCounter.ChargeTokens")
+	defer span.End()
+
+	tokens, _, err := r.runTakeTokens(ctx, key, capacity, refillRate, interval, cost, now, true)
+	return tokens, err
+}
+
+// runTakeTokens validates the arguments and runs the token bucket script.
+func (r *redisCounter) runTakeTokens(ctx context.Context, key string, capacity, refillRate int64, interval time.Duration, cost int64, now time.Time, force bool) (float64, bool, error) {
+	err := assert.All(
+		assert.Greater(capacity, 0, "capacity must be greater than zero"),
+		assert.Greater(refillRate, 0, "refill rate must be greater than zero"),
+		assert.Greater(interval.Milliseconds(), 0, "interval must be at least 1ms"),
+		assert.GreaterOrEqual(cost, 0, "cost must not be negative"),
+	)
+	if err != nil {
+		return 0, false, err
+	}
+
+	forceFlag := 0
+	if force {
+		forceFlag = 1
+	}
+
+	result, err := takeTokensScriptCached.Run(ctx, r.redis, []string{key},
+		capacity,
+		refillRate,
+		interval.Milliseconds(),
+		cost,
+		now.UnixMilli(),
+		forceFlag,
+	).Result()
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Parse the result array [tokens, successFlag]
+	resultSlice, ok := result.([]interface{})
+	if !ok || len(resultSlice) != 2 {
+		return 0, false, fmt.Errorf("unexpected result format from Lua script")
+	}
+
+	tokensString, ok := resultSlice[0].(string)
+	if !ok {
+		return 0, false, fmt.Errorf("invalid tokens in result: %T", resultSlice[0])
+	}
+
+	tokens, err := strconv.ParseFloat(tokensString, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to parse tokens '%s': %w", tokensString, err)
+	}
+
+	successFlag, err := parseNumericValue(resultSlice[1])
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid success flag in result: %w", err)
+	}
+
+	return tokens, successFlag == 1, nil
+}
+
 // parseNumericValue safely converts various numeric types to int64
 func parseNumericValue(v interface{}) (int64, error) {
 	switch val := v.(type) {
@@ -726,6 +726,59 @@ func TestRedisCounterDelete(t *testing.T) {
 	})
 }
 
+func TestRedisCounterSet(t *testing.T) {
+	ctx := context.Background()
+	redisURL := containers.Redis(t)
+
+	ctr, err := NewRedis(RedisConfig{
+		RedisURL: redisURL,
+		Logger:   logging.New(),
+	})
+	require.NoError(t, err)
+	defer ctr.Close()
+
+	t.Run("SetNewKey", func(t *testing.T) {
+		key := fmt.Sprintf("test-set-new-%d", time.Now().UnixNano())
+
+		err := ctr.Set(ctx, key, 42)
+		require.NoError(t, err)
+
+		val, err := ctr.Get(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, int64(42), val)
+	})
+
+	t.Run("SetOverwritesExistingKey", func(t *testing.T) {
+		key := fmt.Sprintf("test-set-overwrite-%d", time.Now().UnixNano())
+
+		_, err := ctr.Increment(ctx, key, 5)
+		require.NoError(t, err)
+
+		err = ctr.Set(ctx, key, 100)
+		require.NoError(t, err)
+
+		// The overwritten value is used by subsequent decrements
+		val, existed, success, err := ctr.DecrementIfExists(ctx, key, 10)
+		require.NoError(t, err)
+		require.True(t, existed)
+		require.True(t, success)
+		require.Equal(t, int64(90), val)
+	})
+
+	t.Run("SetWithTTL", func(t *testing.T) {
+		key := fmt.Sprintf("test-set-ttl-%d", time.Now().UnixNano())
+
+		err := ctr.Set(ctx, key, 10, 1*time.Second)
+		require.NoError(t, err)
+
+		time.Sleep(1500 * time.Millisecond)
+
+		val, err := ctr.Get(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), val)
+	})
+}
+
 // TestRedisCounterDecrementLogic tests the decrement logic that avoids negative values
 func TestRedisCounterDecrementLogic(t *testing.T) {
 	ctx := context.Background()
@@ -915,3 +968,106 @@ func TestRedisCounterDecrementLogic(t *testing.T) {
 		require.Equal(t, int64(0), finalValue, "final value should be 0")
 	})
 }
+
+func TestRedisCounterTakeTokens(t *testing.T) {
+	ctx := context.Background()
+	redisURL := containers.Redis(t)
+
+	ctr, err := NewRedis(RedisConfig{
+		RedisURL: redisURL,
+		Logger:   logging.New(),
+	})
+	require.NoError(t, err)
+	defer ctr.Close()
+
+	t.Run("NewBucketIsFull", func(t *testing.T) {
+		key := uid.New(uid.TestPrefix)
+
+		tokens, success, err := ctr.TakeTokens(ctx, key, 10, 10, time.Second, 1, time.Now())
+		require.NoError(t, err)
+		require.True(t, success)
+		require.Equal(t, float64(9), tokens)
+	})
+
+	t.Run("RejectsWhenEmpty", func(t *testing.T) {
+		key := uid.New(uid.TestPrefix)
+		now := time.Now()
+
+		tokens, success, err := ctr.TakeTokens(ctx, key, 5, 1, time.Minute, 5, now)
+		require.NoError(t, err)
+		require.True(t, success)
+		require.Equal(t, float64(0), tokens)
+
+		tokens, success, err = ctr.TakeTokens(ctx, key, 5, 1, time.Minute, 1, now)
+		require.NoError(t, err)
+		require.False(t, success)
+		require.Equal(t, float64(0), tokens)
+	})
+
+	t.Run("RefillsOverTime", func(t *testing.T) {
+		key := uid.New(uid.TestPrefix)
+		now := time.Now()
+
+		_, success, err := ctr.TakeTokens(ctx, key, 10, 10, time.Second, 10, now)
+		require.NoError(t, err)
+		require.True(t, success)
+
+		// Half the interval refills half the tokens
+		tokens, success, err := ctr.TakeTokens(ctx, key, 10, 10, time.Second, 0, now.Add(500*time.Millisecond))
+		require.NoError(t, err)
+		require.True(t, success)
+		require.InDelta(t, float64(5), tokens, 0.001)
+
+		// The bucket never exceeds its capacity
+		tokens, _, err = ctr.TakeTokens(ctx, key, 10, 10, time.Second, 0, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, float64(10), tokens)
+	})
+
+	t.Run("ChargeGoesNegative", func(t *testing.T) {
+		key := uid.New(uid.TestPrefix)
+		now := time.Now()
+
+		tokens, err := ctr.ChargeTokens(ctx, key, 5, 5, time.Second, 8, now)
+		require.NoError(t, err)
+		require.Equal(t, float64(-3), tokens)
+
+		// The debt has to be paid back before tokens can be taken again
+		_, success, err := ctr.TakeTokens(ctx, key, 5, 5, time.Second, 1, now.Add(600*time.Millisecond))
+		require.NoError(t, err)
+		require.False(t, success)
+
+		tokens, success, err = ctr.TakeTokens(ctx, key, 5, 5, time.Second, 1, now.Add(time.Second))
+		require.NoError(t, err)
+		require.True(t, success)
+		require.InDelta(t, float64(1), tokens, 0.001)
+	})
+
+	t.Run("ConcurrentTakes", func(t *testing.T) {
+		key := uid.New(uid.TestPrefix)
+		now := time.Now()
+
+		const capacity = 50
+		var wg sync.WaitGroup
+		results := make(chan bool, capacity*2)
+		for range capacity * 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, success, takeErr := ctr.TakeTokens(ctx, key, capacity, 1, time.Hour, 1, now)
+				require.NoError(t, takeErr)
+				results <- success
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		successCount := 0
+		for success := range results {
+			if success {
+				successCount++
+			}
+		}
+		require.Equal(t, capacity, successCount)
+	})
+}
@@ -0,0 +1,235 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/hash"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/testutil/containers"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+// TestKeyRefillQueries tests the queries used by the scheduled key refill
+// This test requires Docker to be running for the MySQL container
+func TestKeyRefillQueries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+	ctx := context.Background()
+
+	mysqlCfg := containers.MySQL(t)
+	mysqlCfg.DBName = "unkey"
+
+	dbInstance, err := New(Config{
+		PrimaryDSN: mysqlCfg.FormatDSN(),
+		Logger:     logging.NewNoop(),
+	})
+	require.NoError(t, err)
+	defer dbInstance.Close()
+
+	// createKeyring creates a fresh workspace and keyring, so every subtest
+	// only sees its own keys
+	createKeyring := func(t *testing.T) (string, string) {
+		workspaceID := uid.New(uid.WorkspacePrefix)
+		keyringID := uid.New(uid.KeyAuthPrefix)
+
+		err := Query.InsertWorkspace(ctx, dbInstance.RW(), InsertWorkspaceParams{
+			ID:        workspaceID,
+			OrgID:     workspaceID,
+			Name:      "Test Workspace",
+			CreatedAt: time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+
+		err = Query.InsertKeyring(ctx, dbInstance.RW(), InsertKeyringParams{
+			ID:          keyringID,
+			WorkspaceID: workspaceID,
+			CreatedAtM:  time.Now().UnixMilli(),
+		})
+		require.NoError(t, err)
+
+		return workspaceID, keyringID
+	}
+
+	createKey := func(t *testing.T, workspaceID, keyringID string, refillDay sql.NullInt16, enabled bool) string {
+		keyID := uid.New(uid.KeyPrefix)
+		err := Query.InsertKey(ctx, dbInstance.RW(), InsertKeyParams{
+			ID:                keyID,
+			KeyringID:         keyringID,
+			Hash:              hash.Sha256(keyID),
+			Start:             "refill",
+			WorkspaceID:       workspaceID,
+			ForWorkspaceID:    sql.NullString{},
+			Name:              sql.NullString{String: "refill_key", Valid: true},
+			IdentityID:        sql.NullString{},
+			Meta:              sql.NullString{},
+			Expires:           sql.NullTime{},
+			CreatedAtM:        time.Now().UnixMilli(),
+			Enabled:           enabled,
+			RemainingRequests: sql.NullInt32{Int32: 10, Valid: true},
+			RefillDay:         refillDay,
+			RefillAmount:      sql.NullInt32{Int32: 100, Valid: true},
+		})
+		require.NoError(t, err)
+
+		return keyID
+	}
+
+	// listDue returns the ids of the workspace's keys that are due on day,
+	// computing until_day the same way the refill workflow does
+	listDue := func(t *testing.T, workspaceID string, day time.Time) []string {
+		startOfDay := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+		untilDay := day.Day()
+		if day.Day() == time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+			untilDay = 31
+		}
+
+		rows, err := Query.ListKeysDueForRefill(ctx, dbInstance.RO(), ListKeysDueForRefillParams{
+			RefilledBefore: sql.NullTime{Time: startOfDay, Valid: true},
+			Today:          sql.NullInt16{Int16: int16(day.Day()), Valid: true}, // nolint:gosec
+			UntilDay:       sql.NullInt16{Int16: int16(untilDay), Valid: true},  // nolint:gosec
+			IDCursor:       "",
+			Limit:          10_000,
+		})
+		require.NoError(t, err)
+
+		ids := []string{}
+		for _, row := range rows {
+			if row.WorkspaceID == workspaceID {
+				ids = append(ids, row.ID)
+			}
+		}
+		return ids
+	}
+
+	t.Run("daily keys are due every day", func(t *testing.T) {
+		workspaceID, keyringID := createKeyring(t)
+		keyID := createKey(t, workspaceID, keyringID, sql.NullInt16{}, true)
+
+		require.Equal(t, []string{keyID}, listDue(t, workspaceID, time.Date(2025, time.April, 3, 12, 0, 0, 0, time.UTC)))
+		require.Equal(t, []string{keyID}, listDue(t, workspaceID, time.Date(2025, time.April, 30, 12, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("monthly keys are due on their refill day", func(t *testing.T) {
+		workspaceID, keyringID := createKeyring(t)
+		keyID := createKey(t, workspaceID, keyringID, sql.NullInt16{Int16: 15, Valid: true}, true)
+
+		require.Empty(t, listDue(t, workspaceID, time.Date(2025, time.April, 14, 12, 0, 0, 0, time.UTC)))
+		require.Equal(t, []string{keyID}, listDue(t, workspaceID, time.Date(2025, time.April, 15, 12, 0, 0, 0, time.UTC)))
+		require.Empty(t, listDue(t, workspaceID, time.Date(2025, time.April, 16, 12, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("refill day 31 is due on the last day of shorter months", func(t *testing.T) {
+		workspaceID, keyringID := createKeyring(t)
+		keyID := createKey(t, workspaceID, keyringID, sql.NullInt16{Int16: 31, Valid: true}, true)
+
+		require.Empty(t, listDue(t, workspaceID, time.Date(2025, time.April, 29, 12, 0, 0, 0, time.UTC)))
+		require.Equal(t, []string{keyID}, listDue(t, workspaceID, time.Date(2025, time.April, 30, 12, 0, 0, 0, time.UTC)))
+		require.Equal(t, []string{keyID}, listDue(t, workspaceID, time.Date(2025, time.February, 28, 12, 0, 0, 0, time.UTC)))
+		require.Empty(t, listDue(t, workspaceID, time.Date(2025, time.May, 30, 12, 0, 0, 0, time.UTC)))
+		require.Equal(t, []string{keyID}, listDue(t, workspaceID, time.Date(2025, time.May, 31, 12, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("keys that can not be verified are not due", func(t *testing.T) {
+		workspaceID, keyringID := createKeyring(t)
+		createKey(t, workspaceID, keyringID, sql.NullInt16{}, false)
+
+		require.Empty(t, listDue(t, workspaceID, time.Now()))
+
+		deletedWorkspaceID, deletedKeyringID := createKeyring(t)
+		createKey(t, deletedWorkspaceID, deletedKeyringID, sql.NullInt16{}, true)
+
+		_, err := Query.SoftDeleteWorkspace(ctx, dbInstance.RW(), SoftDeleteWorkspaceParams{
+			Now: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+			ID:  deletedWorkspaceID,
+		})
+		require.NoError(t, err)
+
+		require.Empty(t, listDue(t, deletedWorkspaceID, time.Now()))
+	})
+
+	t.Run("keys are refilled once per day", func(t *testing.T) {
+		workspaceID, keyringID := createKeyring(t)
+		keyID := createKey(t, workspaceID, keyringID, sql.NullInt16{}, true)
+
+		now := time.Now().UTC()
+		startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+		refill := func() int {
+			refilled, err := TxWithResult(ctx, dbInstance.RW(), func(ctx context.Context, tx DBTX) (int, error) {
+				due, err := Query.LockKeysNotRefilledSince(ctx, tx, LockKeysNotRefilledSinceParams{
+					Ids:            []string{keyID},
+					RefilledBefore: sql.NullTime{Time: startOfDay, Valid: true},
+				})
+				if err != nil || len(due) == 0 {
+					return 0, err
+				}
+
+				return len(due), Query.UpdateManyKeysCreditsRefilled(ctx, tx, UpdateManyKeysCreditsRefilledParams{
+					Now: sql.NullTime{Time: now, Valid: true},
+					Ids: []string{due[0].ID},
+				})
+			})
+			require.NoError(t, err)
+			return refilled
+		}
+
+		require.Equal(t, 1, refill())
+
+		credits, err := Query.FindKeyCredits(ctx, dbInstance.RO(), keyID)
+		require.NoError(t, err)
+		require.Equal(t, int32(100), credits.Int32)
+
+		// Running again on the same day must not reset the credits again
+		err = Query.UpdateKeyCreditsDecrement(ctx, dbInstance.RW(), UpdateKeyCreditsDecrementParams{
+			ID:      keyID,
+			Credits: sql.NullInt32{Int32: 30, Valid: true},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 0, refill())
+		require.Empty(t, listDue(t, workspaceID, now))
+
+		credits, err = Query.FindKeyCredits(ctx, dbInstance.RO(), keyID)
+		require.NoError(t, err)
+		require.Equal(t, int32(70), credits.Int32)
+	})
+
+	t.Run("credits spent before a refill are not replayed onto it", func(t *testing.T) {
+		workspaceID, keyringID := createKeyring(t)
+		keyID := createKey(t, workspaceID, keyringID, sql.NullInt16{}, true)
+
+		refilledAt := time.Now().UTC()
+		err := Query.UpdateManyKeysCreditsRefilled(ctx, dbInstance.RW(), UpdateManyKeysCreditsRefilledParams{
+			Now: sql.NullTime{Time: refilledAt, Valid: true},
+			Ids: []string{keyID},
+		})
+		require.NoError(t, err)
+
+		err = Query.UpdateKeyCreditsDecrementUnlessRefilled(ctx, dbInstance.RW(), UpdateKeyCreditsDecrementUnlessRefilledParams{
+			Credits:   sql.NullInt32{Int32: 5, Valid: true},
+			ID:        keyID,
+			ChargedAt: sql.NullTime{Time: refilledAt.Add(-time.Second), Valid: true},
+		})
+		require.NoError(t, err)
+
+		credits, err := Query.FindKeyCredits(ctx, dbInstance.RO(), keyID)
+		require.NoError(t, err)
+		require.Equal(t, int32(100), credits.Int32)
+
+		err = Query.UpdateKeyCreditsDecrementUnlessRefilled(ctx, dbInstance.RW(), UpdateKeyCreditsDecrementUnlessRefilledParams{
+			Credits:   sql.NullInt32{Int32: 5, Valid: true},
+			ID:        keyID,
+			ChargedAt: sql.NullTime{Time: refilledAt.Add(time.Second), Valid: true},
+		})
+		require.NoError(t, err)
+
+		credits, err = Query.FindKeyCredits(ctx, dbInstance.RO(), keyID)
+		require.NoError(t, err)
+		require.Equal(t, int32(95), credits.Int32)
+	})
+}
@@ -453,6 +453,92 @@ func (ns NullPartitionsStatus) Value() (driver.Value, error) {
 	return string(ns.PartitionsStatus), nil
 }
 
+type RatelimitNamespacesAlgorithm string
+
+const (
+	RatelimitNamespacesAlgorithmSlidingWindow RatelimitNamespacesAlgorithm = "sliding_window"
+	RatelimitNamespacesAlgorithmFixedWindow   RatelimitNamespacesAlgorithm = "fixed_window"
+	RatelimitNamespacesAlgorithmTokenBucket   RatelimitNamespacesAlgorithm = "token_bucket"
+)
+
+func (e *RatelimitNamespacesAlgorithm) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RatelimitNamespacesAlgorithm(s)
+	case string:
+		*e = RatelimitNamespacesAlgorithm(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RatelimitNamespacesAlgorithm: %T", src)
+	}
+	return nil
+}
+
+type NullRatelimitNamespacesAlgorithm struct {
+	RatelimitNamespacesAlgorithm RatelimitNamespacesAlgorithm
+	Valid                        bool // Valid is true if RatelimitNamespacesAlgorithm is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRatelimitNamespacesAlgorithm) Scan(value interface{}) error {
+	if value == nil {
+		ns.RatelimitNamespacesAlgorithm, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RatelimitNamespacesAlgorithm.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRatelimitNamespacesAlgorithm) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RatelimitNamespacesAlgorithm), nil
+}
+
+type RatelimitOverridesAlgorithm string
+
+const (
+	RatelimitOverridesAlgorithmSlidingWindow RatelimitOverridesAlgorithm = "sliding_window"
+	RatelimitOverridesAlgorithmFixedWindow   RatelimitOverridesAlgorithm = "fixed_window"
+	RatelimitOverridesAlgorithmTokenBucket   RatelimitOverridesAlgorithm = "token_bucket"
+)
+
+func (e *RatelimitOverridesAlgorithm) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RatelimitOverridesAlgorithm(s)
+	case string:
+		*e = RatelimitOverridesAlgorithm(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RatelimitOverridesAlgorithm: %T", src)
+	}
+	return nil
+}
+
+type NullRatelimitOverridesAlgorithm struct {
+	RatelimitOverridesAlgorithm RatelimitOverridesAlgorithm
+	Valid                       bool // Valid is true if RatelimitOverridesAlgorithm is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRatelimitOverridesAlgorithm) Scan(value interface{}) error {
+	if value == nil {
+		ns.RatelimitOverridesAlgorithm, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RatelimitOverridesAlgorithm.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRatelimitOverridesAlgorithm) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RatelimitOverridesAlgorithm), nil
+}
+
 type RatelimitOverridesSharding string
 
 const (
@@ -910,26 +996,30 @@ type Ratelimit struct {
 }
 
 type RatelimitNamespace struct {
-	ID          string        `db:"id"`
-	WorkspaceID string        `db:"workspace_id"`
-	Name        string        `db:"name"`
-	CreatedAtM  int64         `db:"created_at_m"`
-	UpdatedAtM  sql.NullInt64 `db:"updated_at_m"`
-	DeletedAtM  sql.NullInt64 `db:"deleted_at_m"`
+	ID          string                       `db:"id"`
+	WorkspaceID string                       `db:"workspace_id"`
+	Name        string                       `db:"name"`
+	Algorithm   RatelimitNamespacesAlgorithm `db:"algorithm"`
+	RefillRate  sql.NullInt32                `db:"refill_rate"`
+	CreatedAtM  int64                        `db:"created_at_m"`
+	UpdatedAtM  sql.NullInt64                `db:"updated_at_m"`
+	DeletedAtM  sql.NullInt64                `db:"deleted_at_m"`
 }
 
 type RatelimitOverride struct {
-	ID          string                         `db:"id"`
-	WorkspaceID string                         `db:"workspace_id"`
-	NamespaceID string                         `db:"namespace_id"`
-	Identifier  string                         `db:"identifier"`
-	Limit       int32                          `db:"limit"`
-	Duration    int32                          `db:"duration"`
-	Async       sql.NullBool                   `db:"async"`
-	Sharding    NullRatelimitOverridesSharding `db:"sharding"`
-	CreatedAtM  int64                          `db:"created_at_m"`
-	UpdatedAtM  sql.NullInt64                  `db:"updated_at_m"`
-	DeletedAtM  sql.NullInt64                  `db:"deleted_at_m"`
+	ID          string                          `db:"id"`
+	WorkspaceID string                          `db:"workspace_id"`
+	NamespaceID string                          `db:"namespace_id"`
+	Identifier  string                          `db:"identifier"`
+	Limit       int32                           `db:"limit"`
+	Duration    int32                           `db:"duration"`
+	Async       sql.NullBool                    `db:"async"`
+	Sharding    NullRatelimitOverridesSharding  `db:"sharding"`
+	Algorithm   NullRatelimitOverridesAlgorithm `db:"algorithm"`
+	RefillRate  sql.NullInt32                   `db:"refill_rate"`
+	CreatedAtM  int64                           `db:"created_at_m"`
+	UpdatedAtM  sql.NullInt64                   `db:"updated_at_m"`
+	DeletedAtM  sql.NullInt64                   `db:"deleted_at_m"`
 }
 
 type Role struct {
@@ -985,6 +1075,29 @@ type VercelIntegration struct {
 	DeletedAtM  sql.NullInt64  `db:"deleted_at_m"`
 }
 
+type WebhookEndpoint struct {
+	ID              string          `db:"id"`
+	WorkspaceID     string          `db:"workspace_id"`
+	Url             string          `db:"url"`
+	Events          json.RawMessage `db:"events"`
+	Enabled         bool            `db:"enabled"`
+	Encrypted       string          `db:"encrypted"`
+	EncryptionKeyID string          `db:"encryption_key_id"`
+	CreatedAtM      int64           `db:"created_at_m"`
+	UpdatedAtM      sql.NullInt64   `db:"updated_at_m"`
+	DeletedAtM      sql.NullInt64   `db:"deleted_at_m"`
+}
+
+type WebhookEvent struct {
+	ID           string          `db:"id"`
+	WorkspaceID  string          `db:"workspace_id"`
+	Event        string          `db:"event"`
+	DedupeKey    string          `db:"dedupe_key"`
+	Payload      json.RawMessage `db:"payload"`
+	CreatedAt    int64           `db:"created_at"`
+	DispatchedAt sql.NullInt64   `db:"dispatched_at"`
+}
+
 type Workspace struct {
 	ID                   string             `db:"id"`
 	OrgID                string             `db:"org_id"`
@@ -10,6 +10,13 @@ import (
 )
 
 type Querier interface {
+	//CountKeyEncryptionsByEncryptionKeyID
+	//
+	//  SELECT encryption_key_id, COUNT(*) AS total
+	//  FROM encrypted_keys
+	//  GROUP BY encryption_key_id
+	//  ORDER BY encryption_key_id ASC
+	CountKeyEncryptionsByEncryptionKeyID(ctx context.Context, db DBTX) ([]CountKeyEncryptionsByEncryptionKeyIDRow, error)
 	//DeleteAllKeyPermissionsByKeyID
 	//
 	//  DELETE FROM keys_permissions
@@ -192,6 +199,20 @@ type Querier interface {
 	//
 	//  SELECT id, workspace_id, domain_id, token, authorization, status, type, created_at, updated_at, expires_at FROM domain_challenges WHERE workspace_id = ? AND domain_id = ? AND token = ?
 	FindDomainChallengeByToken(ctx context.Context, db DBTX, arg FindDomainChallengeByTokenParams) (DomainChallenge, error)
+	//FindHostnameRouteByHostname
+	//
+	//  SELECT
+	//      id,
+	//      workspace_id,
+	//      project_id,
+	//      hostname,
+	//      deployment_id,
+	//      is_enabled,
+	//      created_at,
+	//      updated_at
+	//  FROM hostname_routes
+	//  WHERE hostname = ?
+	FindHostnameRouteByHostname(ctx context.Context, db DBTX, hostname string) (HostnameRoute, error)
 	//FindHostnameRoutesByDeploymentId
 	//
 	//  SELECT
@@ -644,14 +665,16 @@ type Querier interface {
 	FindProjectByWorkspaceSlug(ctx context.Context, db DBTX, arg FindProjectByWorkspaceSlugParams) (Project, error)
 	//FindRatelimitNamespace
 	//
-	//  SELECT id, workspace_id, name, created_at_m, updated_at_m, deleted_at_m,
+	//  SELECT id, workspace_id, name, algorithm, created_at_m, updated_at_m, deleted_at_m,
 	//         coalesce(
 	//                 (select json_arrayagg(
 	//                                 json_object(
 	//                                         'id', ro.id,
 	//                                         'identifier', ro.identifier,
 	//                                         'limit', ro.limit,
-	//                                         'duration', ro.duration
+	//                                         'duration', ro.duration,
+	//                                         'algorithm', ro.algorithm,
+	//                                         'refill_rate', ro.refill_rate
 	//                                 )
 	//                         )
 	//                  from ratelimit_overrides ro where ro.namespace_id = ns.id AND ro.deleted_at_m IS NULL),
@@ -663,25 +686,25 @@ type Querier interface {
 	FindRatelimitNamespace(ctx context.Context, db DBTX, arg FindRatelimitNamespaceParams) (FindRatelimitNamespaceRow, error)
 	//FindRatelimitNamespaceByID
 	//
-	//  SELECT id, workspace_id, name, created_at_m, updated_at_m, deleted_at_m FROM `ratelimit_namespaces`
+	//  SELECT id, workspace_id, name, algorithm, created_at_m, updated_at_m, deleted_at_m FROM `ratelimit_namespaces`
 	//  WHERE id = ?
 	FindRatelimitNamespaceByID(ctx context.Context, db DBTX, id string) (RatelimitNamespace, error)
 	//FindRatelimitNamespaceByName
 	//
-	//  SELECT id, workspace_id, name, created_at_m, updated_at_m, deleted_at_m FROM `ratelimit_namespaces`
+	//  SELECT id, workspace_id, name, algorithm, created_at_m, updated_at_m, deleted_at_m FROM `ratelimit_namespaces`
 	//  WHERE name = ?
 	//  AND workspace_id = ?
 	FindRatelimitNamespaceByName(ctx context.Context, db DBTX, arg FindRatelimitNamespaceByNameParams) (RatelimitNamespace, error)
 	//FindRatelimitOverrideByID
 	//
-	//  SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+	//  SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 	//  WHERE
 	//      workspace_id = ?
 	//      AND id = ?
 	FindRatelimitOverrideByID(ctx context.Context, db DBTX, arg FindRatelimitOverrideByIDParams) (RatelimitOverride, error)
 	//FindRatelimitOverrideByIdentifier
 	//
-	//  SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+	//  SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 	//  WHERE
 	//      workspace_id = ?
 	//      AND namespace_id = ?
@@ -738,6 +761,16 @@ type Querier interface {
 	//
 	//  SELECT id, name FROM roles WHERE workspace_id = ? AND name IN (/*SLICE:names*/?)
 	FindRolesByNames(ctx context.Context, db DBTX, arg FindRolesByNamesParams) ([]FindRolesByNamesRow, error)
+	//FindWebhookEndpointByID
+	//
+	//  SELECT id, workspace_id, url, events, enabled, encrypted, encryption_key_id, created_at_m, updated_at_m, deleted_at_m FROM `webhook_endpoints`
+	//  WHERE id = ?
+	FindWebhookEndpointByID(ctx context.Context, db DBTX, id string) (WebhookEndpoint, error)
+	//FindWebhookEventByID
+	//
+	//  SELECT id, workspace_id, event, dedupe_key, payload, created_at, dispatched_at FROM `webhook_events`
+	//  WHERE id = ?
+	FindWebhookEventByID(ctx context.Context, db DBTX, id string) (WebhookEvent, error)
 	//FindWorkspaceByID
 	//
 	//  SELECT id, org_id, name, partition_id, plan, tier, stripe_customer_id, stripe_subscription_id, beta_features, features, subscriptions, enabled, delete_protection, created_at_m, updated_at_m, deleted_at_m FROM `workspaces`
@@ -1224,6 +1257,8 @@ type Querier interface {
 	//      identifier,
 	//      `limit`,
 	//      duration,
+	//      algorithm,
+	//      refill_rate,
 	//      async,
 	//      created_at_m
 	//  )
@@ -1234,12 +1269,16 @@ type Querier interface {
 	//      ?,
 	//      ?,
 	//      ?,
+	//      ?,
+	//      ?,
 	//      false,
 	//      ?
 	//  )
 	//  ON DUPLICATE KEY UPDATE
 	//      `limit` = VALUES(`limit`),
 	//      duration = VALUES(duration),
+	//      algorithm = VALUES(algorithm),
+	//      refill_rate = VALUES(refill_rate),
 	//      async = VALUES(async),
 	//      updated_at_m = ?
 	InsertRatelimitOverride(ctx context.Context, db DBTX, arg InsertRatelimitOverrideParams) error
@@ -1275,6 +1314,47 @@ type Querier interface {
 	//    ?
 	//  )
 	InsertRolePermission(ctx context.Context, db DBTX, arg InsertRolePermissionParams) error
+	//InsertWebhookEndpoint
+	//
+	//  INSERT INTO `webhook_endpoints` (
+	//      id,
+	//      workspace_id,
+	//      url,
+	//      events,
+	//      enabled,
+	//      encrypted,
+	//      encryption_key_id,
+	//      created_at_m
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      CAST(? AS JSON),
+	//      true,
+	//      ?,
+	//      ?,
+	//      ?
+	//  )
+	InsertWebhookEndpoint(ctx context.Context, db DBTX, arg InsertWebhookEndpointParams) error
+	// InsertWebhookEvent records an event unless the same event was already recorded with the
+	// same dedupe key, in which case nothing happens.
+	//
+	//  INSERT IGNORE INTO `webhook_events` (
+	//      id,
+	//      workspace_id,
+	//      event,
+	//      dedupe_key,
+	//      payload,
+	//      created_at
+	//  ) VALUES (
+	//      ?,
+	//      ?,
+	//      ?,
+	//      ?,
+	//      CAST(? AS JSON),
+	//      ?
+	//  )
+	InsertWebhookEvent(ctx context.Context, db DBTX, arg InsertWebhookEventParams) error
 	//InsertWorkspace
 	//
 	//  INSERT INTO `workspaces` (
@@ -1315,6 +1395,29 @@ type Querier interface {
 	//  WHERE dc.status = 'waiting' OR (dc.status = 'verified' AND dc.expires_at <= DATE_ADD(NOW(), INTERVAL 30 DAY))
 	//  ORDER BY d.created_at ASC
 	ListExecutableChallenges(ctx context.Context, db DBTX) ([]ListExecutableChallengesRow, error)
+	//ListHostnameRoutes
+	//
+	//  SELECT
+	//      hr.id,
+	//      hr.workspace_id,
+	//      hr.project_id,
+	//      hr.hostname,
+	//      hr.deployment_id,
+	//      hr.is_enabled,
+	//      hr.created_at,
+	//      hr.updated_at,
+	//      d.environment,
+	//      dom.type AS domain_type
+	//  FROM hostname_routes hr
+	//  JOIN deployments d ON d.id = hr.deployment_id
+	//  LEFT JOIN domains dom ON dom.domain = hr.hostname
+	//  WHERE hr.workspace_id = ?
+	//      AND hr.project_id = ?
+	//      AND hr.hostname > ?
+	//      AND (? = '' OR d.environment = ?)
+	//  ORDER BY hr.hostname ASC
+	//  LIMIT ?
+	ListHostnameRoutes(ctx context.Context, db DBTX, arg ListHostnameRoutesParams) ([]ListHostnameRoutesRow, error)
 	//ListIdentities
 	//
 	//  SELECT id, external_id, workspace_id, environment, meta, deleted, created_at, updated_at
@@ -1340,6 +1443,38 @@ type Querier interface {
 	//
 	//  SELECT id, name, workspace_id, created_at, updated_at, key_id, identity_id, `limit`, duration, auto_apply FROM ratelimits WHERE identity_id IN (/*SLICE:ids*/?)
 	ListIdentityRatelimitsByIDs(ctx context.Context, db DBTX, ids []sql.NullString) ([]Ratelimit, error)
+	// ListKeyAuditLogsSince returns the audit logs of the given events in the given workspaces
+	// that happened at or after since, together with the key they targeted. Deleted keys are
+	// still returned, they are only soft deleted.
+	//
+	//  SELECT
+	//      al.id,
+	//      al.workspace_id,
+	//      al.event,
+	//      al.time,
+	//      al.actor_type,
+	//      al.actor_id,
+	//      alt.id AS key_id,
+	//      alt.name AS key_name,
+	//      k.key_auth_id
+	//  FROM `audit_log` al
+	//  JOIN `audit_log_target` alt ON alt.audit_log_id = al.id
+	//  LEFT JOIN `keys` k ON k.id = alt.id
+	//  WHERE al.workspace_id IN (/*SLICE:workspace_ids*/?)
+	//      AND al.event IN (/*SLICE:events*/?)
+	//      AND al.time >= ?
+	//      AND alt.type = 'key'
+	//      AND al.id > ?
+	//  ORDER BY al.id ASC
+	//  LIMIT ?
+	ListKeyAuditLogsSince(ctx context.Context, db DBTX, arg ListKeyAuditLogsSinceParams) ([]ListKeyAuditLogsSinceRow, error)
+	//ListKeyEncryptions
+	//
+	//  SELECT workspace_id, key_id, created_at, updated_at, encrypted, encryption_key_id FROM encrypted_keys
+	//  WHERE key_id > ?
+	//  ORDER BY key_id ASC
+	//  LIMIT ?
+	ListKeyEncryptions(ctx context.Context, db DBTX, arg ListKeyEncryptionsParams) ([]EncryptedKey, error)
 	//ListKeysByKeyAuthID
 	//
 	//  SELECT
@@ -1360,6 +1495,87 @@ type Querier interface {
 	//  ORDER BY k.id ASC
 	//  LIMIT ?
 	ListKeysByKeyAuthID(ctx context.Context, db DBTX, arg ListKeysByKeyAuthIDParams) ([]ListKeysByKeyAuthIDRow, error)
+	// ListKeysDueForRefill returns live keys whose credits should be refilled on the given day.
+	// Keys without a refill_day are refilled daily, keys with a refill_day are refilled
+	// when it falls between today and until_day. Callers set until_day to the last
+	// possible day (31) at the end of a month, so keys configured for a day that the
+	// current month does not have are refilled on its last day.
+	// Keys that were already refilled since refilled_before are skipped, which makes
+	// the query safe to run multiple times per day.
+	// Disabled keys and keys whose keyspace or workspace was deleted can not be verified,
+	// refilling them would only write audit logs nobody can act on.
+	//
+	//  SELECT
+	//      k.id,
+	//      k.key_auth_id,
+	//      k.workspace_id,
+	//      k.hash,
+	//      k.name,
+	//      k.refill_amount,
+	//      k.refill_day,
+	//      k.remaining_requests
+	//  FROM `keys` k
+	//  JOIN `key_auth` ka ON ka.id = k.key_auth_id
+	//  JOIN `workspaces` w ON w.id = k.workspace_id
+	//  WHERE k.deleted_at_m IS NULL
+	//      AND k.enabled = true
+	//      AND ka.deleted_at_m IS NULL
+	//      AND w.deleted_at_m IS NULL
+	//      AND k.refill_amount IS NOT NULL
+	//      AND k.refill_amount > k.remaining_requests
+	//      AND (k.last_refill_at IS NULL OR k.last_refill_at < ?)
+	//      AND (
+	//          k.refill_day IS NULL
+	//          OR (k.refill_day >= ? AND k.refill_day <= ?)
+	//      )
+	//      AND k.id > ?
+	//  ORDER BY k.id ASC
+	//  LIMIT ?
+	ListKeysDueForRefill(ctx context.Context, db DBTX, arg ListKeysDueForRefillParams) ([]ListKeysDueForRefillRow, error)
+	// ListKeysExpiringBetween returns live keys of the given workspaces that expire after
+	// expires_after and at or before expires_before.
+	// Keys whose keyspace was deleted are skipped, nobody can verify them anymore.
+	//
+	//  SELECT
+	//      k.id,
+	//      k.key_auth_id,
+	//      k.workspace_id,
+	//      k.name,
+	//      k.expires
+	//  FROM `keys` k
+	//  JOIN `key_auth` ka ON ka.id = k.key_auth_id
+	//  WHERE k.workspace_id IN (/*SLICE:workspace_ids*/?)
+	//      AND k.deleted_at_m IS NULL
+	//      AND ka.deleted_at_m IS NULL
+	//      AND k.expires > ?
+	//      AND k.expires <= ?
+	//      AND k.id > ?
+	//  ORDER BY k.id ASC
+	//  LIMIT ?
+	ListKeysExpiringBetween(ctx context.Context, db DBTX, arg ListKeysExpiringBetweenParams) ([]ListKeysExpiringBetweenRow, error)
+	// ListKeysWithCreditsAtMost returns live, enabled keys of the given workspaces that have
+	// at most the given number of credits remaining. Keys without a credit limit are skipped.
+	//
+	//  SELECT
+	//      k.id,
+	//      k.key_auth_id,
+	//      k.workspace_id,
+	//      k.name,
+	//      k.remaining_requests,
+	//      k.refill_amount,
+	//      k.last_refill_at
+	//  FROM `keys` k
+	//  JOIN `key_auth` ka ON ka.id = k.key_auth_id
+	//  WHERE k.workspace_id IN (/*SLICE:workspace_ids*/?)
+	//      AND k.deleted_at_m IS NULL
+	//      AND k.enabled = true
+	//      AND ka.deleted_at_m IS NULL
+	//      AND k.remaining_requests IS NOT NULL
+	//      AND k.remaining_requests <= ?
+	//      AND k.id > ?
+	//  ORDER BY k.id ASC
+	//  LIMIT ?
+	ListKeysWithCreditsAtMost(ctx context.Context, db DBTX, arg ListKeysWithCreditsAtMostParams) ([]ListKeysWithCreditsAtMostRow, error)
 	//ListLiveKeysByKeyAuthID
 	//
 	//  SELECT
@@ -1491,9 +1707,18 @@ type Querier interface {
 	//  WHERE rp.role_id = ?
 	//  ORDER BY p.slug
 	ListPermissionsByRoleID(ctx context.Context, db DBTX, roleID string) ([]Permission, error)
+	//ListRatelimitNamespaces
+	//
+	//  SELECT id, workspace_id, name, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM `ratelimit_namespaces`
+	//  WHERE workspace_id = ?
+	//  AND deleted_at_m IS NULL
+	//  AND id >= ?
+	//  ORDER BY id ASC
+	//  LIMIT ?
+	ListRatelimitNamespaces(ctx context.Context, db DBTX, arg ListRatelimitNamespacesParams) ([]RatelimitNamespace, error)
 	//ListRatelimitOverridesByNamespaceID
 	//
-	//  SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+	//  SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 	//  WHERE
 	//  workspace_id = ?
 	//  AND namespace_id = ?
@@ -1571,6 +1796,22 @@ type Querier interface {
 	//  WHERE kr.key_id = ?
 	//  ORDER BY r.name
 	ListRolesByKeyID(ctx context.Context, db DBTX, keyID string) ([]ListRolesByKeyIDRow, error)
+	//ListUndispatchedWebhookEvents
+	//
+	//  SELECT id, workspace_id, event, dedupe_key, payload, created_at, dispatched_at FROM `webhook_events`
+	//  WHERE dispatched_at IS NULL
+	//      AND id > ?
+	//  ORDER BY id ASC
+	//  LIMIT ?
+	ListUndispatchedWebhookEvents(ctx context.Context, db DBTX, arg ListUndispatchedWebhookEventsParams) ([]WebhookEvent, error)
+	// ListWebhookEndpointsByWorkspaceID returns the endpoints of a workspace that were not deleted,
+	// including disabled ones.
+	//
+	//  SELECT id, workspace_id, url, events, enabled, encrypted, encryption_key_id, created_at_m, updated_at_m, deleted_at_m FROM `webhook_endpoints`
+	//  WHERE workspace_id = ?
+	//      AND deleted_at_m IS NULL
+	//  ORDER BY id ASC
+	ListWebhookEndpointsByWorkspaceID(ctx context.Context, db DBTX, workspaceID string) ([]WebhookEndpoint, error)
 	//ListWorkspaces
 	//
 	//  SELECT
@@ -1582,6 +1823,40 @@ type Querier interface {
 	//  ORDER BY w.id ASC
 	//  LIMIT 100
 	ListWorkspaces(ctx context.Context, db DBTX, cursor string) ([]ListWorkspacesRow, error)
+	// ListWorkspacesWithWebhookEndpoints returns the ids of workspaces that have at least one
+	// enabled endpoint, ordered by id so they can be paginated with a cursor.
+	//
+	//  SELECT DISTINCT workspace_id
+	//  FROM `webhook_endpoints`
+	//  WHERE deleted_at_m IS NULL
+	//      AND enabled = true
+	//      AND workspace_id > ?
+	//  ORDER BY workspace_id ASC
+	//  LIMIT ?
+	ListWorkspacesWithWebhookEndpoints(ctx context.Context, db DBTX, arg ListWorkspacesWithWebhookEndpointsParams) ([]string, error)
+	// LockKeysNotRefilledSince locks the given keys and returns the ones that have not been
+	// refilled since refilled_before. It must run inside a transaction, the row locks are
+	// held until it commits, so concurrent refills of the same keys can not both succeed.
+	//
+	//  SELECT id, refill_amount
+	//  FROM `keys`
+	//  WHERE id IN (/*SLICE:ids*/?)
+	//      AND refill_amount IS NOT NULL
+	//      AND (last_refill_at IS NULL OR last_refill_at < ?)
+	//  ORDER BY id ASC
+	//  FOR UPDATE
+	LockKeysNotRefilledSince(ctx context.Context, db DBTX, arg LockKeysNotRefilledSinceParams) ([]LockKeysNotRefilledSinceRow, error)
+	//RestoreRatelimitNamespace
+	//
+	//  UPDATE `ratelimit_namespaces`
+	//  SET
+	//      algorithm = 'sliding_window',
+	//      refill_rate = NULL,
+	//      created_at_m = ?,
+	//      updated_at_m = NULL,
+	//      deleted_at_m = NULL
+	//  WHERE id = ?
+	RestoreRatelimitNamespace(ctx context.Context, db DBTX, arg RestoreRatelimitNamespaceParams) error
 	//SoftDeleteApi
 	//
 	//  UPDATE apis
@@ -1620,6 +1895,21 @@ type Querier interface {
 	//      deleted_at_m =  ?
 	//  WHERE id = ?
 	SoftDeleteRatelimitOverride(ctx context.Context, db DBTX, arg SoftDeleteRatelimitOverrideParams) error
+	//SoftDeleteRatelimitOverridesByNamespace
+	//
+	//  UPDATE `ratelimit_overrides`
+	//  SET
+	//      deleted_at_m = ?
+	//  WHERE namespace_id = ?
+	//  AND deleted_at_m IS NULL
+	SoftDeleteRatelimitOverridesByNamespace(ctx context.Context, db DBTX, arg SoftDeleteRatelimitOverridesByNamespaceParams) error
+	//SoftDeleteWebhookEndpoint
+	//
+	//  UPDATE `webhook_endpoints`
+	//  SET
+	//      deleted_at_m = ?
+	//  WHERE id = ?
+	SoftDeleteWebhookEndpoint(ctx context.Context, db DBTX, arg SoftDeleteWebhookEndpointParams) error
 	//SoftDeleteWorkspace
 	//
 	//  UPDATE `workspaces`
@@ -1695,6 +1985,15 @@ type Querier interface {
 	//  SET status = ?, updated_at = ?
 	//  WHERE domain_id = ? AND status = 'waiting'
 	UpdateDomainChallengeTryClaiming(ctx context.Context, db DBTX, arg UpdateDomainChallengeTryClaimingParams) error
+	//UpdateHostnameRouteDeployment
+	//
+	//  UPDATE hostname_routes
+	//  SET
+	//      deployment_id = ?,
+	//      is_enabled = true,
+	//      updated_at = ?
+	//  WHERE id = ?
+	UpdateHostnameRouteDeployment(ctx context.Context, db DBTX, arg UpdateHostnameRouteDeploymentParams) error
 	//UpdateIdentity
 	//
 	//  UPDATE `identities`
@@ -1751,6 +2050,18 @@ type Querier interface {
 	//  END
 	//  WHERE id = ?
 	UpdateKeyCreditsDecrement(ctx context.Context, db DBTX, arg UpdateKeyCreditsDecrementParams) error
+	// UpdateKeyCreditsDecrementUnlessRefilled deducts credits that were spent at charged_at.
+	// Credits spent before the key was last refilled are ignored, the refill already reset
+	// the remaining credits and must not be reduced by usage from the previous period.
+	//
+	//  UPDATE `keys`
+	//  SET remaining_requests = CASE
+	//      WHEN remaining_requests >= ? THEN remaining_requests - ?
+	//      ELSE 0
+	//  END
+	//  WHERE id = ?
+	//      AND (last_refill_at IS NULL OR last_refill_at <= ?)
+	UpdateKeyCreditsDecrementUnlessRefilled(ctx context.Context, db DBTX, arg UpdateKeyCreditsDecrementUnlessRefilledParams) error
 	//UpdateKeyCreditsIncrement
 	//
 	//  UPDATE `keys`
@@ -1767,10 +2078,29 @@ type Querier interface {
 	//  SET remaining_requests = ?
 	//  WHERE id = ?
 	UpdateKeyCreditsSet(ctx context.Context, db DBTX, arg UpdateKeyCreditsSetParams) error
+	//UpdateKeyEncryption
+	//
+	//  UPDATE encrypted_keys
+	//  SET encrypted = ?,
+	//      encryption_key_id = ?,
+	//      updated_at = ?
+	//  WHERE key_id = ?
+	//      AND encrypted = ?
+	UpdateKeyEncryption(ctx context.Context, db DBTX, arg UpdateKeyEncryptionParams) error
 	//UpdateKeyringKeyEncryption
 	//
 	//  UPDATE `key_auth` SET store_encrypted_keys = ? WHERE id = ?
 	UpdateKeyringKeyEncryption(ctx context.Context, db DBTX, arg UpdateKeyringKeyEncryptionParams) error
+	// UpdateManyKeysCreditsRefilled resets the remaining credits of the given keys to their
+	// refill amount. Callers lock the keys with LockKeysNotRefilledSince first, so keys that
+	// were refilled already are not part of ids.
+	//
+	//  UPDATE `keys`
+	//  SET
+	//      remaining_requests = refill_amount,
+	//      last_refill_at = ?
+	//  WHERE id IN (/*SLICE:ids*/?)
+	UpdateManyKeysCreditsRefilled(ctx context.Context, db DBTX, arg UpdateManyKeysCreditsRefilledParams) error
 	//UpdateRatelimit
 	//
 	//  UPDATE `ratelimits`
@@ -1783,6 +2113,23 @@ type Querier interface {
 	//  WHERE
 	//      id = ?
 	UpdateRatelimit(ctx context.Context, db DBTX, arg UpdateRatelimitParams) error
+	//UpdateRatelimitNamespaceAlgorithm
+	//
+	//  UPDATE `ratelimit_namespaces`
+	//  SET
+	//      algorithm = ?,
+	//      refill_rate = ?,
+	//      updated_at_m = ?
+	//  WHERE id = ?
+	UpdateRatelimitNamespaceAlgorithm(ctx context.Context, db DBTX, arg UpdateRatelimitNamespaceAlgorithmParams) error
+	//UpdateRatelimitNamespaceName
+	//
+	//  UPDATE `ratelimit_namespaces`
+	//  SET
+	//      name = ?,
+	//      updated_at_m = ?
+	//  WHERE id = ?
+	UpdateRatelimitNamespaceName(ctx context.Context, db DBTX, arg UpdateRatelimitNamespaceNameParams) error
 	//UpdateRatelimitOverride
 	//
 	//  UPDATE `ratelimit_overrides`
@@ -1793,6 +2140,12 @@ type Querier interface {
 	//      updated_at_m= ?
 	//  WHERE id = ?
 	UpdateRatelimitOverride(ctx context.Context, db DBTX, arg UpdateRatelimitOverrideParams) (sql.Result, error)
+	//UpdateWebhookEventDispatched
+	//
+	//  UPDATE `webhook_events`
+	//  SET dispatched_at = ?
+	//  WHERE id = ?
+	UpdateWebhookEventDispatched(ctx context.Context, db DBTX, arg UpdateWebhookEventDispatchedParams) error
 	//UpdateWorkspaceEnabled
 	//
 	//  UPDATE `workspaces`
@@ -0,0 +1,24 @@
+-- name: ListKeyAuditLogsSince :many
+-- ListKeyAuditLogsSince returns the audit logs of the given events in the given workspaces
+-- that happened at or after since, together with the key they targeted. Deleted keys are
+-- still returned, they are only soft deleted.
+SELECT
+    al.id,
+    al.workspace_id,
+    al.event,
+    al.time,
+    al.actor_type,
+    al.actor_id,
+    alt.id AS key_id,
+    alt.name AS key_name,
+    k.key_auth_id
+FROM `audit_log` al
+JOIN `audit_log_target` alt ON alt.audit_log_id = al.id
+LEFT JOIN `keys` k ON k.id = alt.id
+WHERE al.workspace_id IN (sqlc.slice(workspace_ids))
+    AND al.event IN (sqlc.slice(events))
+    AND al.time >= sqlc.arg(since)
+    AND alt.type = 'key'
+    AND al.id > sqlc.arg(id_cursor)
+ORDER BY al.id ASC
+LIMIT ?;
@@ -0,0 +1,5 @@
+-- name: CountKeyEncryptionsByEncryptionKeyID :many
+SELECT encryption_key_id, COUNT(*) AS total
+FROM encrypted_keys
+GROUP BY encryption_key_id
+ORDER BY encryption_key_id ASC;
@@ -0,0 +1,5 @@
+-- name: ListKeyEncryptions :many
+SELECT * FROM encrypted_keys
+WHERE key_id > sqlc.arg(id_cursor)
+ORDER BY key_id ASC
+LIMIT ?;
@@ -0,0 +1,7 @@
+-- name: UpdateKeyEncryption :exec
+UPDATE encrypted_keys
+SET encrypted = sqlc.arg(encrypted),
+    encryption_key_id = sqlc.arg(encryption_key_id),
+    updated_at = sqlc.arg(updated_at)
+WHERE key_id = sqlc.arg(key_id)
+    AND encrypted = sqlc.arg(previous_encrypted);
@@ -0,0 +1,22 @@
+-- name: ListKeysWithCreditsAtMost :many
+-- ListKeysWithCreditsAtMost returns live, enabled keys of the given workspaces that have
+-- at most the given number of credits remaining. Keys without a credit limit are skipped.
+SELECT
+    k.id,
+    k.key_auth_id,
+    k.workspace_id,
+    k.name,
+    k.remaining_requests,
+    k.refill_amount,
+    k.last_refill_at
+FROM `keys` k
+JOIN `key_auth` ka ON ka.id = k.key_auth_id
+WHERE k.workspace_id IN (sqlc.slice(workspace_ids))
+    AND k.deleted_at_m IS NULL
+    AND k.enabled = true
+    AND ka.deleted_at_m IS NULL
+    AND k.remaining_requests IS NOT NULL
+    AND k.remaining_requests <= sqlc.arg(credits)
+    AND k.id > sqlc.arg(id_cursor)
+ORDER BY k.id ASC
+LIMIT ?;
@@ -0,0 +1,36 @@
+-- name: ListKeysDueForRefill :many
+-- ListKeysDueForRefill returns live keys whose credits should be refilled on the given day.
+-- Keys without a refill_day are refilled daily, keys with a refill_day are refilled
+-- when it falls between today and until_day. Callers set until_day to the last
+-- possible day (31) at the end of a month, so keys configured for a day that the
+-- current month does not have are refilled on its last day.
+-- Keys that were already refilled since refilled_before are skipped, which makes
+-- the query safe to run multiple times per day.
+-- Disabled keys and keys whose keyspace or workspace was deleted can not be verified,
+-- refilling them would only write audit logs nobody can act on.
+SELECT
+    k.id,
+    k.key_auth_id,
+    k.workspace_id,
+    k.hash,
+    k.name,
+    k.refill_amount,
+    k.refill_day,
+    k.remaining_requests
+FROM `keys` k
+JOIN `key_auth` ka ON ka.id = k.key_auth_id
+JOIN `workspaces` w ON w.id = k.workspace_id
+WHERE k.deleted_at_m IS NULL
+    AND k.enabled = true
+    AND ka.deleted_at_m IS NULL
+    AND w.deleted_at_m IS NULL
+    AND k.refill_amount IS NOT NULL
+    AND k.refill_amount > k.remaining_requests
+    AND (k.last_refill_at IS NULL OR k.last_refill_at < sqlc.arg(refilled_before))
+    AND (
+        k.refill_day IS NULL
+        OR (k.refill_day >= sqlc.arg(today) AND k.refill_day <= sqlc.arg(until_day))
+    )
+    AND k.id > sqlc.arg(id_cursor)
+ORDER BY k.id ASC
+LIMIT ?;
@@ -0,0 +1,20 @@
+-- name: ListKeysExpiringBetween :many
+-- ListKeysExpiringBetween returns live keys of the given workspaces that expire after
+-- expires_after and at or before expires_before.
+-- Keys whose keyspace was deleted are skipped, nobody can verify them anymore.
+SELECT
+    k.id,
+    k.key_auth_id,
+    k.workspace_id,
+    k.name,
+    k.expires
+FROM `keys` k
+JOIN `key_auth` ka ON ka.id = k.key_auth_id
+WHERE k.workspace_id IN (sqlc.slice(workspace_ids))
+    AND k.deleted_at_m IS NULL
+    AND ka.deleted_at_m IS NULL
+    AND k.expires > sqlc.arg(expires_after)
+    AND k.expires <= sqlc.arg(expires_before)
+    AND k.id > sqlc.arg(id_cursor)
+ORDER BY k.id ASC
+LIMIT ?;
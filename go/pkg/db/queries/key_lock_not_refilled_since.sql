@@ -0,0 +1,11 @@
+-- name: LockKeysNotRefilledSince :many
+-- LockKeysNotRefilledSince locks the given keys and returns the ones that have not been
+-- refilled since refilled_before. It must run inside a transaction, the row locks are
+-- held until it commits, so concurrent refills of the same keys can not both succeed.
+SELECT id, refill_amount
+FROM `keys`
+WHERE id IN (sqlc.slice(ids))
+    AND refill_amount IS NOT NULL
+    AND (last_refill_at IS NULL OR last_refill_at < sqlc.arg(refilled_before))
+ORDER BY id ASC
+FOR UPDATE;
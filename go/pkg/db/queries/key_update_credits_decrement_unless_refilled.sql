@@ -0,0 +1,11 @@
+-- name: UpdateKeyCreditsDecrementUnlessRefilled :exec
+-- UpdateKeyCreditsDecrementUnlessRefilled deducts credits that were spent at charged_at.
+-- Credits spent before the key was last refilled are ignored, the refill already reset
+-- the remaining credits and must not be reduced by usage from the previous period.
+UPDATE `keys`
+SET remaining_requests = CASE
+    WHEN remaining_requests >= sqlc.arg('credits') THEN remaining_requests - sqlc.arg('credits')
+    ELSE 0
+END
+WHERE id = sqlc.arg('id')
+    AND (last_refill_at IS NULL OR last_refill_at <= sqlc.arg('charged_at'));
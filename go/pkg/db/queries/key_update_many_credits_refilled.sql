@@ -0,0 +1,9 @@
+-- name: UpdateManyKeysCreditsRefilled :exec
+-- UpdateManyKeysCreditsRefilled resets the remaining credits of the given keys to their
+-- refill amount. Callers lock the keys with LockKeysNotRefilledSince first, so keys that
+-- were refilled already are not part of ids.
+UPDATE `keys`
+SET
+    remaining_requests = refill_amount,
+    last_refill_at = sqlc.arg(now)
+WHERE id IN (sqlc.slice(ids));
@@ -6,7 +6,9 @@ SELECT *,
                                        'id', ro.id,
                                        'identifier', ro.identifier,
                                        'limit', ro.limit,
-                                       'duration', ro.duration
+                                       'duration', ro.duration,
+                                       'algorithm', ro.algorithm,
+                                       'refill_rate', ro.refill_rate
                                )
                        )
                 from ratelimit_overrides ro where ro.namespace_id = ns.id AND ro.deleted_at_m IS NULL),
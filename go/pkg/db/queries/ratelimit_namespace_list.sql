@@ -0,0 +1,7 @@
+-- name: ListRatelimitNamespaces :many
+SELECT * FROM `ratelimit_namespaces`
+WHERE workspace_id = sqlc.arg(workspace_id)
+AND deleted_at_m IS NULL
+AND id >= sqlc.arg(cursor_id)
+ORDER BY id ASC
+LIMIT ?;
@@ -0,0 +1,9 @@
+-- name: RestoreRatelimitNamespace :exec
+UPDATE `ratelimit_namespaces`
+SET
+    algorithm = 'sliding_window',
+    refill_rate = NULL,
+    created_at_m = sqlc.arg(now),
+    updated_at_m = NULL,
+    deleted_at_m = NULL
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,7 @@
+-- name: UpdateRatelimitNamespaceAlgorithm :exec
+UPDATE `ratelimit_namespaces`
+SET
+    algorithm = sqlc.arg(algorithm),
+    refill_rate = sqlc.narg(refill_rate),
+    updated_at_m = sqlc.arg(now)
+WHERE id = sqlc.arg(id);
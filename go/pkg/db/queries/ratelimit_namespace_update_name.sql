@@ -0,0 +1,6 @@
+-- name: UpdateRatelimitNamespaceName :exec
+UPDATE `ratelimit_namespaces`
+SET
+    name = sqlc.arg(name),
+    updated_at_m = sqlc.arg(now)
+WHERE id = sqlc.arg(id);
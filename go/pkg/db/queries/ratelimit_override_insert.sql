@@ -6,6 +6,8 @@ INSERT INTO ratelimit_overrides (
     identifier,
     `limit`,
     duration,
+    algorithm,
+    refill_rate,
     async,
     created_at_m
 )
@@ -16,11 +18,15 @@ VALUES (
     sqlc.arg("identifier"),
     sqlc.arg("limit"),
     sqlc.arg("duration"),
+    sqlc.narg("algorithm"),
+    sqlc.narg("refill_rate"),
     false,
     sqlc.arg("created_at")
 )
 ON DUPLICATE KEY UPDATE
     `limit` = VALUES(`limit`),
     duration = VALUES(duration),
+    algorithm = VALUES(algorithm),
+    refill_rate = VALUES(refill_rate),
     async = VALUES(async),
     updated_at_m = sqlc.arg('updated_at')
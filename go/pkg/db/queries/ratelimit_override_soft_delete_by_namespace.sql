@@ -0,0 +1,6 @@
+-- name: SoftDeleteRatelimitOverridesByNamespace :exec
+UPDATE `ratelimit_overrides`
+SET
+    deleted_at_m = sqlc.arg(now)
+WHERE namespace_id = sqlc.arg(namespace_id)
+AND deleted_at_m IS NULL;
@@ -0,0 +1,12 @@
+-- name: FindHostnameRouteByHostname :one
+SELECT
+    id,
+    workspace_id,
+    project_id,
+    hostname,
+    deployment_id,
+    is_enabled,
+    created_at,
+    updated_at
+FROM hostname_routes
+WHERE hostname = sqlc.arg(hostname);
@@ -0,0 +1,21 @@
+-- name: ListHostnameRoutes :many
+SELECT
+    hr.id,
+    hr.workspace_id,
+    hr.project_id,
+    hr.hostname,
+    hr.deployment_id,
+    hr.is_enabled,
+    hr.created_at,
+    hr.updated_at,
+    d.environment,
+    dom.type AS domain_type
+FROM hostname_routes hr
+JOIN deployments d ON d.id = hr.deployment_id
+LEFT JOIN domains dom ON dom.domain = hr.hostname
+WHERE hr.workspace_id = sqlc.arg(workspace_id)
+    AND hr.project_id = sqlc.arg(project_id)
+    AND hr.hostname > sqlc.arg(hostname_cursor)
+    AND (sqlc.arg(environment) = '' OR d.environment = sqlc.arg(environment))
+ORDER BY hr.hostname ASC
+LIMIT ?;
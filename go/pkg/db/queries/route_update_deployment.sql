@@ -0,0 +1,7 @@
+-- name: UpdateHostnameRouteDeployment :exec
+UPDATE hostname_routes
+SET
+    deployment_id = sqlc.arg(deployment_id),
+    is_enabled = true,
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,3 @@
+-- name: FindWebhookEndpointByID :one
+SELECT * FROM `webhook_endpoints`
+WHERE id = sqlc.arg(id);
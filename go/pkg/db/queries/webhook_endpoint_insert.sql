@@ -0,0 +1,20 @@
+-- name: InsertWebhookEndpoint :exec
+INSERT INTO `webhook_endpoints` (
+    id,
+    workspace_id,
+    url,
+    events,
+    enabled,
+    encrypted,
+    encryption_key_id,
+    created_at_m
+) VALUES (
+    sqlc.arg('id'),
+    sqlc.arg('workspace_id'),
+    sqlc.arg('url'),
+    CAST(sqlc.arg('events') AS JSON),
+    true,
+    sqlc.arg('encrypted'),
+    sqlc.arg('encryption_key_id'),
+    sqlc.arg('created_at')
+);
@@ -0,0 +1,7 @@
+-- name: ListWebhookEndpointsByWorkspaceID :many
+-- ListWebhookEndpointsByWorkspaceID returns the endpoints of a workspace that were not deleted,
+-- including disabled ones.
+SELECT * FROM `webhook_endpoints`
+WHERE workspace_id = sqlc.arg(workspace_id)
+    AND deleted_at_m IS NULL
+ORDER BY id ASC;
@@ -0,0 +1,10 @@
+-- name: ListWorkspacesWithWebhookEndpoints :many
+-- ListWorkspacesWithWebhookEndpoints returns the ids of workspaces that have at least one
+-- enabled endpoint, ordered by id so they can be paginated with a cursor.
+SELECT DISTINCT workspace_id
+FROM `webhook_endpoints`
+WHERE deleted_at_m IS NULL
+    AND enabled = true
+    AND workspace_id > sqlc.arg(workspace_id_cursor)
+ORDER BY workspace_id ASC
+LIMIT ?;
@@ -0,0 +1,5 @@
+-- name: SoftDeleteWebhookEndpoint :exec
+UPDATE `webhook_endpoints`
+SET
+    deleted_at_m = sqlc.arg(now)
+WHERE id = sqlc.arg(id);
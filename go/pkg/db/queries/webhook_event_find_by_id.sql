@@ -0,0 +1,3 @@
+-- name: FindWebhookEventByID :one
+SELECT * FROM `webhook_events`
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,18 @@
+-- name: InsertWebhookEvent :exec
+-- InsertWebhookEvent records an event unless the same event was already recorded with the
+-- same dedupe key, in which case nothing happens.
+INSERT IGNORE INTO `webhook_events` (
+    id,
+    workspace_id,
+    event,
+    dedupe_key,
+    payload,
+    created_at
+) VALUES (
+    sqlc.arg('id'),
+    sqlc.arg('workspace_id'),
+    sqlc.arg('event'),
+    sqlc.arg('dedupe_key'),
+    CAST(sqlc.arg('payload') AS JSON),
+    sqlc.arg('created_at')
+);
@@ -0,0 +1,6 @@
+-- name: ListUndispatchedWebhookEvents :many
+SELECT * FROM `webhook_events`
+WHERE dispatched_at IS NULL
+    AND id > sqlc.arg(id_cursor)
+ORDER BY id ASC
+LIMIT ?;
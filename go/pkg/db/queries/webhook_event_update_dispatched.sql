@@ -0,0 +1,4 @@
+-- name: UpdateWebhookEventDispatched :exec
+UPDATE `webhook_events`
+SET dispatched_at = sqlc.arg(dispatched_at)
+WHERE id = sqlc.arg(id);
@@ -9,12 +9,17 @@ type FindRatelimitNamespaceLimitOverride struct {
 	Identifier string `json:"identifier"`
 	Limit      int64  `json:"limit"`
 	Duration   int64  `json:"duration"`
+	// Algorithm is empty if the override uses the algorithm of its namespace.
+	Algorithm  string `json:"algorithm"`
+	RefillRate int64  `json:"refill_rate"`
 }
 
 type FindRatelimitNamespace struct {
 	ID                string                                         `db:"id"`
 	WorkspaceID       string                                         `db:"workspace_id"`
 	Name              string                                         `db:"name"`
+	Algorithm         RatelimitNamespacesAlgorithm                   `db:"algorithm"`
+	RefillRate        sql.NullInt32                                  `db:"refill_rate"`
 	CreatedAtM        int64                                          `db:"created_at_m"`
 	UpdatedAtM        sql.NullInt64                                  `db:"updated_at_m"`
 	DeletedAtM        sql.NullInt64                                  `db:"deleted_at_m"`
@@ -11,14 +11,16 @@ import (
 )
 
 const findRatelimitNamespace = `-- name: FindRatelimitNamespace :one
-SELECT id, workspace_id, name, created_at_m, updated_at_m, deleted_at_m,
+SELECT id, workspace_id, name, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m,
        coalesce(
                (select json_arrayagg(
                                json_object(
                                        'id', ro.id,
                                        'identifier', ro.identifier,
                                        'limit', ro.limit,
-                                       'duration', ro.duration
+                                       'duration', ro.duration,
+                                       'algorithm', ro.algorithm,
+                                       'refill_rate', ro.refill_rate
                                )
                        )
                 from ratelimit_overrides ro where ro.namespace_id = ns.id AND ro.deleted_at_m IS NULL),
@@ -35,25 +37,29 @@ type FindRatelimitNamespaceParams struct {
 }
 
 type FindRatelimitNamespaceRow struct {
-	ID          string        `db:"id"`
-	WorkspaceID string        `db:"workspace_id"`
-	Name        string        `db:"name"`
-	CreatedAtM  int64         `db:"created_at_m"`
-	UpdatedAtM  sql.NullInt64 `db:"updated_at_m"`
-	DeletedAtM  sql.NullInt64 `db:"deleted_at_m"`
-	Overrides   interface{}   `db:"overrides"`
+	ID          string                       `db:"id"`
+	WorkspaceID string                       `db:"workspace_id"`
+	Name        string                       `db:"name"`
+	Algorithm   RatelimitNamespacesAlgorithm `db:"algorithm"`
+	RefillRate  sql.NullInt32                `db:"refill_rate"`
+	CreatedAtM  int64                        `db:"created_at_m"`
+	UpdatedAtM  sql.NullInt64                `db:"updated_at_m"`
+	DeletedAtM  sql.NullInt64                `db:"deleted_at_m"`
+	Overrides   interface{}                  `db:"overrides"`
 }
 
 // FindRatelimitNamespace
 //
-//	SELECT id, workspace_id, name, created_at_m, updated_at_m, deleted_at_m,
+//	SELECT id, workspace_id, name, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m,
 //	       coalesce(
 //	               (select json_arrayagg(
 //	                               json_object(
 //	                                       'id', ro.id,
 //	                                       'identifier', ro.identifier,
 //	                                       'limit', ro.limit,
-//	                                       'duration', ro.duration
+//	                                       'duration', ro.duration,
+//	                                       'algorithm', ro.algorithm,
+//	                                       'refill_rate', ro.refill_rate
 //	                               )
 //	                       )
 //	                from ratelimit_overrides ro where ro.namespace_id = ns.id AND ro.deleted_at_m IS NULL),
@@ -69,6 +75,8 @@ func (q *Queries) FindRatelimitNamespace(ctx context.Context, db DBTX, arg FindR
 		&i.ID,
 		&i.WorkspaceID,
 		&i.Name,
+		&i.Algorithm,
+		&i.RefillRate,
 		&i.CreatedAtM,
 		&i.UpdatedAtM,
 		&i.DeletedAtM,
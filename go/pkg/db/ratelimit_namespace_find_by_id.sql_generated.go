@@ -10,13 +10,13 @@ import (
 )
 
 const findRatelimitNamespaceByID = `-- name: FindRatelimitNamespaceByID :one
-SELECT id, workspace_id, name, created_at_m, updated_at_m, deleted_at_m FROM ` + "`" + `ratelimit_namespaces` + "`" + `
+SELECT id, workspace_id, name, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ` + "`" + `ratelimit_namespaces` + "`" + `
 WHERE id = ?
 `
 
 // FindRatelimitNamespaceByID
 //
-//	SELECT id, workspace_id, name, created_at_m, updated_at_m, deleted_at_m FROM `ratelimit_namespaces`
+//	SELECT id, workspace_id, name, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM `ratelimit_namespaces`
 //	WHERE id = ?
 func (q *Queries) FindRatelimitNamespaceByID(ctx context.Context, db DBTX, id string) (RatelimitNamespace, error) {
 	row := db.QueryRowContext(ctx, findRatelimitNamespaceByID, id)
@@ -25,6 +25,8 @@ func (q *Queries) FindRatelimitNamespaceByID(ctx context.Context, db DBTX, id st
 		&i.ID,
 		&i.WorkspaceID,
 		&i.Name,
+		&i.Algorithm,
+		&i.RefillRate,
 		&i.CreatedAtM,
 		&i.UpdatedAtM,
 		&i.DeletedAtM,
@@ -10,7 +10,7 @@ import (
 )
 
 const findRatelimitNamespaceByName = `-- name: FindRatelimitNamespaceByName :one
-SELECT id, workspace_id, name, created_at_m, updated_at_m, deleted_at_m FROM ` + "`" + `ratelimit_namespaces` + "`" + `
+SELECT id, workspace_id, name, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ` + "`" + `ratelimit_namespaces` + "`" + `
 WHERE name = ?
 AND workspace_id = ?
 `
@@ -22,7 +22,7 @@ type FindRatelimitNamespaceByNameParams struct {
 
 // FindRatelimitNamespaceByName
 //
-//	SELECT id, workspace_id, name, created_at_m, updated_at_m, deleted_at_m FROM `ratelimit_namespaces`
+//	SELECT id, workspace_id, name, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM `ratelimit_namespaces`
 //	WHERE name = ?
 //	AND workspace_id = ?
 func (q *Queries) FindRatelimitNamespaceByName(ctx context.Context, db DBTX, arg FindRatelimitNamespaceByNameParams) (RatelimitNamespace, error) {
@@ -32,6 +32,8 @@ func (q *Queries) FindRatelimitNamespaceByName(ctx context.Context, db DBTX, arg
 		&i.ID,
 		&i.WorkspaceID,
 		&i.Name,
+		&i.Algorithm,
+		&i.RefillRate,
 		&i.CreatedAtM,
 		&i.UpdatedAtM,
 		&i.DeletedAtM,
@@ -10,7 +10,7 @@ import (
 )
 
 const findRatelimitOverrideByID = `-- name: FindRatelimitOverrideByID :one
-SELECT id, workspace_id, namespace_id, identifier, ` + "`" + `limit` + "`" + `, duration, async, sharding, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+SELECT id, workspace_id, namespace_id, identifier, ` + "`" + `limit` + "`" + `, duration, async, sharding, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 WHERE
     workspace_id = ?
     AND id = ?
@@ -23,7 +23,7 @@ type FindRatelimitOverrideByIDParams struct {
 
 // FindRatelimitOverrideByID
 //
-//	SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+//	SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 //	WHERE
 //	    workspace_id = ?
 //	    AND id = ?
@@ -39,6 +39,8 @@ func (q *Queries) FindRatelimitOverrideByID(ctx context.Context, db DBTX, arg Fi
 		&i.Duration,
 		&i.Async,
 		&i.Sharding,
+		&i.Algorithm,
+		&i.RefillRate,
 		&i.CreatedAtM,
 		&i.UpdatedAtM,
 		&i.DeletedAtM,
@@ -10,7 +10,7 @@ import (
 )
 
 const findRatelimitOverrideByIdentifier = `-- name: FindRatelimitOverrideByIdentifier :one
-SELECT id, workspace_id, namespace_id, identifier, ` + "`" + `limit` + "`" + `, duration, async, sharding, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+SELECT id, workspace_id, namespace_id, identifier, ` + "`" + `limit` + "`" + `, duration, async, sharding, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 WHERE
     workspace_id = ?
     AND namespace_id = ?
@@ -25,7 +25,7 @@ type FindRatelimitOverrideByIdentifierParams struct {
 
 // FindRatelimitOverrideByIdentifier
 //
-//	SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+//	SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 //	WHERE
 //	    workspace_id = ?
 //	    AND namespace_id = ?
@@ -42,6 +42,8 @@ func (q *Queries) FindRatelimitOverrideByIdentifier(ctx context.Context, db DBTX
 		&i.Duration,
 		&i.Async,
 		&i.Sharding,
+		&i.Algorithm,
+		&i.RefillRate,
 		&i.CreatedAtM,
 		&i.UpdatedAtM,
 		&i.DeletedAtM,
@@ -18,6 +18,8 @@ INSERT INTO ratelimit_overrides (
     identifier,
     ` + "`" + `limit` + "`" + `,
     duration,
+    algorithm,
+    refill_rate,
     async,
     created_at_m
 )
@@ -28,25 +30,31 @@ VALUES (
     ?,
     ?,
     ?,
+    ?,
+    ?,
     false,
     ?
 )
 ON DUPLICATE KEY UPDATE
     ` + "`" + `limit` + "`" + ` = VALUES(` + "`" + `limit` + "`" + `),
     duration = VALUES(duration),
+    algorithm = VALUES(algorithm),
+    refill_rate = VALUES(refill_rate),
     async = VALUES(async),
     updated_at_m = ?
 `
 
 type InsertRatelimitOverrideParams struct {
-	ID          string        `db:"id"`
-	WorkspaceID string        `db:"workspace_id"`
-	NamespaceID string        `db:"namespace_id"`
-	Identifier  string        `db:"identifier"`
-	Limit       int32         `db:"limit"`
-	Duration    int32         `db:"duration"`
-	CreatedAt   int64         `db:"created_at"`
-	UpdatedAt   sql.NullInt64 `db:"updated_at"`
+	ID          string                          `db:"id"`
+	WorkspaceID string                          `db:"workspace_id"`
+	NamespaceID string                          `db:"namespace_id"`
+	Identifier  string                          `db:"identifier"`
+	Limit       int32                           `db:"limit"`
+	Duration    int32                           `db:"duration"`
+	Algorithm   NullRatelimitOverridesAlgorithm `db:"algorithm"`
+	RefillRate  sql.NullInt32                   `db:"refill_rate"`
+	CreatedAt   int64                           `db:"created_at"`
+	UpdatedAt   sql.NullInt64                   `db:"updated_at"`
 }
 
 // InsertRatelimitOverride
@@ -58,6 +66,8 @@ type InsertRatelimitOverrideParams struct {
 //	    identifier,
 //	    `limit`,
 //	    duration,
+//	    algorithm,
+//	    refill_rate,
 //	    async,
 //	    created_at_m
 //	)
@@ -68,12 +78,16 @@ type InsertRatelimitOverrideParams struct {
 //	    ?,
 //	    ?,
 //	    ?,
+//	    ?,
+//	    ?,
 //	    false,
 //	    ?
 //	)
 //	ON DUPLICATE KEY UPDATE
 //	    `limit` = VALUES(`limit`),
 //	    duration = VALUES(duration),
+//	    algorithm = VALUES(algorithm),
+//	    refill_rate = VALUES(refill_rate),
 //	    async = VALUES(async),
 //	    updated_at_m = ?
 func (q *Queries) InsertRatelimitOverride(ctx context.Context, db DBTX, arg InsertRatelimitOverrideParams) error {
@@ -84,6 +98,8 @@ func (q *Queries) InsertRatelimitOverride(ctx context.Context, db DBTX, arg Inse
 		arg.Identifier,
 		arg.Limit,
 		arg.Duration,
+		arg.Algorithm,
+		arg.RefillRate,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
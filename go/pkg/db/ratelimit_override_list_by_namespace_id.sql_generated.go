@@ -10,7 +10,7 @@ import (
 )
 
 const listRatelimitOverridesByNamespaceID = `-- name: ListRatelimitOverridesByNamespaceID :many
-SELECT id, workspace_id, namespace_id, identifier, ` + "`" + `limit` + "`" + `, duration, async, sharding, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+SELECT id, workspace_id, namespace_id, identifier, ` + "`" + `limit` + "`" + `, duration, async, sharding, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 WHERE
 workspace_id = ?
 AND namespace_id = ?
@@ -29,7 +29,7 @@ type ListRatelimitOverridesByNamespaceIDParams struct {
 
 // ListRatelimitOverridesByNamespaceID
 //
-//	SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
+//	SELECT id, workspace_id, namespace_id, identifier, `limit`, duration, async, sharding, algorithm, refill_rate, created_at_m, updated_at_m, deleted_at_m FROM ratelimit_overrides
 //	WHERE
 //	workspace_id = ?
 //	AND namespace_id = ?
@@ -60,6 +60,8 @@ func (q *Queries) ListRatelimitOverridesByNamespaceID(ctx context.Context, db DB
 			&i.Duration,
 			&i.Async,
 			&i.Sharding,
+			&i.Algorithm,
+			&i.RefillRate,
 			&i.CreatedAtM,
 			&i.UpdatedAtM,
 			&i.DeletedAtM,
@@ -152,6 +152,8 @@ CREATE TABLE `ratelimit_namespaces` (
 	`id` varchar(256) NOT NULL,
 	`workspace_id` varchar(256) NOT NULL,
 	`name` varchar(512) NOT NULL,
+	`algorithm` enum('sliding_window','fixed_window','token_bucket') NOT NULL DEFAULT 'sliding_window',
+	`refill_rate` int,
 	`created_at_m` bigint NOT NULL DEFAULT 0,
 	`updated_at_m` bigint,
 	`deleted_at_m` bigint,
@@ -168,6 +170,8 @@ CREATE TABLE `ratelimit_overrides` (
 	`duration` int NOT NULL,
 	`async` boolean,
 	`sharding` enum('edge'),
+	`algorithm` enum('sliding_window','fixed_window','token_bucket'),
+	`refill_rate` int,
 	`created_at_m` bigint NOT NULL DEFAULT 0,
 	`updated_at_m` bigint,
 	`deleted_at_m` bigint,
@@ -431,6 +435,32 @@ CREATE TABLE `domains` (
 	CONSTRAINT `domain_idx` UNIQUE(`domain`)
 );
 
+CREATE TABLE `webhook_endpoints` (
+	`id` varchar(256) NOT NULL,
+	`workspace_id` varchar(256) NOT NULL,
+	`url` varchar(1024) NOT NULL,
+	`events` json NOT NULL,
+	`enabled` boolean NOT NULL DEFAULT true,
+	`encrypted` varchar(1024) NOT NULL,
+	`encryption_key_id` varchar(256) NOT NULL,
+	`created_at_m` bigint NOT NULL DEFAULT 0,
+	`updated_at_m` bigint,
+	`deleted_at_m` bigint,
+	CONSTRAINT `webhook_endpoints_id` PRIMARY KEY(`id`)
+);
+
+CREATE TABLE `webhook_events` (
+	`id` varchar(256) NOT NULL,
+	`workspace_id` varchar(256) NOT NULL,
+	`event` varchar(256) NOT NULL,
+	`dedupe_key` varchar(256) NOT NULL,
+	`payload` json NOT NULL,
+	`created_at` bigint NOT NULL,
+	`dispatched_at` bigint,
+	CONSTRAINT `webhook_events_id` PRIMARY KEY(`id`),
+	CONSTRAINT `dedupe_idx` UNIQUE(`workspace_id`,`event`,`dedupe_key`)
+);
+
 CREATE INDEX `workspace_id_idx` ON `apis` (`workspace_id`);
 CREATE INDEX `workspace_id_idx` ON `roles` (`workspace_id`);
 CREATE INDEX `key_auth_id_deleted_at_idx` ON `keys` (`key_auth_id`,`deleted_at_m`);
@@ -471,3 +501,5 @@ CREATE INDEX `deployment_idx` ON `hostname_routes` (`deployment_id`);
 CREATE INDEX `domain_status_idx` ON `domain_challenges` (`status`);
 CREATE INDEX `workspace_idx` ON `domains` (`workspace_id`);
 CREATE INDEX `project_idx` ON `domains` (`project_id`);
+CREATE INDEX `workspace_idx` ON `webhook_endpoints` (`workspace_id`);
+CREATE INDEX `dispatched_at_idx` ON `webhook_events` (`dispatched_at`);
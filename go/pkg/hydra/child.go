@@ -0,0 +1,386 @@
+package hydra
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unkeyed/unkey/go/pkg/hydra/metrics"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrChildWorkflowFailed is returned by AwaitChildren when at least one of
+// the awaited child workflows failed permanently or was cancelled.
+var ErrChildWorkflowFailed = errors.New("child workflow failed")
+
+// awaitChildrenInterval is how often a parent re-checks its children in case
+// a wake up from a finished child was lost, for example due to a crash.
+const awaitChildrenInterval = time.Minute
+
+// ChildWorkflow starts a child workflow from within a parent workflow and
+// returns its execution ID.
+//
+// The child is created together with an internal step in a single
+// transaction, so replaying the parent never starts the same child twice.
+// Children run independently on any worker, which makes it possible to fan
+// out work and wait for all of it with AwaitChildren.
+//
+// Parameters:
+// - ctx: The workflow context from the parent's Run() method
+// - name: A unique name for this child within the parent workflow
+// - workflowName: Must match the Name() method of a registered workflow type
+// - payload: The input data for the child workflow
+// - opts: Optional configuration for retry behavior and timeouts
+//
+// Example usage:
+//
+//	// Fan out: provision every region in parallel
+//	children := make([]string, 0, len(regions))
+//	for _, region := range regions {
+//	    childID, err := hydra.ChildWorkflow(ctx, "provision-"+region, "provision-region", &ProvisionRequest{
+//	        Region: region,
+//	    })
+//	    if err != nil {
+//	        return err
+//	    }
+//	    children = append(children, childID)
+//	}
+//
+//	// Fan in: wait for all of them
+//	vms, err := hydra.AwaitChildren[*VM](ctx, children...)
+//
+// If the parent fails permanently or is cancelled, all of its descendants
+// are cancelled as well.
+//
+// Metrics recorded:
+// - hydra_workflows_started_total (counter)
+// - hydra_workflows_queued (gauge)
+func ChildWorkflow(ctx WorkflowContext, name, workflowName string, payload any, opts ...WorkflowOption) (string, error) {
+	wctx, ok := ctx.(*workflowContext)
+	if !ok {
+		return "", fmt.Errorf("invalid workflow context")
+	}
+
+	stepName := fmt.Sprintf("child-%s", name)
+	wctx.reach(stepName)
+
+	completed, err := store.Query.GetCompletedStep(wctx.ctx, wctx.db, store.GetCompletedStepParams{
+		Namespace:   wctx.namespace,
+		ExecutionID: wctx.ExecutionID(),
+		StepName:    stepName,
+	})
+	if err == nil {
+		var childID string
+		err = wctx.marshaller.Unmarshal(completed.OutputData, &childID)
+		if err != nil {
+			return "", fmt.Errorf("failed to unmarshal child execution id: %w", err)
+		}
+		return childID, nil
+	}
+
+	config := newWorkflowConfig(opts...)
+
+	data, err := wctx.marshaller.Marshal(payload)
+	if err != nil {
+		metrics.SerializationErrorsTotal.WithLabelValues(wctx.namespace, workflowName, "input").Inc()
+		return "", fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	metrics.RecordPayloadSize(wctx.namespace, workflowName, "input", len(data))
+
+	childID := uid.New(uid.WorkflowPrefix)
+	output, err := wctx.marshaller.Marshal(childID)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal child execution id: %w", err)
+	}
+
+	// Children continue the trace of the parent
+	traceID := ""
+	spanID := ""
+	if spanContext := trace.SpanContextFromContext(wctx.ctx); spanContext.IsValid() {
+		traceID = spanContext.TraceID().String()
+		spanID = spanContext.SpanID().String()
+	}
+
+	tx, err := wctx.db.BeginTx(wctx.ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	now := time.Now().UnixMilli()
+	err = store.Query.CreateWorkflow(wctx.ctx, tx, store.CreateWorkflowParams{
+		ID:                childID,
+		WorkflowName:      workflowName,
+		Status:            store.WorkflowExecutionsStatusPending,
+		InputData:         data,
+		OutputData:        []byte{},
+		ErrorMessage:      sql.NullString{String: "", Valid: false},
+		CreatedAt:         now,
+		StartedAt:         sql.NullInt64{Int64: 0, Valid: false},
+		CompletedAt:       sql.NullInt64{Int64: 0, Valid: false},
+		MaxAttempts:       config.MaxAttempts,
+		RemainingAttempts: config.MaxAttempts,
+		NextRetryAt:       sql.NullInt64{Int64: 0, Valid: false},
+		Namespace:         wctx.namespace,
+		TriggerType:       store.NullWorkflowExecutionsTriggerType{WorkflowExecutionsTriggerType: store.WorkflowExecutionsTriggerTypeEvent, Valid: true},
+		TriggerSource:     sql.NullString{String: wctx.executionID, Valid: true},
+		SleepUntil:        sql.NullInt64{Int64: 0, Valid: false},
+		TraceID:           sql.NullString{String: traceID, Valid: traceID != ""},
+		SpanID:            sql.NullString{String: spanID, Valid: spanID != ""},
+		ParentExecutionID: sql.NullString{String: wctx.executionID, Valid: true},
+	})
+	if err != nil {
+		metrics.RecordError(wctx.namespace, "child", "workflow_creation_failed")
+		return "", fmt.Errorf("failed to create child workflow: %w", err)
+	}
+
+	err = wctx.createStep(tx, stepName, store.WorkflowStepsStatusCompleted, output, "", now)
+	if err != nil {
+		return "", err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", fmt.Errorf("failed to commit child workflow: %w", err)
+	}
+
+	metrics.WorkflowsStartedTotal.WithLabelValues(wctx.namespace, workflowName, "child").Inc()
+	metrics.WorkflowsQueued.WithLabelValues(wctx.namespace, "pending").Inc()
+
+	return childID, nil
+}
+
+// SetResult sets the result of the current workflow execution. It is stored
+// when the execution completes, and a parent that awaits the execution as a
+// child receives it from AwaitChildren.
+//
+// Like everything else in a workflow, the result must be deterministic, so
+// derive it from step outputs. Calling SetResult again replaces the result.
+//
+// Example usage:
+//
+//	vm, err := hydra.Step(ctx, "create-vm", createVM)
+//	if err != nil {
+//	    return err
+//	}
+//	return hydra.SetResult(ctx, vm)
+func SetResult(ctx WorkflowContext, result any) error {
+	wctx, ok := ctx.(*workflowContext)
+	if !ok {
+		return fmt.Errorf("invalid workflow context")
+	}
+
+	data, err := wctx.marshaller.Marshal(result)
+	if err != nil {
+		metrics.SerializationErrorsTotal.WithLabelValues(wctx.namespace, wctx.workflowName, "output").Inc()
+		return fmt.Errorf("failed to marshal workflow result: %w", err)
+	}
+	metrics.RecordPayloadSize(wctx.namespace, wctx.workflowName, "output", len(data))
+
+	wctx.result = data
+	return nil
+}
+
+// AwaitChildren suspends the parent workflow until all given child workflows
+// have finished and returns their results, in the same order as the
+// execution IDs. Children that did not call SetResult return the zero value.
+//
+// The outcome is recorded durably, so replaying the parent returns the same
+// results without checking the children again. If any child failed
+// permanently or was cancelled, an error wrapping ErrChildWorkflowFailed is
+// returned with the child's error message, along with the results of the
+// children that completed, so the parent can undo their work. Children that
+// are still retrying are not considered finished.
+//
+// Example usage:
+//
+//	vms, err := hydra.AwaitChildren[*VM](ctx, usEastID, euWestID)
+//	if errors.Is(err, hydra.ErrChildWorkflowFailed) {
+//	    return rollback(ctx, vms)
+//	}
+//	if err != nil {
+//	    return err
+//	}
+//
+// Like Sleep, the error must be returned from the workflow's Run method
+// unchanged unless it wraps ErrChildWorkflowFailed, as it is used to suspend
+// the execution.
+func AwaitChildren[T any](ctx WorkflowContext, executionIDs ...string) ([]T, error) {
+	wctx, ok := ctx.(*workflowContext)
+	if !ok {
+		return nil, fmt.Errorf("invalid workflow context")
+	}
+
+	if len(executionIDs) == 0 {
+		return []T{}, nil
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(executionIDs, ",")))
+	stepName := fmt.Sprintf("await-%s", hex.EncodeToString(sum[:8]))
+	wctx.reach(stepName)
+
+	existingStep, err := store.Query.GetStep(wctx.ctx, wctx.db, store.GetStepParams{
+		Namespace:   wctx.namespace,
+		ExecutionID: wctx.ExecutionID(),
+		StepName:    stepName,
+	})
+	if err == nil {
+		var outputs [][]byte
+		if len(existingStep.OutputData) > 0 {
+			err = wctx.marshaller.Unmarshal(existingStep.OutputData, &outputs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal child results: %w", err)
+			}
+		}
+
+		results, err := unmarshalChildResults[T](wctx, executionIDs, outputs)
+		if err != nil {
+			return nil, err
+		}
+
+		if existingStep.Status == store.WorkflowStepsStatusFailed {
+			return results, fmt.Errorf("%w: %s", ErrChildWorkflowFailed, existingStep.ErrorMessage.String)
+		}
+		return results, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to load await step: %w", err)
+	}
+
+	outputs, finished, failure, err := wctx.checkChildren(wctx.ctx, executionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	if !finished {
+		return nil, &WorkflowSuspendedError{
+			Reason:     fmt.Sprintf("%d child workflows", len(executionIDs)),
+			ResumeTime: now + awaitChildrenInterval.Milliseconds(),
+			ready: func(ctx context.Context) (bool, error) {
+				_, done, _, checkErr := wctx.checkChildren(ctx, executionIDs)
+				return done, checkErr
+			},
+		}
+	}
+
+	results, err := unmarshalChildResults[T](wctx, executionIDs, outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := wctx.marshaller.Marshal(outputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal child results: %w", err)
+	}
+
+	if failure != "" {
+		err = wctx.createStep(wctx.db, stepName, store.WorkflowStepsStatusFailed, data, failure, now)
+		if err != nil {
+			return nil, err
+		}
+		return results, fmt.Errorf("%w: %s", ErrChildWorkflowFailed, failure)
+	}
+
+	err = wctx.createStep(wctx.db, stepName, store.WorkflowStepsStatusCompleted, data, "", now)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// AwaitChild suspends the parent workflow until the given child workflow has
+// finished and returns its result. See AwaitChildren.
+func AwaitChild[T any](ctx WorkflowContext, executionID string) (T, error) {
+	results, err := AwaitChildren[T](ctx, executionID)
+	if len(results) == 0 {
+		var zero T
+		return zero, err
+	}
+
+	return results[0], err
+}
+
+// unmarshalChildResults unmarshals the raw results of children. Children
+// without a result, because they did not complete or never called SetResult,
+// get the zero value.
+func unmarshalChildResults[T any](wctx *workflowContext, executionIDs []string, outputs [][]byte) ([]T, error) {
+	results := make([]T, len(executionIDs))
+	for i, output := range outputs {
+		if i >= len(results) || len(output) == 0 {
+			continue
+		}
+
+		err := wctx.marshaller.Unmarshal(output, &results[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal result of child workflow %s: %w", executionIDs[i], err)
+		}
+	}
+
+	return results, nil
+}
+
+// checkChildren reports whether all children have finished, returns the
+// results of the children that completed and describes the first child that
+// did not complete, if any.
+func (w *workflowContext) checkChildren(ctx context.Context, executionIDs []string) ([][]byte, bool, string, error) {
+	outputs := make([][]byte, len(executionIDs))
+	failure := ""
+	for i, id := range executionIDs {
+		child, err := store.Query.GetWorkflow(ctx, w.db, store.GetWorkflowParams{
+			ID:        id,
+			Namespace: w.namespace,
+		})
+		if err != nil {
+			return nil, false, "", fmt.Errorf("failed to load child workflow %q: %w", id, err)
+		}
+
+		if child.ParentExecutionID.String != w.executionID {
+			return nil, false, "", fmt.Errorf("workflow %q is not a child of %q", id, w.executionID)
+		}
+
+		switch {
+		case child.Status == store.WorkflowExecutionsStatusCompleted:
+			outputs[i] = child.OutputData
+		case isFinished(child):
+			if failure == "" {
+				failure = fmt.Sprintf("%s (%s) %s: %s", id, child.WorkflowName, child.Status, child.ErrorMessage.String)
+			}
+		default:
+			return nil, false, "", nil
+		}
+	}
+
+	return outputs, true, failure, nil
+}
+
+// wakeParent wakes up the parent of a child workflow that just finished, so
+// it can check on its children without waiting for its next scheduled check.
+func (e *Engine) wakeParent(ctx context.Context, workflow store.WorkflowExecution) {
+	if !workflow.ParentExecutionID.Valid {
+		return
+	}
+
+	err := store.Query.WakeWorkflow(ctx, e.db, store.WakeWorkflowParams{
+		SleepUntil: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		ID:         workflow.ParentExecutionID.String,
+		Namespace:  e.namespace,
+	})
+	if err != nil {
+		e.logger.Error("Failed to wake parent workflow",
+			"workflow_id", workflow.ID,
+			"parent_id", workflow.ParentExecutionID.String,
+			"error", err.Error(),
+		)
+	}
+}
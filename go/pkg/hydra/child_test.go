@@ -0,0 +1,266 @@
+package hydra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+)
+
+type childRequest struct {
+	Region string `json:"region"`
+	Fail   bool   `json:"fail"`
+}
+
+type childWorkflow struct {
+	executions atomic.Int64
+}
+
+func (w *childWorkflow) Name() string {
+	return "provision-region"
+}
+
+func (w *childWorkflow) Run(ctx WorkflowContext, req *childRequest) error {
+	vm, err := Step(ctx, "provision", func(context.Context) (string, error) {
+		w.executions.Add(1)
+		if req.Fail {
+			return "", fmt.Errorf("region %s is unavailable", req.Region)
+		}
+		return "vm-" + req.Region, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return SetResult(ctx, vm)
+}
+
+type parentRequest struct {
+	Regions    []string `json:"regions"`
+	FailRegion string   `json:"fail_region"`
+}
+
+type parentWorkflow struct {
+	childFailed atomic.Bool
+	results     atomic.Pointer[[]string]
+}
+
+func (w *parentWorkflow) Name() string {
+	return "deploy-regions"
+}
+
+func (w *parentWorkflow) Run(ctx WorkflowContext, req *parentRequest) error {
+	children := make([]string, 0, len(req.Regions))
+	for _, region := range req.Regions {
+		childID, err := ChildWorkflow(ctx, region, "provision-region", &childRequest{
+			Region: region,
+			Fail:   region == req.FailRegion,
+		}, WithMaxAttempts(1))
+		if err != nil {
+			return err
+		}
+		children = append(children, childID)
+	}
+
+	vms, err := AwaitChildren[string](ctx, children...)
+	if err == nil || errors.Is(err, ErrChildWorkflowFailed) {
+		w.results.Store(&vms)
+	}
+	if errors.Is(err, ErrChildWorkflowFailed) {
+		w.childFailed.Store(true)
+		return nil
+	}
+
+	return err
+}
+
+func startParentWorker(t *testing.T, engine *Engine, parent *parentWorkflow, child *childWorkflow) {
+	t.Helper()
+
+	worker, err := NewWorker(engine, WorkerConfig{
+		Concurrency:  4,
+		PollInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, RegisterWorkflow(worker, parent))
+	require.NoError(t, RegisterWorkflow(worker, child))
+	require.NoError(t, worker.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = worker.Shutdown(context.Background())
+	})
+}
+
+// TestChildWorkflowsFanOutFanIn guarantees that a parent can start several
+// children, each of them runs exactly once, and the parent resumes after all
+// of them finished with their results in order.
+func TestChildWorkflowsFanOutFanIn(t *testing.T) {
+	engine := newTestEngine(t)
+	parent := &parentWorkflow{}
+	child := &childWorkflow{}
+	startParentWorker(t, engine, parent, child)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, parent.Name(), &parentRequest{
+		Regions: []string{"us-east-1", "eu-west-1", "ap-south-1"},
+	})
+	require.NoError(t, err)
+
+	wf := waitForWorkflowCompletion(t, engine, executionID, 10*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, wf.Status)
+	require.Equal(t, int64(3), child.executions.Load())
+	require.False(t, parent.childFailed.Load())
+	require.Equal(t, []string{"vm-us-east-1", "vm-eu-west-1", "vm-ap-south-1"}, *parent.results.Load())
+}
+
+// TestChildWorkflowFailurePropagates guarantees that a permanently failed
+// child is reported to the parent along with the results of the children that
+// completed.
+func TestChildWorkflowFailurePropagates(t *testing.T) {
+	engine := newTestEngine(t)
+	parent := &parentWorkflow{}
+	child := &childWorkflow{}
+	startParentWorker(t, engine, parent, child)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, parent.Name(), &parentRequest{
+		Regions:    []string{"us-east-1", "eu-west-1"},
+		FailRegion: "eu-west-1",
+	})
+	require.NoError(t, err)
+
+	wf := waitForWorkflowCompletion(t, engine, executionID, 10*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, wf.Status)
+	require.True(t, parent.childFailed.Load())
+	require.Equal(t, []string{"vm-us-east-1", ""}, *parent.results.Load())
+}
+
+// waitingChildWorkflow waits for a signal that never arrives.
+type waitingChildWorkflow struct{}
+
+func (w *waitingChildWorkflow) Name() string {
+	return "waiting"
+}
+
+func (w *waitingChildWorkflow) Run(ctx WorkflowContext, _ *struct{}) error {
+	_, err := WaitForSignal[struct{}](ctx, "never", time.Hour)
+	return err
+}
+
+// blockingChildWorkflow starts a waiting child and then blocks in a step until it
+// is released.
+type blockingChildWorkflow struct {
+	started  chan struct{}
+	release  chan struct{}
+	resumed  atomic.Bool
+	childIDs chan string
+}
+
+func (w *blockingChildWorkflow) Name() string {
+	return "blocking"
+}
+
+func (w *blockingChildWorkflow) Run(ctx WorkflowContext, _ *struct{}) error {
+	childID, err := ChildWorkflow(ctx, "waiting", "waiting", &struct{}{})
+	if err != nil {
+		return err
+	}
+
+	err = StepVoid(ctx, "block", func(context.Context) error {
+		w.childIDs <- childID
+		close(w.started)
+		<-w.release
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return StepVoid(ctx, "after-block", func(context.Context) error {
+		w.resumed.Store(true)
+		return nil
+	})
+}
+
+// failingParentWorkflow starts a blocking child and fails permanently once the
+// child is running.
+type failingParentWorkflow struct {
+	started  chan struct{}
+	childIDs chan string
+}
+
+func (w *failingParentWorkflow) Name() string {
+	return "failing-parent"
+}
+
+func (w *failingParentWorkflow) Run(ctx WorkflowContext, _ *struct{}) error {
+	childID, err := ChildWorkflow(ctx, "blocking", "blocking", &struct{}{})
+	if err != nil {
+		return err
+	}
+
+	return StepVoid(ctx, "fail", func(context.Context) error {
+		w.childIDs <- childID
+		<-w.started
+		return errors.New("parent failed")
+	})
+}
+
+// TestParentFailureCancelsDescendants guarantees that a permanently failed
+// parent cancels its running child cooperatively and its waiting grandchild
+// right away.
+func TestParentFailureCancelsDescendants(t *testing.T) {
+	engine := newTestEngine(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	parent := &failingParentWorkflow{started: started, childIDs: make(chan string, 1)}
+	child := &blockingChildWorkflow{started: started, release: release, childIDs: make(chan string, 1)}
+
+	worker, err := NewWorker(engine, WorkerConfig{
+		Concurrency:  4,
+		PollInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, RegisterWorkflow(worker, parent))
+	require.NoError(t, RegisterWorkflow(worker, child))
+	require.NoError(t, RegisterWorkflow(worker, &waitingChildWorkflow{}))
+	require.NoError(t, worker.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = worker.Shutdown(context.Background())
+	})
+
+	// Never leave the child blocked, or the worker cannot shut down
+	var releaseOnce sync.Once
+	releaseChild := func() {
+		releaseOnce.Do(func() { close(release) })
+	}
+	t.Cleanup(releaseChild)
+
+	ctx := context.Background()
+	parentID, err := engine.StartWorkflow(ctx, parent.Name(), &struct{}{}, WithMaxAttempts(1))
+	require.NoError(t, err)
+
+	wf := waitForWorkflowCompletion(t, engine, parentID, 10*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusFailed, wf.Status)
+
+	childID := <-parent.childIDs
+	grandchildID := <-child.childIDs
+
+	// The grandchild is waiting, so it is cancelled right away
+	grandchild := waitForWorkflowCompletion(t, engine, grandchildID, 10*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCancelled, grandchild.Status)
+
+	// The child is still running its step and stops before the next one
+	releaseChild()
+	wf = waitForWorkflowCompletion(t, engine, childID, 10*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCancelled, wf.Status)
+	require.Contains(t, wf.ErrorMessage.String, parentID)
+	require.False(t, child.resumed.Load())
+}
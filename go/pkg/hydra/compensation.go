@@ -0,0 +1,80 @@
+package hydra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/unkeyed/unkey/go/pkg/hydra/metrics"
+)
+
+// compensationStepPrefix prefixes the steps that record the outcome of a
+// compensation.
+const compensationStepPrefix = "compensate-"
+
+// StepOption configures a single step.
+type StepOption[TResponse any] func(*stepOptions[TResponse])
+
+type stepOptions[TResponse any] struct {
+	compensate func(context.Context, TResponse) error
+}
+
+// WithCompensation registers a function that undoes the side effects of a
+// step, for example deleting a VM that the step created.
+//
+// Once the step completed, the compensation is registered with the execution.
+// If the execution later fails permanently or is cancelled, the worker runs
+// the compensations of all completed steps in reverse order. Each
+// compensation receives the result of its step and is recorded as a step
+// named "compensate-<step name>", so a compensation that succeeded is never
+// run again, even if the worker crashes while compensating.
+//
+// Compensations must be idempotent. Terminated executions are not
+// compensated.
+//
+// Example:
+//
+//	vm, err := hydra.Step(ctx, "create-vm", func(stepCtx context.Context) (*VM, error) {
+//	    return vms.Create(stepCtx, config)
+//	}, hydra.WithCompensation(func(stepCtx context.Context, vm *VM) error {
+//	    return vms.Delete(stepCtx, vm.ID)
+//	}))
+func WithCompensation[TResponse any](fn func(context.Context, TResponse) error) StepOption[TResponse] {
+	return func(o *stepOptions[TResponse]) {
+		o.compensate = fn
+	}
+}
+
+// compensation undoes a completed step.
+type compensation struct {
+	stepName string
+	fn       func(context.Context) error
+}
+
+// addCompensation registers the compensation of a completed step. Workflows
+// replay from the start on every run, so the compensations of steps that
+// completed in earlier runs are registered again from their cached results.
+func (w *workflowContext) addCompensation(stepName string, fn func(context.Context) error) {
+	w.compensations = append(w.compensations, compensation{stepName: stepName, fn: fn})
+}
+
+// compensate runs all registered compensations in reverse order. A failing
+// compensation does not stop the remaining ones, all errors are returned
+// together.
+func (w *workflowContext) compensate() error {
+	w.compensating = true
+	defer func() { w.compensating = false }()
+
+	var errs []error
+	for i := len(w.compensations) - 1; i >= 0; i-- {
+		c := w.compensations[i]
+
+		err := StepVoid(w, compensationStepPrefix+c.stepName, c.fn)
+		if err != nil {
+			metrics.RecordError(w.namespace, "step", "compensation_failed")
+			errs = append(errs, fmt.Errorf("failed to compensate step %s: %w", c.stepName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
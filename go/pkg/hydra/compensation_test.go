@@ -0,0 +1,234 @@
+package hydra
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+)
+
+// sagaWorkflow creates two resources, then runs a step that fails or blocks,
+// depending on the test, and finally a step without side effects.
+type sagaWorkflow struct {
+	failLast    bool
+	blockLast   chan struct{}
+	started     chan struct{}
+	created     atomic.Int64
+	mu          sync.Mutex
+	compensated []string
+}
+
+func (w *sagaWorkflow) Name() string {
+	return "saga-workflow"
+}
+
+func (w *sagaWorkflow) compensate(name string) func(context.Context, string) error {
+	return func(_ context.Context, id string) error {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.compensated = append(w.compensated, fmt.Sprintf("%s:%s", name, id))
+		return nil
+	}
+}
+
+func (w *sagaWorkflow) Run(ctx WorkflowContext, req struct{}) error {
+	_, err := Step(ctx, "create-a", func(context.Context) (string, error) {
+		w.created.Add(1)
+		return "a_123", nil
+	}, WithCompensation(w.compensate("delete-a")))
+	if err != nil {
+		return err
+	}
+
+	_, err = Step(ctx, "create-b", func(context.Context) (string, error) {
+		w.created.Add(1)
+		return "b_456", nil
+	}, WithCompensation(w.compensate("delete-b")))
+	if err != nil {
+		return err
+	}
+
+	err = StepVoid(ctx, "last", func(context.Context) error {
+		if w.blockLast != nil {
+			w.started <- struct{}{}
+			<-w.blockLast
+		}
+		if w.failLast {
+			return fmt.Errorf("last step failed")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return StepVoid(ctx, "notify", func(context.Context) error {
+		return nil
+	})
+}
+
+func (w *sagaWorkflow) compensations() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string{}, w.compensated...)
+}
+
+func startSagaWorker(t *testing.T, engine *Engine, workflow *sagaWorkflow) {
+	t.Helper()
+
+	worker, err := NewWorker(engine, WorkerConfig{
+		Concurrency:  1,
+		PollInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, RegisterWorkflow(worker, workflow))
+	require.NoError(t, worker.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = worker.Shutdown(context.Background())
+	})
+}
+
+// TestCompensationOnFailure guarantees that compensations run in reverse
+// order once an execution fails permanently, and are recorded as steps.
+func TestCompensationOnFailure(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := &sagaWorkflow{failLast: true}
+	startSagaWorker(t, engine, workflow)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{}, WithMaxAttempts(2))
+	require.NoError(t, err)
+
+	// Wait for the last attempt, earlier attempts fail without completing
+	require.Eventually(t, func() bool {
+		wf, getErr := engine.GetExecution(ctx, executionID)
+		return getErr == nil && wf.Status == store.WorkflowExecutionsStatusFailed && wf.CompletedAt.Valid
+	}, 10*time.Second, 100*time.Millisecond)
+
+	require.Equal(t, []string{"delete-b:b_456", "delete-a:a_123"}, workflow.compensations(),
+		"compensations must run once, in reverse order, and only after the last attempt")
+
+	steps, err := engine.ListSteps(ctx, executionID)
+	require.NoError(t, err)
+	statuses := map[string]store.WorkflowStepsStatus{}
+	for _, step := range steps {
+		statuses[step.StepName] = step.Status
+	}
+	require.Equal(t, store.WorkflowStepsStatusCompleted, statuses["compensate-create-a"])
+	require.Equal(t, store.WorkflowStepsStatusCompleted, statuses["compensate-create-b"])
+}
+
+// TestCompensationOnCancellation guarantees that cancelling a running
+// execution compensates the steps it completed.
+func TestCompensationOnCancellation(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := &sagaWorkflow{
+		blockLast: make(chan struct{}),
+		started:   make(chan struct{}, 1),
+	}
+	startSagaWorker(t, engine, workflow)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{})
+	require.NoError(t, err)
+
+	select {
+	case <-workflow.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("last step did not start")
+	}
+
+	require.NoError(t, engine.Cancel(ctx, executionID, "cancelled by operator"))
+	close(workflow.blockLast)
+
+	final := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCancelled, final.Status)
+	require.Equal(t, []string{"delete-b:b_456", "delete-a:a_123"}, workflow.compensations())
+}
+
+// TestCompensationOnCancellationWithoutWorker guarantees that an execution
+// with completed steps is compensated by the next worker that picks it up.
+func TestCompensationOnCancellationWithoutWorker(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := &sagaWorkflow{failLast: true}
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{}, WithMaxAttempts(5))
+	require.NoError(t, err)
+
+	// Run the first attempt, which fails in the last step and is retried later
+	worker, err := NewWorker(engine, WorkerConfig{
+		Concurrency:  1,
+		PollInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, RegisterWorkflow(worker, workflow))
+	require.NoError(t, worker.Start(ctx))
+
+	require.Eventually(t, func() bool {
+		wf, getErr := engine.GetExecution(ctx, executionID)
+		return getErr == nil && wf.Status == store.WorkflowExecutionsStatusFailed
+	}, 5*time.Second, 50*time.Millisecond)
+	require.NoError(t, worker.Shutdown(ctx))
+
+	require.NoError(t, engine.Cancel(ctx, executionID, "cancelled by operator"))
+
+	wf, err := engine.GetExecution(ctx, executionID)
+	require.NoError(t, err)
+	require.Equal(t, store.WorkflowExecutionsStatusPending, wf.Status, "a worker must compensate the execution")
+
+	startSagaWorker(t, engine, workflow)
+
+	final := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCancelled, final.Status)
+	require.Equal(t, "cancelled by operator", final.ErrorMessage.String)
+	require.Equal(t, []string{"delete-b:b_456", "delete-a:a_123"}, workflow.compensations())
+}
+
+// TestRetryCompensatedExecution guarantees that retrying a compensated
+// execution runs the undone steps again instead of reusing their results.
+func TestRetryCompensatedExecution(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := &sagaWorkflow{
+		blockLast: make(chan struct{}),
+		started:   make(chan struct{}, 1),
+	}
+	startSagaWorker(t, engine, workflow)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{})
+	require.NoError(t, err)
+
+	select {
+	case <-workflow.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("last step did not start")
+	}
+
+	require.NoError(t, engine.Cancel(ctx, executionID, "cancelled by operator"))
+	close(workflow.blockLast)
+
+	cancelled := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCancelled, cancelled.Status)
+	require.Equal(t, int64(2), workflow.created.Load())
+
+	require.NoError(t, engine.Retry(ctx, executionID))
+
+	completed := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, completed.Status)
+	require.Equal(t, int64(4), workflow.created.Load(), "compensated steps must run again")
+	require.Equal(t, []string{"delete-b:b_456", "delete-a:a_123"}, workflow.compensations())
+
+	steps, err := engine.ListSteps(ctx, executionID)
+	require.NoError(t, err)
+	for _, step := range steps {
+		require.Equal(t, store.WorkflowStepsStatusCompleted, step.Status, step.StepName)
+		require.NotContains(t, step.StepName, compensationStepPrefix)
+	}
+}
@@ -150,6 +150,37 @@
 //	// Sleep for 24 hours for manual approval
 //	return hydra.Sleep(ctx, 24*time.Hour)
 //
+// Signals: Workflows can wait until something external happens and receive
+// a payload from whoever sends the signal:
+//
+//	// In the workflow, wait up to 72 hours for an approval
+//	approval, err := hydra.WaitForSignal[*Approval](ctx, "approval", 72*time.Hour)
+//
+//	// Anywhere else, resume the workflow
+//	err = engine.Signal(ctx, executionID, "approval", &Approval{ApprovedBy: "user_123"})
+//
+// Child Workflows: Workflows can start child workflows that run in parallel
+// and wait for their results:
+//
+//	childID, err := hydra.ChildWorkflow(ctx, "provision-us-east-1", "provision-region", req)
+//	vm, err := hydra.AwaitChild[*VM](ctx, childID)
+//
+// Cancellation: Executions can be cancelled, which stops them before their
+// next step, terminated right away, or retried once they finished:
+//
+//	err := engine.Cancel(ctx, executionID, "superseded by a newer deployment")
+//	err = engine.Retry(ctx, executionID)
+//
+// Compensations: Steps can register a function that undoes their side
+// effects. Compensations run in reverse order when an execution fails
+// permanently or is cancelled:
+//
+//	vm, err := hydra.Step(ctx, "create-vm", createVM, hydra.WithCompensation(
+//	    func(stepCtx context.Context, vm *VM) error {
+//	        return vms.Delete(stepCtx, vm.ID)
+//	    },
+//	))
+//
 // Cron Scheduling: Register workflows to run on a schedule:
 //
 //	err = engine.RegisterCron("0 0 * * *", "daily-report", func(ctx context.Context) error {
@@ -165,6 +196,48 @@
 //	    hydra.WithTimeout(10*time.Minute),
 //	)
 //
+// Idempotent Starts: Starting a workflow again with the same idempotency key
+// returns the existing execution instead of creating a duplicate:
+//
+//	executionID, err := engine.StartWorkflow(ctx, "order-processing", request,
+//	    hydra.WithIdempotencyKey(request.OrderID),
+//	)
+//
+// Retention: Finished executions are deleted by a janitor cron job once they
+// are older than the configured retention period, optionally after archiving
+// them:
+//
+//	engine, err := hydra.New(hydra.Config{
+//	    Retention: hydra.RetentionConfig{
+//	        Default:  30 * 24 * time.Hour,
+//	        Archiver: archiver,
+//	    },
+//	    // ... other config
+//	})
+//
+// Versioning: Changes to the step sequence of a workflow are guarded with
+// Version, so executions that are in flight keep the old sequence:
+//
+//	v, err := hydra.Version(ctx, "verify-dns", hydra.DefaultVersion, 1)
+//	if v == 1 {
+//	    err = hydra.StepVoid(ctx, "verify-dns", verifyDNS)
+//	}
+//
+// Storage Backends: State is stored in MySQL by default. Postgres and SQLite
+// run the same queries, rewritten for their dialect, and are selected with
+// the database/sql driver:
+//
+//	import _ "modernc.org/sqlite"
+//
+//	db, err := sql.Open("sqlite", dsn)
+//	_, err = db.Exec(store.Schema(store.SQLite))
+//
+//	engine, err := hydra.New(hydra.Config{
+//	    Driver: "sqlite",
+//	    DSN:    dsn,
+//	    // ... other config
+//	})
+//
 // # Observability
 //
 // Hydra provides comprehensive Prometheus metrics out of the box:
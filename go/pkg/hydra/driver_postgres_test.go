@@ -0,0 +1,7 @@
+//go:build hydra_postgres
+
+package hydra
+
+// Registers the "pgx" driver, run the suites against Postgres with
+// HYDRA_TEST_DRIVER=pgx HYDRA_TEST_POSTGRES_DSN=... go test -tags hydra_postgres ./pkg/hydra
+import _ "github.com/jackc/pgx/v5/stdlib"
@@ -0,0 +1,7 @@
+//go:build hydra_sqlite
+
+package hydra
+
+// Registers the "sqlite" driver, run the suites against SQLite with
+// HYDRA_TEST_DRIVER=sqlite go test -tags hydra_sqlite ./pkg/hydra
+import _ "modernc.org/sqlite"
@@ -3,9 +3,11 @@ package hydra
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/unkeyed/unkey/go/pkg/assert"
 	"github.com/unkeyed/unkey/go/pkg/clock"
 	"github.com/unkeyed/unkey/go/pkg/hydra/metrics"
@@ -15,21 +17,29 @@ import (
 	"github.com/unkeyed/unkey/go/pkg/retry"
 	"github.com/unkeyed/unkey/go/pkg/uid"
 	"go.opentelemetry.io/otel/attribute"
-
-	// MySQL driver
-	_ "github.com/go-sql-driver/mysql"
 )
 
+// defaultIdempotencyKeyTTL is used when Config.IdempotencyKeyTTL is not set.
+const defaultIdempotencyKeyTTL = 24 * time.Hour
+
 // Config holds the configuration for creating a new Engine instance.
 //
 // All fields except Store are optional and will use sensible defaults
 // if not provided.
 type Config struct {
-	// DSN is the database connection string for MySQL.
+	// DSN is the database connection string in the format of the Driver.
 	// This field is required and cannot be empty.
 	// The engine will create an SQLC store from this connection.
 	DSN string
 
+	// Driver is the database/sql driver used to connect to DSN, which
+	// selects the storage backend: "mysql", "pgx" or "postgres" for
+	// Postgres, and "sqlite" or "sqlite3" for SQLite. Drivers other than
+	// mysql must be imported by the caller, and the tables must be created
+	// with store.Schema before starting the engine.
+	// Defaults to "mysql" if not specified.
+	Driver string
+
 	// Namespace provides tenant isolation for workflows. All workflows
 	// created by this engine will be scoped to this namespace.
 	// Defaults to "default" if not specified.
@@ -46,6 +56,17 @@ type Config struct {
 	// Marshaller handles serialization of workflow payloads and step results.
 	// Defaults to JSON marshalling if not specified.
 	Marshaller Marshaller
+
+	// IdempotencyKeyTTL is how long a key passed to WithIdempotencyKey
+	// returns the execution it started. Starting the workflow again with the
+	// same key after that creates a new execution.
+	// Defaults to 24 hours if not specified.
+	IdempotencyKeyTTL time.Duration
+
+	// Retention configures how long finished executions are kept. If any
+	// retention period is set, the engine registers a janitor cron job that
+	// deletes expired executions. Executions are kept forever by default.
+	Retention RetentionConfig
 }
 
 // NewConfig creates a default config with sensible defaults.
@@ -56,11 +77,13 @@ type Config struct {
 // - All other fields will be set to their defaults when passed to New()
 func NewConfig() Config {
 	return Config{
-		DSN:        "",
-		Namespace:  "default",
-		Clock:      clock.New(),
-		Logger:     nil,
-		Marshaller: nil,
+		DSN:               "",
+		Driver:            "mysql",
+		Namespace:         "default",
+		Clock:             clock.New(),
+		Logger:            nil,
+		Marshaller:        nil,
+		IdempotencyKeyTTL: defaultIdempotencyKeyTTL,
 	}
 }
 
@@ -77,12 +100,14 @@ func NewConfig() Config {
 // Engine instances are thread-safe and can be shared across multiple
 // workers and goroutines.
 type Engine struct {
-	db           *sql.DB
-	namespace    string
-	cronHandlers map[string]CronHandler
-	clock        clock.Clock
-	logger       logging.Logger
-	marshaller   Marshaller
+	db                *store.DB
+	namespace         string
+	cronHandlers      map[string]CronHandler
+	clock             clock.Clock
+	logger            logging.Logger
+	marshaller        Marshaller
+	idempotencyKeyTTL time.Duration
+	retention         RetentionConfig
 }
 
 // New creates a new Engine instance with the provided configuration.
@@ -111,6 +136,19 @@ func New(config Config) (*Engine, error) {
 		return nil, err
 	}
 
+	if config.IdempotencyKeyTTL <= 0 {
+		config.IdempotencyKeyTTL = defaultIdempotencyKeyTTL
+	}
+
+	if config.Driver == "" {
+		config.Driver = "mysql"
+	}
+
+	dialect, err := store.DialectForDriver(config.Driver)
+	if err != nil {
+		return nil, fmt.Errorf("hydra: %w", err)
+	}
+
 	var db *sql.DB
 	err = retry.New(
 		retry.Attempts(10),
@@ -119,9 +157,9 @@ func New(config Config) (*Engine, error) {
 		}),
 	).Do(func() error {
 		var openErr error
-		db, openErr = sql.Open("mysql", config.DSN)
+		db, openErr = sql.Open(config.Driver, config.DSN)
 		if openErr != nil {
-			config.Logger.Info("mysql not ready yet, retrying...", "error", openErr.Error())
+			config.Logger.Info("database not ready yet, retrying...", "error", openErr.Error())
 		}
 		return openErr
 
@@ -145,14 +183,26 @@ func New(config Config) (*Engine, error) {
 		return nil, fmt.Errorf("hydra: failed to ping database: %v", err)
 	}
 
-	return &Engine{
-		db:           db,
-		namespace:    config.Namespace,
-		cronHandlers: make(map[string]CronHandler),
-		clock:        config.Clock,
-		logger:       config.Logger,
-		marshaller:   config.Marshaller,
-	}, nil
+	e := &Engine{
+		db:                store.NewDB(db, dialect),
+		namespace:         config.Namespace,
+		cronHandlers:      make(map[string]CronHandler),
+		clock:             config.Clock,
+		logger:            config.Logger,
+		marshaller:        config.Marshaller,
+		idempotencyKeyTTL: config.IdempotencyKeyTTL,
+		retention:         config.Retention.withDefaults(),
+	}
+
+	if e.retention.enabled() {
+		err = e.RegisterCron(e.retention.Schedule, janitorCronName, e.runJanitor)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("hydra: failed to register retention janitor: %w", err)
+		}
+	}
+
+	return e, nil
 }
 
 // GetNamespace returns the namespace for this engine instance.
@@ -163,8 +213,9 @@ func (e *Engine) GetNamespace() string {
 	return e.namespace
 }
 
-// GetDB returns the database connection for direct query usage
-func (e *Engine) GetDB() *sql.DB {
+// GetDB returns the database connection for direct query usage. Queries run
+// through it are rewritten for the dialect of the storage backend.
+func (e *Engine) GetDB() *store.DB {
 	return e.db
 }
 
@@ -219,6 +270,9 @@ func (e *Engine) RegisterCron(cronSpec, name string, handler CronHandler) error
 // - executionID: A unique identifier for this workflow execution
 // - error: Any error that occurred during workflow creation
 //
+// With WithIdempotencyKey, the ID of the existing execution is returned if
+// the workflow was already started with the same key.
+//
 // The payload will be marshalled using the engine's configured marshaller (JSON by default)
 // and must be serializable. The workflow will be executed with the configured retry
 // policy and timeout settings.
@@ -247,16 +301,7 @@ func (e *Engine) StartWorkflow(ctx context.Context, workflowName string, payload
 		attribute.String("hydra.namespace", e.namespace),
 	)
 
-	config := &WorkflowConfig{
-		MaxAttempts:     3, // Default to 3 attempts total (1 initial + 2 retries)
-		TimeoutDuration: 1 * time.Hour,
-		RetryBackoff:    1 * time.Second,
-		TriggerType:     store.WorkflowExecutionsTriggerTypeApi, // Default trigger type
-		TriggerSource:   nil,
-	}
-	for _, opt := range opts {
-		opt(config)
-	}
+	config := newWorkflowConfig(opts...)
 
 	span.SetAttributes(
 		attribute.String("hydra.trigger.type", string(config.TriggerType)),
@@ -281,7 +326,7 @@ func (e *Engine) StartWorkflow(ctx context.Context, workflowName string, payload
 	}
 
 	// Use new Query pattern instead of store abstraction
-	err = store.Query.CreateWorkflow(ctx, e.db, store.CreateWorkflowParams{
+	params := store.CreateWorkflowParams{
 		ID:                executionID,
 		WorkflowName:      workflowName,
 		Status:            store.WorkflowExecutionsStatusPending,
@@ -300,11 +345,44 @@ func (e *Engine) StartWorkflow(ctx context.Context, workflowName string, payload
 		SleepUntil:        sql.NullInt64{Int64: 0, Valid: false},
 		TraceID:           sql.NullString{String: traceID, Valid: traceID != ""},
 		SpanID:            sql.NullString{String: spanID, Valid: spanID != ""},
-	})
-	if err != nil {
-		metrics.RecordError(e.namespace, "engine", "workflow_creation_failed")
-		tracing.RecordError(span, err)
-		return "", fmt.Errorf("failed to create workflow: %w", err)
+		ParentExecutionID: sql.NullString{String: "", Valid: false},
+	}
+
+	if config.IdempotencyKey != "" {
+		var existingID string
+		var created bool
+
+		// Concurrent starts with the same key can deadlock on the key's row
+		createErr := retry.New(
+			retry.Attempts(5),
+			retry.Backoff(func(n int) time.Duration {
+				return time.Duration(n) * 20 * time.Millisecond
+			}),
+			retry.ShouldRetry(isDeadlock),
+		).Do(func() error {
+			var claimErr error
+			existingID, created, claimErr = e.createWorkflowWithIdempotencyKey(ctx, params, config.IdempotencyKey)
+			return claimErr
+		})
+		if createErr != nil {
+			metrics.RecordError(e.namespace, "engine", "workflow_creation_failed")
+			tracing.RecordError(span, createErr)
+			return "", fmt.Errorf("failed to create workflow: %w", createErr)
+		}
+		if !created {
+			span.SetAttributes(
+				attribute.Bool("hydra.workflow.deduplicated", true),
+				attribute.String("hydra.execution.existing_id", existingID),
+			)
+			return existingID, nil
+		}
+	} else {
+		err = store.Query.CreateWorkflow(ctx, e.db, params)
+		if err != nil {
+			metrics.RecordError(e.namespace, "engine", "workflow_creation_failed")
+			tracing.RecordError(span, err)
+			return "", fmt.Errorf("failed to create workflow: %w", err)
+		}
 	}
 
 	// Record workflow started
@@ -316,3 +394,70 @@ func (e *Engine) StartWorkflow(ctx context.Context, workflowName string, payload
 
 	return executionID, nil
 }
+
+// createWorkflowWithIdempotencyKey creates the workflow execution unless an
+// unexpired idempotency key of the same workflow already points to another
+// execution. The key and the execution are written in one transaction, so
+// concurrent starts with the same key create a single execution.
+//
+// Returns the ID of the execution the key points to, and whether it was
+// created by this call.
+func (e *Engine) createWorkflowWithIdempotencyKey(ctx context.Context, params store.CreateWorkflowParams, idempotencyKey string) (string, bool, error) {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	err = store.Query.ClaimIdempotencyKey(ctx, tx, store.ClaimIdempotencyKeyParams{
+		Namespace:      e.namespace,
+		WorkflowName:   params.WorkflowName,
+		IdempotencyKey: idempotencyKey,
+		ExecutionID:    params.ID,
+		CreatedAt:      params.CreatedAt,
+		ExpiresAt:      params.CreatedAt + e.idempotencyKeyTTL.Milliseconds(),
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	claimed, err := store.Query.GetIdempotencyKey(ctx, tx, store.GetIdempotencyKeyParams{
+		Namespace:      e.namespace,
+		WorkflowName:   params.WorkflowName,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to load idempotency key: %w", err)
+	}
+
+	if claimed.ExecutionID != params.ID {
+		return claimed.ExecutionID, false, nil
+	}
+
+	err = store.Query.CreateWorkflow(ctx, tx, params)
+	if err != nil {
+		return "", false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return params.ID, true, nil
+}
+
+// isDeadlock reports whether the database rolled back the transaction to
+// resolve a deadlock, in which case it can be retried.
+func isDeadlock(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1213
+	}
+
+	// Postgres drivers expose the SQLSTATE of their errors
+	var pgErr interface{ SQLState() string }
+	return errors.As(err, &pgErr) && pgErr.SQLState() == "40P01"
+}
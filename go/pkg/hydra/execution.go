@@ -0,0 +1,505 @@
+package hydra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/unkeyed/unkey/go/pkg/hydra/metrics"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+	"github.com/unkeyed/unkey/go/pkg/otel/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	// ErrExecutionNotFound is returned when a workflow execution does not
+	// exist in the engine's namespace.
+	ErrExecutionNotFound = errors.New("workflow execution not found")
+
+	// ErrExecutionFinished is returned when cancelling or terminating a
+	// workflow execution that has already completed, failed permanently or
+	// was cancelled before.
+	ErrExecutionFinished = errors.New("workflow execution has already finished")
+
+	// ErrExecutionNotRetryable is returned by Engine.Retry when the workflow
+	// execution did not fail permanently, was not cancelled or is still held
+	// by a worker.
+	ErrExecutionNotRetryable = errors.New("workflow execution can not be retried")
+
+	// ErrExecutionCancelled is returned by Step once Engine.Cancel was called
+	// for the running execution. Workflows must return it from Run unchanged.
+	ErrExecutionCancelled = errors.New("workflow execution was cancelled")
+)
+
+const (
+	defaultListExecutionsLimit = 100
+	maxListExecutionsLimit     = 1000
+)
+
+// ListExecutionsRequest filters and paginates Engine.ListExecutions.
+//
+// All fields are optional.
+type ListExecutionsRequest struct {
+	// Status only returns executions in this status.
+	Status store.WorkflowExecutionsStatus
+
+	// WorkflowName only returns executions of this workflow.
+	WorkflowName string
+
+	// Cursor is the ID of the last execution of the previous page.
+	Cursor string
+
+	// Limit is the maximum number of executions to return.
+	// Defaults to 100, and can not be larger than 1000.
+	Limit int
+}
+
+// Cancel stops a workflow execution and all of its unfinished children.
+//
+// Executions that have not completed any step and are not currently held by
+// a worker are cancelled right away. All other executions are cancelled
+// cooperatively: a worker stops the execution before its next step, so a step
+// that is already running is never interrupted, and runs the compensations
+// registered with WithCompensation. The reason is stored as the execution's
+// error message.
+//
+// Example:
+//
+//	err := engine.Cancel(ctx, executionID, "superseded by a newer deployment")
+//	if errors.Is(err, hydra.ErrExecutionFinished) {
+//	    // nothing to cancel
+//	}
+//
+// Returns ErrExecutionNotFound if the execution does not exist and
+// ErrExecutionFinished if it has already finished.
+//
+// Metrics recorded:
+// - hydra_workflows_completed_total (counter with status "cancelled")
+func (e *Engine) Cancel(ctx context.Context, executionID, reason string) error {
+	ctx, span := tracing.Start(ctx, "hydra.engine.Cancel")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("hydra.execution.id", executionID),
+		attribute.String("hydra.namespace", e.namespace),
+	)
+
+	workflow, err := e.GetExecution(ctx, executionID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	if isFinished(workflow) {
+		err = fmt.Errorf("%w: %s", ErrExecutionFinished, executionID)
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	err = e.cancel(ctx, workflow, reason)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+// cancel requests the cancellation of an execution, cancels it right away if
+// there is nothing to compensate and no worker holds its lease, and cascades
+// to its children.
+func (e *Engine) cancel(ctx context.Context, workflow store.WorkflowExecution, reason string) error {
+	now := e.clock.Now().UnixMilli()
+
+	_, err := store.Query.RequestWorkflowCancellation(ctx, e.db, store.RequestWorkflowCancellationParams{
+		CancelRequestedAt: sql.NullInt64{Int64: now, Valid: true},
+		ErrorMessage:      sql.NullString{String: reason, Valid: reason != ""},
+		ID:                workflow.ID,
+		Namespace:         e.namespace,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to request cancellation: %w", err)
+	}
+
+	cancelled, err := store.Query.CancelWorkflow(ctx, e.db, store.CancelWorkflowParams{
+		CompletedAt: sql.NullInt64{Int64: now, Valid: true},
+		ID:          workflow.ID,
+		Namespace:   e.namespace,
+		ExpiresAt:   now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cancel workflow: %w", err)
+	}
+
+	// Executions with completed steps need a worker to run their compensations
+	if cancelled == 0 {
+		_, err = store.Query.ResumeWorkflowForCancellation(ctx, e.db, store.ResumeWorkflowForCancellationParams{
+			ID:        workflow.ID,
+			Namespace: e.namespace,
+			ExpiresAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resume workflow for cancellation: %w", err)
+		}
+	}
+
+	err = e.cancelChildren(ctx, workflow.ID, fmt.Sprintf("parent workflow %s was cancelled", workflow.ID))
+	if err != nil {
+		return err
+	}
+
+	if cancelled > 0 {
+		e.wakeParent(ctx, workflow)
+		metrics.WorkflowsCompletedTotal.WithLabelValues(e.namespace, workflow.WorkflowName, "cancelled").Inc()
+	}
+
+	return nil
+}
+
+// cancelChildren cancels all unfinished children of an execution, and through
+// them all of its descendants. Children held by a worker are cancelled
+// cooperatively, like with Cancel.
+func (e *Engine) cancelChildren(ctx context.Context, executionID, reason string) error {
+	children, err := store.Query.GetUnfinishedChildWorkflows(ctx, e.db, store.GetUnfinishedChildWorkflowsParams{
+		Namespace:         e.namespace,
+		ParentExecutionID: sql.NullString{String: executionID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load child workflows: %w", err)
+	}
+
+	for _, child := range children {
+		err = e.cancel(ctx, child, reason)
+		if err != nil {
+			return fmt.Errorf("failed to cancel child workflow %s: %w", child.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Terminate forcefully stops a workflow execution and all of its unfinished
+// children.
+//
+// Unlike Cancel, Terminate does not wait for the worker to reach the next
+// step. The lease is revoked, so every further write of the worker that is
+// currently running the execution is rejected, and no compensations are run.
+// Use it for executions that are stuck, for example because a step never
+// returns.
+//
+// Returns ErrExecutionNotFound if the execution does not exist and
+// ErrExecutionFinished if it has already finished.
+//
+// Metrics recorded:
+// - hydra_workflows_completed_total (counter with status "terminated")
+func (e *Engine) Terminate(ctx context.Context, executionID, reason string) error {
+	ctx, span := tracing.Start(ctx, "hydra.engine.Terminate")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("hydra.execution.id", executionID),
+		attribute.String("hydra.namespace", e.namespace),
+	)
+
+	workflow, err := e.GetExecution(ctx, executionID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	err = e.terminate(ctx, workflow, reason)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
+
+func (e *Engine) terminate(ctx context.Context, workflow store.WorkflowExecution, reason string) error {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	terminated, err := store.Query.TerminateWorkflow(ctx, tx, store.TerminateWorkflowParams{
+		ErrorMessage: sql.NullString{String: reason, Valid: reason != ""},
+		CompletedAt:  sql.NullInt64{Int64: e.clock.Now().UnixMilli(), Valid: true},
+		ID:           workflow.ID,
+		Namespace:    e.namespace,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to terminate workflow: %w", err)
+	}
+	if terminated == 0 {
+		return fmt.Errorf("%w: %s", ErrExecutionFinished, workflow.ID)
+	}
+
+	err = store.Query.DeleteLease(ctx, tx, store.DeleteLeaseParams{
+		ResourceID: workflow.ID,
+		Kind:       store.LeasesKindWorkflow,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke lease: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit termination: %w", err)
+	}
+
+	metrics.WorkflowsCompletedTotal.WithLabelValues(e.namespace, workflow.WorkflowName, "terminated").Inc()
+
+	children, err := store.Query.GetUnfinishedChildWorkflows(ctx, e.db, store.GetUnfinishedChildWorkflowsParams{
+		Namespace:         e.namespace,
+		ParentExecutionID: sql.NullString{String: workflow.ID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load child workflows: %w", err)
+	}
+
+	for _, child := range children {
+		err = e.terminate(ctx, child, fmt.Sprintf("parent workflow %s was terminated", workflow.ID))
+		if err != nil && !errors.Is(err, ErrExecutionFinished) {
+			return fmt.Errorf("failed to terminate child workflow %s: %w", child.ID, err)
+		}
+	}
+
+	e.wakeParent(ctx, workflow)
+
+	return nil
+}
+
+// Retry queues a permanently failed, cancelled or terminated workflow
+// execution again.
+//
+// The execution gets its full number of attempts back and resumes from its
+// last completed step, steps that already completed are not executed again.
+// All other steps run again from the start: a WaitForSignal that timed out
+// waits again with its full timeout, and an AwaitChildren that saw a failed
+// child checks the children again, so retry the failed child first. Steps
+// that were undone by their compensation run again as well.
+//
+// Returns ErrExecutionNotFound if the execution does not exist and
+// ErrExecutionNotRetryable if it is still pending, running or completed.
+//
+// Metrics recorded:
+// - hydra_workflows_queued (gauge)
+func (e *Engine) Retry(ctx context.Context, executionID string) error {
+	ctx, span := tracing.Start(ctx, "hydra.engine.Retry")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("hydra.execution.id", executionID),
+		attribute.String("hydra.namespace", e.namespace),
+	)
+
+	workflow, err := e.GetExecution(ctx, executionID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	retried, err := store.Query.RetryWorkflow(ctx, tx, store.RetryWorkflowParams{
+		ID:        executionID,
+		Namespace: e.namespace,
+		ExpiresAt: e.clock.Now().UnixMilli(),
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to retry workflow: %w", err)
+	}
+	if retried == 0 {
+		err = fmt.Errorf("%w: %s is %s", ErrExecutionNotRetryable, executionID, workflow.Status)
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	err = e.resetCompensatedSteps(ctx, tx, executionID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	// Failed steps would fail the retry again right away, for example a timed
+	// out WaitForSignal or an AwaitChildren with a failed child, and the
+	// timeouts of unfinished steps start over.
+	err = store.Query.DeleteUnfinishedSteps(ctx, tx, store.DeleteUnfinishedStepsParams{
+		Namespace:   e.namespace,
+		ExecutionID: executionID,
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to reset unfinished steps: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to commit retry: %w", err)
+	}
+
+	metrics.WorkflowsQueued.WithLabelValues(e.namespace, "pending").Inc()
+
+	return nil
+}
+
+// resetCompensatedSteps deletes the completed compensations of an execution
+// together with the steps they undid, so a retry runs those steps again
+// instead of continuing with results that no longer exist.
+func (e *Engine) resetCompensatedSteps(ctx context.Context, tx store.DBTX, executionID string) error {
+	steps, err := store.Query.ListSteps(ctx, tx, store.ListStepsParams{
+		Namespace:   e.namespace,
+		ExecutionID: executionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load steps: %w", err)
+	}
+
+	for _, step := range steps {
+		compensatedStep, ok := strings.CutPrefix(step.StepName, compensationStepPrefix)
+		if !ok || step.Status != store.WorkflowStepsStatusCompleted {
+			continue
+		}
+
+		for _, stepName := range []string{step.StepName, compensatedStep} {
+			err = store.Query.DeleteStep(ctx, tx, store.DeleteStepParams{
+				Namespace:   e.namespace,
+				ExecutionID: executionID,
+				StepName:    stepName,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to reset compensated step %s: %w", compensatedStep, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetExecution returns a workflow execution by ID.
+//
+// Returns ErrExecutionNotFound if the execution does not exist.
+func (e *Engine) GetExecution(ctx context.Context, executionID string) (store.WorkflowExecution, error) {
+	workflow, err := store.Query.GetWorkflow(ctx, e.db, store.GetWorkflowParams{
+		ID:        executionID,
+		Namespace: e.namespace,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return workflow, fmt.Errorf("%w: %s", ErrExecutionNotFound, executionID)
+		}
+		return workflow, fmt.Errorf("failed to load workflow execution: %w", err)
+	}
+
+	return workflow, nil
+}
+
+// FindExecutionByIdempotencyKey returns the execution a workflow was started
+// with for an idempotency key, see WithIdempotencyKey.
+//
+// Returns ErrExecutionNotFound if no unexpired key of the workflow exists.
+func (e *Engine) FindExecutionByIdempotencyKey(ctx context.Context, workflowName, idempotencyKey string) (store.WorkflowExecution, error) {
+	key, err := store.Query.GetIdempotencyKey(ctx, e.db, store.GetIdempotencyKeyParams{
+		Namespace:      e.namespace,
+		WorkflowName:   workflowName,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return store.WorkflowExecution{}, fmt.Errorf("%w: idempotency key %s", ErrExecutionNotFound, idempotencyKey)
+		}
+		return store.WorkflowExecution{}, fmt.Errorf("failed to load idempotency key: %w", err)
+	}
+
+	if key.ExpiresAt <= e.clock.Now().UnixMilli() {
+		return store.WorkflowExecution{}, fmt.Errorf("%w: idempotency key %s", ErrExecutionNotFound, idempotencyKey)
+	}
+
+	return e.GetExecution(ctx, key.ExecutionID)
+}
+
+// ListExecutions returns workflow executions, newest first.
+//
+// To fetch the next page, pass the ID of the last returned execution as
+// Cursor. Fewer executions than the limit means there are no more pages.
+//
+// Example:
+//
+//	failed, err := engine.ListExecutions(ctx, hydra.ListExecutionsRequest{
+//	    Status:       store.WorkflowExecutionsStatusFailed,
+//	    WorkflowName: "deployment",
+//	})
+func (e *Engine) ListExecutions(ctx context.Context, req ListExecutionsRequest) ([]store.WorkflowExecution, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListExecutionsLimit
+	}
+	if limit > maxListExecutionsLimit {
+		limit = maxListExecutionsLimit
+	}
+
+	workflows, err := store.Query.ListWorkflows(ctx, e.db, store.ListWorkflowsParams{
+		Namespace:    e.namespace,
+		Status:       req.Status,
+		WorkflowName: req.WorkflowName,
+		Cursor:       req.Cursor,
+		Limit:        int32(limit), //nolint:gosec // G115: limit is bounded to [1, 1000]
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow executions: %w", err)
+	}
+
+	return workflows, nil
+}
+
+// ListSteps returns the step history of a workflow execution in the order
+// the steps were started, including internal steps created by Sleep,
+// WaitForSignal, ChildWorkflow and AwaitChildren.
+//
+// Returns ErrExecutionNotFound if the execution does not exist.
+func (e *Engine) ListSteps(ctx context.Context, executionID string) ([]store.WorkflowStep, error) {
+	_, err := e.GetExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := store.Query.ListSteps(ctx, e.db, store.ListStepsParams{
+		Namespace:   e.namespace,
+		ExecutionID: executionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list steps: %w", err)
+	}
+
+	return steps, nil
+}
+
+// isFinished reports whether a workflow execution reached a final status and
+// will never run again.
+func isFinished(workflow store.WorkflowExecution) bool {
+	switch workflow.Status {
+	case store.WorkflowExecutionsStatusCompleted,
+		store.WorkflowExecutionsStatusCancelled,
+		store.WorkflowExecutionsStatusTerminated:
+		return true
+	case store.WorkflowExecutionsStatusFailed:
+		return !workflow.NextRetryAt.Valid
+	default:
+		return false
+	}
+}
@@ -0,0 +1,274 @@
+package hydra
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+)
+
+// blockingWorkflow runs two steps, the first one blocks until it is released.
+type blockingWorkflow struct {
+	started  chan struct{}
+	release  chan struct{}
+	fail     atomic.Bool
+	first    atomic.Int64
+	second   atomic.Int64
+	finished atomic.Bool
+}
+
+func newBlockingWorkflow() *blockingWorkflow {
+	return &blockingWorkflow{
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+}
+
+func (w *blockingWorkflow) Name() string {
+	return "blocking-workflow"
+}
+
+func (w *blockingWorkflow) Run(ctx WorkflowContext, req struct{}) error {
+	err := StepVoid(ctx, "first", func(context.Context) error {
+		w.first.Add(1)
+		select {
+		case w.started <- struct{}{}:
+		default:
+		}
+		<-w.release
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = StepVoid(ctx, "second", func(context.Context) error {
+		w.second.Add(1)
+		if w.fail.Load() {
+			return fmt.Errorf("second step failed")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.finished.Store(true)
+	return nil
+}
+
+func startBlockingWorker(t *testing.T, engine *Engine, workflow *blockingWorkflow) {
+	t.Helper()
+
+	worker, err := NewWorker(engine, WorkerConfig{
+		Concurrency:  1,
+		PollInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, RegisterWorkflow(worker, workflow))
+	require.NoError(t, worker.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = worker.Shutdown(context.Background())
+	})
+}
+
+func TestCancelPendingWorkflow(t *testing.T) {
+	engine := newTestEngine(t)
+	ctx := context.Background()
+
+	executionID, err := engine.StartWorkflow(ctx, "blocking-workflow", struct{}{})
+	require.NoError(t, err)
+
+	err = engine.Cancel(ctx, executionID, "no longer needed")
+	require.NoError(t, err)
+
+	wf, err := engine.GetExecution(ctx, executionID)
+	require.NoError(t, err)
+	require.Equal(t, store.WorkflowExecutionsStatusCancelled, wf.Status)
+	require.Equal(t, "no longer needed", wf.ErrorMessage.String)
+	require.True(t, wf.CompletedAt.Valid)
+
+	err = engine.Cancel(ctx, executionID, "again")
+	require.ErrorIs(t, err, ErrExecutionFinished)
+
+	err = engine.Cancel(ctx, "wf_does_not_exist", "")
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+}
+
+// TestCancelRunningWorkflow guarantees that a running step is not
+// interrupted, and the execution stops before its next step.
+func TestCancelRunningWorkflow(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := newBlockingWorkflow()
+	startBlockingWorker(t, engine, workflow)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{})
+	require.NoError(t, err)
+
+	select {
+	case <-workflow.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("first step did not start")
+	}
+
+	err = engine.Cancel(ctx, executionID, "cancelled by operator")
+	require.NoError(t, err)
+
+	// The worker still holds the lease
+	wf, err := engine.GetExecution(ctx, executionID)
+	require.NoError(t, err)
+	require.Equal(t, store.WorkflowExecutionsStatusRunning, wf.Status)
+	require.True(t, wf.CancelRequestedAt.Valid)
+
+	close(workflow.release)
+
+	final := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCancelled, final.Status)
+	require.Equal(t, "cancelled by operator", final.ErrorMessage.String)
+	require.Equal(t, int64(1), workflow.first.Load())
+	require.Equal(t, int64(0), workflow.second.Load())
+	require.False(t, workflow.finished.Load())
+}
+
+// TestTerminateWorkflow guarantees that a terminated execution stays
+// terminated even if the worker that was running it finishes later.
+func TestTerminateWorkflow(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := newBlockingWorkflow()
+	startBlockingWorker(t, engine, workflow)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{})
+	require.NoError(t, err)
+
+	select {
+	case <-workflow.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("first step did not start")
+	}
+
+	err = engine.Terminate(ctx, executionID, "stuck")
+	require.NoError(t, err)
+
+	wf, err := engine.GetExecution(ctx, executionID)
+	require.NoError(t, err)
+	require.Equal(t, store.WorkflowExecutionsStatusTerminated, wf.Status)
+
+	_, err = store.Query.GetLease(ctx, engine.GetDB(), store.GetLeaseParams{
+		ResourceID: executionID,
+		Kind:       store.LeasesKindWorkflow,
+	})
+	require.Error(t, err, "lease should be revoked")
+
+	close(workflow.release)
+
+	require.Never(t, func() bool {
+		wf, err = engine.GetExecution(ctx, executionID)
+		return err != nil || wf.Status != store.WorkflowExecutionsStatusTerminated
+	}, time.Second, 50*time.Millisecond)
+	require.False(t, workflow.finished.Load())
+
+	err = engine.Terminate(ctx, executionID, "again")
+	require.ErrorIs(t, err, ErrExecutionFinished)
+}
+
+// TestRetryWorkflow guarantees that a permanently failed execution resumes
+// after its last completed step.
+func TestRetryWorkflow(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := newBlockingWorkflow()
+	workflow.fail.Store(true)
+	close(workflow.release)
+	startBlockingWorker(t, engine, workflow)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{}, WithMaxAttempts(1))
+	require.NoError(t, err)
+
+	err = engine.Retry(ctx, executionID)
+	require.ErrorIs(t, err, ErrExecutionNotRetryable)
+
+	failed := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusFailed, failed.Status)
+
+	workflow.fail.Store(false)
+	err = engine.Retry(ctx, executionID)
+	require.NoError(t, err)
+
+	completed := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, completed.Status)
+	require.Equal(t, int64(1), workflow.first.Load(), "completed steps must not run again")
+	require.True(t, workflow.finished.Load())
+
+	err = engine.Retry(ctx, executionID)
+	require.ErrorIs(t, err, ErrExecutionNotRetryable)
+}
+
+func TestListExecutionsAndSteps(t *testing.T) {
+	engine := newTestEngine(t)
+	ctx := context.Background()
+
+	ids := make([]string, 3)
+	for i := range ids {
+		id, err := engine.StartWorkflow(ctx, "blocking-workflow", struct{}{})
+		require.NoError(t, err)
+		ids[i] = id
+	}
+	_, err := engine.StartWorkflow(ctx, "other-workflow", struct{}{})
+	require.NoError(t, err)
+
+	require.NoError(t, engine.Cancel(ctx, ids[1], ""))
+
+	cancelled, err := engine.ListExecutions(ctx, ListExecutionsRequest{
+		Status: store.WorkflowExecutionsStatusCancelled,
+	})
+	require.NoError(t, err)
+	require.Len(t, cancelled, 1)
+	require.Equal(t, ids[1], cancelled[0].ID)
+
+	// Page through all executions of one workflow
+	seen := map[string]bool{}
+	cursor := ""
+	for {
+		page, listErr := engine.ListExecutions(ctx, ListExecutionsRequest{
+			WorkflowName: "blocking-workflow",
+			Cursor:       cursor,
+			Limit:        2,
+		})
+		require.NoError(t, listErr)
+		for _, wf := range page {
+			require.False(t, seen[wf.ID], "execution %s returned twice", wf.ID)
+			seen[wf.ID] = true
+		}
+		if len(page) < 2 {
+			break
+		}
+		cursor = page[len(page)-1].ID
+	}
+	require.Len(t, seen, len(ids))
+
+	workflow := newBlockingWorkflow()
+	close(workflow.release)
+	startBlockingWorker(t, engine, workflow)
+
+	completed := waitForWorkflowCompletion(t, engine, ids[0], 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, completed.Status)
+
+	steps, err := engine.ListSteps(ctx, ids[0])
+	require.NoError(t, err)
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.StepName
+		require.Equal(t, store.WorkflowStepsStatusCompleted, step.Status)
+	}
+	require.ElementsMatch(t, []string{"first", "second"}, names)
+
+	_, err = engine.ListSteps(ctx, "wf_does_not_exist")
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+}
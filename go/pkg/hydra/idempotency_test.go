@@ -0,0 +1,106 @@
+package hydra
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/clock"
+)
+
+func TestStartWorkflowWithIdempotencyKey(t *testing.T) {
+	testClock := clock.NewTestClock()
+	engine := newTestEngineWithClock(t, testClock)
+	ctx := context.Background()
+
+	first, err := engine.StartWorkflow(ctx, "deploy", struct{}{}, WithIdempotencyKey("deployment_123"))
+	require.NoError(t, err)
+
+	duplicate, err := engine.StartWorkflow(ctx, "deploy", struct{}{}, WithIdempotencyKey("deployment_123"))
+	require.NoError(t, err)
+	require.Equal(t, first, duplicate)
+
+	otherKey, err := engine.StartWorkflow(ctx, "deploy", struct{}{}, WithIdempotencyKey("deployment_456"))
+	require.NoError(t, err)
+	require.NotEqual(t, first, otherKey)
+
+	// Keys are scoped to the workflow name
+	otherWorkflow, err := engine.StartWorkflow(ctx, "rollback", struct{}{}, WithIdempotencyKey("deployment_123"))
+	require.NoError(t, err)
+	require.NotEqual(t, first, otherWorkflow)
+
+	withoutKey, err := engine.StartWorkflow(ctx, "deploy", struct{}{})
+	require.NoError(t, err)
+	require.NotEqual(t, first, withoutKey)
+
+	// Once the key expired, a new execution is started
+	testClock.Tick(defaultIdempotencyKeyTTL + time.Second)
+
+	afterExpiry, err := engine.StartWorkflow(ctx, "deploy", struct{}{}, WithIdempotencyKey("deployment_123"))
+	require.NoError(t, err)
+	require.NotEqual(t, first, afterExpiry)
+
+	again, err := engine.StartWorkflow(ctx, "deploy", struct{}{}, WithIdempotencyKey("deployment_123"))
+	require.NoError(t, err)
+	require.Equal(t, afterExpiry, again)
+
+	executions, err := engine.ListExecutions(ctx, ListExecutionsRequest{WorkflowName: "deploy"})
+	require.NoError(t, err)
+	require.Len(t, executions, 4)
+}
+
+func TestFindExecutionByIdempotencyKey(t *testing.T) {
+	testClock := clock.NewTestClock()
+	engine := newTestEngineWithClock(t, testClock)
+	ctx := context.Background()
+
+	executionID, err := engine.StartWorkflow(ctx, "deploy", struct{}{}, WithIdempotencyKey("deployment_123"))
+	require.NoError(t, err)
+
+	execution, err := engine.FindExecutionByIdempotencyKey(ctx, "deploy", "deployment_123")
+	require.NoError(t, err)
+	require.Equal(t, executionID, execution.ID)
+
+	_, err = engine.FindExecutionByIdempotencyKey(ctx, "rollback", "deployment_123")
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+
+	_, err = engine.FindExecutionByIdempotencyKey(ctx, "deploy", "deployment_456")
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+
+	testClock.Tick(defaultIdempotencyKeyTTL + time.Second)
+
+	_, err = engine.FindExecutionByIdempotencyKey(ctx, "deploy", "deployment_123")
+	require.ErrorIs(t, err, ErrExecutionNotFound)
+}
+
+// TestStartWorkflowWithIdempotencyKeyConcurrently guarantees that concurrent
+// starts with the same key create a single execution.
+func TestStartWorkflowWithIdempotencyKeyConcurrently(t *testing.T) {
+	engine := newTestEngine(t)
+	ctx := context.Background()
+
+	const starts = 10
+	ids := make([]string, starts)
+	errs := make([]error, starts)
+
+	var wg sync.WaitGroup
+	for i := range starts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids[i], errs[i] = engine.StartWorkflow(ctx, "deploy", struct{}{}, WithIdempotencyKey("deployment_123"))
+		}()
+	}
+	wg.Wait()
+
+	for i := range starts {
+		require.NoError(t, errs[i])
+		require.Equal(t, ids[0], ids[i])
+	}
+
+	executions, err := engine.ListExecutions(ctx, ListExecutionsRequest{WorkflowName: "deploy"})
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+}
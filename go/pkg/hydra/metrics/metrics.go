@@ -59,6 +59,16 @@ var WorkflowsRetriedTotal = promauto.NewCounterVec(
 	[]string{"namespace", "workflow_name", "attempt"},
 )
 
+var WorkflowsPurgedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem:   "hydra",
+		Name:        "workflows_purged_total",
+		Help:        "Total number of finished workflows deleted by the retention janitor",
+		ConstLabels: constLabels,
+	},
+	[]string{"namespace", "workflow_name"},
+)
+
 var WorkflowDurationSeconds = promauto.NewHistogramVec(
 	prometheus.HistogramOpts{
 		Subsystem:   "hydra",
@@ -233,6 +243,26 @@ var SleepsResumedTotal = promauto.NewCounterVec(
 	[]string{"namespace", "workflow_name"},
 )
 
+var SignalsSentTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem:   "hydra",
+		Name:        "signals_sent_total",
+		Help:        "Total number of signals sent to workflows",
+		ConstLabels: constLabels,
+	},
+	[]string{"namespace", "workflow_name"},
+)
+
+var SignalsReceivedTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem:   "hydra",
+		Name:        "signals_received_total",
+		Help:        "Total number of signals received by waiting workflows",
+		ConstLabels: constLabels,
+	},
+	[]string{"namespace", "workflow_name"},
+)
+
 var CronTriggersTotal = promauto.NewCounterVec(
 	prometheus.CounterOpts{
 		Subsystem:   "hydra",
@@ -0,0 +1,287 @@
+package hydra
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/go/pkg/hydra/metrics"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+	"github.com/unkeyed/unkey/go/pkg/otel/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// janitorCronName is the name of the cron job that enforces the
+	// retention policy.
+	janitorCronName = "hydra-retention-janitor"
+
+	defaultJanitorSchedule = "0 * * * *"
+	defaultPurgeBatchSize  = 500
+	maxPurgeBatchSize      = 10000
+)
+
+// RetentionConfig configures how long finished workflow executions are kept.
+//
+// An execution is finished once it completed, failed permanently, was
+// cancelled or was terminated. The janitor deletes it together with its
+// steps and signals once the retention period passed since it finished.
+// The janitor also deletes expired idempotency keys.
+type RetentionConfig struct {
+	// Default is how long executions of workflows without their own entry
+	// in Workflows are kept. Zero keeps them forever.
+	Default time.Duration
+
+	// Workflows overrides Default per workflow name. Zero keeps the
+	// executions of that workflow forever.
+	Workflows map[string]time.Duration
+
+	// Schedule is the cron spec of the janitor.
+	// Defaults to every hour if not specified.
+	Schedule string
+
+	// BatchSize is how many executions are archived and deleted at once.
+	// Defaults to 500 if not specified, and can not be larger than 10000.
+	BatchSize int
+
+	// Archiver receives executions before they are deleted.
+	// Executions are deleted without archiving if not specified.
+	Archiver Archiver
+}
+
+// enabled reports whether any executions expire.
+func (c RetentionConfig) enabled() bool {
+	if c.Default > 0 {
+		return true
+	}
+	for _, keep := range c.Workflows {
+		if keep > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// withDefaults returns the config with defaults applied.
+func (c RetentionConfig) withDefaults() RetentionConfig {
+	if c.Schedule == "" {
+		c.Schedule = defaultJanitorSchedule
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultPurgeBatchSize
+	}
+	if c.BatchSize > maxPurgeBatchSize {
+		c.BatchSize = maxPurgeBatchSize
+	}
+	return c
+}
+
+// keepFor returns how long executions of a workflow are kept, zero means
+// forever.
+func (c RetentionConfig) keepFor(workflowName string) time.Duration {
+	if keep, ok := c.Workflows[workflowName]; ok {
+		return keep
+	}
+	return c.Default
+}
+
+// Archiver stores finished executions before the janitor deletes them, for
+// example in ClickHouse or object storage.
+type Archiver interface {
+	// Archive is called with every batch of executions before it is deleted.
+	// If it returns an error, the batch is not deleted and the janitor
+	// retries on its next run, so Archive must tolerate seeing the same
+	// execution twice.
+	Archive(ctx context.Context, executions []ArchivedExecution) error
+}
+
+// ArchivedExecution is a finished execution with all of its steps.
+type ArchivedExecution struct {
+	Execution store.WorkflowExecution
+	Steps     []store.WorkflowStep
+}
+
+// PurgeExpiredExecutions deletes all finished executions whose retention
+// period passed, after handing them to the configured Archiver.
+//
+// The janitor calls it on its schedule when Config.Retention is set, calling
+// it directly is only needed to purge on demand.
+//
+// Returns the number of deleted executions.
+//
+// Metrics recorded:
+// - hydra_workflows_purged_total (counter)
+func (e *Engine) PurgeExpiredExecutions(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "hydra.engine.PurgeExpiredExecutions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("hydra.namespace", e.namespace))
+
+	workflowNames, err := store.Query.GetWorkflowNames(ctx, e.db, e.namespace)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, fmt.Errorf("failed to load workflow names: %w", err)
+	}
+
+	now := e.clock.Now()
+	purged := 0
+	for _, workflowName := range workflowNames {
+		keep := e.retention.keepFor(workflowName)
+		if keep <= 0 {
+			continue
+		}
+
+		n, purgeErr := e.purgeWorkflow(ctx, workflowName, now.Add(-keep).UnixMilli())
+		purged += n
+		if purgeErr != nil {
+			tracing.RecordError(span, purgeErr)
+			return purged, purgeErr
+		}
+	}
+
+	for {
+		deleted, deleteErr := store.Query.DeleteExpiredIdempotencyKeys(ctx, e.db, store.DeleteExpiredIdempotencyKeysParams{
+			Namespace: e.namespace,
+			ExpiresAt: now.UnixMilli(),
+			Limit:     int32(e.retention.BatchSize), //nolint:gosec // G115: batch size is bounded to [1, 10000]
+		})
+		if deleteErr != nil {
+			tracing.RecordError(span, deleteErr)
+			return purged, fmt.Errorf("failed to delete expired idempotency keys: %w", deleteErr)
+		}
+		if deleted < int64(e.retention.BatchSize) {
+			break
+		}
+	}
+
+	span.SetAttributes(attribute.Int("hydra.purged", purged))
+	return purged, nil
+}
+
+// purgeWorkflow archives and deletes the executions of one workflow that
+// finished before the cutoff, one batch at a time.
+func (e *Engine) purgeWorkflow(ctx context.Context, workflowName string, completedBefore int64) (int, error) {
+	purged := 0
+	for {
+		expired, err := store.Query.ListExpiredWorkflows(ctx, e.db, store.ListExpiredWorkflowsParams{
+			Namespace:       e.namespace,
+			WorkflowName:    workflowName,
+			CompletedBefore: sql.NullInt64{Int64: completedBefore, Valid: true},
+			Limit:           int32(e.retention.BatchSize), //nolint:gosec // G115: batch size is bounded to [1, 10000]
+		})
+		if err != nil {
+			return purged, fmt.Errorf("failed to list expired workflows: %w", err)
+		}
+		if len(expired) == 0 {
+			return purged, nil
+		}
+
+		if e.retention.Archiver != nil {
+			err = e.archive(ctx, expired)
+			if err != nil {
+				return purged, err
+			}
+		}
+
+		err = e.deleteExecutions(ctx, expired)
+		if err != nil {
+			return purged, err
+		}
+
+		purged += len(expired)
+		metrics.WorkflowsPurgedTotal.WithLabelValues(e.namespace, workflowName).Add(float64(len(expired)))
+
+		if len(expired) < e.retention.BatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// archive hands a batch of executions and their steps to the Archiver.
+func (e *Engine) archive(ctx context.Context, executions []store.WorkflowExecution) error {
+	archived := make([]ArchivedExecution, len(executions))
+	for i, execution := range executions {
+		steps, err := store.Query.ListSteps(ctx, e.db, store.ListStepsParams{
+			Namespace:   e.namespace,
+			ExecutionID: execution.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list steps of %s: %w", execution.ID, err)
+		}
+
+		archived[i] = ArchivedExecution{
+			Execution: execution,
+			Steps:     steps,
+		}
+	}
+
+	err := e.retention.Archiver.Archive(ctx, archived)
+	if err != nil {
+		return fmt.Errorf("failed to archive workflows: %w", err)
+	}
+
+	return nil
+}
+
+// deleteExecutions deletes a batch of executions with their steps and
+// signals in one transaction.
+func (e *Engine) deleteExecutions(ctx context.Context, executions []store.WorkflowExecution) error {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, execution := range executions {
+		err = store.Query.DeleteSteps(ctx, tx, store.DeleteStepsParams{
+			Namespace:   e.namespace,
+			ExecutionID: execution.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete steps of %s: %w", execution.ID, err)
+		}
+
+		err = store.Query.DeleteSignals(ctx, tx, store.DeleteSignalsParams{
+			Namespace:   e.namespace,
+			ExecutionID: execution.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete signals of %s: %w", execution.ID, err)
+		}
+
+		err = store.Query.DeleteWorkflow(ctx, tx, store.DeleteWorkflowParams{
+			ID:        execution.ID,
+			Namespace: e.namespace,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete workflow %s: %w", execution.ID, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// runJanitor is the cron handler that enforces the retention policy.
+func (e *Engine) runJanitor(ctx context.Context, payload CronPayload) error {
+	purged, err := e.PurgeExpiredExecutions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		e.logger.Info("purged expired workflow executions",
+			"namespace", e.namespace,
+			"purged", purged,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package hydra
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/clock"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+)
+
+type testArchiver struct {
+	archived []ArchivedExecution
+	err      error
+}
+
+func (a *testArchiver) Archive(ctx context.Context, executions []ArchivedExecution) error {
+	if a.err != nil {
+		return a.err
+	}
+	a.archived = append(a.archived, executions...)
+	return nil
+}
+
+func TestPurgeExpiredExecutions(t *testing.T) {
+	testClock := clock.NewTestClock()
+	engine := newTestEngineWithClock(t, testClock)
+	ctx := context.Background()
+
+	archiver := &testArchiver{archived: nil, err: nil}
+	engine.retention = RetentionConfig{
+		Default: 7 * 24 * time.Hour,
+		Workflows: map[string]time.Duration{
+			"audit": 0, // kept forever
+		},
+		BatchSize: 2,
+		Archiver:  archiver,
+	}.withDefaults()
+
+	finished := func(workflowName string) string {
+		id, err := engine.StartWorkflow(ctx, workflowName, struct{}{})
+		require.NoError(t, err)
+		require.NoError(t, engine.Cancel(ctx, id, ""))
+		return id
+	}
+
+	old := []string{finished("deploy"), finished("deploy"), finished("deploy")}
+	audit := finished("audit")
+	running, err := engine.StartWorkflow(ctx, "deploy", struct{}{})
+	require.NoError(t, err)
+
+	testClock.Tick(6 * 24 * time.Hour)
+	recent := finished("deploy")
+
+	testClock.Tick(2 * 24 * time.Hour)
+
+	purged, err := engine.PurgeExpiredExecutions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(old), purged)
+
+	archivedIDs := make([]string, len(archiver.archived))
+	for i, a := range archiver.archived {
+		archivedIDs[i] = a.Execution.ID
+	}
+	require.ElementsMatch(t, old, archivedIDs)
+
+	for _, id := range old {
+		_, err = engine.GetExecution(ctx, id)
+		require.ErrorIs(t, err, ErrExecutionNotFound)
+	}
+	for _, id := range []string{audit, running, recent} {
+		_, err = engine.GetExecution(ctx, id)
+		require.NoError(t, err, "execution %s must be kept", id)
+	}
+
+	purged, err = engine.PurgeExpiredExecutions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, purged)
+}
+
+// TestPurgeExpiredExecutionsArchiveFailure guarantees that executions are
+// only deleted once they were archived.
+func TestPurgeExpiredExecutionsArchiveFailure(t *testing.T) {
+	testClock := clock.NewTestClock()
+	engine := newTestEngineWithClock(t, testClock)
+	ctx := context.Background()
+
+	archiver := &testArchiver{archived: nil, err: fmt.Errorf("clickhouse unavailable")}
+	engine.retention = RetentionConfig{
+		Default:  time.Hour,
+		Archiver: archiver,
+	}.withDefaults()
+
+	id, err := engine.StartWorkflow(ctx, "deploy", struct{}{})
+	require.NoError(t, err)
+	require.NoError(t, engine.Terminate(ctx, id, ""))
+
+	testClock.Tick(2 * time.Hour)
+
+	_, err = engine.PurgeExpiredExecutions(ctx)
+	require.Error(t, err)
+
+	wf, err := engine.GetExecution(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, store.WorkflowExecutionsStatusTerminated, wf.Status)
+
+	archiver.err = nil
+	purged, err := engine.PurgeExpiredExecutions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	require.Len(t, archiver.archived, 1)
+}
@@ -0,0 +1,283 @@
+package hydra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/unkeyed/unkey/go/pkg/hydra/metrics"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+	"github.com/unkeyed/unkey/go/pkg/otel/tracing"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ErrSignalTimeout is returned by WaitForSignal when no signal arrived
+// before the timeout elapsed.
+var ErrSignalTimeout = errors.New("timed out waiting for signal")
+
+// WaitForSignal suspends workflow execution until a signal with the given
+// name is delivered with Engine.Signal, and returns its payload.
+//
+// This function allows workflows to wait for something external to happen,
+// such as a build finishing, a DNS record being published or a human
+// approving a change. The workflow is marked as sleeping until either the
+// signal arrives or the timeout elapses, and workers will not execute it in
+// the meantime.
+//
+// Signals are buffered, a signal that is delivered before the workflow
+// starts waiting for it is returned immediately once it does. If multiple
+// signals with the same name are delivered, they are received in the order
+// they were sent.
+//
+// The wait is durable - if the worker crashes or restarts, the workflow will
+// still resume when the signal arrives, and the timeout is measured from the
+// first time the workflow started waiting.
+//
+// Example usage:
+//
+//	approval, err := hydra.WaitForSignal[*Approval](ctx, "approval", 72*time.Hour)
+//	if errors.Is(err, hydra.ErrSignalTimeout) {
+//	    return rejectRequest(ctx)
+//	}
+//	if err != nil {
+//	    return err
+//	}
+//
+// Like Sleep, the error must be returned from the workflow's Run method
+// unchanged unless it is ErrSignalTimeout, as it is used to suspend the
+// execution.
+//
+// Note: WaitForSignal creates an internal step named after the signal, so
+// every signal name can only be waited for once per workflow execution.
+//
+// Metrics recorded:
+// - hydra_sleeps_started_total (counter)
+// - hydra_signals_received_total (counter)
+func WaitForSignal[T any](ctx WorkflowContext, name string, timeout time.Duration) (T, error) {
+	var zero T
+
+	wctx, ok := ctx.(*workflowContext)
+	if !ok {
+		return zero, fmt.Errorf("invalid workflow context")
+	}
+
+	if timeout <= 0 {
+		return zero, fmt.Errorf("signal timeout must be positive, got %s", timeout)
+	}
+
+	stepName := fmt.Sprintf("signal-%s", name)
+	wctx.reach(stepName)
+
+	completed, err := store.Query.GetCompletedStep(wctx.ctx, wctx.db, store.GetCompletedStepParams{
+		Namespace:   wctx.namespace,
+		ExecutionID: wctx.ExecutionID(),
+		StepName:    stepName,
+	})
+	if err == nil {
+		return unmarshalSignal[T](wctx, completed.OutputData)
+	}
+
+	now := time.Now().UnixMilli()
+	startedAt := now
+
+	existingStep, err := store.Query.GetStep(wctx.ctx, wctx.db, store.GetStepParams{
+		Namespace:   wctx.namespace,
+		ExecutionID: wctx.ExecutionID(),
+		StepName:    stepName,
+	})
+	switch {
+	case err == nil:
+		if existingStep.Status == store.WorkflowStepsStatusFailed {
+			return zero, fmt.Errorf("%w %q", ErrSignalTimeout, name)
+		}
+		if existingStep.StartedAt.Valid {
+			startedAt = existingStep.StartedAt.Int64
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		err = wctx.createStep(wctx.db, stepName, store.WorkflowStepsStatusRunning, []byte{}, "", now)
+		if err != nil {
+			return zero, err
+		}
+	default:
+		return zero, fmt.Errorf("failed to load signal step: %w", err)
+	}
+
+	payload, received, err := wctx.receiveSignal(name, stepName, now)
+	if err != nil {
+		return zero, err
+	}
+	if received {
+		metrics.SignalsReceivedTotal.WithLabelValues(wctx.namespace, wctx.workflowName).Inc()
+		return unmarshalSignal[T](wctx, payload)
+	}
+
+	timeoutAt := startedAt + timeout.Milliseconds()
+	if timeoutAt <= now {
+		if markErr := wctx.markStepFailed(stepName, ErrSignalTimeout.Error()); markErr != nil {
+			return zero, fmt.Errorf("failed to mark signal step as timed out: %w", markErr)
+		}
+		return zero, fmt.Errorf("%w %q", ErrSignalTimeout, name)
+	}
+
+	return zero, &WorkflowSuspendedError{
+		Reason:     fmt.Sprintf("signal %q", name),
+		ResumeTime: timeoutAt,
+		SignalName: name,
+	}
+}
+
+// receiveSignal consumes the oldest pending signal with the given name and
+// completes the signal step with its payload in a single transaction, so a
+// signal is never lost or received twice.
+func (w *workflowContext) receiveSignal(name, stepName string, now int64) ([]byte, bool, error) {
+	tx, err := w.db.BeginTx(w.ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	signal, err := store.Query.GetPendingSignal(w.ctx, tx, store.GetPendingSignalParams{
+		Namespace:   w.namespace,
+		ExecutionID: w.executionID,
+		SignalName:  name,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load pending signal: %w", err)
+	}
+
+	err = store.Query.ConsumeSignal(w.ctx, tx, store.ConsumeSignalParams{
+		ConsumedAt: sql.NullInt64{Int64: now, Valid: true},
+		ID:         signal.ID,
+		Namespace:  w.namespace,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to consume signal: %w", err)
+	}
+
+	err = store.Query.UpdateStepStatus(w.ctx, tx, store.UpdateStepStatusParams{
+		Status:       store.WorkflowStepsStatusCompleted,
+		CompletedAt:  sql.NullInt64{Int64: now, Valid: true},
+		OutputData:   signal.Payload,
+		ErrorMessage: sql.NullString{String: "", Valid: false},
+		Namespace:    w.namespace,
+		ExecutionID:  w.executionID,
+		StepName:     stepName,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to complete signal step: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to commit signal: %w", err)
+	}
+
+	return signal.Payload, true, nil
+}
+
+func unmarshalSignal[T any](wctx *workflowContext, data []byte) (T, error) {
+	var payload T
+	if len(data) == 0 {
+		return payload, nil
+	}
+
+	err := wctx.marshaller.Unmarshal(data, &payload)
+	if err != nil {
+		metrics.SerializationErrorsTotal.WithLabelValues(wctx.namespace, wctx.workflowName, "signal").Inc()
+		return payload, fmt.Errorf("failed to unmarshal signal payload: %w", err)
+	}
+
+	return payload, nil
+}
+
+// Signal delivers a named signal with a payload to a workflow execution and
+// wakes it up if it is waiting for one with WaitForSignal.
+//
+// Signals are stored durably, so they may be sent before the workflow starts
+// waiting for them. The payload will be marshalled using the engine's
+// configured marshaller and is returned by WaitForSignal.
+//
+// Example:
+//
+//	err := engine.Signal(ctx, executionID, "approval", &Approval{
+//	    ApprovedBy: "user_123",
+//	})
+//
+// Returns an error if the execution does not exist or has already finished.
+//
+// Metrics recorded:
+// - hydra_signals_sent_total (counter)
+func (e *Engine) Signal(ctx context.Context, executionID, name string, payload any) error {
+	ctx, span := tracing.Start(ctx, "hydra.engine.Signal")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("hydra.execution.id", executionID),
+		attribute.String("hydra.signal.name", name),
+		attribute.String("hydra.namespace", e.namespace),
+	)
+
+	workflow, err := store.Query.GetWorkflow(ctx, e.db, store.GetWorkflowParams{
+		ID:        executionID,
+		Namespace: e.namespace,
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("workflow execution %q not found", executionID)
+		}
+		return fmt.Errorf("failed to load workflow execution: %w", err)
+	}
+
+	if isFinished(workflow) {
+		err = fmt.Errorf("workflow execution %q has already finished", executionID)
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	data, err := e.marshaller.Marshal(payload)
+	if err != nil {
+		metrics.SerializationErrorsTotal.WithLabelValues(e.namespace, workflow.WorkflowName, "signal").Inc()
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to marshal signal payload: %w", err)
+	}
+
+	err = store.Query.CreateSignal(ctx, e.db, store.CreateSignalParams{
+		ID:          uid.New(uid.SignalPrefix),
+		ExecutionID: executionID,
+		SignalName:  name,
+		Payload:     data,
+		CreatedAt:   e.clock.Now().UnixMilli(),
+		ConsumedAt:  sql.NullInt64{Int64: 0, Valid: false},
+		Namespace:   e.namespace,
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to create signal: %w", err)
+	}
+
+	// Workers compare sleep_until against the wall clock, so wake it up now.
+	// If the workflow is not sleeping yet, it will find the signal once it
+	// starts waiting.
+	err = store.Query.WakeWorkflow(ctx, e.db, store.WakeWorkflowParams{
+		SleepUntil: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		ID:         executionID,
+		Namespace:  e.namespace,
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to wake workflow: %w", err)
+	}
+
+	metrics.SignalsSentTotal.WithLabelValues(e.namespace, workflow.WorkflowName).Inc()
+
+	return nil
+}
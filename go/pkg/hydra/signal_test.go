@@ -0,0 +1,162 @@
+package hydra
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+)
+
+type approval struct {
+	ApprovedBy string `json:"approved_by"`
+}
+
+type approvalWorkflow struct {
+	timeout       time.Duration
+	failOnTimeout bool
+	approvedBy    atomic.Value
+	timedOut      atomic.Bool
+}
+
+func (w *approvalWorkflow) Name() string {
+	return "approval-workflow"
+}
+
+func (w *approvalWorkflow) Run(ctx WorkflowContext, req struct{}) error {
+	res, err := WaitForSignal[*approval](ctx, "approval", w.timeout)
+	if errors.Is(err, ErrSignalTimeout) {
+		w.timedOut.Store(true)
+		if w.failOnTimeout {
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	w.approvedBy.Store(res.ApprovedBy)
+	return nil
+}
+
+func startApprovalWorker(t *testing.T, engine *Engine, workflow *approvalWorkflow) {
+	t.Helper()
+
+	worker, err := NewWorker(engine, WorkerConfig{
+		Concurrency:  1,
+		PollInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, RegisterWorkflow(worker, workflow))
+	require.NoError(t, worker.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = worker.Shutdown(context.Background())
+	})
+}
+
+// TestWaitForSignal guarantees that a workflow waiting for a signal is
+// suspended durably and resumes with the payload once the signal is sent.
+func TestWaitForSignal(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := &approvalWorkflow{timeout: time.Hour}
+	startApprovalWorker(t, engine, workflow)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		wf, getErr := store.Query.GetWorkflow(ctx, engine.GetDB(), store.GetWorkflowParams{
+			ID:        executionID,
+			Namespace: engine.GetNamespace(),
+		})
+		return getErr == nil && wf.Status == store.WorkflowExecutionsStatusSleeping
+	}, 5*time.Second, 50*time.Millisecond, "workflow should be sleeping while waiting for the signal")
+
+	err = engine.Signal(ctx, executionID, "approval", &approval{ApprovedBy: "user_123"})
+	require.NoError(t, err)
+
+	wf := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, wf.Status)
+	require.Equal(t, "user_123", workflow.approvedBy.Load())
+	require.False(t, workflow.timedOut.Load())
+}
+
+// TestSignalBeforeWait guarantees that signals sent before the workflow
+// starts waiting are buffered and not lost.
+func TestSignalBeforeWait(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := &approvalWorkflow{timeout: time.Hour}
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{})
+	require.NoError(t, err)
+
+	err = engine.Signal(ctx, executionID, "approval", &approval{ApprovedBy: "user_456"})
+	require.NoError(t, err)
+
+	startApprovalWorker(t, engine, workflow)
+
+	wf := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, wf.Status)
+	require.Equal(t, "user_456", workflow.approvedBy.Load())
+}
+
+// TestWaitForSignalTimeout guarantees that a workflow resumes with
+// ErrSignalTimeout if no signal arrives in time.
+func TestWaitForSignalTimeout(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := &approvalWorkflow{timeout: 500 * time.Millisecond}
+	startApprovalWorker(t, engine, workflow)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{})
+	require.NoError(t, err)
+
+	wf := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, wf.Status)
+	require.True(t, workflow.timedOut.Load())
+	require.Nil(t, workflow.approvedBy.Load())
+
+	// A late signal can no longer be delivered
+	err = engine.Signal(ctx, executionID, "approval", &approval{ApprovedBy: "user_789"})
+	require.Error(t, err)
+}
+
+// TestRetryAfterSignalTimeout guarantees that a retried execution waits for
+// the signal again instead of failing with the old timeout.
+func TestRetryAfterSignalTimeout(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := &approvalWorkflow{timeout: 500 * time.Millisecond, failOnTimeout: true}
+	startApprovalWorker(t, engine, workflow)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{}, WithMaxAttempts(1))
+	require.NoError(t, err)
+
+	wf := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusFailed, wf.Status)
+	require.True(t, workflow.timedOut.Load())
+
+	err = engine.Retry(ctx, executionID)
+	require.NoError(t, err)
+
+	err = engine.Signal(ctx, executionID, "approval", &approval{ApprovedBy: "user_321"})
+	require.NoError(t, err)
+
+	wf = waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, wf.Status)
+	require.Equal(t, "user_321", workflow.approvedBy.Load())
+}
+
+func TestSignalUnknownExecution(t *testing.T) {
+	engine := newTestEngine(t)
+
+	err := engine.Signal(context.Background(), "wf_does_not_exist", "approval", &approval{ApprovedBy: "user_123"})
+	require.Error(t, err)
+}
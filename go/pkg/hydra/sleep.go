@@ -52,6 +52,7 @@ func Sleep(ctx WorkflowContext, duration time.Duration) error {
 	}
 
 	stepName := fmt.Sprintf("sleep-%d", duration.Milliseconds())
+	wctx.reach(stepName)
 
 	_, err := store.Query.GetCompletedStep(wctx.ctx, wctx.db, store.GetCompletedStepParams{
 		Namespace:   wctx.namespace,
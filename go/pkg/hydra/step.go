@@ -26,6 +26,7 @@ import (
 // - ctx: The workflow context from the workflow's Run() method
 // - stepName: A unique name for this step within the workflow
 // - fn: The function to execute, which should be idempotent
+// - opts: Optional step options, e.g. WithCompensation
 //
 // The stepName must be unique within the workflow and should remain stable
 // across deployments. If a step has already completed successfully, its
@@ -63,8 +64,9 @@ import (
 // - hydra_steps_retried_total (counter for retry attempts)
 //
 // Returns the result of the function execution or the cached result if the
-// step has already completed successfully.
-func Step[TResponse any](ctx WorkflowContext, stepName string, fn func(context.Context) (TResponse, error)) (TResponse, error) {
+// step has already completed successfully. If the execution was cancelled with
+// Engine.Cancel, ErrExecutionCancelled is returned without executing fn.
+func Step[TResponse any](ctx WorkflowContext, stepName string, fn func(context.Context) (TResponse, error), opts ...StepOption[TResponse]) (TResponse, error) {
 	var zero TResponse
 
 	wctx, ok := ctx.(*workflowContext)
@@ -72,6 +74,13 @@ func Step[TResponse any](ctx WorkflowContext, stepName string, fn func(context.C
 		return zero, fmt.Errorf("invalid workflow context")
 	}
 
+	wctx.reach(stepName)
+
+	options := stepOptions[TResponse]{compensate: nil}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Start tracing span for this step
 	stepCtx, span := tracing.Start(wctx.ctx, fmt.Sprintf("hydra.step.%s", stepName))
 	defer span.End()
@@ -116,11 +125,27 @@ func Step[TResponse any](ctx WorkflowContext, stepName string, fn func(context.C
 			}
 		}
 
+		if options.compensate != nil {
+			wctx.addCompensation(stepName, func(compensateCtx context.Context) error {
+				return options.compensate(compensateCtx, response)
+			})
+		}
+
 		return response, nil
 	}
 
 	span.SetAttributes(attribute.Bool("hydra.step.cached", false))
 
+	// Stop before running any new work if the execution was cancelled,
+	// compensations must still run
+	if !wctx.compensating {
+		err = wctx.checkCancelled()
+		if err != nil {
+			tracing.RecordError(span, err)
+			return zero, err
+		}
+	}
+
 	_, err = store.Query.GetStep(wctx.ctx, wctx.db, store.GetStepParams{
 		Namespace:   wctx.namespace,
 		ExecutionID: wctx.ExecutionID(),
@@ -272,6 +297,12 @@ func Step[TResponse any](ctx WorkflowContext, stepName string, fn func(context.C
 	metrics.ObserveStepDuration(wctx.namespace, wctx.workflowName, stepName, "completed", stepStartTime)
 	metrics.StepsExecutedTotal.WithLabelValues(wctx.namespace, wctx.workflowName, stepName, "completed").Inc()
 
+	if options.compensate != nil {
+		wctx.addCompensation(stepName, func(compensateCtx context.Context) error {
+			return options.compensate(compensateCtx, response)
+		})
+	}
+
 	return response, nil
 }
 
@@ -0,0 +1,353 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Dialect is the SQL dialect of the database hydra stores its state in.
+//
+// The queries of this package are written for MySQL. DB rewrites them for
+// the other dialects, so every backend runs the same queries and provides
+// the same guarantees.
+type Dialect string
+
+const (
+	MySQL    Dialect = "mysql"
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+var (
+	//go:embed schema.sql
+	mysqlSchema string
+
+	//go:embed schema_postgres.sql
+	postgresSchema string
+
+	//go:embed schema_sqlite.sql
+	sqliteSchema string
+)
+
+// DialectForDriver returns the dialect of a database/sql driver.
+//
+// Supported drivers are "mysql" (github.com/go-sql-driver/mysql), "pgx"
+// (github.com/jackc/pgx/v5/stdlib), "postgres" (github.com/lib/pq) and
+// "sqlite" (modernc.org/sqlite) or "sqlite3" (github.com/mattn/go-sqlite3).
+func DialectForDriver(driverName string) (Dialect, error) {
+	switch driverName {
+	case "mysql":
+		return MySQL, nil
+	case "pgx", "postgres":
+		return Postgres, nil
+	case "sqlite", "sqlite3":
+		return SQLite, nil
+	default:
+		return "", fmt.Errorf("unsupported database driver %q", driverName)
+	}
+}
+
+// Schema returns the statements creating the hydra tables in the dialect.
+// All tables are created with IF NOT EXISTS, so the schema can be applied
+// on every start.
+func Schema(dialect Dialect) string {
+	switch dialect {
+	case Postgres:
+		return postgresSchema
+	case SQLite:
+		return sqliteSchema
+	default:
+		return mysqlSchema
+	}
+}
+
+// override replaces a query in a dialect that can not run the MySQL version.
+type override struct {
+	query string
+
+	// args is how many of the query's arguments the override uses, from the
+	// start. All arguments are used if zero.
+	args int
+}
+
+// Upserts resolve conflicts with ON CONFLICT instead of ON DUPLICATE KEY,
+// and DELETE does not support LIMIT, in both Postgres and SQLite.
+var portableOverrides = map[string]override{
+	"CreateCronJob": {
+		query: `-- name: CreateCronJob :exec
+INSERT INTO cron_jobs (
+    id, name, cron_spec, namespace, workflow_name, enabled,
+    created_at, updated_at, last_run_at, next_run_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+) ON CONFLICT (name, namespace) DO UPDATE SET
+    cron_spec = excluded.cron_spec, enabled = excluded.enabled, updated_at = excluded.updated_at,
+    last_run_at = excluded.last_run_at, next_run_at = excluded.next_run_at`,
+		args: 10,
+	},
+	"ClaimIdempotencyKey": {
+		query: `-- name: ClaimIdempotencyKey :exec
+INSERT INTO workflow_idempotency_keys (
+    namespace, workflow_name, idempotency_key, execution_id, created_at, expires_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (namespace, workflow_name, idempotency_key) DO UPDATE SET
+    execution_id = CASE WHEN workflow_idempotency_keys.expires_at <= excluded.created_at THEN excluded.execution_id ELSE workflow_idempotency_keys.execution_id END,
+    created_at = CASE WHEN workflow_idempotency_keys.expires_at <= excluded.created_at THEN excluded.created_at ELSE workflow_idempotency_keys.created_at END,
+    expires_at = CASE WHEN workflow_idempotency_keys.expires_at <= excluded.created_at THEN excluded.expires_at ELSE workflow_idempotency_keys.expires_at END`,
+		args: 0,
+	},
+	"DeleteExpiredIdempotencyKeys": {
+		query: `-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM workflow_idempotency_keys
+WHERE (namespace, workflow_name, idempotency_key) IN (
+    SELECT namespace, workflow_name, idempotency_key FROM workflow_idempotency_keys
+    WHERE namespace = ? AND expires_at <= ?
+    LIMIT ?
+)`,
+		args: 0,
+	},
+}
+
+var overrides = map[Dialect]map[string]override{
+	Postgres: withOverrides(portableOverrides, map[string]override{
+		// Postgres infers the type of the CASE from its arguments, which
+		// would make it text
+		"SleepWorkflowUntilSignal": {
+			query: `-- name: SleepWorkflowUntilSignal :exec
+UPDATE workflow_executions
+SET status = 'sleeping',
+    sleep_until = CASE WHEN EXISTS (
+        SELECT 1 FROM workflow_signals
+        WHERE workflow_signals.namespace = workflow_executions.namespace
+          AND workflow_signals.execution_id = workflow_executions.id
+          AND workflow_signals.signal_name = ?
+          AND workflow_signals.consumed_at IS NULL
+    ) THEN CAST(? AS BIGINT) ELSE CAST(? AS BIGINT) END
+WHERE id = ? AND namespace = ? AND status = 'running'`,
+			args: 0,
+		},
+	}),
+	SQLite: portableOverrides,
+}
+
+func withOverrides(base, extra map[string]override) map[string]override {
+	merged := make(map[string]override, len(base)+len(extra))
+	for name, o := range base {
+		merged[name] = o
+	}
+	for name, o := range extra {
+		merged[name] = o
+	}
+	return merged
+}
+
+// SQLite locks the whole database for writes, rows can not be locked
+var forUpdate = regexp.MustCompile(`(?i)\s+FOR\s+UPDATE\b`)
+
+// rewrittenQuery is a query rewritten for a dialect.
+type rewrittenQuery struct {
+	query string
+	args  int
+}
+
+// rewrite translates a MySQL query to the dialect.
+func rewrite(dialect Dialect, query string) rewrittenQuery {
+	r := rewrittenQuery{query: query, args: 0}
+	if dialect == MySQL {
+		return r
+	}
+
+	if o, ok := overrides[dialect][queryName(query)]; ok {
+		r = rewrittenQuery{query: o.query, args: o.args}
+	}
+
+	switch dialect {
+	case Postgres:
+		r.query = rebind(r.query)
+	case SQLite:
+		r.query = forUpdate.ReplaceAllString(r.query, "")
+	case MySQL:
+	}
+
+	return r
+}
+
+// queryName returns the name of a query generated by sqlc, which starts with
+// a "-- name: <Name> :<kind>" comment, or an empty string for other queries.
+func queryName(query string) string {
+	header, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(header, " ")
+	return name
+}
+
+// rebind replaces the ? placeholders of a query with the numbered $1, $2, ...
+// placeholders of Postgres. Question marks in string literals and comments
+// are kept.
+func rebind(query string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 16)
+
+	n := 0
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			end := strings.IndexByte(query[i+1:], '\'')
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 1
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end])
+			i += end - 1
+		case c == '?':
+			n++
+			fmt.Fprintf(&b, "$%d", n)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// DB is a database connection that runs the queries of this package in its
+// dialect. It implements DBTX, so it can be passed to Query.
+type DB struct {
+	db      *sql.DB
+	dialect Dialect
+
+	// rewritten caches the rewritten queries by their MySQL version
+	rewritten sync.Map
+}
+
+// NewDB wraps a database connection of the dialect.
+func NewDB(db *sql.DB, dialect Dialect) *DB {
+	return &DB{
+		db:        db,
+		dialect:   dialect,
+		rewritten: sync.Map{},
+	}
+}
+
+// Dialect returns the dialect of the database.
+func (d *DB) Dialect() Dialect {
+	return d.dialect
+}
+
+// DB returns the underlying connection, which does not rewrite queries.
+func (d *DB) DB() *sql.DB {
+	return d.db
+}
+
+func (d *DB) rewrite(query string, args []any) (string, []any) {
+	if d.dialect == MySQL {
+		return query, args
+	}
+
+	cached, ok := d.rewritten.Load(query)
+	if !ok {
+		cached, _ = d.rewritten.LoadOrStore(query, rewrite(d.dialect, query))
+	}
+	r := cached.(rewrittenQuery) //nolint:forcetypeassert // only rewrittenQuery is stored
+
+	if r.args > 0 && len(args) > r.args {
+		args = args[:r.args]
+	}
+	return r.query, args
+}
+
+func (d *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	query, args = d.rewrite(query, args)
+	return d.db.ExecContext(ctx, query, args...)
+}
+
+func (d *DB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	query, _ = d.rewrite(query, nil)
+	return d.db.PrepareContext(ctx, query)
+}
+
+func (d *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	query, args = d.rewrite(query, args)
+	return d.db.QueryContext(ctx, query, args...)
+}
+
+func (d *DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	query, args = d.rewrite(query, args)
+	return d.db.QueryRowContext(ctx, query, args...)
+}
+
+// BeginTx starts a transaction, whose queries are rewritten like the ones of
+// the DB.
+func (d *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := d.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{tx: tx, db: d}, nil
+}
+
+// PingContext verifies the connection to the database.
+func (d *DB) PingContext(ctx context.Context) error {
+	return d.db.PingContext(ctx)
+}
+
+// Close closes the underlying connection.
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// Tx is a transaction of a DB. It implements DBTX, so it can be passed to
+// Query.
+type Tx struct {
+	tx *sql.Tx
+	db *DB
+}
+
+func (t *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	query, args = t.db.rewrite(query, args)
+	return t.tx.ExecContext(ctx, query, args...)
+}
+
+func (t *Tx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	query, _ = t.db.rewrite(query, nil)
+	return t.tx.PrepareContext(ctx, query)
+}
+
+func (t *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	query, args = t.db.rewrite(query, args)
+	return t.tx.QueryContext(ctx, query, args...)
+}
+
+func (t *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	query, args = t.db.rewrite(query, args)
+	return t.tx.QueryRowContext(ctx, query, args...)
+}
+
+// Commit commits the transaction.
+func (t *Tx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback aborts the transaction. It returns sql.ErrTxDone if the
+// transaction was already committed or rolled back.
+func (t *Tx) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "placeholders",
+			query: "SELECT * FROM leases WHERE resource_id = ? AND kind = ?",
+			want:  "SELECT * FROM leases WHERE resource_id = $1 AND kind = $2",
+		},
+		{
+			name:  "string literals are kept",
+			query: "SELECT '?' FROM leases WHERE kind = 'it''s?' AND resource_id = ?",
+			want:  "SELECT '?' FROM leases WHERE kind = 'it''s?' AND resource_id = $1",
+		},
+		{
+			name:  "comments are kept",
+			query: "-- name: GetLease :one?\nSELECT * FROM leases WHERE resource_id = ?",
+			want:  "-- name: GetLease :one?\nSELECT * FROM leases WHERE resource_id = $1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, rebind(tt.query))
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	t.Run("mysql queries are not rewritten", func(t *testing.T) {
+		require.Equal(t, createCronJob, rewrite(MySQL, createCronJob).query)
+	})
+
+	t.Run("sqlite does not lock rows", func(t *testing.T) {
+		r := rewrite(SQLite, getPendingSignal)
+		require.NotContains(t, r.query, "FOR UPDATE")
+		require.Contains(t, r.query, "LIMIT 1")
+	})
+
+	t.Run("postgres locks rows", func(t *testing.T) {
+		r := rewrite(Postgres, getPendingSignal)
+		require.Contains(t, r.query, "FOR UPDATE")
+		require.Contains(t, r.query, "signal_name = $3")
+	})
+
+	t.Run("overrides use a subset of the arguments", func(t *testing.T) {
+		for _, dialect := range []Dialect{Postgres, SQLite} {
+			r := rewrite(dialect, createCronJob)
+			require.Contains(t, r.query, "ON CONFLICT (name, namespace)")
+			require.Equal(t, 10, r.args)
+		}
+	})
+
+	t.Run("overrides take the same arguments", func(t *testing.T) {
+		for name, query := range map[string]string{
+			"ClaimIdempotencyKey":          claimIdempotencyKey,
+			"DeleteExpiredIdempotencyKeys": deleteExpiredIdempotencyKeys,
+			"SleepWorkflowUntilSignal":     sleepWorkflowUntilSignal,
+		} {
+			o, ok := overrides[Postgres][name]
+			require.True(t, ok, name)
+			require.Equal(t, strings.Count(query, "?"), strings.Count(o.query, "?"), name)
+		}
+	})
+}
+
+func TestDBRewriteTruncatesArguments(t *testing.T) {
+	db := NewDB(nil, SQLite)
+
+	args := make([]any, 16)
+	_, rewritten := db.rewrite(createCronJob, args)
+	require.Len(t, rewritten, 10)
+
+	_, rewritten = db.rewrite(getLease, []any{"wf_123", LeasesKindWorkflow})
+	require.Len(t, rewritten, 2)
+}
+
+func TestDialectForDriver(t *testing.T) {
+	for driver, want := range map[string]Dialect{
+		"mysql":    MySQL,
+		"pgx":      Postgres,
+		"postgres": Postgres,
+		"sqlite":   SQLite,
+		"sqlite3":  SQLite,
+	} {
+		got, err := DialectForDriver(driver)
+		require.NoError(t, err)
+		require.Equal(t, want, got, driver)
+	}
+
+	_, err := DialectForDriver("oracle")
+	require.Error(t, err)
+}
@@ -56,11 +56,13 @@ func (ns NullLeasesKind) Value() (driver.Value, error) {
 type WorkflowExecutionsStatus string
 
 const (
-	WorkflowExecutionsStatusPending   WorkflowExecutionsStatus = "pending"
-	WorkflowExecutionsStatusRunning   WorkflowExecutionsStatus = "running"
-	WorkflowExecutionsStatusSleeping  WorkflowExecutionsStatus = "sleeping"
-	WorkflowExecutionsStatusCompleted WorkflowExecutionsStatus = "completed"
-	WorkflowExecutionsStatusFailed    WorkflowExecutionsStatus = "failed"
+	WorkflowExecutionsStatusPending    WorkflowExecutionsStatus = "pending"
+	WorkflowExecutionsStatusRunning    WorkflowExecutionsStatus = "running"
+	WorkflowExecutionsStatusSleeping   WorkflowExecutionsStatus = "sleeping"
+	WorkflowExecutionsStatusCompleted  WorkflowExecutionsStatus = "completed"
+	WorkflowExecutionsStatusFailed     WorkflowExecutionsStatus = "failed"
+	WorkflowExecutionsStatusCancelled  WorkflowExecutionsStatus = "cancelled"
+	WorkflowExecutionsStatusTerminated WorkflowExecutionsStatus = "terminated"
 )
 
 func (e *WorkflowExecutionsStatus) Scan(src interface{}) error {
@@ -228,6 +230,28 @@ type WorkflowExecution struct {
 	SleepUntil        sql.NullInt64                     `db:"sleep_until" json:"sleep_until"`
 	TraceID           sql.NullString                    `db:"trace_id" json:"trace_id"`
 	SpanID            sql.NullString                    `db:"span_id" json:"span_id"`
+	ParentExecutionID sql.NullString                    `db:"parent_execution_id" json:"parent_execution_id"`
+	CancelRequestedAt sql.NullInt64                     `db:"cancel_requested_at" json:"cancel_requested_at"`
+	WorkflowVersion   int32                             `db:"workflow_version" json:"workflow_version"`
+}
+
+type WorkflowIdempotencyKey struct {
+	Namespace      string `db:"namespace" json:"namespace"`
+	WorkflowName   string `db:"workflow_name" json:"workflow_name"`
+	IdempotencyKey string `db:"idempotency_key" json:"idempotency_key"`
+	ExecutionID    string `db:"execution_id" json:"execution_id"`
+	CreatedAt      int64  `db:"created_at" json:"created_at"`
+	ExpiresAt      int64  `db:"expires_at" json:"expires_at"`
+}
+
+type WorkflowSignal struct {
+	ID          string        `db:"id" json:"id"`
+	ExecutionID string        `db:"execution_id" json:"execution_id"`
+	SignalName  string        `db:"signal_name" json:"signal_name"`
+	Payload     []byte        `db:"payload" json:"payload"`
+	CreatedAt   int64         `db:"created_at" json:"created_at"`
+	ConsumedAt  sql.NullInt64 `db:"consumed_at" json:"consumed_at"`
+	Namespace   string        `db:"namespace" json:"namespace"`
 }
 
 type WorkflowStep struct {
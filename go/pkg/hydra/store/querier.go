@@ -9,26 +9,51 @@ import (
 )
 
 type Querier interface {
+	CancelWorkflow(ctx context.Context, db DBTX, arg CancelWorkflowParams) (int64, error)
+	CancelWorkflowWithLease(ctx context.Context, db DBTX, arg CancelWorkflowWithLeaseParams) (int64, error)
+	ClaimIdempotencyKey(ctx context.Context, db DBTX, arg ClaimIdempotencyKeyParams) error
 	CleanupExpiredLeases(ctx context.Context, db DBTX, arg CleanupExpiredLeasesParams) error
 	CompleteWorkflow(ctx context.Context, db DBTX, arg CompleteWorkflowParams) error
+	ConsumeSignal(ctx context.Context, db DBTX, arg ConsumeSignalParams) error
 	CreateCronJob(ctx context.Context, db DBTX, arg CreateCronJobParams) error
 	CreateLease(ctx context.Context, db DBTX, arg CreateLeaseParams) error
+	CreateSignal(ctx context.Context, db DBTX, arg CreateSignalParams) error
 	CreateStep(ctx context.Context, db DBTX, arg CreateStepParams) error
 	CreateWorkflow(ctx context.Context, db DBTX, arg CreateWorkflowParams) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, db DBTX, arg DeleteExpiredIdempotencyKeysParams) (int64, error)
+	DeleteLease(ctx context.Context, db DBTX, arg DeleteLeaseParams) error
+	DeleteSignals(ctx context.Context, db DBTX, arg DeleteSignalsParams) error
+	DeleteStep(ctx context.Context, db DBTX, arg DeleteStepParams) error
+	DeleteSteps(ctx context.Context, db DBTX, arg DeleteStepsParams) error
+	DeleteUnfinishedSteps(ctx context.Context, db DBTX, arg DeleteUnfinishedStepsParams) error
+	DeleteWorkflow(ctx context.Context, db DBTX, arg DeleteWorkflowParams) error
 	GetCompletedStep(ctx context.Context, db DBTX, arg GetCompletedStepParams) (WorkflowStep, error)
 	GetCronJob(ctx context.Context, db DBTX, arg GetCronJobParams) (CronJob, error)
 	GetCronJobs(ctx context.Context, db DBTX, namespace string) ([]CronJob, error)
 	GetDueCronJobs(ctx context.Context, db DBTX, arg GetDueCronJobsParams) ([]CronJob, error)
+	GetIdempotencyKey(ctx context.Context, db DBTX, arg GetIdempotencyKeyParams) (WorkflowIdempotencyKey, error)
 	GetLease(ctx context.Context, db DBTX, arg GetLeaseParams) (Lease, error)
+	GetPendingSignal(ctx context.Context, db DBTX, arg GetPendingSignalParams) (WorkflowSignal, error)
 	GetPendingWorkflows(ctx context.Context, db DBTX, arg GetPendingWorkflowsParams) ([]WorkflowExecution, error)
 	GetPendingWorkflowsFiltered(ctx context.Context, db DBTX, arg GetPendingWorkflowsFilteredParams) ([]WorkflowExecution, error)
 	GetSleepingWorkflows(ctx context.Context, db DBTX, arg GetSleepingWorkflowsParams) ([]WorkflowExecution, error)
 	GetStep(ctx context.Context, db DBTX, arg GetStepParams) (WorkflowStep, error)
+	GetUnfinishedChildWorkflows(ctx context.Context, db DBTX, arg GetUnfinishedChildWorkflowsParams) ([]WorkflowExecution, error)
 	GetWorkflow(ctx context.Context, db DBTX, arg GetWorkflowParams) (WorkflowExecution, error)
+	GetWorkflowNames(ctx context.Context, db DBTX, namespace string) ([]string, error)
 	HeartbeatLease(ctx context.Context, db DBTX, arg HeartbeatLeaseParams) error
+	ListExpiredWorkflows(ctx context.Context, db DBTX, arg ListExpiredWorkflowsParams) ([]WorkflowExecution, error)
+	ListSteps(ctx context.Context, db DBTX, arg ListStepsParams) ([]WorkflowStep, error)
+	ListWorkflows(ctx context.Context, db DBTX, arg ListWorkflowsParams) ([]WorkflowExecution, error)
 	ReleaseLease(ctx context.Context, db DBTX, arg ReleaseLeaseParams) error
+	RequestWorkflowCancellation(ctx context.Context, db DBTX, arg RequestWorkflowCancellationParams) (int64, error)
 	ResetOrphanedWorkflows(ctx context.Context, db DBTX, arg ResetOrphanedWorkflowsParams) error
+	ResumeWorkflowForCancellation(ctx context.Context, db DBTX, arg ResumeWorkflowForCancellationParams) (int64, error)
+	RetryWorkflow(ctx context.Context, db DBTX, arg RetryWorkflowParams) (int64, error)
+	SetWorkflowVersion(ctx context.Context, db DBTX, arg SetWorkflowVersionParams) error
 	SleepWorkflow(ctx context.Context, db DBTX, arg SleepWorkflowParams) error
+	SleepWorkflowUntilSignal(ctx context.Context, db DBTX, arg SleepWorkflowUntilSignalParams) error
+	TerminateWorkflow(ctx context.Context, db DBTX, arg TerminateWorkflowParams) (int64, error)
 	UpdateCronJob(ctx context.Context, db DBTX, arg UpdateCronJobParams) error
 	UpdateCronJobLastRun(ctx context.Context, db DBTX, arg UpdateCronJobLastRunParams) error
 	UpdateLease(ctx context.Context, db DBTX, arg UpdateLeaseParams) error
@@ -36,6 +61,7 @@ type Querier interface {
 	UpdateStepStatusWithLease(ctx context.Context, db DBTX, arg UpdateStepStatusWithLeaseParams) error
 	UpdateWorkflowFields(ctx context.Context, db DBTX, arg UpdateWorkflowFieldsParams) error
 	UpdateWorkflowToRunning(ctx context.Context, db DBTX, arg UpdateWorkflowToRunningParams) error
+	WakeWorkflow(ctx context.Context, db DBTX, arg WakeWorkflowParams) error
 }
 
 var _ Querier = (*Queries)(nil)
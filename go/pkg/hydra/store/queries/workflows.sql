@@ -7,12 +7,12 @@ INSERT INTO workflow_executions (
     id, workflow_name, status, input_data, output_data, error_message,
     created_at, started_at, completed_at, max_attempts, remaining_attempts,
     next_retry_at, namespace, trigger_type, trigger_source, sleep_until,
-    trace_id, span_id
+    trace_id, span_id, parent_execution_id
 ) VALUES (
     ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?,
-    ?, ?
+    ?, ?, ?
 );
 
 -- name: GetPendingWorkflows :many
@@ -64,8 +64,146 @@ WHERE namespace = ? AND execution_id = ? AND step_name = ?;
 -- name: SleepWorkflow :exec
 UPDATE workflow_executions 
 SET status = 'sleeping', sleep_until = ?
+WHERE id = ? AND namespace = ? AND status = 'running';
+
+-- name: SleepWorkflowUntilSignal :exec
+UPDATE workflow_executions 
+SET status = 'sleeping',
+    sleep_until = CASE WHEN EXISTS (
+        SELECT 1 FROM workflow_signals 
+        WHERE workflow_signals.namespace = workflow_executions.namespace 
+          AND workflow_signals.execution_id = workflow_executions.id 
+          AND workflow_signals.signal_name = sqlc.arg('signal_name') 
+          AND workflow_signals.consumed_at IS NULL
+    ) THEN sqlc.arg('now') ELSE sqlc.arg('timeout_at') END
+WHERE id = sqlc.arg('id') AND namespace = sqlc.arg('namespace') AND status = 'running';
+
+-- name: WakeWorkflow :exec
+UPDATE workflow_executions 
+SET sleep_until = ?
+WHERE id = ? AND namespace = ? AND status = 'sleeping';
+
+-- name: CreateSignal :exec
+INSERT INTO workflow_signals (
+    id, execution_id, signal_name, payload, created_at, consumed_at, namespace
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetPendingSignal :one
+SELECT * FROM workflow_signals 
+WHERE namespace = ? AND execution_id = ? AND signal_name = ? AND consumed_at IS NULL
+ORDER BY created_at ASC, id ASC
+LIMIT 1
+FOR UPDATE;
+
+-- name: ConsumeSignal :exec
+UPDATE workflow_signals 
+SET consumed_at = ?
 WHERE id = ? AND namespace = ?;
 
+-- name: GetUnfinishedChildWorkflows :many
+SELECT * FROM workflow_executions 
+WHERE namespace = ? AND parent_execution_id = ?
+  AND (
+    status IN ('pending', 'running', 'sleeping') 
+    OR (status = 'failed' AND next_retry_at IS NOT NULL)
+  );
+
+-- name: ListWorkflows :many
+SELECT * FROM workflow_executions 
+WHERE namespace = sqlc.arg('namespace') 
+  AND (sqlc.arg('status') = '' OR status = sqlc.arg('status')) 
+  AND (sqlc.arg('workflow_name') = '' OR workflow_name = sqlc.arg('workflow_name')) 
+  AND (sqlc.arg('cursor') = '' OR (created_at, id) < (
+    SELECT c.created_at, c.id FROM workflow_executions c 
+    WHERE c.namespace = sqlc.arg('namespace') AND c.id = sqlc.arg('cursor')
+  ))
+ORDER BY created_at DESC, id DESC 
+LIMIT ?;
+
+-- name: RequestWorkflowCancellation :execrows
+UPDATE workflow_executions 
+SET cancel_requested_at = ?, error_message = ?
+WHERE id = ? AND namespace = ? AND cancel_requested_at IS NULL
+  AND (
+    status IN ('pending', 'running', 'sleeping') 
+    OR (status = 'failed' AND next_retry_at IS NOT NULL)
+  );
+
+-- name: CancelWorkflow :execrows
+UPDATE workflow_executions 
+SET status = 'cancelled', completed_at = ?, next_retry_at = NULL, sleep_until = NULL
+WHERE id = ? AND namespace = ? AND cancel_requested_at IS NOT NULL
+  AND (
+    status IN ('pending', 'running', 'sleeping') 
+    OR (status = 'failed' AND next_retry_at IS NOT NULL)
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM workflow_steps 
+    WHERE workflow_steps.namespace = workflow_executions.namespace 
+      AND workflow_steps.execution_id = workflow_executions.id 
+      AND workflow_steps.status = 'completed'
+  )
+  AND id NOT IN (
+    SELECT resource_id FROM leases 
+    WHERE kind = 'workflow' AND expires_at > ?
+  );
+
+-- name: ResumeWorkflowForCancellation :execrows
+UPDATE workflow_executions 
+SET status = 'pending', next_retry_at = NULL, sleep_until = NULL
+WHERE id = ? AND namespace = ? AND cancel_requested_at IS NOT NULL
+  AND (
+    status IN ('pending', 'running', 'sleeping') 
+    OR (status = 'failed' AND next_retry_at IS NOT NULL)
+  )
+  AND id NOT IN (
+    SELECT resource_id FROM leases 
+    WHERE kind = 'workflow' AND expires_at > ?
+  );
+
+-- name: CancelWorkflowWithLease :execrows
+UPDATE workflow_executions 
+SET status = 'cancelled', completed_at = ?, next_retry_at = NULL, sleep_until = NULL
+WHERE id = ? AND workflow_executions.namespace = ?
+  AND EXISTS (
+    SELECT 1 FROM leases 
+    WHERE resource_id = ? AND kind = 'workflow' 
+    AND worker_id = ? AND expires_at > ?
+  );
+
+-- name: TerminateWorkflow :execrows
+UPDATE workflow_executions 
+SET status = 'terminated', error_message = ?, completed_at = ?, next_retry_at = NULL, sleep_until = NULL
+WHERE id = ? AND namespace = ?
+  AND (
+    status IN ('pending', 'running', 'sleeping') 
+    OR (status = 'failed' AND next_retry_at IS NOT NULL)
+  );
+
+-- name: RetryWorkflow :execrows
+UPDATE workflow_executions 
+SET status = 'pending', error_message = NULL, completed_at = NULL, next_retry_at = NULL, 
+    sleep_until = NULL, cancel_requested_at = NULL, remaining_attempts = max_attempts
+WHERE id = ? AND namespace = ?
+  AND (
+    status IN ('cancelled', 'terminated') 
+    OR (status = 'failed' AND next_retry_at IS NULL)
+  )
+  AND id NOT IN (
+    SELECT resource_id FROM leases 
+    WHERE kind = 'workflow' AND expires_at > ?
+  );
+
+-- name: DeleteStep :exec
+DELETE FROM workflow_steps 
+WHERE namespace = ? AND execution_id = ? AND step_name = ?;
+
+-- name: DeleteUnfinishedSteps :exec
+DELETE FROM workflow_steps 
+WHERE namespace = ? AND execution_id = ? AND status != 'completed';
+
 -- name: CreateStep :exec
 INSERT INTO workflow_steps (
     id, execution_id, step_name, status, output_data, error_message,
@@ -83,6 +221,11 @@ WHERE namespace = ? AND execution_id = ? AND step_name = ?;
 SELECT * FROM workflow_steps 
 WHERE namespace = ? AND execution_id = ? AND step_name = ? AND status = 'completed';
 
+-- name: ListSteps :many
+SELECT * FROM workflow_steps 
+WHERE namespace = ? AND execution_id = ?
+ORDER BY started_at ASC, id ASC;
+
 -- name: UpdateStepStatusWithLease :exec
 UPDATE workflow_steps 
 SET status = ?, completed_at = ?, output_data = ?, error_message = ?
@@ -135,6 +278,10 @@ WHERE resource_id = ? AND worker_id = ?;
 DELETE FROM leases 
 WHERE resource_id = ? AND worker_id = ?;
 
+-- name: DeleteLease :exec
+DELETE FROM leases 
+WHERE resource_id = ? AND kind = ?;
+
 -- name: GetSleepingWorkflows :many
 SELECT * FROM workflow_executions 
 WHERE namespace = ? AND status = 'sleeping' AND sleep_until <= ?
@@ -187,3 +334,54 @@ WHERE workflow_executions.namespace = ?
     WHERE kind = 'workflow' AND leases.namespace = ?
   );
 
+-- name: ClaimIdempotencyKey :exec
+INSERT INTO workflow_idempotency_keys (
+    namespace, workflow_name, idempotency_key, execution_id, created_at, expires_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+ON DUPLICATE KEY UPDATE
+    execution_id = IF(expires_at <= VALUES(created_at), VALUES(execution_id), execution_id),
+    created_at = IF(expires_at <= VALUES(created_at), VALUES(created_at), created_at),
+    expires_at = IF(expires_at <= VALUES(created_at), VALUES(expires_at), expires_at);
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM workflow_idempotency_keys 
+WHERE namespace = ? AND workflow_name = ? AND idempotency_key = ?;
+
+-- name: GetWorkflowNames :many
+SELECT DISTINCT workflow_name FROM workflow_executions 
+WHERE namespace = ?;
+
+-- name: ListExpiredWorkflows :many
+SELECT * FROM workflow_executions 
+WHERE namespace = sqlc.arg('namespace') AND workflow_name = sqlc.arg('workflow_name') 
+  AND completed_at < sqlc.arg('completed_before')
+  AND (
+    status IN ('completed', 'cancelled', 'terminated') 
+    OR (status = 'failed' AND next_retry_at IS NULL)
+  )
+ORDER BY completed_at ASC 
+LIMIT ?;
+
+-- name: DeleteWorkflow :exec
+DELETE FROM workflow_executions 
+WHERE id = ? AND namespace = ?;
+
+-- name: DeleteSteps :exec
+DELETE FROM workflow_steps 
+WHERE namespace = ? AND execution_id = ?;
+
+-- name: DeleteSignals :exec
+DELETE FROM workflow_signals 
+WHERE namespace = ? AND execution_id = ?;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM workflow_idempotency_keys 
+WHERE namespace = ? AND expires_at <= ?
+LIMIT ?;
+
+-- name: SetWorkflowVersion :exec
+UPDATE workflow_executions 
+SET workflow_version = ?
+WHERE id = ? AND namespace = ?;
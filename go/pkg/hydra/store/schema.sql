@@ -1,10 +1,12 @@
 CREATE DATABASE IF NOT EXISTS `hydra`;
 USE `hydra`;
 
+-- Changes must be made to schema_postgres.sql and schema_sqlite.sql as well
+
 CREATE TABLE IF NOT EXISTS workflow_executions (
     id VARCHAR(255) PRIMARY KEY,
     workflow_name VARCHAR(255) NOT NULL,
-    status ENUM('pending', 'running', 'sleeping', 'completed', 'failed') NOT NULL,
+    status ENUM('pending', 'running', 'sleeping', 'completed', 'failed', 'cancelled', 'terminated') NOT NULL,
     input_data LONGBLOB,  -- Large binary data for workflow inputs
     output_data MEDIUMBLOB,  -- Medium binary data for workflow outputs
     error_message TEXT,
@@ -24,7 +26,21 @@ CREATE TABLE IF NOT EXISTS workflow_executions (
     sleep_until BIGINT,
 
     trace_id VARCHAR(255),
-    span_id VARCHAR(255)
+    span_id VARCHAR(255),
+
+    -- Set for child workflows started with hydra.ChildWorkflow
+    parent_execution_id VARCHAR(255),
+
+    -- Set by Engine.Cancel while a worker holds the lease, the worker stops
+    -- the execution before its next step
+    cancel_requested_at BIGINT,
+
+    -- Version of the workflow code that first ran the execution, workers
+    -- with an older version do not run it
+    workflow_version INT NOT NULL DEFAULT 0,
+
+    INDEX idx_workflow_executions_parent (namespace, parent_execution_id),
+    INDEX idx_workflow_executions_completed (namespace, workflow_name, completed_at)
 );
 
 CREATE TABLE IF NOT EXISTS workflow_steps (
@@ -44,6 +60,35 @@ CREATE TABLE IF NOT EXISTS workflow_steps (
     namespace VARCHAR(255) NOT NULL
 );
 
+-- Signals delivered to workflow executions, consumed by WaitForSignal
+CREATE TABLE IF NOT EXISTS workflow_signals (
+    id VARCHAR(255) PRIMARY KEY,
+    execution_id VARCHAR(255) NOT NULL,
+    signal_name VARCHAR(255) NOT NULL,
+    payload LONGBLOB,
+
+    created_at BIGINT NOT NULL,
+    consumed_at BIGINT,
+
+    namespace VARCHAR(255) NOT NULL,
+
+    INDEX idx_workflow_signals_execution (namespace, execution_id, signal_name)
+);
+
+-- Idempotency keys of started workflows, a key returns the execution it
+-- started until it expires
+CREATE TABLE IF NOT EXISTS workflow_idempotency_keys (
+    namespace VARCHAR(255) NOT NULL,
+    workflow_name VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    execution_id VARCHAR(255) NOT NULL,
+
+    created_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+
+    PRIMARY KEY (namespace, workflow_name, idempotency_key)
+);
+
 -- Cron Jobs Table
 CREATE TABLE IF NOT EXISTS `cron_jobs` (
   `id` varchar(255) NOT NULL,
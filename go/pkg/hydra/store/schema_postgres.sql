@@ -0,0 +1,104 @@
+-- Postgres version of schema.sql, keep both in sync
+
+CREATE TABLE IF NOT EXISTS workflow_executions (
+    id VARCHAR(255) PRIMARY KEY,
+    workflow_name VARCHAR(255) NOT NULL,
+    status VARCHAR(32) NOT NULL CHECK (status IN ('pending', 'running', 'sleeping', 'completed', 'failed', 'cancelled', 'terminated')),
+    input_data BYTEA,
+    output_data BYTEA,
+    error_message TEXT,
+
+    created_at BIGINT NOT NULL,
+    started_at BIGINT,
+    completed_at BIGINT,
+    max_attempts INTEGER NOT NULL,
+    remaining_attempts INTEGER NOT NULL,
+    next_retry_at BIGINT,
+
+    namespace VARCHAR(255) NOT NULL,
+
+    trigger_type VARCHAR(32) CHECK (trigger_type IN ('manual', 'cron', 'event', 'api')),
+    trigger_source VARCHAR(255),
+
+    sleep_until BIGINT,
+
+    trace_id VARCHAR(255),
+    span_id VARCHAR(255),
+
+    parent_execution_id VARCHAR(255),
+
+    cancel_requested_at BIGINT,
+
+    workflow_version INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_executions_parent ON workflow_executions (namespace, parent_execution_id);
+CREATE INDEX IF NOT EXISTS idx_workflow_executions_completed ON workflow_executions (namespace, workflow_name, completed_at);
+
+CREATE TABLE IF NOT EXISTS workflow_steps (
+    id VARCHAR(255) PRIMARY KEY,
+    execution_id VARCHAR(255) NOT NULL,
+    step_name VARCHAR(255) NOT NULL,
+    status VARCHAR(32) NOT NULL CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    output_data BYTEA,
+    error_message TEXT,
+
+    started_at BIGINT,
+    completed_at BIGINT,
+
+    max_attempts INTEGER NOT NULL,
+    remaining_attempts INTEGER NOT NULL,
+
+    namespace VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS workflow_signals (
+    id VARCHAR(255) PRIMARY KEY,
+    execution_id VARCHAR(255) NOT NULL,
+    signal_name VARCHAR(255) NOT NULL,
+    payload BYTEA,
+
+    created_at BIGINT NOT NULL,
+    consumed_at BIGINT,
+
+    namespace VARCHAR(255) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_signals_execution ON workflow_signals (namespace, execution_id, signal_name);
+
+CREATE TABLE IF NOT EXISTS workflow_idempotency_keys (
+    namespace VARCHAR(255) NOT NULL,
+    workflow_name VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    execution_id VARCHAR(255) NOT NULL,
+
+    created_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+
+    PRIMARY KEY (namespace, workflow_name, idempotency_key)
+);
+
+CREATE TABLE IF NOT EXISTS cron_jobs (
+    id VARCHAR(255) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    cron_spec VARCHAR(255) NOT NULL,
+    namespace VARCHAR(255) NOT NULL,
+    workflow_name VARCHAR(255) DEFAULT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    last_run_at BIGINT DEFAULT NULL,
+    next_run_at BIGINT NOT NULL,
+
+    CONSTRAINT cron_jobs_name_namespace_idx UNIQUE (name, namespace)
+);
+
+CREATE TABLE IF NOT EXISTS leases (
+    resource_id VARCHAR(255) PRIMARY KEY,
+    kind VARCHAR(32) NOT NULL CHECK (kind IN ('workflow', 'step', 'cron_job')),
+    namespace VARCHAR(255) NOT NULL,
+    worker_id VARCHAR(255) NOT NULL,
+    acquired_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    heartbeat_at BIGINT NOT NULL
+);
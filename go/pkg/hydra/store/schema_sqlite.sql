@@ -0,0 +1,104 @@
+-- SQLite version of schema.sql, keep both in sync
+
+CREATE TABLE IF NOT EXISTS workflow_executions (
+    id TEXT PRIMARY KEY,
+    workflow_name TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'running', 'sleeping', 'completed', 'failed', 'cancelled', 'terminated')),
+    input_data BLOB,
+    output_data BLOB,
+    error_message TEXT,
+
+    created_at BIGINT NOT NULL,
+    started_at BIGINT,
+    completed_at BIGINT,
+    max_attempts INTEGER NOT NULL,
+    remaining_attempts INTEGER NOT NULL,
+    next_retry_at BIGINT,
+
+    namespace TEXT NOT NULL,
+
+    trigger_type TEXT CHECK (trigger_type IN ('manual', 'cron', 'event', 'api')),
+    trigger_source TEXT,
+
+    sleep_until BIGINT,
+
+    trace_id TEXT,
+    span_id TEXT,
+
+    parent_execution_id TEXT,
+
+    cancel_requested_at BIGINT,
+
+    workflow_version INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_executions_parent ON workflow_executions (namespace, parent_execution_id);
+CREATE INDEX IF NOT EXISTS idx_workflow_executions_completed ON workflow_executions (namespace, workflow_name, completed_at);
+
+CREATE TABLE IF NOT EXISTS workflow_steps (
+    id TEXT PRIMARY KEY,
+    execution_id TEXT NOT NULL,
+    step_name TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    output_data BLOB,
+    error_message TEXT,
+
+    started_at BIGINT,
+    completed_at BIGINT,
+
+    max_attempts INTEGER NOT NULL,
+    remaining_attempts INTEGER NOT NULL,
+
+    namespace TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS workflow_signals (
+    id TEXT PRIMARY KEY,
+    execution_id TEXT NOT NULL,
+    signal_name TEXT NOT NULL,
+    payload BLOB,
+
+    created_at BIGINT NOT NULL,
+    consumed_at BIGINT,
+
+    namespace TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_signals_execution ON workflow_signals (namespace, execution_id, signal_name);
+
+CREATE TABLE IF NOT EXISTS workflow_idempotency_keys (
+    namespace TEXT NOT NULL,
+    workflow_name TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    execution_id TEXT NOT NULL,
+
+    created_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+
+    PRIMARY KEY (namespace, workflow_name, idempotency_key)
+);
+
+CREATE TABLE IF NOT EXISTS cron_jobs (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    cron_spec TEXT NOT NULL,
+    namespace TEXT NOT NULL,
+    workflow_name TEXT DEFAULT NULL,
+    enabled INTEGER NOT NULL DEFAULT 1,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    last_run_at BIGINT DEFAULT NULL,
+    next_run_at BIGINT NOT NULL,
+
+    CONSTRAINT cron_jobs_name_namespace_idx UNIQUE (name, namespace)
+);
+
+CREATE TABLE IF NOT EXISTS leases (
+    resource_id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL CHECK (kind IN ('workflow', 'step', 'cron_job')),
+    namespace TEXT NOT NULL,
+    worker_id TEXT NOT NULL,
+    acquired_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    heartbeat_at BIGINT NOT NULL
+);
@@ -10,6 +10,114 @@ import (
 	"database/sql"
 )
 
+const cancelWorkflow = `-- name: CancelWorkflow :execrows
+UPDATE workflow_executions 
+SET status = 'cancelled', completed_at = ?, next_retry_at = NULL, sleep_until = NULL
+WHERE id = ? AND namespace = ? AND cancel_requested_at IS NOT NULL
+  AND (
+    status IN ('pending', 'running', 'sleeping') 
+    OR (status = 'failed' AND next_retry_at IS NOT NULL)
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM workflow_steps 
+    WHERE workflow_steps.namespace = workflow_executions.namespace 
+      AND workflow_steps.execution_id = workflow_executions.id 
+      AND workflow_steps.status = 'completed'
+  )
+  AND id NOT IN (
+    SELECT resource_id FROM leases 
+    WHERE kind = 'workflow' AND expires_at > ?
+  )
+`
+
+type CancelWorkflowParams struct {
+	CompletedAt sql.NullInt64 `db:"completed_at" json:"completed_at"`
+	ID          string        `db:"id" json:"id"`
+	Namespace   string        `db:"namespace" json:"namespace"`
+	ExpiresAt   int64         `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CancelWorkflow(ctx context.Context, db DBTX, arg CancelWorkflowParams) (int64, error) {
+	result, err := db.ExecContext(ctx, cancelWorkflow,
+		arg.CompletedAt,
+		arg.ID,
+		arg.Namespace,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cancelWorkflowWithLease = `-- name: CancelWorkflowWithLease :execrows
+UPDATE workflow_executions 
+SET status = 'cancelled', completed_at = ?, next_retry_at = NULL, sleep_until = NULL
+WHERE id = ? AND workflow_executions.namespace = ?
+  AND EXISTS (
+    SELECT 1 FROM leases 
+    WHERE resource_id = ? AND kind = 'workflow' 
+    AND worker_id = ? AND expires_at > ?
+  )
+`
+
+type CancelWorkflowWithLeaseParams struct {
+	CompletedAt sql.NullInt64 `db:"completed_at" json:"completed_at"`
+	ID          string        `db:"id" json:"id"`
+	Namespace   string        `db:"namespace" json:"namespace"`
+	ResourceID  string        `db:"resource_id" json:"resource_id"`
+	WorkerID    string        `db:"worker_id" json:"worker_id"`
+	ExpiresAt   int64         `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CancelWorkflowWithLease(ctx context.Context, db DBTX, arg CancelWorkflowWithLeaseParams) (int64, error) {
+	result, err := db.ExecContext(ctx, cancelWorkflowWithLease,
+		arg.CompletedAt,
+		arg.ID,
+		arg.Namespace,
+		arg.ResourceID,
+		arg.WorkerID,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const claimIdempotencyKey = `-- name: ClaimIdempotencyKey :exec
+INSERT INTO workflow_idempotency_keys (
+    namespace, workflow_name, idempotency_key, execution_id, created_at, expires_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+ON DUPLICATE KEY UPDATE
+    execution_id = IF(expires_at <= VALUES(created_at), VALUES(execution_id), execution_id),
+    created_at = IF(expires_at <= VALUES(created_at), VALUES(created_at), created_at),
+    expires_at = IF(expires_at <= VALUES(created_at), VALUES(expires_at), expires_at)
+`
+
+type ClaimIdempotencyKeyParams struct {
+	Namespace      string `db:"namespace" json:"namespace"`
+	WorkflowName   string `db:"workflow_name" json:"workflow_name"`
+	IdempotencyKey string `db:"idempotency_key" json:"idempotency_key"`
+	ExecutionID    string `db:"execution_id" json:"execution_id"`
+	CreatedAt      int64  `db:"created_at" json:"created_at"`
+	ExpiresAt      int64  `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) ClaimIdempotencyKey(ctx context.Context, db DBTX, arg ClaimIdempotencyKeyParams) error {
+	_, err := db.ExecContext(ctx, claimIdempotencyKey,
+		arg.Namespace,
+		arg.WorkflowName,
+		arg.IdempotencyKey,
+		arg.ExecutionID,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
 const cleanupExpiredLeases = `-- name: CleanupExpiredLeases :exec
 DELETE FROM leases 
 WHERE namespace = ? AND expires_at < ?
@@ -48,6 +156,23 @@ func (q *Queries) CompleteWorkflow(ctx context.Context, db DBTX, arg CompleteWor
 	return err
 }
 
+const consumeSignal = `-- name: ConsumeSignal :exec
+UPDATE workflow_signals 
+SET consumed_at = ?
+WHERE id = ? AND namespace = ?
+`
+
+type ConsumeSignalParams struct {
+	ConsumedAt sql.NullInt64 `db:"consumed_at" json:"consumed_at"`
+	ID         string        `db:"id" json:"id"`
+	Namespace  string        `db:"namespace" json:"namespace"`
+}
+
+func (q *Queries) ConsumeSignal(ctx context.Context, db DBTX, arg ConsumeSignalParams) error {
+	_, err := db.ExecContext(ctx, consumeSignal, arg.ConsumedAt, arg.ID, arg.Namespace)
+	return err
+}
+
 const createCronJob = `-- name: CreateCronJob :exec
 INSERT INTO cron_jobs (
     id, name, cron_spec, namespace, workflow_name, enabled, 
@@ -124,6 +249,37 @@ func (q *Queries) CreateLease(ctx context.Context, db DBTX, arg CreateLeaseParam
 	return err
 }
 
+const createSignal = `-- name: CreateSignal :exec
+INSERT INTO workflow_signals (
+    id, execution_id, signal_name, payload, created_at, consumed_at, namespace
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateSignalParams struct {
+	ID          string        `db:"id" json:"id"`
+	ExecutionID string        `db:"execution_id" json:"execution_id"`
+	SignalName  string        `db:"signal_name" json:"signal_name"`
+	Payload     []byte        `db:"payload" json:"payload"`
+	CreatedAt   int64         `db:"created_at" json:"created_at"`
+	ConsumedAt  sql.NullInt64 `db:"consumed_at" json:"consumed_at"`
+	Namespace   string        `db:"namespace" json:"namespace"`
+}
+
+func (q *Queries) CreateSignal(ctx context.Context, db DBTX, arg CreateSignalParams) error {
+	_, err := db.ExecContext(ctx, createSignal,
+		arg.ID,
+		arg.ExecutionID,
+		arg.SignalName,
+		arg.Payload,
+		arg.CreatedAt,
+		arg.ConsumedAt,
+		arg.Namespace,
+	)
+	return err
+}
+
 const createStep = `-- name: CreateStep :exec
 INSERT INTO workflow_steps (
     id, execution_id, step_name, status, output_data, error_message,
@@ -170,12 +326,12 @@ INSERT INTO workflow_executions (
     id, workflow_name, status, input_data, output_data, error_message,
     created_at, started_at, completed_at, max_attempts, remaining_attempts,
     next_retry_at, namespace, trigger_type, trigger_source, sleep_until,
-    trace_id, span_id
+    trace_id, span_id, parent_execution_id
 ) VALUES (
     ?, ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?,
     ?, ?, ?, ?, ?,
-    ?, ?
+    ?, ?, ?
 )
 `
 
@@ -198,6 +354,7 @@ type CreateWorkflowParams struct {
 	SleepUntil        sql.NullInt64                     `db:"sleep_until" json:"sleep_until"`
 	TraceID           sql.NullString                    `db:"trace_id" json:"trace_id"`
 	SpanID            sql.NullString                    `db:"span_id" json:"span_id"`
+	ParentExecutionID sql.NullString                    `db:"parent_execution_id" json:"parent_execution_id"`
 }
 
 func (q *Queries) CreateWorkflow(ctx context.Context, db DBTX, arg CreateWorkflowParams) error {
@@ -220,7 +377,123 @@ func (q *Queries) CreateWorkflow(ctx context.Context, db DBTX, arg CreateWorkflo
 		arg.SleepUntil,
 		arg.TraceID,
 		arg.SpanID,
+		arg.ParentExecutionID,
+	)
+	return err
+}
+
+const deleteExpiredIdempotencyKeys = `-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM workflow_idempotency_keys 
+WHERE namespace = ? AND expires_at <= ?
+LIMIT ?
+`
+
+type DeleteExpiredIdempotencyKeysParams struct {
+	Namespace string `db:"namespace" json:"namespace"`
+	ExpiresAt int64  `db:"expires_at" json:"expires_at"`
+	Limit     int32  `db:"limit" json:"limit"`
+}
+
+func (q *Queries) DeleteExpiredIdempotencyKeys(ctx context.Context, db DBTX, arg DeleteExpiredIdempotencyKeysParams) (int64, error) {
+	result, err := db.ExecContext(ctx, deleteExpiredIdempotencyKeys,
+		arg.Namespace,
+		arg.ExpiresAt,
+		arg.Limit,
 	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteLease = `-- name: DeleteLease :exec
+DELETE FROM leases 
+WHERE resource_id = ? AND kind = ?
+`
+
+type DeleteLeaseParams struct {
+	ResourceID string     `db:"resource_id" json:"resource_id"`
+	Kind       LeasesKind `db:"kind" json:"kind"`
+}
+
+func (q *Queries) DeleteLease(ctx context.Context, db DBTX, arg DeleteLeaseParams) error {
+	_, err := db.ExecContext(ctx, deleteLease, arg.ResourceID, arg.Kind)
+	return err
+}
+
+const deleteSignals = `-- name: DeleteSignals :exec
+DELETE FROM workflow_signals 
+WHERE namespace = ? AND execution_id = ?
+`
+
+type DeleteSignalsParams struct {
+	Namespace   string `db:"namespace" json:"namespace"`
+	ExecutionID string `db:"execution_id" json:"execution_id"`
+}
+
+func (q *Queries) DeleteSignals(ctx context.Context, db DBTX, arg DeleteSignalsParams) error {
+	_, err := db.ExecContext(ctx, deleteSignals, arg.Namespace, arg.ExecutionID)
+	return err
+}
+
+const deleteStep = `-- name: DeleteStep :exec
+DELETE FROM workflow_steps 
+WHERE namespace = ? AND execution_id = ? AND step_name = ?
+`
+
+type DeleteStepParams struct {
+	Namespace   string `db:"namespace" json:"namespace"`
+	ExecutionID string `db:"execution_id" json:"execution_id"`
+	StepName    string `db:"step_name" json:"step_name"`
+}
+
+func (q *Queries) DeleteStep(ctx context.Context, db DBTX, arg DeleteStepParams) error {
+	_, err := db.ExecContext(ctx, deleteStep, arg.Namespace, arg.ExecutionID, arg.StepName)
+	return err
+}
+
+const deleteSteps = `-- name: DeleteSteps :exec
+DELETE FROM workflow_steps 
+WHERE namespace = ? AND execution_id = ?
+`
+
+type DeleteStepsParams struct {
+	Namespace   string `db:"namespace" json:"namespace"`
+	ExecutionID string `db:"execution_id" json:"execution_id"`
+}
+
+func (q *Queries) DeleteSteps(ctx context.Context, db DBTX, arg DeleteStepsParams) error {
+	_, err := db.ExecContext(ctx, deleteSteps, arg.Namespace, arg.ExecutionID)
+	return err
+}
+
+const deleteUnfinishedSteps = `-- name: DeleteUnfinishedSteps :exec
+DELETE FROM workflow_steps 
+WHERE namespace = ? AND execution_id = ? AND status != 'completed'
+`
+
+type DeleteUnfinishedStepsParams struct {
+	Namespace   string `db:"namespace" json:"namespace"`
+	ExecutionID string `db:"execution_id" json:"execution_id"`
+}
+
+func (q *Queries) DeleteUnfinishedSteps(ctx context.Context, db DBTX, arg DeleteUnfinishedStepsParams) error {
+	_, err := db.ExecContext(ctx, deleteUnfinishedSteps, arg.Namespace, arg.ExecutionID)
+	return err
+}
+
+const deleteWorkflow = `-- name: DeleteWorkflow :exec
+DELETE FROM workflow_executions 
+WHERE id = ? AND namespace = ?
+`
+
+type DeleteWorkflowParams struct {
+	ID        string `db:"id" json:"id"`
+	Namespace string `db:"namespace" json:"namespace"`
+}
+
+func (q *Queries) DeleteWorkflow(ctx context.Context, db DBTX, arg DeleteWorkflowParams) error {
+	_, err := db.ExecContext(ctx, deleteWorkflow, arg.ID, arg.Namespace)
 	return err
 }
 
@@ -365,6 +638,31 @@ func (q *Queries) GetDueCronJobs(ctx context.Context, db DBTX, arg GetDueCronJob
 	return items, nil
 }
 
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT namespace, workflow_name, idempotency_key, execution_id, created_at, expires_at FROM workflow_idempotency_keys 
+WHERE namespace = ? AND workflow_name = ? AND idempotency_key = ?
+`
+
+type GetIdempotencyKeyParams struct {
+	Namespace      string `db:"namespace" json:"namespace"`
+	WorkflowName   string `db:"workflow_name" json:"workflow_name"`
+	IdempotencyKey string `db:"idempotency_key" json:"idempotency_key"`
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, db DBTX, arg GetIdempotencyKeyParams) (WorkflowIdempotencyKey, error) {
+	row := db.QueryRowContext(ctx, getIdempotencyKey, arg.Namespace, arg.WorkflowName, arg.IdempotencyKey)
+	var i WorkflowIdempotencyKey
+	err := row.Scan(
+		&i.Namespace,
+		&i.WorkflowName,
+		&i.IdempotencyKey,
+		&i.ExecutionID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
 const getLease = `-- name: GetLease :one
 SELECT resource_id, kind, namespace, worker_id, acquired_at, expires_at, heartbeat_at FROM leases 
 WHERE resource_id = ? AND kind = ?
@@ -390,8 +688,37 @@ func (q *Queries) GetLease(ctx context.Context, db DBTX, arg GetLeaseParams) (Le
 	return i, err
 }
 
+const getPendingSignal = `-- name: GetPendingSignal :one
+SELECT id, execution_id, signal_name, payload, created_at, consumed_at, namespace FROM workflow_signals 
+WHERE namespace = ? AND execution_id = ? AND signal_name = ? AND consumed_at IS NULL
+ORDER BY created_at ASC, id ASC
+LIMIT 1
+FOR UPDATE
+`
+
+type GetPendingSignalParams struct {
+	Namespace   string `db:"namespace" json:"namespace"`
+	ExecutionID string `db:"execution_id" json:"execution_id"`
+	SignalName  string `db:"signal_name" json:"signal_name"`
+}
+
+func (q *Queries) GetPendingSignal(ctx context.Context, db DBTX, arg GetPendingSignalParams) (WorkflowSignal, error) {
+	row := db.QueryRowContext(ctx, getPendingSignal, arg.Namespace, arg.ExecutionID, arg.SignalName)
+	var i WorkflowSignal
+	err := row.Scan(
+		&i.ID,
+		&i.ExecutionID,
+		&i.SignalName,
+		&i.Payload,
+		&i.CreatedAt,
+		&i.ConsumedAt,
+		&i.Namespace,
+	)
+	return i, err
+}
+
 const getPendingWorkflows = `-- name: GetPendingWorkflows :many
-SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id FROM workflow_executions 
+SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id, parent_execution_id, cancel_requested_at, workflow_version FROM workflow_executions 
 WHERE namespace = ? 
   AND (
     status = 'pending' 
@@ -442,6 +769,9 @@ func (q *Queries) GetPendingWorkflows(ctx context.Context, db DBTX, arg GetPendi
 			&i.SleepUntil,
 			&i.TraceID,
 			&i.SpanID,
+			&i.ParentExecutionID,
+			&i.CancelRequestedAt,
+			&i.WorkflowVersion,
 		); err != nil {
 			return nil, err
 		}
@@ -457,7 +787,7 @@ func (q *Queries) GetPendingWorkflows(ctx context.Context, db DBTX, arg GetPendi
 }
 
 const getPendingWorkflowsFiltered = `-- name: GetPendingWorkflowsFiltered :many
-SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id FROM workflow_executions 
+SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id, parent_execution_id, cancel_requested_at, workflow_version FROM workflow_executions 
 WHERE namespace = ? 
   AND (
     status = 'pending' 
@@ -511,6 +841,9 @@ func (q *Queries) GetPendingWorkflowsFiltered(ctx context.Context, db DBTX, arg
 			&i.SleepUntil,
 			&i.TraceID,
 			&i.SpanID,
+			&i.ParentExecutionID,
+			&i.CancelRequestedAt,
+			&i.WorkflowVersion,
 		); err != nil {
 			return nil, err
 		}
@@ -526,7 +859,7 @@ func (q *Queries) GetPendingWorkflowsFiltered(ctx context.Context, db DBTX, arg
 }
 
 const getSleepingWorkflows = `-- name: GetSleepingWorkflows :many
-SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id FROM workflow_executions 
+SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id, parent_execution_id, cancel_requested_at, workflow_version FROM workflow_executions 
 WHERE namespace = ? AND status = 'sleeping' AND sleep_until <= ?
 ORDER BY sleep_until ASC
 `
@@ -564,6 +897,9 @@ func (q *Queries) GetSleepingWorkflows(ctx context.Context, db DBTX, arg GetSlee
 			&i.SleepUntil,
 			&i.TraceID,
 			&i.SpanID,
+			&i.ParentExecutionID,
+			&i.CancelRequestedAt,
+			&i.WorkflowVersion,
 		); err != nil {
 			return nil, err
 		}
@@ -608,8 +944,67 @@ func (q *Queries) GetStep(ctx context.Context, db DBTX, arg GetStepParams) (Work
 	return i, err
 }
 
+const getUnfinishedChildWorkflows = `-- name: GetUnfinishedChildWorkflows :many
+SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id, parent_execution_id, cancel_requested_at, workflow_version FROM workflow_executions 
+WHERE namespace = ? AND parent_execution_id = ?
+  AND (
+    status IN ('pending', 'running', 'sleeping') 
+    OR (status = 'failed' AND next_retry_at IS NOT NULL)
+  )
+`
+
+type GetUnfinishedChildWorkflowsParams struct {
+	Namespace         string         `db:"namespace" json:"namespace"`
+	ParentExecutionID sql.NullString `db:"parent_execution_id" json:"parent_execution_id"`
+}
+
+func (q *Queries) GetUnfinishedChildWorkflows(ctx context.Context, db DBTX, arg GetUnfinishedChildWorkflowsParams) ([]WorkflowExecution, error) {
+	rows, err := db.QueryContext(ctx, getUnfinishedChildWorkflows, arg.Namespace, arg.ParentExecutionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WorkflowExecution{}
+	for rows.Next() {
+		var i WorkflowExecution
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkflowName,
+			&i.Status,
+			&i.InputData,
+			&i.OutputData,
+			&i.ErrorMessage,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.MaxAttempts,
+			&i.RemainingAttempts,
+			&i.NextRetryAt,
+			&i.Namespace,
+			&i.TriggerType,
+			&i.TriggerSource,
+			&i.SleepUntil,
+			&i.TraceID,
+			&i.SpanID,
+			&i.ParentExecutionID,
+			&i.CancelRequestedAt,
+			&i.WorkflowVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getWorkflow = `-- name: GetWorkflow :one
-SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id FROM workflow_executions 
+SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id, parent_execution_id, cancel_requested_at, workflow_version FROM workflow_executions 
 WHERE id = ? AND namespace = ?
 `
 
@@ -640,10 +1035,41 @@ func (q *Queries) GetWorkflow(ctx context.Context, db DBTX, arg GetWorkflowParam
 		&i.SleepUntil,
 		&i.TraceID,
 		&i.SpanID,
+		&i.ParentExecutionID,
+		&i.CancelRequestedAt,
+		&i.WorkflowVersion,
 	)
 	return i, err
 }
 
+const getWorkflowNames = `-- name: GetWorkflowNames :many
+SELECT DISTINCT workflow_name FROM workflow_executions 
+WHERE namespace = ?
+`
+
+func (q *Queries) GetWorkflowNames(ctx context.Context, db DBTX, namespace string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, getWorkflowNames, namespace)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var workflow_name string
+		if err := rows.Scan(&workflow_name); err != nil {
+			return nil, err
+		}
+		items = append(items, workflow_name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const heartbeatLease = `-- name: HeartbeatLease :exec
 UPDATE leases 
 SET heartbeat_at = ?, expires_at = ?
@@ -667,6 +1093,197 @@ func (q *Queries) HeartbeatLease(ctx context.Context, db DBTX, arg HeartbeatLeas
 	return err
 }
 
+const listExpiredWorkflows = `-- name: ListExpiredWorkflows :many
+SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id, parent_execution_id, cancel_requested_at, workflow_version FROM workflow_executions 
+WHERE namespace = ? AND workflow_name = ? 
+  AND completed_at < ?
+  AND (
+    status IN ('completed', 'cancelled', 'terminated') 
+    OR (status = 'failed' AND next_retry_at IS NULL)
+  )
+ORDER BY completed_at ASC 
+LIMIT ?
+`
+
+type ListExpiredWorkflowsParams struct {
+	Namespace       string        `db:"namespace" json:"namespace"`
+	WorkflowName    string        `db:"workflow_name" json:"workflow_name"`
+	CompletedBefore sql.NullInt64 `db:"completed_before" json:"completed_before"`
+	Limit           int32         `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListExpiredWorkflows(ctx context.Context, db DBTX, arg ListExpiredWorkflowsParams) ([]WorkflowExecution, error) {
+	rows, err := db.QueryContext(ctx, listExpiredWorkflows,
+		arg.Namespace,
+		arg.WorkflowName,
+		arg.CompletedBefore,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WorkflowExecution{}
+	for rows.Next() {
+		var i WorkflowExecution
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkflowName,
+			&i.Status,
+			&i.InputData,
+			&i.OutputData,
+			&i.ErrorMessage,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.MaxAttempts,
+			&i.RemainingAttempts,
+			&i.NextRetryAt,
+			&i.Namespace,
+			&i.TriggerType,
+			&i.TriggerSource,
+			&i.SleepUntil,
+			&i.TraceID,
+			&i.SpanID,
+			&i.ParentExecutionID,
+			&i.CancelRequestedAt,
+			&i.WorkflowVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSteps = `-- name: ListSteps :many
+SELECT id, execution_id, step_name, status, output_data, error_message, started_at, completed_at, max_attempts, remaining_attempts, namespace FROM workflow_steps 
+WHERE namespace = ? AND execution_id = ?
+ORDER BY started_at ASC, id ASC
+`
+
+type ListStepsParams struct {
+	Namespace   string `db:"namespace" json:"namespace"`
+	ExecutionID string `db:"execution_id" json:"execution_id"`
+}
+
+func (q *Queries) ListSteps(ctx context.Context, db DBTX, arg ListStepsParams) ([]WorkflowStep, error) {
+	rows, err := db.QueryContext(ctx, listSteps, arg.Namespace, arg.ExecutionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WorkflowStep{}
+	for rows.Next() {
+		var i WorkflowStep
+		if err := rows.Scan(
+			&i.ID,
+			&i.ExecutionID,
+			&i.StepName,
+			&i.Status,
+			&i.OutputData,
+			&i.ErrorMessage,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.MaxAttempts,
+			&i.RemainingAttempts,
+			&i.Namespace,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkflows = `-- name: ListWorkflows :many
+SELECT id, workflow_name, status, input_data, output_data, error_message, created_at, started_at, completed_at, max_attempts, remaining_attempts, next_retry_at, namespace, trigger_type, trigger_source, sleep_until, trace_id, span_id, parent_execution_id, cancel_requested_at, workflow_version FROM workflow_executions 
+WHERE namespace = ? 
+  AND (? = '' OR status = ?) 
+  AND (? = '' OR workflow_name = ?) 
+  AND (? = '' OR (created_at, id) < (
+    SELECT c.created_at, c.id FROM workflow_executions c 
+    WHERE c.namespace = ? AND c.id = ?
+  ))
+ORDER BY created_at DESC, id DESC 
+LIMIT ?
+`
+
+type ListWorkflowsParams struct {
+	Namespace    string                   `db:"namespace" json:"namespace"`
+	Status       WorkflowExecutionsStatus `db:"status" json:"status"`
+	WorkflowName string                   `db:"workflow_name" json:"workflow_name"`
+	Cursor       string                   `db:"cursor" json:"cursor"`
+	Limit        int32                    `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListWorkflows(ctx context.Context, db DBTX, arg ListWorkflowsParams) ([]WorkflowExecution, error) {
+	rows, err := db.QueryContext(ctx, listWorkflows,
+		arg.Namespace,
+		arg.Status,
+		arg.Status,
+		arg.WorkflowName,
+		arg.WorkflowName,
+		arg.Cursor,
+		arg.Namespace,
+		arg.Cursor,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WorkflowExecution{}
+	for rows.Next() {
+		var i WorkflowExecution
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkflowName,
+			&i.Status,
+			&i.InputData,
+			&i.OutputData,
+			&i.ErrorMessage,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+			&i.MaxAttempts,
+			&i.RemainingAttempts,
+			&i.NextRetryAt,
+			&i.Namespace,
+			&i.TriggerType,
+			&i.TriggerSource,
+			&i.SleepUntil,
+			&i.TraceID,
+			&i.SpanID,
+			&i.ParentExecutionID,
+			&i.CancelRequestedAt,
+			&i.WorkflowVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const releaseLease = `-- name: ReleaseLease :exec
 DELETE FROM leases 
 WHERE resource_id = ? AND worker_id = ?
@@ -682,6 +1299,36 @@ func (q *Queries) ReleaseLease(ctx context.Context, db DBTX, arg ReleaseLeasePar
 	return err
 }
 
+const requestWorkflowCancellation = `-- name: RequestWorkflowCancellation :execrows
+UPDATE workflow_executions 
+SET cancel_requested_at = ?, error_message = ?
+WHERE id = ? AND namespace = ? AND cancel_requested_at IS NULL
+  AND (
+    status IN ('pending', 'running', 'sleeping') 
+    OR (status = 'failed' AND next_retry_at IS NOT NULL)
+  )
+`
+
+type RequestWorkflowCancellationParams struct {
+	CancelRequestedAt sql.NullInt64  `db:"cancel_requested_at" json:"cancel_requested_at"`
+	ErrorMessage      sql.NullString `db:"error_message" json:"error_message"`
+	ID                string         `db:"id" json:"id"`
+	Namespace         string         `db:"namespace" json:"namespace"`
+}
+
+func (q *Queries) RequestWorkflowCancellation(ctx context.Context, db DBTX, arg RequestWorkflowCancellationParams) (int64, error) {
+	result, err := db.ExecContext(ctx, requestWorkflowCancellation,
+		arg.CancelRequestedAt,
+		arg.ErrorMessage,
+		arg.ID,
+		arg.Namespace,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const resetOrphanedWorkflows = `-- name: ResetOrphanedWorkflows :exec
 UPDATE workflow_executions 
 SET status = 'pending' 
@@ -704,10 +1351,88 @@ func (q *Queries) ResetOrphanedWorkflows(ctx context.Context, db DBTX, arg Reset
 	return err
 }
 
+const resumeWorkflowForCancellation = `-- name: ResumeWorkflowForCancellation :execrows
+UPDATE workflow_executions 
+SET status = 'pending', next_retry_at = NULL, sleep_until = NULL
+WHERE id = ? AND namespace = ? AND cancel_requested_at IS NOT NULL
+  AND (
+    status IN ('pending', 'running', 'sleeping') 
+    OR (status = 'failed' AND next_retry_at IS NOT NULL)
+  )
+  AND id NOT IN (
+    SELECT resource_id FROM leases 
+    WHERE kind = 'workflow' AND expires_at > ?
+  )
+`
+
+type ResumeWorkflowForCancellationParams struct {
+	ID        string `db:"id" json:"id"`
+	Namespace string `db:"namespace" json:"namespace"`
+	ExpiresAt int64  `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) ResumeWorkflowForCancellation(ctx context.Context, db DBTX, arg ResumeWorkflowForCancellationParams) (int64, error) {
+	result, err := db.ExecContext(ctx, resumeWorkflowForCancellation,
+		arg.ID,
+		arg.Namespace,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const retryWorkflow = `-- name: RetryWorkflow :execrows
+UPDATE workflow_executions 
+SET status = 'pending', error_message = NULL, completed_at = NULL, next_retry_at = NULL, 
+    sleep_until = NULL, cancel_requested_at = NULL, remaining_attempts = max_attempts
+WHERE id = ? AND namespace = ?
+  AND (
+    status IN ('cancelled', 'terminated') 
+    OR (status = 'failed' AND next_retry_at IS NULL)
+  )
+  AND id NOT IN (
+    SELECT resource_id FROM leases 
+    WHERE kind = 'workflow' AND expires_at > ?
+  )
+`
+
+type RetryWorkflowParams struct {
+	ID        string `db:"id" json:"id"`
+	Namespace string `db:"namespace" json:"namespace"`
+	ExpiresAt int64  `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) RetryWorkflow(ctx context.Context, db DBTX, arg RetryWorkflowParams) (int64, error) {
+	result, err := db.ExecContext(ctx, retryWorkflow, arg.ID, arg.Namespace, arg.ExpiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setWorkflowVersion = `-- name: SetWorkflowVersion :exec
+UPDATE workflow_executions 
+SET workflow_version = ?
+WHERE id = ? AND namespace = ?
+`
+
+type SetWorkflowVersionParams struct {
+	WorkflowVersion int32  `db:"workflow_version" json:"workflow_version"`
+	ID              string `db:"id" json:"id"`
+	Namespace       string `db:"namespace" json:"namespace"`
+}
+
+func (q *Queries) SetWorkflowVersion(ctx context.Context, db DBTX, arg SetWorkflowVersionParams) error {
+	_, err := db.ExecContext(ctx, setWorkflowVersion, arg.WorkflowVersion, arg.ID, arg.Namespace)
+	return err
+}
+
 const sleepWorkflow = `-- name: SleepWorkflow :exec
 UPDATE workflow_executions 
 SET status = 'sleeping', sleep_until = ?
-WHERE id = ? AND namespace = ?
+WHERE id = ? AND namespace = ? AND status = 'running'
 `
 
 type SleepWorkflowParams struct {
@@ -721,6 +1446,68 @@ func (q *Queries) SleepWorkflow(ctx context.Context, db DBTX, arg SleepWorkflowP
 	return err
 }
 
+const sleepWorkflowUntilSignal = `-- name: SleepWorkflowUntilSignal :exec
+UPDATE workflow_executions 
+SET status = 'sleeping',
+    sleep_until = CASE WHEN EXISTS (
+        SELECT 1 FROM workflow_signals 
+        WHERE workflow_signals.namespace = workflow_executions.namespace 
+          AND workflow_signals.execution_id = workflow_executions.id 
+          AND workflow_signals.signal_name = ? 
+          AND workflow_signals.consumed_at IS NULL
+    ) THEN ? ELSE ? END
+WHERE id = ? AND namespace = ? AND status = 'running'
+`
+
+type SleepWorkflowUntilSignalParams struct {
+	SignalName string `db:"signal_name" json:"signal_name"`
+	Now        int64  `db:"now" json:"now"`
+	TimeoutAt  int64  `db:"timeout_at" json:"timeout_at"`
+	ID         string `db:"id" json:"id"`
+	Namespace  string `db:"namespace" json:"namespace"`
+}
+
+func (q *Queries) SleepWorkflowUntilSignal(ctx context.Context, db DBTX, arg SleepWorkflowUntilSignalParams) error {
+	_, err := db.ExecContext(ctx, sleepWorkflowUntilSignal,
+		arg.SignalName,
+		arg.Now,
+		arg.TimeoutAt,
+		arg.ID,
+		arg.Namespace,
+	)
+	return err
+}
+
+const terminateWorkflow = `-- name: TerminateWorkflow :execrows
+UPDATE workflow_executions 
+SET status = 'terminated', error_message = ?, completed_at = ?, next_retry_at = NULL, sleep_until = NULL
+WHERE id = ? AND namespace = ?
+  AND (
+    status IN ('pending', 'running', 'sleeping') 
+    OR (status = 'failed' AND next_retry_at IS NOT NULL)
+  )
+`
+
+type TerminateWorkflowParams struct {
+	ErrorMessage sql.NullString `db:"error_message" json:"error_message"`
+	CompletedAt  sql.NullInt64  `db:"completed_at" json:"completed_at"`
+	ID           string         `db:"id" json:"id"`
+	Namespace    string         `db:"namespace" json:"namespace"`
+}
+
+func (q *Queries) TerminateWorkflow(ctx context.Context, db DBTX, arg TerminateWorkflowParams) (int64, error) {
+	result, err := db.ExecContext(ctx, terminateWorkflow,
+		arg.ErrorMessage,
+		arg.CompletedAt,
+		arg.ID,
+		arg.Namespace,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateCronJob = `-- name: UpdateCronJob :exec
 UPDATE cron_jobs 
 SET cron_spec = ?, workflow_name = ?, enabled = ?, updated_at = ?, next_run_at = ?
@@ -960,3 +1747,20 @@ func (q *Queries) UpdateWorkflowToRunning(ctx context.Context, db DBTX, arg Upda
 	)
 	return err
 }
+
+const wakeWorkflow = `-- name: WakeWorkflow :exec
+UPDATE workflow_executions 
+SET sleep_until = ?
+WHERE id = ? AND namespace = ? AND status = 'sleeping'
+`
+
+type WakeWorkflowParams struct {
+	SleepUntil sql.NullInt64 `db:"sleep_until" json:"sleep_until"`
+	ID         string        `db:"id" json:"id"`
+	Namespace  string        `db:"namespace" json:"namespace"`
+}
+
+func (q *Queries) WakeWorkflow(ctx context.Context, db DBTX, arg WakeWorkflowParams) error {
+	_, err := db.ExecContext(ctx, wakeWorkflow, arg.SleepUntil, arg.ID, arg.Namespace)
+	return err
+}
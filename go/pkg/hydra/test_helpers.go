@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -19,15 +21,7 @@ import (
 func newTestEngineWithClock(t *testing.T, clk clock.Clock) *Engine {
 	t.Helper()
 
-	// Use testcontainers for MySQL
-	mysqlCfg := containers.MySQL(t)
-	mysqlCfg.DBName = "hydra"
-	hydraDsn := mysqlCfg.FormatDSN()
-
-	// Load the hydra schema into the database
-	db, err := sql.Open("mysql", hydraDsn)
-	require.NoError(t, err)
-	defer db.Close()
+	driver, hydraDsn := testBackend(t)
 
 	// Create a unique namespace for this test to avoid data pollution
 	testNamespace := fmt.Sprintf("test_%s_%s", t.Name(), uid.New(uid.Prefix("test")))
@@ -35,6 +29,7 @@ func newTestEngineWithClock(t *testing.T, clk clock.Clock) *Engine {
 	// Create the engine with the properly configured database
 	engine, err := New(Config{
 		DSN:        hydraDsn,
+		Driver:     driver,
 		Namespace:  testNamespace,
 		Clock:      clk,
 		Logger:     logging.NewNoop(),
@@ -47,6 +42,56 @@ func newTestEngineWithClock(t *testing.T, clk clock.Clock) *Engine {
 	return engine
 }
 
+// testBackend returns the driver and DSN of the storage backend the tests
+// run against, so every backend runs the same suites. The HYDRA_TEST_DRIVER
+// environment variable selects the backend:
+//
+//   - unset or "mysql": a MySQL container
+//   - "sqlite": a new database file per test, requires the hydra_sqlite
+//     build tag
+//   - "pgx": the database at HYDRA_TEST_POSTGRES_DSN, requires the
+//     hydra_postgres build tag
+func testBackend(t *testing.T) (string, string) {
+	t.Helper()
+
+	driver := os.Getenv("HYDRA_TEST_DRIVER")
+	switch driver {
+	case "", "mysql":
+		// Use testcontainers for MySQL, which loads the hydra schema
+		mysqlCfg := containers.MySQL(t)
+		mysqlCfg.DBName = "hydra"
+		return "mysql", mysqlCfg.FormatDSN()
+	case "sqlite":
+		dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_txlock=immediate",
+			filepath.Join(t.TempDir(), "hydra.db"))
+		loadSchema(t, driver, dsn)
+		return driver, dsn
+	case "pgx":
+		dsn := os.Getenv("HYDRA_TEST_POSTGRES_DSN")
+		require.NotEmpty(t, dsn, "HYDRA_TEST_POSTGRES_DSN must be set to test against postgres")
+		loadSchema(t, driver, dsn)
+		return driver, dsn
+	default:
+		t.Fatalf("unsupported HYDRA_TEST_DRIVER %q", driver)
+		return "", ""
+	}
+}
+
+// loadSchema creates the hydra tables in the database.
+func loadSchema(t *testing.T, driver, dsn string) {
+	t.Helper()
+
+	dialect, err := store.DialectForDriver(driver)
+	require.NoError(t, err)
+
+	db, err := sql.Open(driver, dsn)
+	require.NoError(t, err, "the %s driver is only registered with its build tag", driver)
+	defer db.Close()
+
+	_, err = db.ExecContext(context.Background(), store.Schema(dialect))
+	require.NoError(t, err)
+}
+
 // newTestEngine creates a test engine with default clock
 func newTestEngine(t *testing.T) *Engine {
 	return newTestEngineWithClock(t, clock.New())
@@ -68,7 +113,9 @@ func waitForWorkflowCompletion(t *testing.T, engine *Engine, workflowID string,
 			return false
 		}
 		return workflow.Status == store.WorkflowExecutionsStatusCompleted ||
-			workflow.Status == store.WorkflowExecutionsStatusFailed
+			workflow.Status == store.WorkflowExecutionsStatusFailed ||
+			workflow.Status == store.WorkflowExecutionsStatusCancelled ||
+			workflow.Status == store.WorkflowExecutionsStatusTerminated
 	}, timeout, 100*time.Millisecond, "Workflow should complete within timeout")
 
 	require.NoError(t, err)
@@ -0,0 +1,160 @@
+package hydra
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+)
+
+// DefaultVersion is returned by Version for executions that passed the
+// change before it was introduced.
+const DefaultVersion = -1
+
+// ErrUnsupportedVersion is returned by Version when the execution recorded a
+// version outside of the supported range.
+var ErrUnsupportedVersion = errors.New("unsupported workflow version")
+
+// versionStepPrefix prefixes the internal steps that record the versions
+// returned by Version.
+const versionStepPrefix = "version-"
+
+// VersionedWorkflow is implemented by workflows that declare the version of
+// their code.
+//
+// The version is stored on an execution when it runs for the first time,
+// and workers whose workflow has an older version do not run it. Bump the
+// version together with every Version change, so a rolling deployment never
+// runs a new execution with the old code.
+type VersionedWorkflow interface {
+	Version() int32
+}
+
+// workflowVersion returns the version of a registered workflow, zero if it
+// does not implement VersionedWorkflow.
+func workflowVersion(wf GenericWorkflow) int32 {
+	versioned, ok := wf.(interface{ version() int32 })
+	if !ok {
+		return 0
+	}
+	return versioned.version()
+}
+
+// Version returns which version of a change the execution uses, so the step
+// sequence of a workflow can change without breaking executions that are
+// in flight.
+//
+// The first call records the version in the step history of the execution,
+// every replay returns the same version:
+//   - Executions that reach the change for the first time get maxSupported.
+//   - Executions that already ran past the change with the code before it
+//     get DefaultVersion.
+//
+// Once no execution uses an old version anymore, raise minSupported and
+// remove the code of the old version. Executions that recorded a version
+// outside of [minSupported, maxSupported] fail with ErrUnsupportedVersion.
+//
+// Example usage:
+//
+//	v, err := hydra.Version(ctx, "verify-dns", hydra.DefaultVersion, 1)
+//	if err != nil {
+//	    return err
+//	}
+//	if v == 1 {
+//	    err = hydra.StepVoid(ctx, "verify-dns", verifyDNS)
+//	    if err != nil {
+//	        return err
+//	    }
+//	}
+//
+// Each change needs a changeID that is unique within the workflow.
+func Version(ctx WorkflowContext, changeID string, minSupported, maxSupported int) (int, error) {
+	wctx, ok := ctx.(*workflowContext)
+	if !ok {
+		return 0, fmt.Errorf("invalid workflow context")
+	}
+
+	if minSupported < DefaultVersion || minSupported > maxSupported {
+		return 0, fmt.Errorf("invalid version range [%d, %d]", minSupported, maxSupported)
+	}
+
+	stepName := versionStepPrefix + changeID
+	wctx.reach(stepName)
+
+	recorded, err := store.Query.GetCompletedStep(wctx.ctx, wctx.db, store.GetCompletedStepParams{
+		Namespace:   wctx.namespace,
+		ExecutionID: wctx.ExecutionID(),
+		StepName:    stepName,
+	})
+	if err == nil {
+		version, parseErr := strconv.Atoi(string(recorded.OutputData))
+		if parseErr != nil {
+			return 0, fmt.Errorf("failed to parse version of %s: %w", changeID, parseErr)
+		}
+		return checkVersion(changeID, version, minSupported, maxSupported)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to load version of %s: %w", changeID, err)
+	}
+
+	err = wctx.checkCancelled()
+	if err != nil {
+		return 0, err
+	}
+
+	replaying, err := wctx.replaying()
+	if err != nil {
+		return 0, err
+	}
+
+	version := maxSupported
+	if replaying {
+		version = DefaultVersion
+	}
+
+	err = wctx.createStep(wctx.db, stepName, store.WorkflowStepsStatusCompleted, []byte(strconv.Itoa(version)), "", time.Now().UnixMilli())
+	if err != nil {
+		return 0, fmt.Errorf("failed to record version of %s: %w", changeID, err)
+	}
+
+	return checkVersion(changeID, version, minSupported, maxSupported)
+}
+
+func checkVersion(changeID string, version, minSupported, maxSupported int) (int, error) {
+	if version < minSupported || version > maxSupported {
+		return 0, fmt.Errorf("%w: %s is at version %d, supported are [%d, %d]",
+			ErrUnsupportedVersion, changeID, version, minSupported, maxSupported)
+	}
+	return version, nil
+}
+
+// reach marks a step as reached by the current run of the workflow.
+func (w *workflowContext) reach(stepName string) {
+	if w.reached == nil {
+		w.reached = make(map[string]struct{})
+	}
+	w.reached[stepName] = struct{}{}
+}
+
+// replaying reports whether the execution recorded steps that the current
+// run did not reach yet, which means an earlier run got further.
+func (w *workflowContext) replaying() (bool, error) {
+	steps, err := store.Query.ListSteps(w.ctx, w.db, store.ListStepsParams{
+		Namespace:   w.namespace,
+		ExecutionID: w.executionID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list steps: %w", err)
+	}
+
+	for _, step := range steps {
+		if _, ok := w.reached[step.StepName]; !ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,203 @@
+package hydra
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/hydra/store"
+)
+
+// upgradingWorkflow changes its step sequence once useVersion is set, the
+// old code runs "configure" and the new code "configure-v2".
+type upgradingWorkflow struct {
+	version    int32
+	useVersion bool
+	failOnce   string
+
+	mu       sync.Mutex
+	versions []int
+	ran      []string
+}
+
+func (w *upgradingWorkflow) Name() string {
+	return "upgrading-workflow"
+}
+
+func (w *upgradingWorkflow) Version() int32 {
+	return w.version
+}
+
+func (w *upgradingWorkflow) run(name string) func(context.Context) error {
+	return func(context.Context) error {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.failOnce == name {
+			w.failOnce = ""
+			return fmt.Errorf("%s failed", name)
+		}
+		w.ran = append(w.ran, name)
+		return nil
+	}
+}
+
+func (w *upgradingWorkflow) Run(ctx WorkflowContext, req struct{}) error {
+	err := StepVoid(ctx, "create", w.run("create"))
+	if err != nil {
+		return err
+	}
+
+	if w.useVersion {
+		v, versionErr := Version(ctx, "configure", DefaultVersion, 1)
+		if versionErr != nil {
+			return versionErr
+		}
+
+		w.mu.Lock()
+		w.versions = append(w.versions, v)
+		w.mu.Unlock()
+
+		if v == 1 {
+			return StepVoid(ctx, "configure-v2", w.run("configure-v2"))
+		}
+	}
+
+	return StepVoid(ctx, "configure", w.run("configure"))
+}
+
+func (w *upgradingWorkflow) state() ([]int, []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]int{}, w.versions...), append([]string{}, w.ran...)
+}
+
+func startUpgradingWorker(t *testing.T, engine *Engine, workflow *upgradingWorkflow) Worker {
+	t.Helper()
+
+	worker, err := NewWorker(engine, WorkerConfig{
+		Concurrency:  1,
+		PollInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, RegisterWorkflow(worker, workflow))
+	require.NoError(t, worker.Start(context.Background()))
+	t.Cleanup(func() {
+		_ = worker.Shutdown(context.Background())
+	})
+
+	return worker
+}
+
+func TestVersionOfNewExecution(t *testing.T) {
+	engine := newTestEngine(t)
+	workflow := &upgradingWorkflow{useVersion: true}
+	startUpgradingWorker(t, engine, workflow)
+
+	ctx := context.Background()
+	executionID, err := engine.StartWorkflow(ctx, workflow.Name(), struct{}{})
+	require.NoError(t, err)
+
+	final := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, final.Status)
+
+	versions, ran := workflow.state()
+	require.Equal(t, []int{1}, versions)
+	require.Equal(t, []string{"create", "configure-v2"}, ran)
+
+	steps, err := engine.ListSteps(ctx, executionID)
+	require.NoError(t, err)
+	recorded := map[string]string{}
+	for _, step := range steps {
+		recorded[step.StepName] = string(step.OutputData)
+	}
+	require.Equal(t, "1", recorded["version-configure"])
+}
+
+// TestVersionOfInFlightExecution guarantees that an execution which ran past
+// a change with the old code keeps running the old code after an upgrade.
+func TestVersionOfInFlightExecution(t *testing.T) {
+	engine := newTestEngine(t)
+	ctx := context.Background()
+
+	// The old code fails in "configure", the execution is retried later
+	old := &upgradingWorkflow{failOnce: "configure"}
+	worker := startUpgradingWorker(t, engine, old)
+
+	executionID, err := engine.StartWorkflow(ctx, old.Name(), struct{}{}, WithMaxAttempts(3))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		wf, getErr := engine.GetExecution(ctx, executionID)
+		return getErr == nil && wf.Status == store.WorkflowExecutionsStatusFailed
+	}, 5*time.Second, 50*time.Millisecond)
+	require.NoError(t, worker.Shutdown(ctx))
+
+	upgraded := &upgradingWorkflow{useVersion: true}
+	startUpgradingWorker(t, engine, upgraded)
+
+	// The execution stays failed until its retry is due, so wait for the
+	// retry to complete rather than for any final status
+	require.Eventually(t, func() bool {
+		wf, getErr := engine.GetExecution(ctx, executionID)
+		return getErr == nil && wf.Status == store.WorkflowExecutionsStatusCompleted
+	}, 10*time.Second, 50*time.Millisecond)
+
+	versions, ran := upgraded.state()
+	require.Equal(t, []int{DefaultVersion}, versions)
+	require.Equal(t, []string{"configure"}, ran, "the in-flight execution must keep the old step sequence")
+}
+
+func TestVersionOutOfRange(t *testing.T) {
+	v, err := checkVersion("configure", 1, DefaultVersion, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+
+	_, err = checkVersion("configure", DefaultVersion, 0, 1)
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = checkVersion("configure", 2, 0, 1)
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+// TestWorkflowVersionPinning guarantees that executions are pinned to the
+// workflow version of their first run, and older workers do not run them.
+func TestWorkflowVersionPinning(t *testing.T) {
+	engine := newTestEngine(t)
+	ctx := context.Background()
+
+	executionID, err := engine.StartWorkflow(ctx, "upgrading-workflow", struct{}{})
+	require.NoError(t, err)
+
+	err = store.Query.SetWorkflowVersion(ctx, engine.GetDB(), store.SetWorkflowVersionParams{
+		WorkflowVersion: 2,
+		ID:              executionID,
+		Namespace:       engine.GetNamespace(),
+	})
+	require.NoError(t, err)
+
+	outdated := &upgradingWorkflow{version: 1}
+	worker := startUpgradingWorker(t, engine, outdated)
+
+	require.Never(t, func() bool {
+		_, ran := outdated.state()
+		return len(ran) > 0
+	}, time.Second, 50*time.Millisecond, "an outdated worker must not run the execution")
+	require.NoError(t, worker.Shutdown(ctx))
+
+	current := &upgradingWorkflow{version: 2}
+	startUpgradingWorker(t, engine, current)
+
+	final := waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, store.WorkflowExecutionsStatusCompleted, final.Status)
+
+	// New executions are pinned on their first run
+	executionID, err = engine.StartWorkflow(ctx, current.Name(), struct{}{})
+	require.NoError(t, err)
+
+	final = waitForWorkflowCompletion(t, engine, executionID, 5*time.Second)
+	require.Equal(t, int32(2), final.WorkflowVersion)
+}
@@ -3,6 +3,7 @@ package hydra
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
@@ -456,6 +457,7 @@ func (w *worker) executeWorkflow(ctx context.Context, e *store.WorkflowExecution
 		marshaller:      w.engine.marshaller,
 		stepTimeout:     5 * time.Minute, // Default step timeout
 		stepMaxAttempts: 3,               // Default step max attempts
+		result:          []byte{},
 	}
 
 	err = wf.Run(wctx, payload)
@@ -463,15 +465,41 @@ func (w *worker) executeWorkflow(ctx context.Context, e *store.WorkflowExecution
 	if err != nil {
 		tracing.RecordError(span, err)
 
+		if errors.Is(err, ErrExecutionCancelled) {
+			span.SetAttributes(attribute.String("hydra.workflow.status", "cancelled"))
+			w.cancelWorkflow(ctx, wctx, e, startTime)
+			return
+		}
+
 		if suspendErr, ok := err.(*WorkflowSuspendedError); ok {
+			// Don't suspend an execution that was cancelled in the meantime
+			if errors.Is(wctx.checkCancelled(), ErrExecutionCancelled) {
+				span.SetAttributes(attribute.String("hydra.workflow.status", "cancelled"))
+				w.cancelWorkflow(ctx, wctx, e, startTime)
+				return
+			}
+
 			span.SetAttributes(attribute.String("hydra.workflow.status", "suspended"))
 
 			// Use simple sleep workflow since we have the lease
-			if sleepErr := store.Query.SleepWorkflow(ctx, w.engine.GetDB(), store.SleepWorkflowParams{
-				SleepUntil: sql.NullInt64{Int64: suspendErr.ResumeTime, Valid: true},
-				ID:         e.ID,
-				Namespace:  e.Namespace,
-			}); sleepErr != nil {
+			var sleepErr error
+			if suspendErr.SignalName != "" {
+				// Wake up immediately if the signal arrived while we were running
+				sleepErr = store.Query.SleepWorkflowUntilSignal(ctx, w.engine.GetDB(), store.SleepWorkflowUntilSignalParams{
+					SignalName: suspendErr.SignalName,
+					Now:        time.Now().UnixMilli(),
+					TimeoutAt:  suspendErr.ResumeTime,
+					ID:         e.ID,
+					Namespace:  e.Namespace,
+				})
+			} else {
+				sleepErr = store.Query.SleepWorkflow(ctx, w.engine.GetDB(), store.SleepWorkflowParams{
+					SleepUntil: sql.NullInt64{Int64: suspendErr.ResumeTime, Valid: true},
+					ID:         e.ID,
+					Namespace:  e.Namespace,
+				})
+			}
+			if sleepErr != nil {
 				w.engine.logger.Error("Failed to suspend workflow",
 					"workflow_id", e.ID,
 					"workflow_name", e.WorkflowName,
@@ -480,6 +508,9 @@ func (w *worker) executeWorkflow(ctx context.Context, e *store.WorkflowExecution
 					"error", sleepErr.Error(),
 				)
 			}
+			if sleepErr == nil && suspendErr.ready != nil {
+				w.wakeIfReady(ctx, e, suspendErr.ready)
+			}
 			metrics.SleepsStartedTotal.WithLabelValues(e.Namespace, e.WorkflowName).Inc()
 			return
 		}
@@ -487,6 +518,19 @@ func (w *worker) executeWorkflow(ctx context.Context, e *store.WorkflowExecution
 		isFinal := e.RemainingAttempts <= 1
 		span.SetAttributes(attribute.String("hydra.workflow.status", "failed"))
 
+		// Undo the completed steps before the execution fails for good
+		if isFinal {
+			if compensateErr := wctx.compensate(); compensateErr != nil {
+				w.engine.logger.Error("Failed to compensate workflow",
+					"workflow_id", e.ID,
+					"workflow_name", e.WorkflowName,
+					"namespace", e.Namespace,
+					"error", compensateErr.Error(),
+				)
+				err = fmt.Errorf("%w; %w", err, compensateErr)
+			}
+		}
+
 		// Use lease-validated failure to ensure correctness
 		finalFailureTime := w.clock.Now().UnixMilli()
 		var result sql.Result
@@ -557,6 +601,11 @@ func (w *worker) executeWorkflow(ctx context.Context, e *store.WorkflowExecution
 			}
 		}
 
+		if isFinal {
+			w.failChildren(ctx, e)
+			w.engine.wakeParent(ctx, *e)
+		}
+
 		if !isFinal {
 			metrics.WorkflowsRetriedTotal.WithLabelValues(e.Namespace, e.WorkflowName, fmt.Sprintf("%d", e.MaxAttempts-e.RemainingAttempts+1)).Inc()
 		}
@@ -580,7 +629,7 @@ func (w *worker) executeWorkflow(ctx context.Context, e *store.WorkflowExecution
 		    AND worker_id = ? AND expires_at > ?
 		  )`,
 		sql.NullInt64{Int64: now, Valid: true},
-		[]byte{}, // No output data for now
+		wctx.result,
 		e.ID,
 		e.Namespace,
 		e.ID,              // resource_id for lease check
@@ -615,10 +664,105 @@ func (w *worker) executeWorkflow(ctx context.Context, e *store.WorkflowExecution
 		return
 	}
 
+	w.engine.wakeParent(ctx, *e)
+
 	metrics.ObserveWorkflowDuration(e.Namespace, e.WorkflowName, "completed", startTime)
 	metrics.WorkflowsCompletedTotal.WithLabelValues(e.Namespace, e.WorkflowName, "completed").Inc()
 }
 
+// cancelWorkflow compensates the completed steps of a workflow that stopped
+// because its cancellation was requested, marks it as cancelled and cancels
+// its children.
+func (w *worker) cancelWorkflow(ctx context.Context, wctx *workflowContext, e *store.WorkflowExecution, startTime time.Time) {
+	err := wctx.compensate()
+	if err != nil {
+		w.engine.logger.Error("Failed to compensate workflow",
+			"workflow_id", e.ID,
+			"workflow_name", e.WorkflowName,
+			"namespace", e.Namespace,
+			"error", err.Error(),
+		)
+	}
+
+	now := w.clock.Now().UnixMilli()
+	rowsAffected, err := store.Query.CancelWorkflowWithLease(ctx, w.engine.GetDB(), store.CancelWorkflowWithLeaseParams{
+		CompletedAt: sql.NullInt64{Int64: now, Valid: true},
+		ID:          e.ID,
+		Namespace:   e.Namespace,
+		ResourceID:  e.ID,
+		WorkerID:    w.config.WorkerID,
+		ExpiresAt:   now,
+	})
+	if err != nil {
+		w.engine.logger.Error("Failed to mark workflow as cancelled",
+			"workflow_id", e.ID,
+			"workflow_name", e.WorkflowName,
+			"namespace", e.Namespace,
+			"error", err.Error(),
+		)
+		return
+	}
+	if rowsAffected == 0 {
+		w.engine.logger.Warn("Workflow cancellation failed: lease expired or invalid",
+			"workflow_id", e.ID,
+			"worker_id", w.config.WorkerID,
+		)
+		return
+	}
+
+	err = w.engine.cancelChildren(ctx, e.ID, fmt.Sprintf("parent workflow %s was cancelled", e.ID))
+	if err != nil {
+		w.engine.logger.Error("Failed to cancel child workflows",
+			"workflow_id", e.ID,
+			"error", err.Error(),
+		)
+	}
+	w.engine.wakeParent(ctx, *e)
+
+	metrics.ObserveWorkflowDuration(e.Namespace, e.WorkflowName, "cancelled", startTime)
+	metrics.WorkflowsCompletedTotal.WithLabelValues(e.Namespace, e.WorkflowName, "cancelled").Inc()
+}
+
+// failChildren cancels all descendants of a permanently failed workflow,
+// including the ones currently held by a worker.
+func (w *worker) failChildren(ctx context.Context, e *store.WorkflowExecution) {
+	err := w.engine.cancelChildren(ctx, e.ID, fmt.Sprintf("parent workflow %s failed", e.ID))
+	if err != nil {
+		w.engine.logger.Error("Failed to cancel child workflows",
+			"workflow_id", e.ID,
+			"error", err.Error(),
+		)
+	}
+}
+
+// wakeIfReady wakes up a workflow that just went to sleep if what it was
+// waiting for already happened in the meantime.
+func (w *worker) wakeIfReady(ctx context.Context, e *store.WorkflowExecution, ready func(context.Context) (bool, error)) {
+	ok, err := ready(ctx)
+	if err != nil {
+		w.engine.logger.Warn("Failed to check whether suspended workflow is ready",
+			"workflow_id", e.ID,
+			"error", err.Error(),
+		)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	err = store.Query.WakeWorkflow(ctx, w.engine.GetDB(), store.WakeWorkflowParams{
+		SleepUntil: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		ID:         e.ID,
+		Namespace:  e.Namespace,
+	})
+	if err != nil {
+		w.engine.logger.Error("Failed to wake workflow",
+			"workflow_id", e.ID,
+			"error", err.Error(),
+		)
+	}
+}
+
 func (w *worker) sendHeartbeats(ctx context.Context) {
 	defer w.wg.Done()
 
@@ -933,18 +1077,38 @@ func (w *worker) acquireWorkflowLease(ctx context.Context, workflowID, workerID
 		return fmt.Errorf("workflow still sleeping")
 	}
 
-	// Try to create the lease
-	err = store.Query.CreateLease(ctx, tx, store.CreateLeaseParams{
-		ResourceID:  workflowID,
-		Kind:        store.LeasesKindWorkflow,
-		Namespace:   w.engine.namespace,
-		WorkerID:    workerID,
-		AcquiredAt:  now,
-		ExpiresAt:   expiresAt,
-		HeartbeatAt: now,
+	// Executions only run on workers with the version of the workflow that
+	// first ran them, or a newer one
+	version := workflowVersion(w.workflows[workflow.WorkflowName])
+	if workflow.WorkflowVersion > version {
+		return fmt.Errorf("workflow requires version %d, worker runs version %d", workflow.WorkflowVersion, version)
+	}
+
+	// Take over the lease if it exists, otherwise create it. Checking first
+	// keeps a failing insert from aborting the transaction on Postgres.
+	_, err = store.Query.GetLease(ctx, tx, store.GetLeaseParams{
+		ResourceID: workflowID,
+		Kind:       store.LeasesKindWorkflow,
 	})
-	if err != nil {
-		// If lease creation failed, try to take over ONLY expired leases
+	switch {
+	case db.IsNotFound(err):
+		err = store.Query.CreateLease(ctx, tx, store.CreateLeaseParams{
+			ResourceID:  workflowID,
+			Kind:        store.LeasesKindWorkflow,
+			Namespace:   w.engine.namespace,
+			WorkerID:    workerID,
+			AcquiredAt:  now,
+			ExpiresAt:   expiresAt,
+			HeartbeatAt: now,
+		})
+		if err != nil {
+			// Another worker created the lease concurrently
+			return fmt.Errorf("workflow is already leased by another worker")
+		}
+	case err != nil:
+		return fmt.Errorf("failed to load lease: %w", err)
+	default:
+		// Take over ONLY expired leases
 		leaseResult, leaseErr := tx.ExecContext(ctx, `
 			UPDATE leases
 			SET worker_id = ?, acquired_at = ?, expires_at = ?, heartbeat_at = ?
@@ -977,6 +1141,18 @@ func (w *worker) acquireWorkflowLease(ctx context.Context, workflowID, workerID
 		return fmt.Errorf("failed to update workflow status: %w", err)
 	}
 
+	// Pin the execution to the version of its first run
+	if !workflow.StartedAt.Valid && workflow.WorkflowVersion != version {
+		err = store.Query.SetWorkflowVersion(ctx, tx, store.SetWorkflowVersionParams{
+			WorkflowVersion: version,
+			ID:              workflowID,
+			Namespace:       w.engine.namespace,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set workflow version: %w", err)
+		}
+	}
+
 	// Commit the transaction
 	return tx.Commit()
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/unkeyed/unkey/go/pkg/hydra/store"
 	"github.com/unkeyed/unkey/go/pkg/otel/tracing"
+	"github.com/unkeyed/unkey/go/pkg/uid"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -101,10 +102,19 @@ type workflowContext struct {
 	workflowName    string
 	namespace       string
 	workerID        string
-	db              *sql.DB
+	db              *store.DB
 	marshaller      Marshaller
 	stepTimeout     time.Duration
 	stepMaxAttempts int32
+	compensations   []compensation
+	compensating    bool
+
+	// result is stored as the execution's output once it completes, see
+	// SetResult
+	result []byte
+
+	// reached holds the steps reached by the current run, see Version
+	reached map[string]struct{}
 }
 
 func (w *workflowContext) Context() context.Context {
@@ -145,6 +155,75 @@ func (w *workflowContext) markStepFailed(stepName string, errorMsg string) error
 	})
 }
 
+// checkCancelled returns ErrExecutionCancelled if the cancellation of the
+// execution was requested with Engine.Cancel.
+func (w *workflowContext) checkCancelled() error {
+	workflow, err := store.Query.GetWorkflow(w.ctx, w.db, store.GetWorkflowParams{
+		ID:        w.executionID,
+		Namespace: w.namespace,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load workflow execution: %w", err)
+	}
+
+	if workflow.CancelRequestedAt.Valid {
+		return ErrExecutionCancelled
+	}
+
+	return nil
+}
+
+// createStep records an internal step with the given status, only if the
+// worker still holds the workflow's lease. Terminal steps are completed
+// immediately.
+func (w *workflowContext) createStep(db store.DBTX, stepName string, status store.WorkflowStepsStatus, outputData []byte, errorMsg string, now int64) error {
+	completedAt := sql.NullInt64{Int64: 0, Valid: false}
+	if status == store.WorkflowStepsStatusCompleted || status == store.WorkflowStepsStatusFailed {
+		completedAt = sql.NullInt64{Int64: now, Valid: true}
+	}
+
+	result, err := db.ExecContext(w.ctx, `
+		INSERT INTO workflow_steps (
+		    id, execution_id, step_name, status, output_data, error_message,
+		    started_at, completed_at, max_attempts, remaining_attempts, namespace
+		)
+		SELECT ?, ?, ?, ?, ?, ?,
+		       ?, ?, ?, ?, ?
+		WHERE EXISTS (
+		    SELECT 1 FROM leases
+		    WHERE resource_id = ? AND kind = 'workflow'
+		    AND worker_id = ? AND expires_at > ?
+		)`,
+		uid.New(uid.StepPrefix),
+		w.executionID,
+		stepName,
+		status,
+		outputData,
+		sql.NullString{String: errorMsg, Valid: errorMsg != ""},
+		sql.NullInt64{Int64: now, Valid: true},
+		completedAt,
+		1, // Internal steps don't need retries
+		1,
+		w.namespace,
+		w.executionID, // resource_id for lease check
+		w.workerID,    // worker_id for lease check
+		now,           // expires_at check
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create step %q: %w", stepName, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check step creation result: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("step %q creation failed: lease expired or invalid", stepName)
+	}
+
+	return nil
+}
+
 // RegisterWorkflow registers a typed workflow with a worker.
 //
 // This function associates a workflow implementation with a worker so that
@@ -210,6 +289,14 @@ func (w *workflowWrapper[TReq]) Name() string {
 	return w.wrapped.Name()
 }
 
+func (w *workflowWrapper[TReq]) version() int32 {
+	versioned, ok := w.wrapped.(VersionedWorkflow)
+	if !ok {
+		return 0
+	}
+	return versioned.Version()
+}
+
 func (w *workflowWrapper[TReq]) Run(ctx WorkflowContext, req any) error {
 	wctx, ok := ctx.(*workflowContext)
 	if !ok {
@@ -269,6 +356,25 @@ type WorkflowConfig struct {
 
 	TriggerType   store.WorkflowExecutionsTriggerType
 	TriggerSource *string
+
+	IdempotencyKey string
+}
+
+// newWorkflowConfig returns the default workflow configuration with opts applied
+func newWorkflowConfig(opts ...WorkflowOption) *WorkflowConfig {
+	config := &WorkflowConfig{
+		MaxAttempts:     3, // Default to 3 attempts total (1 initial + 2 retries)
+		TimeoutDuration: 1 * time.Hour,
+		RetryBackoff:    1 * time.Second,
+		TriggerType:     store.WorkflowExecutionsTriggerTypeApi, // Default trigger type
+		TriggerSource:   nil,
+		IdempotencyKey:  "",
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
 }
 
 // WithMaxAttempts sets the maximum number of retry attempts for a workflow
@@ -300,11 +406,30 @@ func WithTrigger(triggerType store.WorkflowExecutionsTriggerType, triggerSource
 	}
 }
 
+// WithIdempotencyKey deduplicates workflow starts. Starting the same workflow
+// again with the same key returns the ID of the existing execution instead of
+// creating a new one, until the key expires after the engine's
+// IdempotencyKeyTTL.
+func WithIdempotencyKey(key string) WorkflowOption {
+	return func(c *WorkflowConfig) {
+		c.IdempotencyKey = key
+	}
+}
+
 // WorkflowSuspendedError represents an error that suspends workflow execution until a specific time
 type WorkflowSuspendedError struct {
 	Reason string
 
 	ResumeTime int64
+
+	// SignalName is set when the workflow waits for a signal, which wakes it
+	// up before ResumeTime.
+	SignalName string
+
+	// ready reports whether the workflow can resume right away. It is checked
+	// after the workflow went to sleep, to catch wake ups that happened while
+	// it was still running.
+	ready func(context.Context) (bool, error)
 }
 
 func (e *WorkflowSuspendedError) Error() string {
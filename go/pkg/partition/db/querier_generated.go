@@ -27,6 +27,10 @@ type Querier interface {
 	//
 	//  SELECT id, deployment_id, metal_host_id, region, private_ip, port, cpu_millicores, memory_mb, status, health_status, last_heartbeat FROM vms WHERE id = ?
 	FindVMById(ctx context.Context, db DBTX, id string) (Vm, error)
+	//FindVMsByIds
+	//
+	//  SELECT id, deployment_id, metal_host_id, region, private_ip, port, cpu_millicores, memory_mb, status, health_status, last_heartbeat FROM vms WHERE id IN (/*SLICE:ids*/?)
+	FindVMsByIds(ctx context.Context, db DBTX, ids []string) ([]Vm, error)
 	//InsertCertificate
 	//
 	//  INSERT INTO certificates (workspace_id, hostname, certificate, encrypted_private_key, created_at)
@@ -37,6 +41,10 @@ type Querier interface {
 	//  encrypted_private_key = VALUES(encrypted_private_key),
 	//  updated_at = ?
 	InsertCertificate(ctx context.Context, db DBTX, arg InsertCertificateParams) error
+	//ListVMsByDeploymentId
+	//
+	//  SELECT id, deployment_id, metal_host_id, region, private_ip, port, cpu_millicores, memory_mb, status, health_status, last_heartbeat FROM vms WHERE deployment_id = ?
+	ListVMsByDeploymentId(ctx context.Context, db DBTX, deploymentID string) ([]Vm, error)
 	//UpsertGateway
 	//
 	//  INSERT INTO gateways (hostname, config)
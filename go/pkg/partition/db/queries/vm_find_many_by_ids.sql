@@ -0,0 +1,2 @@
+-- name: FindVMsByIds :many
+SELECT * FROM vms WHERE id IN (sqlc.slice(ids));
@@ -0,0 +1,2 @@
+-- name: ListVMsByDeploymentId :many
+SELECT * FROM vms WHERE deployment_id = ?;
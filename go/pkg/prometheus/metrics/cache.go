@@ -28,6 +28,23 @@ var (
 		[]string{"resource", "hit"},
 	)
 
+	// CacheTierReads tracks which tier served the reads of SWR, labeled by
+	// resource type and tier. The tier is "l1" for the in-memory cache, "l2"
+	// for the shared second tier and "origin" if neither had the entry.
+	//
+	// Example usage:
+	//   metrics.CacheTierReads.WithLabelValues("user_profile", "l2").Inc()
+	CacheTierReads = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   "unkey",
+			Subsystem:   "cache",
+			Name:        "tier_reads_total",
+			Help:        "Number of SWR reads by resource type and the tier that served them.",
+			ConstLabels: constLabels,
+		},
+		[]string{"resource", "tier"},
+	)
+
 	// CacheWrites tracks the number of cache write operations, labeled by resource type.
 	// Use this to monitor write pressure on the cache.
 	//
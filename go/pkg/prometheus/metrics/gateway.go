@@ -0,0 +1,73 @@
+/*
+Package metrics provides Prometheus metric collectors for monitoring application performance.
+
+This file contains gateway metrics for tracking the health of upstream VMs.
+*/
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// GatewayHealthChecksTotal tracks the active health probes sent to upstream VMs,
+	// labeled by deployment and result.
+	//
+	// Possible result values are:
+	// - "healthy": The VM answered without a server error.
+	// - "unhealthy": The VM could not be reached or answered with a 5xx.
+	//
+	// Example usage:
+	//   metrics.GatewayHealthChecksTotal.WithLabelValues("d_123", "healthy").Inc()
+	GatewayHealthChecksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   "unkey",
+		Subsystem:   "gateway",
+		Name:        "health_checks_total",
+		Help:        "Total number of active health checks of upstream VMs by deployment and result.",
+		ConstLabels: constLabels,
+	}, []string{"deployment_id", "result"})
+
+	// GatewayEjectionsTotal tracks how often upstream VMs were taken out of rotation,
+	// labeled by deployment and reason.
+	//
+	// Possible reason values are:
+	// - "health_check": Consecutive active health checks failed.
+	// - "outlier": Consecutive proxied requests failed.
+	//
+	// Example usage:
+	//   metrics.GatewayEjectionsTotal.WithLabelValues("d_123", "outlier").Inc()
+	GatewayEjectionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   "unkey",
+		Subsystem:   "gateway",
+		Name:        "ejections_total",
+		Help:        "Total number of upstream VM ejections by deployment and reason.",
+		ConstLabels: constLabels,
+	}, []string{"deployment_id", "reason"})
+
+	// GatewayEjectedVMs tracks the number of upstream VMs currently out of rotation,
+	// labeled by deployment.
+	//
+	// Example usage:
+	//   metrics.GatewayEjectedVMs.WithLabelValues("d_123").Inc()
+	GatewayEjectedVMs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   "unkey",
+		Subsystem:   "gateway",
+		Name:        "ejected_vms",
+		Help:        "Number of upstream VMs currently ejected by deployment.",
+		ConstLabels: constLabels,
+	}, []string{"deployment_id"})
+
+	// GatewayRetriesTotal tracks idempotent requests that were retried on another VM,
+	// labeled by deployment.
+	//
+	// Example usage:
+	//   metrics.GatewayRetriesTotal.WithLabelValues("d_123").Inc()
+	GatewayRetriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   "unkey",
+		Subsystem:   "gateway",
+		Name:        "retries_total",
+		Help:        "Total number of requests retried on another upstream VM by deployment.",
+		ConstLabels: constLabels,
+	}, []string{"deployment_id"})
+)
@@ -99,6 +99,24 @@
 //   - Maximum query length: 1000 characters
 //   - Maximum permissions per query: 100
 //
+// # Wildcards and Deny Entries
+//
+// The permissions a query is evaluated against may grant broad scopes, so
+// keys and roles do not need to list every permission:
+//   - "*" matches any characters within a segment, "documents.*" grants
+//     "documents.read" and "api.*.read_key" grants "api.api1.read_key"
+//   - "**" as the last segment grants every permission below a prefix,
+//     "documents.**" grants "documents.read" and "documents.invoices.read"
+//   - a leading "!" denies what the permission matches, "!documents.delete"
+//     revokes "documents.delete" regardless of other grants
+//
+// Segments are separated by dots. Queries are always matched literally, the
+// query "documents.*" is not satisfied by "documents.read" but by
+// "documents.*" or "documents.**". A deny entry revokes a query with
+// wildcards if it matches any permission the query stands for, so
+// "!api.api2.delete_key" revokes the query "api.*.delete_key" even if
+// "api.*.delete_key" is granted.
+//
 // The package supports complex permission queries through logical operators,
 // allowing you to express requirements like "user must have permission X AND
 // either permission Y OR permission Z".
@@ -0,0 +1,240 @@
+package rbac
+
+import (
+	"strings"
+)
+
+// The wildcard and deny syntax of permissions is described in the package
+// documentation.
+const (
+	// Wildcard matches any characters within a segment of a permission.
+	Wildcard = "*"
+
+	// PrefixWildcard as the last segment of a permission matches one or more
+	// segments.
+	PrefixWildcard = "**"
+
+	// DenyPrefix marks a permission as an explicit deny.
+	DenyPrefix = "!"
+
+	// segmentSeparator separates the hierarchical segments of a permission.
+	segmentSeparator = "."
+)
+
+// grant is a permission held by a key or role, which may contain wildcards.
+type grant struct {
+	// value is the permission as it was granted, including the deny prefix.
+	value string
+
+	// segments of the permission without the deny prefix, nil if the
+	// permission does not contain wildcards.
+	segments []string
+
+	// literal is the permission without the deny prefix.
+	literal string
+}
+
+// matches reports whether the grant covers the required permission.
+func (g grant) matches(required string) bool {
+	if g.segments == nil {
+		return g.literal == required
+	}
+	return matchSegments(g.segments, strings.Split(required, segmentSeparator))
+}
+
+// overlaps reports whether the grant matches any of the permissions the
+// wildcards of the required permission stand for.
+func (g grant) overlaps(required string) bool {
+	if !strings.Contains(required, Wildcard) {
+		return g.matches(required)
+	}
+
+	pattern := g.segments
+	if pattern == nil {
+		pattern = strings.Split(g.literal, segmentSeparator)
+	}
+	return overlapSegments(pattern, strings.Split(required, segmentSeparator))
+}
+
+// grants are the compiled permissions of a key or role.
+//
+// Permissions without wildcards are looked up in a set, so evaluating a
+// query against exact permissions behaves and performs like before
+// wildcards were supported.
+type grants struct {
+	exact    map[string]struct{}
+	patterns []grant
+	denies   []grant
+}
+
+// compileGrants parses the permissions of a key or role.
+func compileGrants(permissions []string) grants {
+	g := grants{
+		exact:    make(map[string]struct{}, len(permissions)),
+		patterns: nil,
+		denies:   nil,
+	}
+
+	for _, p := range permissions {
+		parsed := parseGrant(p)
+		switch {
+		case strings.HasPrefix(p, DenyPrefix):
+			g.denies = append(g.denies, parsed)
+		case parsed.segments != nil:
+			g.patterns = append(g.patterns, parsed)
+		default:
+			g.exact[p] = struct{}{}
+		}
+	}
+
+	return g
+}
+
+// parseGrant splits a permission into its segments if it contains
+// wildcards.
+func parseGrant(permission string) grant {
+	literal := strings.TrimPrefix(permission, DenyPrefix)
+
+	g := grant{
+		value:    permission,
+		segments: nil,
+		literal:  literal,
+	}
+	if strings.Contains(literal, Wildcard) {
+		g.segments = strings.Split(literal, segmentSeparator)
+	}
+
+	return g
+}
+
+// check returns whether the required permission is granted, and the deny
+// entry revoking it if there is one.
+//
+// A required permission with wildcards is revoked by a deny entry matching
+// any of its instances, so "!api.api2.delete_key" revokes "api.*.delete_key".
+func (g grants) check(required string) (bool, string) {
+	for _, deny := range g.denies {
+		if deny.overlaps(required) {
+			return false, deny.value
+		}
+	}
+
+	if _, ok := g.exact[required]; ok {
+		return true, ""
+	}
+
+	for _, pattern := range g.patterns {
+		if pattern.matches(required) {
+			return true, ""
+		}
+	}
+
+	return false, ""
+}
+
+// matchSegments matches the segments of a permission against the segments
+// of a pattern.
+//
+// Every segment of the pattern matches exactly one segment, except for a
+// trailing PrefixWildcard, which matches all remaining segments as long as
+// there is at least one.
+func matchSegments(pattern, segments []string) bool {
+	for i, p := range pattern {
+		if p == PrefixWildcard && i == len(pattern)-1 {
+			return len(segments) > i
+		}
+		if i >= len(segments) || !matchSegment(p, segments[i]) {
+			return false
+		}
+	}
+
+	return len(segments) == len(pattern)
+}
+
+// matchSegment matches a single segment against a glob pattern, in which
+// Wildcard matches any run of characters.
+func matchSegment(pattern, segment string) bool {
+	// Backtracking glob matcher, it only needs to remember the position of
+	// the last wildcard
+	p, s := 0, 0
+	star, match := -1, 0
+	for s < len(segment) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, match = p, s
+			p++
+		case p < len(pattern) && pattern[p] == segment[s]:
+			p++
+			s++
+		case star >= 0:
+			p = star + 1
+			match++
+			s = match
+		default:
+			return false
+		}
+	}
+
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+
+	return p == len(pattern)
+}
+
+// overlapSegments reports whether two permissions with wildcards, split
+// into their segments, match a common permission.
+func overlapSegments(a, b []string) bool {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if i == len(a)-1 && a[i] == PrefixWildcard {
+			return len(b) > i
+		}
+		if i == len(b)-1 && b[i] == PrefixWildcard {
+			return len(a) > i
+		}
+		if i >= len(a) || i >= len(b) || !overlapSegment(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// overlapSegment reports whether two glob patterns match a common segment.
+func overlapSegment(a, b string) bool {
+	// reachable[i][j] is whether a[:i] and b[:j] can match the same string,
+	// a wildcard may absorb characters and wildcards of the other pattern
+	reachable := make([][]bool, len(a)+1)
+	for i := range reachable {
+		reachable[i] = make([]bool, len(b)+1)
+	}
+	reachable[0][0] = true
+
+	for i := 0; i <= len(a); i++ {
+		for j := 0; j <= len(b); j++ {
+			if !reachable[i][j] {
+				continue
+			}
+
+			aStar := i < len(a) && a[i] == '*'
+			bStar := j < len(b) && b[j] == '*'
+			if aStar {
+				reachable[i+1][j] = true
+				if j < len(b) {
+					reachable[i][j+1] = true
+				}
+			}
+			if bStar {
+				reachable[i][j+1] = true
+				if i < len(a) {
+					reachable[i+1][j] = true
+				}
+			}
+			if i < len(a) && j < len(b) && !aStar && !bStar && a[i] == b[j] {
+				reachable[i+1][j+1] = true
+			}
+		}
+	}
+
+	return reachable[len(a)][len(b)]
+}
@@ -0,0 +1,88 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchSegment(t *testing.T) {
+	tests := []struct {
+		pattern string
+		segment string
+		want    bool
+	}{
+		{pattern: "read", segment: "read", want: true},
+		{pattern: "read", segment: "write", want: false},
+		{pattern: "*", segment: "read", want: true},
+		{pattern: "*", segment: "*", want: true},
+		{pattern: "read_*", segment: "read_key", want: true},
+		{pattern: "read_*", segment: "read_", want: true},
+		{pattern: "read_*", segment: "update_key", want: false},
+		{pattern: "*_key", segment: "read_key", want: true},
+		{pattern: "*_key", segment: "read_api", want: false},
+		{pattern: "r*_*y", segment: "read_key", want: true},
+		{pattern: "r*_*y", segment: "read_api", want: false},
+		{pattern: "**", segment: "read", want: true},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, matchSegment(tt.pattern, tt.segment), "%s ~ %s", tt.pattern, tt.segment)
+	}
+}
+
+func TestOverlapSegment(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want bool
+	}{
+		{a: "read", b: "read", want: true},
+		{a: "read", b: "write", want: false},
+		{a: "*", b: "read", want: true},
+		{a: "read_*", b: "*_key", want: true},
+		{a: "read_*", b: "update_*", want: false},
+		{a: "*_key", b: "*_api", want: false},
+		{a: "a*c", b: "*b*", want: true},
+		{a: "", b: "*", want: true},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, overlapSegment(tt.a, tt.b), "%s ~ %s", tt.a, tt.b)
+		require.Equal(t, tt.want, overlapSegment(tt.b, tt.a), "%s ~ %s", tt.b, tt.a)
+	}
+}
+
+func TestGrantsCheck(t *testing.T) {
+	g := compileGrants([]string{
+		"api.api1.read_key",
+		"api.*.create_key",
+		"ratelimit.**",
+		"!ratelimit.ns1.delete_override",
+		"!api.api2.*",
+	})
+
+	tests := []struct {
+		required string
+		granted  bool
+		deniedBy string
+	}{
+		{required: "api.api1.read_key", granted: true, deniedBy: ""},
+		{required: "api.api1.create_key", granted: true, deniedBy: ""},
+		{required: "api.api2.create_key", granted: false, deniedBy: "!api.api2.*"},
+		{required: "api.api1.delete_key", granted: false, deniedBy: ""},
+		{required: "ratelimit.ns1.limit", granted: true, deniedBy: ""},
+		{required: "ratelimit.ns1.delete_override", granted: false, deniedBy: "!ratelimit.ns1.delete_override"},
+		{required: "ratelimit", granted: false, deniedBy: ""},
+		{required: "api.*.create_key", granted: false, deniedBy: "!api.api2.*"},
+		{required: "ratelimit.*.delete_override", granted: false, deniedBy: "!ratelimit.ns1.delete_override"},
+		{required: "ratelimit.**", granted: false, deniedBy: "!ratelimit.ns1.delete_override"},
+		{required: "ratelimit.*.limit", granted: true, deniedBy: ""},
+	}
+
+	for _, tt := range tests {
+		granted, deniedBy := g.check(tt.required)
+		require.Equal(t, tt.granted, granted, tt.required)
+		require.Equal(t, tt.deniedBy, deniedBy, tt.required)
+	}
+}
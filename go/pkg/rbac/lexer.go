@@ -182,9 +182,9 @@ func (l *lexer) readIdentifier() string {
 //   - Colons (:) for namespace separation (e.g., "system:admin")
 //   - Asterisks (*) for literal permission names (e.g., "api.*")
 //
-// Note: The asterisk (*) character is treated as a literal character in permission
-// names, NOT as a wildcard pattern. For example, "api.*" matches only the exact
-// permission "api.*", not "api.read" or "api.write".
+// Note: The asterisk (*) character is treated as a literal character in queries,
+// NOT as a wildcard pattern. For example, the query "api.*" is not satisfied by
+// "api.read" or "api.write", only by permissions covering the literal "api.*".
 //
 // This character set matches the regex: /^[a-zA-Z0-9_:\-\.\*]+$/
 //
@@ -27,10 +27,10 @@ import (
 // This grammar ensures AND has higher precedence than OR, matching SQL conventions.
 //
 // Note on asterisk (*) characters:
-// Asterisks are treated as literal characters in permission names, NOT as wildcard
-// patterns. For example, "api.*" will only match a permission literally named "api.*",
-// not permissions like "api.read" or "api.write". This allows for exact permission
-// matching without pattern expansion.
+// Asterisks are treated as literal characters in queries, NOT as wildcard
+// patterns. A query for "api.*" requires a permission covering "api.*", it is
+// not expanded to "api.read" or "api.write". Wildcards are only expanded in the
+// permissions a query is evaluated against, see [RBAC.EvaluatePermissions].
 type parser struct {
 	// lexer provides the token stream for parsing
 	lexer *lexer
@@ -28,6 +28,9 @@ const (
 
 	// Identity represents user and identity management resources
 	Identity ResourceType = "identity"
+
+	// Webhook represents webhook endpoints and their deliveries
+	Webhook ResourceType = "webhook"
 )
 
 // Predefined API actions. These constants define operations that can be
@@ -160,6 +163,19 @@ const (
 	DeleteIdentity ActionType = "delete_identity"
 )
 
+// Predefined webhook actions. These constants define operations that can be
+// performed on webhook resources.
+const (
+	// CreateEndpoint permits creating webhook endpoints
+	CreateEndpoint ActionType = "create_endpoint"
+
+	// ReadEndpoint permits viewing webhook endpoints
+	ReadEndpoint ActionType = "read_endpoint"
+
+	// DeleteEndpoint permits removing webhook endpoints
+	DeleteEndpoint ActionType = "delete_endpoint"
+)
+
 // Tuple represents a specific permission as a combination of resource type,
 // resource ID, and action. It forms the basic unit of permission definition
 // in the RBAC system.
@@ -2,7 +2,6 @@ package rbac
 
 import (
 	"fmt"
-	"slices"
 	"strings"
 
 	"github.com/unkeyed/unkey/go/pkg/codes"
@@ -41,7 +40,9 @@ type EvaluationResult struct {
 // whether the permissions are valid and, if not, why they failed.
 //
 // The permissions parameter should contain a list of permission strings in the
-// format "resourceType.resourceID.action".
+// format "resourceType.resourceID.action". Permissions may contain wildcards
+// and deny entries, see "Wildcards and Deny Entries" in the package
+// documentation.
 //
 // Example:
 //
@@ -67,16 +68,24 @@ type EvaluationResult struct {
 //	    fmt.Printf("Access denied: %s\n", result.Message)
 //	}
 func (r *RBAC) EvaluatePermissions(query PermissionQuery, permissions []string) (EvaluationResult, error) {
-	return r.evaluateQueryV1(query, permissions)
+	return r.evaluateQueryV1(query, permissions, compileGrants(permissions))
 }
 
-func (r *RBAC) evaluateQueryV1(query PermissionQuery, permissions []string) (EvaluationResult, error) {
+func (r *RBAC) evaluateQueryV1(query PermissionQuery, permissions []string, grants grants) (EvaluationResult, error) {
 	// Handle simple permission check
 	if query.Value != "" {
-		if slices.Contains(permissions, query.Value) {
+		granted, deniedBy := grants.check(query.Value)
+		if granted {
 			return EvaluationResult{Valid: true, Message: ""}, nil
 		}
 
+		if deniedBy != "" {
+			return EvaluationResult{
+				Valid:   false,
+				Message: fmt.Sprintf("Permission '%s' is denied by '%s'", query.Value, deniedBy),
+			}, nil
+		}
+
 		return EvaluationResult{
 			Valid:   false,
 			Message: fmt.Sprintf("Missing permission: '%s'", query.Value),
@@ -86,7 +95,7 @@ func (r *RBAC) evaluateQueryV1(query PermissionQuery, permissions []string) (Eva
 	// Handle AND operation
 	if query.Operation == OperatorAnd {
 		for _, child := range query.Children {
-			result, err := r.evaluateQueryV1(child, permissions)
+			result, err := r.evaluateQueryV1(child, permissions, grants)
 			if err != nil {
 				return EvaluationResult{}, err
 			}
@@ -101,7 +110,7 @@ func (r *RBAC) evaluateQueryV1(query PermissionQuery, permissions []string) (Eva
 	if query.Operation == OperatorOr {
 		missingPerms := make([]string, 0)
 		for _, child := range query.Children {
-			result, err := r.evaluateQueryV1(child, permissions)
+			result, err := r.evaluateQueryV1(child, permissions, grants)
 			if err != nil {
 				return EvaluationResult{}, err
 			}
@@ -144,13 +153,13 @@ func formatPermissions(permissions []string) []string {
 //   - Grouping: parentheses ()
 //   - Precedence: AND has higher precedence than OR
 //
-// Important: Asterisks (*) in permission names are treated as literal characters,
-// NOT as wildcard patterns. For example, "api.*" will only match a permission
-// literally named "api.*", not permissions like "api.read" or "api.write".
+// Important: Asterisks (*) in queries are treated as literal characters, NOT as
+// wildcard patterns, see "Wildcards and Deny Entries" in the package
+// documentation.
 //
 // Examples:
 //   - "api.key1.read_key"
-//   - "api.*" (matches only the literal permission "api.*")
+//   - "api.*" (requires a permission covering the literal "api.*")
 //   - "perm1 AND perm2"
 //   - "perm1 OR perm2 AND perm3" (parsed as "perm1 OR (perm2 AND perm3)")
 //   - "(perm1 OR perm2) AND perm3"
@@ -1,6 +1,7 @@
 package rbac
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -98,6 +99,93 @@ func TestRBAC_EvaluatePermissions(t *testing.T) {
 			permissions: []string{"system:admin:*", "api_v2:read", "user:basic:read"},
 			wantValid:   true,
 		},
+		{
+			name:        "Segment wildcard (Pass)",
+			query:       S("documents.read"),
+			permissions: []string{"documents.*"},
+			wantValid:   true,
+		},
+		{
+			name:        "Segment wildcard in the middle (Pass)",
+			query:       T(Tuple{ResourceType: Api, ResourceID: "api1", Action: ReadKey}),
+			permissions: []string{"api.*.read_key"},
+			wantValid:   true,
+		},
+		{
+			name:        "Segment wildcard does not match other actions (Fail)",
+			query:       T(Tuple{ResourceType: Api, ResourceID: "api1", Action: DeleteKey}),
+			permissions: []string{"api.*.read_key"},
+			wantValid:   false,
+		},
+		{
+			name:        "Segment wildcard matches a single segment (Fail)",
+			query:       S("documents.invoices.read"),
+			permissions: []string{"documents.*"},
+			wantValid:   false,
+		},
+		{
+			name:        "Partial segment wildcard (Pass)",
+			query:       S("documents.read_invoices"),
+			permissions: []string{"documents.read_*"},
+			wantValid:   true,
+		},
+		{
+			name:        "Prefix grant (Pass)",
+			query:       S("documents.invoices.read"),
+			permissions: []string{"documents.**"},
+			wantValid:   true,
+		},
+		{
+			name:        "Prefix grant requires a segment (Fail)",
+			query:       S("documents"),
+			permissions: []string{"documents.**"},
+			wantValid:   false,
+		},
+		{
+			name:        "Explicit deny overrides exact grant (Fail)",
+			query:       S("documents.delete"),
+			permissions: []string{"documents.delete", "!documents.delete"},
+			wantValid:   false,
+		},
+		{
+			name:        "Explicit deny overrides wildcard grant (Fail)",
+			query:       S("documents.delete"),
+			permissions: []string{"documents.*", "!documents.delete"},
+			wantValid:   false,
+		},
+		{
+			name:        "Explicit deny leaves other grants (Pass)",
+			query:       S("documents.read"),
+			permissions: []string{"documents.*", "!documents.delete"},
+			wantValid:   true,
+		},
+		{
+			name: "Explicit deny is skipped by OR (Pass)",
+			query: Or(
+				S("documents.delete"),
+				S("documents.read"),
+			),
+			permissions: []string{"documents.**", "!documents.delete"},
+			wantValid:   true,
+		},
+		{
+			name: "Explicit deny revokes a queried wildcard covering it (Fail)",
+			query: Or(
+				T(Tuple{ResourceType: Api, ResourceID: "*", Action: DeleteKey}),
+				T(Tuple{ResourceType: Api, ResourceID: "api2", Action: DeleteKey}),
+			),
+			permissions: []string{"api.*.delete_key", "!api.api2.delete_key"},
+			wantValid:   false,
+		},
+		{
+			name: "Explicit deny leaves other instances of a queried wildcard (Pass)",
+			query: Or(
+				T(Tuple{ResourceType: Api, ResourceID: "*", Action: DeleteKey}),
+				T(Tuple{ResourceType: Api, ResourceID: "api1", Action: DeleteKey}),
+			),
+			permissions: []string{"api.*.delete_key", "!api.api2.delete_key"},
+			wantValid:   true,
+		},
 	}
 
 	rbac := New()
@@ -115,3 +203,42 @@ func TestRBAC_EvaluatePermissions(t *testing.T) {
 		})
 	}
 }
+
+// TestRBAC_ExactMatchCompatibility guarantees that queries which do not rely
+// on a wildcard are evaluated like before wildcards were supported, including
+// queries for permissions that literally contain an asterisk.
+func TestRBAC_ExactMatchCompatibility(t *testing.T) {
+	permissions := []string{
+		"api.api1.read_api",
+		"api.api1.read_key",
+		"api.*.create_key",
+		"system:admin:*",
+		"documents.read",
+		"perm1",
+	}
+
+	queries := []string{
+		"api.api1.read_api",
+		"api.api2.read_api",
+		"api.api1.read_key",
+		"api.*.create_key",
+		"system:admin:*",
+		"documents.read",
+		"documents",
+		"documents.read.all",
+		"perm1",
+		"perm",
+		"perm12",
+	}
+
+	rbac := New()
+	for _, q := range queries {
+		result, err := rbac.EvaluatePermissions(S(q), permissions)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := slices.Contains(permissions, q); result.Valid != want {
+			t.Errorf("query %q: want valid=%v, got valid=%v", q, want, result.Valid)
+		}
+	}
+}
@@ -37,6 +37,9 @@ const (
 	OrgPrefix                Prefix = "org"
 	WorkflowPrefix           Prefix = "wf"
 	StepPrefix               Prefix = "step"
+	SignalPrefix             Prefix = "sig"
+	WebhookEndpointPrefix    Prefix = "whep"
+	WebhookEventPrefix       Prefix = "whev"
 
 	// Control plane prefixes
 	ProjectPrefix     Prefix = "proj"
@@ -22,16 +22,9 @@ func (s *Service) Encrypt(
 	defer span.End()
 	span.SetAttributes(attribute.String("keyring", req.GetKeyring()))
 
-	cacheKey := fmt.Sprintf("%s-%s", req.GetKeyring(), LATEST)
-
-	dek, hit := s.keyCache.Get(ctx, cacheKey)
-	if hit != cache.Hit {
-		var err error
-		dek, err = s.keyring.GetOrCreateKey(ctx, req.GetKeyring(), LATEST)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get latest dek in keyring %s: %w", req.GetKeyring(), err)
-		}
-		s.keyCache.Set(ctx, cacheKey, dek)
+	dek, err := s.latestDEK(ctx, req.GetKeyring())
+	if err != nil {
+		return nil, err
 	}
 
 	nonce, ciphertext, err := encryption.Encrypt(dek.GetKey(), []byte(req.GetData()))
@@ -57,3 +50,18 @@ func (s *Service) Encrypt(
 		KeyId:     dek.GetId(),
 	}, nil
 }
+
+func (s *Service) latestDEK(ctx context.Context, keyring string) (*vaultv1.DataEncryptionKey, error) {
+	cacheKey := fmt.Sprintf("%s-%s", keyring, LATEST)
+
+	dek, hit := s.keyCache.Get(ctx, cacheKey)
+	if hit != cache.Hit {
+		var err error
+		dek, err = s.keyring.GetOrCreateKey(ctx, keyring, LATEST)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest dek in keyring %s: %w", keyring, err)
+		}
+		s.keyCache.Set(ctx, cacheKey, dek)
+	}
+	return dek, nil
+}
@@ -0,0 +1,107 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	vaultv1 "github.com/unkeyed/unkey/go/gen/proto/vault/v1"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+	"github.com/unkeyed/unkey/go/pkg/vault"
+	"github.com/unkeyed/unkey/go/pkg/vault/keys"
+	"github.com/unkeyed/unkey/go/pkg/vault/storage"
+)
+
+// This scenario tests rotating the DEK of a keyring after the KEK was rolled.
+func TestRotateDEK(t *testing.T) {
+
+	logger := logging.NewNoop()
+
+	storage, err := storage.NewMemory(storage.MemoryConfig{
+		Logger: logger,
+	})
+	require.NoError(t, err)
+
+	_, masterKeyOld, err := keys.GenerateMasterKey()
+	require.NoError(t, err)
+
+	v, err := vault.New(vault.Config{
+		Storage:    storage,
+		Logger:     logger,
+		MasterKeys: []string{masterKeyOld},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	keyring := uid.New("test")
+	enc, err := v.Encrypt(ctx, &vaultv1.EncryptRequest{
+		Keyring: keyring,
+		Data:    "secret",
+	})
+	require.NoError(t, err)
+
+	_, masterKeyNew, err := keys.GenerateMasterKey()
+	require.NoError(t, err)
+
+	v, err = vault.New(vault.Config{
+		Storage:    storage,
+		Logger:     logger,
+		MasterKeys: []string{masterKeyOld, masterKeyNew},
+	})
+	require.NoError(t, err)
+
+	keyrings, err := v.ListKeyrings(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{keyring}, keyrings)
+
+	require.NoError(t, v.RollKeyring(ctx, keyring))
+
+	latest, err := v.LatestDEK(ctx, keyring)
+	require.NoError(t, err)
+	require.Equal(t, enc.GetKeyId(), latest)
+
+	dekID, err := v.RotateDEK(ctx, keyring)
+	require.NoError(t, err)
+	require.NotEqual(t, enc.GetKeyId(), dekID)
+
+	latest, err = v.LatestDEK(ctx, keyring)
+	require.NoError(t, err)
+	require.Equal(t, dekID, latest)
+
+	deks, err := v.ListDEKs(ctx, keyring)
+	require.NoError(t, err)
+	require.Len(t, deks, 2)
+	latestByID := map[string]bool{}
+	for _, dek := range deks {
+		latestByID[dek.ID] = dek.Latest
+	}
+	require.Equal(t, map[string]bool{enc.GetKeyId(): false, dekID: true}, latestByID)
+	require.Equal(t, deks[0].EncryptionKeyID, deks[1].EncryptionKeyID, "both deks use the newest kek")
+
+	// Data encrypted with the old DEK can still be decrypted and moves to the new one
+	res, err := v.ReEncrypt(ctx, &vaultv1.ReEncryptRequest{
+		Keyring:   keyring,
+		Encrypted: enc.GetEncrypted(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, dekID, res.GetKeyId())
+
+	// The old master key is no longer needed
+	v, err = vault.New(vault.Config{
+		Storage:    storage,
+		Logger:     logger,
+		MasterKeys: []string{masterKeyNew},
+	})
+	require.NoError(t, err)
+
+	for _, encrypted := range []string{enc.GetEncrypted(), res.GetEncrypted()} {
+		decrypted, decryptErr := v.Decrypt(ctx, &vaultv1.DecryptRequest{
+			Keyring:   keyring,
+			Encrypted: encrypted,
+		})
+		require.NoError(t, decryptErr)
+		require.Equal(t, "secret", decrypted.GetPlaintext())
+	}
+}
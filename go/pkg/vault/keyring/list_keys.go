@@ -0,0 +1,110 @@
+package keyring
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/unkeyed/unkey/go/pkg/otel/tracing"
+	"github.com/unkeyed/unkey/go/pkg/vault/storage"
+)
+
+// KeyInfo describes a data encryption key of a keyring, without its key
+// material.
+type KeyInfo struct {
+	// ID is the id of the data encryption key.
+	ID string
+
+	// EncryptionKeyID is the id of the key encryption key the data
+	// encryption key is encrypted with.
+	EncryptionKeyID string
+
+	// CreatedAt is the unix milli timestamp the key was created at.
+	CreatedAt int64
+
+	// Latest is true for the key new data is encrypted with.
+	Latest bool
+}
+
+// ListRings returns the ids of all keyrings, sorted.
+func (k *Keyring) ListRings(ctx context.Context) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "keyring.ListRings")
+	defer span.End()
+
+	lookupKeys, err := k.store.ListObjectKeys(ctx, "keyring/")
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	seen := make(map[string]struct{})
+	ringIDs := []string{}
+	for _, lookupKey := range lookupKeys {
+		ringID, _, ok := strings.Cut(strings.TrimPrefix(lookupKey, "keyring/"), "/")
+		if !ok {
+			continue
+		}
+		if _, ok := seen[ringID]; ok {
+			continue
+		}
+		seen[ringID] = struct{}{}
+		ringIDs = append(ringIDs, ringID)
+	}
+	sort.Strings(ringIDs)
+
+	return ringIDs, nil
+}
+
+// ListKeys returns all data encryption keys of a keyring, sorted by their
+// creation time.
+func (k *Keyring) ListKeys(ctx context.Context, ringID string) ([]KeyInfo, error) {
+	ctx, span := tracing.Start(ctx, "keyring.ListKeys")
+	defer span.End()
+
+	lookupKeys, err := k.store.ListObjectKeys(ctx, k.buildLookupKey(ringID, "dek_"))
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	latestID := ""
+	latest, err := k.GetKey(ctx, ringID, "LATEST")
+	if err == nil {
+		latestID = latest.GetId()
+	} else if err != storage.ErrObjectNotFound {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get latest key: %w", err)
+	}
+
+	keys := make([]KeyInfo, 0, len(lookupKeys))
+	for _, objectKey := range lookupKeys {
+		b, found, err := k.store.GetObject(ctx, objectKey)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, fmt.Errorf("failed to get object: %w", err)
+		}
+		if !found {
+			return nil, storage.ErrObjectNotFound
+		}
+
+		dek, encryptionKeyID, err := k.DecodeAndDecryptKey(ctx, b)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, fmt.Errorf("failed to decode and decrypt key: %w", err)
+		}
+
+		keys = append(keys, KeyInfo{
+			ID:              dek.GetId(),
+			EncryptionKeyID: encryptionKeyID,
+			CreatedAt:       dek.GetCreatedAt(),
+			Latest:          dek.GetId() == latestID,
+		})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt < keys[j].CreatedAt
+	})
+
+	return keys, nil
+}
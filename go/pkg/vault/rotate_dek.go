@@ -0,0 +1,72 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/unkeyed/unkey/go/pkg/otel/tracing"
+	"github.com/unkeyed/unkey/go/pkg/vault/keyring"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ListKeyrings returns the ids of all keyrings.
+func (s *Service) ListKeyrings(ctx context.Context) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "vault.ListKeyrings")
+	defer span.End()
+
+	return s.keyring.ListRings(ctx)
+}
+
+// ListDEKs returns the data encryption keys of a keyring, oldest first.
+func (s *Service) ListDEKs(ctx context.Context, ring string) ([]keyring.KeyInfo, error) {
+	ctx, span := tracing.Start(ctx, "vault.ListDEKs")
+	defer span.End()
+	span.SetAttributes(attribute.String("keyring", ring))
+
+	return s.keyring.ListKeys(ctx, ring)
+}
+
+// LatestDEK returns the id of the data encryption key new data of the
+// keyring is encrypted with.
+func (s *Service) LatestDEK(ctx context.Context, ring string) (string, error) {
+	ctx, span := tracing.Start(ctx, "vault.LatestDEK")
+	defer span.End()
+	span.SetAttributes(attribute.String("keyring", ring))
+
+	dek, err := s.latestDEK(ctx, ring)
+	if err != nil {
+		return "", err
+	}
+	return dek.GetId(), nil
+}
+
+// RollKeyring re-encrypts the data encryption keys of a keyring with the
+// newest key encryption key.
+func (s *Service) RollKeyring(ctx context.Context, ring string) error {
+	ctx, span := tracing.Start(ctx, "vault.RollKeyring")
+	defer span.End()
+	span.SetAttributes(attribute.String("keyring", ring))
+
+	return s.keyring.RollKeys(ctx, ring)
+}
+
+// RotateDEK creates a new data encryption key in the keyring and makes it
+// the one new data is encrypted with. Data encrypted with older keys can
+// still be decrypted, use ReEncrypt to move it to the new key.
+//
+// Other instances keep encrypting with their cached key until it expires.
+func (s *Service) RotateDEK(ctx context.Context, ring string) (string, error) {
+	ctx, span := tracing.Start(ctx, "vault.RotateDEK")
+	defer span.End()
+	span.SetAttributes(attribute.String("keyring", ring))
+
+	dek, err := s.keyring.CreateKey(ctx, ring)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return "", fmt.Errorf("failed to create dek: %w", err)
+	}
+
+	s.keyCache.Remove(ctx, fmt.Sprintf("%s-%s", ring, LATEST))
+
+	return dek.GetId(), nil
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
+
+// Copy copies all objects whose key starts with prefix from one storage to
+// another and returns how many objects were written. Objects that already
+// exist with the same data are skipped, so an interrupted copy can be
+// restarted.
+func Copy(ctx context.Context, from, to Storage, prefix string) (int, error) {
+	keys, err := from.ListObjectKeys(ctx, prefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	copied := 0
+	for _, key := range keys {
+		b, found, err := from.GetObject(ctx, key)
+		if err != nil {
+			return copied, fmt.Errorf("failed to get object %s: %w", key, err)
+		}
+		if !found {
+			// Deleted after it was listed
+			continue
+		}
+
+		existing, found, err := to.GetObject(ctx, key)
+		if err != nil {
+			return copied, fmt.Errorf("failed to get object %s: %w", key, err)
+		}
+		if found && bytes.Equal(existing, b) {
+			continue
+		}
+
+		err = to.PutObject(ctx, key, b)
+		if err != nil {
+			return copied, fmt.Errorf("failed to put object %s: %w", key, err)
+		}
+		copied++
+	}
+
+	return copied, nil
+}
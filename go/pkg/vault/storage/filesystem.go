@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+// maxFilenameLength is the longest filename most filesystems support.
+const maxFilenameLength = 255
+
+// filesystem stores objects as files in a local directory.
+//
+// Object keys are encoded into filenames, and the files are sharded into
+// two levels of directories by the hash of their key, so no directory grows
+// too large. Writes go to a temporary file that is synced and renamed over
+// the object, so a crash never leaves a partially written object behind.
+type filesystem struct {
+	config FilesystemConfig
+	logger logging.Logger
+}
+
+type FilesystemConfig struct {
+	// Path is the directory the objects are stored in, it is created if it
+	// does not exist.
+	Path   string
+	Logger logging.Logger
+}
+
+func NewFilesystem(config FilesystemConfig) (Storage, error) {
+	logger := config.Logger.With("service", "storage")
+
+	logger.Info("using filesystem storage", "path", config.Path)
+
+	err := os.MkdirAll(config.Path, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &filesystem{config: config, logger: logger}, nil
+}
+
+func (s *filesystem) Key(workspaceId string, dekID string) string {
+	return fmt.Sprintf("%s/%s", workspaceId, dekID)
+}
+
+func (s *filesystem) Latest(workspaceId string) string {
+	return s.Key(workspaceId, "LATEST")
+}
+
+// path returns the file an object is stored in.
+func (s *filesystem) path(key string) (string, error) {
+	filename := base64.RawURLEncoding.EncodeToString([]byte(key))
+	if len(filename) > maxFilenameLength {
+		return "", fmt.Errorf("object key is too long: %s", key)
+	}
+
+	hash := sha256.Sum256([]byte(key))
+	shard := hex.EncodeToString(hash[:2])
+
+	return filepath.Join(s.config.Path, shard[:2], shard[2:], filename), nil
+}
+
+func (s *filesystem) PutObject(ctx context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	err = s.mkdir(dir)
+	if err != nil {
+		return err
+	}
+
+	// Temporary files start with a dot, which base64url never produces
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		// Fails once the file was renamed, which is expected
+		_ = os.Remove(tmp.Name())
+	}()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to close object: %w", closeErr)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+
+	return syncDir(dir)
+}
+
+// mkdir creates the shard directories of an object, and syncs their parents
+// so the directories survive a crash.
+func (s *filesystem) mkdir(dir string) error {
+	_, err := os.Stat(dir)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to stat directory: %w", err)
+	}
+
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	for parent := filepath.Dir(dir); ; parent = filepath.Dir(parent) {
+		err = syncDir(parent)
+		if err != nil {
+			return err
+		}
+		if parent == filepath.Clean(s.config.Path) {
+			return nil
+		}
+	}
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open directory: %w", err)
+	}
+	defer d.Close()
+
+	err = d.Sync()
+	if err != nil {
+		return fmt.Errorf("failed to sync directory: %w", err)
+	}
+	return nil
+}
+
+func (s *filesystem) GetObject(ctx context.Context, key string) ([]byte, bool, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to read object: %w", err)
+	}
+	return b, true, nil
+}
+
+// ListObjectKeys walks the whole directory, as the objects are sharded by
+// their hash rather than their key.
+func (s *filesystem) ListObjectKeys(ctx context.Context, prefix string) ([]string, error) {
+	keys := []string{}
+	err := filepath.WalkDir(s.config.Path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+
+		key, err := base64.RawURLEncoding.DecodeString(d.Name())
+		if err != nil {
+			s.logger.Warn("skipping unknown file in storage directory", "path", path)
+			return nil
+		}
+		if strings.HasPrefix(string(key), prefix) {
+			keys = append(keys, string(key))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
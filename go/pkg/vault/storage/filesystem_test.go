@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+func TestFilesystem(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := NewFilesystem(FilesystemConfig{
+		Path:   dir,
+		Logger: logging.NewNoop(),
+	})
+	require.NoError(t, err)
+
+	_, found, err := s.GetObject(ctx, "keyring/ws_1/LATEST")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, s.PutObject(ctx, "keyring/ws_1/dek_a", []byte("a")))
+	require.NoError(t, s.PutObject(ctx, "keyring/ws_1/dek_A", []byte("A")))
+	require.NoError(t, s.PutObject(ctx, "keyring/ws_1/LATEST", []byte("a")))
+	require.NoError(t, s.PutObject(ctx, "keyring/ws_2/dek_b", []byte("b")))
+
+	// Overwrites replace the object
+	require.NoError(t, s.PutObject(ctx, "keyring/ws_1/LATEST", []byte("A")))
+
+	b, found, err := s.GetObject(ctx, "keyring/ws_1/LATEST")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("A"), b)
+
+	keys, err := s.ListObjectKeys(ctx, "keyring/ws_1/dek_")
+	require.NoError(t, err)
+	require.Equal(t, []string{"keyring/ws_1/dek_A", "keyring/ws_1/dek_a"}, keys)
+
+	keys, err = s.ListObjectKeys(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, keys, 4)
+
+	// Leftovers of interrupted writes are not objects
+	path, err := s.(*filesystem).path("keyring/ws_1/dek_a")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(path), ".tmp-123"), []byte("partial"), 0o600))
+
+	keys, err = s.ListObjectKeys(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, keys, 4)
+}
+
+func TestCopy(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewNoop()
+
+	from, err := NewMemory(MemoryConfig{Logger: logger})
+	require.NoError(t, err)
+
+	to, err := NewFilesystem(FilesystemConfig{
+		Path:   t.TempDir(),
+		Logger: logger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, from.PutObject(ctx, "keyring/ws_1/dek_a", []byte("a")))
+	require.NoError(t, from.PutObject(ctx, "keyring/ws_1/LATEST", []byte("a")))
+	require.NoError(t, from.PutObject(ctx, "keyring/ws_2/dek_b", []byte("b")))
+
+	copied, err := Copy(ctx, from, to, "")
+	require.NoError(t, err)
+	require.Equal(t, 3, copied)
+
+	for _, key := range []string{"keyring/ws_1/dek_a", "keyring/ws_1/LATEST", "keyring/ws_2/dek_b"} {
+		want, _, getErr := from.GetObject(ctx, key)
+		require.NoError(t, getErr)
+		got, found, getErr := to.GetObject(ctx, key)
+		require.NoError(t, getErr)
+		require.True(t, found, key)
+		require.Equal(t, want, got, key)
+	}
+
+	// A second copy only writes changed objects
+	require.NoError(t, from.PutObject(ctx, "keyring/ws_1/LATEST", []byte("c")))
+	copied, err = Copy(ctx, from, to, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, copied)
+}
@@ -66,7 +66,7 @@ func (s *memory) ListObjectKeys(ctx context.Context, prefix string) ([]string, e
 	defer s.mu.RUnlock()
 	keys := []string{}
 	for key := range s.data {
-		if prefix == "" || !strings.HasPrefix(key, prefix) {
+		if !strings.HasPrefix(key, prefix) {
 			continue
 		}
 
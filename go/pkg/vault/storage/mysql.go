@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+)
+
+// Object keys contain case sensitive ids, so they are stored as binary
+// strings instead of using the case insensitive default collation.
+const createVaultObjectsTable = `CREATE TABLE IF NOT EXISTS vault_objects (
+    object_key VARBINARY(768) NOT NULL,
+    object MEDIUMBLOB NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NULL,
+    PRIMARY KEY (object_key)
+)`
+
+// mysql stores objects in the vault_objects table of a MySQL database, which
+// is created if it does not exist.
+type mysql struct {
+	db     *sql.DB
+	logger logging.Logger
+}
+
+type MySQLConfig struct {
+	// DSN is the connection string of the database.
+	// Example: user:pass@tcp(localhost:3306)/vault
+	DSN    string
+	Logger logging.Logger
+}
+
+func NewMySQL(config MySQLConfig) (Storage, error) {
+	logger := config.Logger.With("service", "storage")
+
+	logger.Info("using mysql storage")
+
+	db, err := sql.Open("mysql", config.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err = db.ExecContext(ctx, createVaultObjectsTable)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create vault_objects table: %w", err)
+	}
+
+	logger.Info("mysql storage initialized")
+
+	return &mysql{db: db, logger: logger}, nil
+}
+
+func (s *mysql) Key(workspaceId string, dekID string) string {
+	return fmt.Sprintf("%s/%s", workspaceId, dekID)
+}
+
+func (s *mysql) Latest(workspaceId string) string {
+	return s.Key(workspaceId, "LATEST")
+}
+
+func (s *mysql) PutObject(ctx context.Context, key string, data []byte) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO vault_objects (object_key, object, created_at)
+VALUES (?, ?, ?)
+ON DUPLICATE KEY UPDATE object = VALUES(object), updated_at = VALUES(created_at)`,
+		key, data, time.Now().UnixMilli(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	return nil
+}
+
+func (s *mysql) GetObject(ctx context.Context, key string) ([]byte, bool, error) {
+	var b []byte
+	err := s.db.QueryRowContext(ctx, `SELECT object FROM vault_objects WHERE object_key = ?`, key).Scan(&b)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get object: %w", err)
+	}
+	return b, true, nil
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns, object keys contain
+// underscores.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (s *mysql) ListObjectKeys(ctx context.Context, prefix string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT object_key FROM vault_objects WHERE object_key LIKE ? ORDER BY object_key`,
+		likeEscaper.Replace(prefix)+"%",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []string{}
+	for rows.Next() {
+		var key string
+		err = rows.Scan(&key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan object key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+	return keys, nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/unkeyed/unkey/go/pkg/otel/logging"
+	"github.com/unkeyed/unkey/go/pkg/testutil/containers"
+	"github.com/unkeyed/unkey/go/pkg/uid"
+)
+
+func TestMySQL(t *testing.T) {
+	ctx := context.Background()
+
+	mysqlCfg := containers.MySQL(t)
+	mysqlCfg.DBName = "unkey"
+
+	s, err := NewMySQL(MySQLConfig{
+		DSN:    mysqlCfg.FormatDSN(),
+		Logger: logging.NewNoop(),
+	})
+	require.NoError(t, err)
+
+	// The table outlives the test, so every run uses its own keys
+	prefix := uid.New("test") + "/"
+
+	_, found, err := s.GetObject(ctx, prefix+"keyring/ws_1/LATEST")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, s.PutObject(ctx, prefix+"keyring/ws_1/dek_a", []byte("a")))
+	require.NoError(t, s.PutObject(ctx, prefix+"keyring/ws_1/dek_A", []byte("A")))
+	require.NoError(t, s.PutObject(ctx, prefix+"keyring/ws_1/LATEST", []byte("a")))
+	require.NoError(t, s.PutObject(ctx, prefix+"keyring/ws_2/dek_b", []byte("b")))
+	require.NoError(t, s.PutObject(ctx, prefix+"keyring/ws_1/dekXc", []byte("c")))
+
+	// Overwrites replace the object
+	require.NoError(t, s.PutObject(ctx, prefix+"keyring/ws_1/LATEST", []byte("A")))
+
+	b, found, err := s.GetObject(ctx, prefix+"keyring/ws_1/LATEST")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("A"), b)
+
+	// Keys are case sensitive
+	b, found, err = s.GetObject(ctx, prefix+"keyring/ws_1/dek_a")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("a"), b)
+
+	// Underscores in the prefix are not wildcards
+	keys, err := s.ListObjectKeys(ctx, prefix+"keyring/ws_1/dek_")
+	require.NoError(t, err)
+	require.Equal(t, []string{prefix + "keyring/ws_1/dek_A", prefix + "keyring/ws_1/dek_a"}, keys)
+
+	keys, err = s.ListObjectKeys(ctx, prefix)
+	require.NoError(t, err)
+	require.Len(t, keys, 5)
+}
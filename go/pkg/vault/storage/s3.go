@@ -121,14 +121,17 @@ func (s *s3) ListObjectKeys(ctx context.Context, prefix string) ([]string, error
 		input.Prefix = aws.String(prefix)
 	}
 
-	o, err := s.client.ListObjectsV2(ctx, input)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to list objects: %w", err)
-	}
-	keys := make([]string, len(o.Contents))
-	for i, obj := range o.Contents {
-		keys[i] = *obj.Key
+	// A single page holds at most 1000 keys
+	keys := []string{}
+	paginator := awsS3.NewListObjectsV2Paginator(s.client, input)
+	for paginator.HasMorePages() {
+		o, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		for _, obj := range o.Contents {
+			keys = append(keys, *obj.Key)
+		}
 	}
 	return keys, nil
 }
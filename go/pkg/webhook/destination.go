@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateDestination is returned for endpoints that resolve to an address
+// which is not reachable from the public internet, such as loopback, private
+// networks or cloud metadata services.
+var ErrPrivateDestination = errors.New("webhook destination is not a public address")
+
+// reservedPrefixes are special purpose ranges that netip.Addr does not
+// classify, see the IANA special-purpose address registries.
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/23"),
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"),
+}
+
+// IsPublicAddr reports whether webhooks may be delivered to the address.
+// Loopback, private, link-local, multicast, unspecified and other reserved
+// addresses are not public.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() {
+		return false
+	}
+
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ValidateURL checks that webhooks can be delivered to the url: it must use
+// https and its host must only resolve to public addresses.
+//
+// The addresses of a host can change after the check, so deliveries must
+// also use a client created by NewHTTPClient.
+func ValidateURL(ctx context.Context, resolver *net.Resolver, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("url must be an https url with a host")
+	}
+
+	if addr, parseErr := netip.ParseAddr(u.Hostname()); parseErr == nil {
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("%w: %s", ErrPrivateDestination, addr)
+		}
+		return nil
+	}
+
+	addrs, err := resolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return fmt.Errorf("unable to resolve %s: %w", u.Hostname(), err)
+	}
+
+	for _, addr := range addrs {
+		if !IsPublicAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrPrivateDestination, u.Hostname(), addr)
+		}
+	}
+
+	return nil
+}
+
+// NewHTTPClient returns a client for deliveries that refuses to connect to
+// addresses which are not public.
+//
+// The address is checked after the host was resolved, right before the
+// connection is made, so hosts whose addresses changed since ValidateURL and
+// redirects to private addresses are rejected as well. Proxies are not used,
+// they would connect on the client's behalf.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("invalid address %s: %w", address, err)
+			}
+			if !IsPublicAddr(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", ErrPrivateDestination, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert // the default transport is always a *http.Transport
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+}
@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	public := []string{"1.1.1.1", "8.8.8.8", "93.184.216.34", "2606:4700:4700::1111", "::ffff:1.1.1.1"}
+	for _, addr := range public {
+		require.True(t, IsPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+
+	private := []string{
+		"0.0.0.0",
+		"127.0.0.1",
+		"10.0.0.1",
+		"172.16.0.1",
+		"192.168.1.1",
+		"169.254.169.254",
+		"100.64.0.1",
+		"198.18.0.1",
+		"192.0.2.1",
+		"224.0.0.1",
+		"255.255.255.255",
+		"::",
+		"::1",
+		"fc00::1",
+		"fe80::1",
+		"ff02::1",
+		"::ffff:127.0.0.1",
+		"::ffff:169.254.169.254",
+		"64:ff9b::a9fe:a9fe",
+		"2001:db8::1",
+		"2002:a9fe:a9fe::1",
+	}
+	for _, addr := range private {
+		require.False(t, IsPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+
+	require.False(t, IsPublicAddr(netip.Addr{}))
+}
+
+func TestValidateURL(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, ValidateURL(ctx, net.DefaultResolver, "https://1.1.1.1/webhooks"))
+	require.NoError(t, ValidateURL(ctx, net.DefaultResolver, "https://[2606:4700:4700::1111]:8443/webhooks"))
+
+	for _, rawURL := range []string{
+		"https://127.0.0.1/webhooks",
+		"https://10.0.0.1",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/webhooks",
+		"https://[::ffff:192.168.0.1]/webhooks",
+	} {
+		require.ErrorIs(t, ValidateURL(ctx, net.DefaultResolver, rawURL), ErrPrivateDestination, rawURL)
+	}
+
+	for _, rawURL := range []string{"http://1.1.1.1/webhooks", "https:///webhooks", "1.1.1.1", "://"} {
+		err := ValidateURL(ctx, net.DefaultResolver, rawURL)
+		require.Error(t, err, rawURL)
+		require.NotErrorIs(t, err, ErrPrivateDestination, rawURL)
+	}
+}
+
+func TestNewHTTPClientRefusesPrivateAddresses(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+
+	res, err := NewHTTPClient(time.Second).Do(req)
+	if res != nil {
+		res.Body.Close()
+	}
+	require.ErrorIs(t, err, ErrPrivateDestination)
+	require.Equal(t, 0, requests)
+}
@@ -0,0 +1,20 @@
+// Package webhook defines the events delivered to webhook endpoints and how
+// their payloads are signed.
+//
+// Every delivery carries a signature header of the form
+//
+//	t=1700000000,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd
+//
+// where t is the unix timestamp of the delivery and v1 the hex encoded
+// HMAC-SHA256 of "<t>.<body>", keyed with the endpoint's signing secret.
+// Receivers should recompute the signature with Verify and reject deliveries
+// whose timestamp is too old, which prevents replaying captured requests.
+//
+// Example usage:
+//
+//	body, _ := io.ReadAll(r.Body)
+//	err := webhook.Verify(secret, r.Header.Get(webhook.SignatureHeader), body, time.Now(), 5*time.Minute)
+//	if err != nil {
+//	    // Reject the delivery
+//	}
+package webhook
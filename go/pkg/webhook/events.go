@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"github.com/unkeyed/unkey/go/pkg/auditlog"
+)
+
+// Event is the name of an event webhook endpoints can subscribe to.
+type Event string
+
+const (
+	// KeyExpiringSoonEvent is sent once when a key is about to expire.
+	KeyExpiringSoonEvent Event = "key.expiring_soon"
+
+	// KeyExpiredEvent is sent once after a key expired.
+	KeyExpiredEvent Event = "key.expired"
+
+	// KeyCreditsLowEvent is sent once per refill period when the remaining
+	// credits of a key drop to the configured threshold.
+	KeyCreditsLowEvent Event = "key.credits_low"
+
+	// KeyCreditsExhaustedEvent is sent once per refill period when a key has
+	// no credits left.
+	KeyCreditsExhaustedEvent Event = "key.credits_exhausted"
+
+	// KeyCreateEvent and KeyDeleteEvent share the names of their audit log
+	// events, they are sent for every audit log of that event.
+	KeyCreateEvent = Event(auditlog.KeyCreateEvent)
+	KeyDeleteEvent = Event(auditlog.KeyDeleteEvent)
+)
+
+// Events lists every event endpoints can subscribe to.
+var Events = []Event{
+	KeyExpiringSoonEvent,
+	KeyExpiredEvent,
+	KeyCreditsLowEvent,
+	KeyCreditsExhaustedEvent,
+	KeyCreateEvent,
+	KeyDeleteEvent,
+}
+
+// IsValid reports whether the event is one endpoints can subscribe to.
+func IsValid(event string) bool {
+	for _, e := range Events {
+		if string(e) == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package webhook
+
+// Payload is the body of a webhook delivery.
+type Payload struct {
+	// ID identifies the event, retried deliveries of the same event share
+	// it so receivers can discard duplicates.
+	ID string `json:"id"`
+
+	Event       Event  `json:"event"`
+	WorkspaceID string `json:"workspaceId"`
+
+	// Time is the unix milli timestamp the event happened at.
+	Time int64 `json:"time"`
+
+	Data KeyData `json:"data"`
+}
+
+// KeyData describes the key an event is about.
+type KeyData struct {
+	KeyID      string `json:"keyId"`
+	KeySpaceID string `json:"keySpaceId,omitempty"`
+	Name       string `json:"name,omitempty"`
+
+	// Expires is the unix milli timestamp the key expires at, only set for
+	// expiration events.
+	Expires *int64 `json:"expires,omitempty"`
+
+	// Remaining is the number of credits the key has left, only set for
+	// credit events.
+	Remaining *int32 `json:"remaining,omitempty"`
+}
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is the header a delivery's signature is sent in.
+	SignatureHeader = "Unkey-Signature"
+
+	// EventIDHeader is the header the id of the delivered event is sent in.
+	EventIDHeader = "Unkey-Event-Id"
+
+	// secretPrefix makes signing secrets recognizable, for example in
+	// secret scanners.
+	secretPrefix = "whsec_"
+
+	// secretBytes is the number of random bytes in a signing secret.
+	secretBytes = 32
+)
+
+var (
+	ErrMalformedSignature = errors.New("malformed signature header")
+	ErrSignatureMismatch  = errors.New("signature does not match")
+	ErrSignatureExpired   = errors.New("signature timestamp is outside the tolerance")
+)
+
+// GenerateSecret creates a new random signing secret.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// Sign returns the signature header of a delivery of body at the given time.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := timestamp.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", t, hex.EncodeToString(mac(secret, t, body)))
+}
+
+// Verify checks that the signature header matches body and that its
+// timestamp is within tolerance of now.
+func Verify(secret string, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var t int64
+	var signatures [][]byte
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return ErrMalformedSignature
+		}
+
+		switch key {
+		case "t":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return ErrMalformedSignature
+			}
+			t = parsed
+		case "v1":
+			signature, err := hex.DecodeString(value)
+			if err != nil {
+				return ErrMalformedSignature
+			}
+			signatures = append(signatures, signature)
+		}
+	}
+	if t == 0 || len(signatures) == 0 {
+		return ErrMalformedSignature
+	}
+
+	age := now.Sub(time.Unix(t, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrSignatureExpired
+	}
+
+	expected := mac(secret, t, body)
+	for _, signature := range signatures {
+		if hmac.Equal(signature, expected) {
+			return nil
+		}
+	}
+	return ErrSignatureMismatch
+}
+
+func mac(secret string, t int64, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(strconv.FormatInt(t, 10)))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
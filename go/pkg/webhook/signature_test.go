@@ -0,0 +1,42 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(secret, secretPrefix))
+
+	now := time.Now()
+	body := []byte(`{"id":"whev_123","event":"key.expired"}`)
+	header := Sign(secret, now, body)
+
+	require.NoError(t, Verify(secret, header, body, now, time.Minute))
+
+	// Receivers may verify a little later than the delivery was signed
+	require.NoError(t, Verify(secret, header, body, now.Add(30*time.Second), time.Minute))
+
+	require.ErrorIs(t, Verify(secret, header, body, now.Add(2*time.Minute), time.Minute), ErrSignatureExpired)
+	require.ErrorIs(t, Verify(secret, header, []byte(`{"id":"whev_456"}`), now, time.Minute), ErrSignatureMismatch)
+
+	other, err := GenerateSecret()
+	require.NoError(t, err)
+	require.ErrorIs(t, Verify(other, header, body, now, time.Minute), ErrSignatureMismatch)
+
+	for _, malformed := range []string{"", "t=abc,v1=00", "v1=00", "t=123", "t=123,v1=zz", "garbage"} {
+		require.ErrorIs(t, Verify(secret, malformed, body, now, time.Minute), ErrMalformedSignature, malformed)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	require.True(t, IsValid("key.expiring_soon"))
+	require.True(t, IsValid("key.create"))
+	require.False(t, IsValid("key.update"))
+	require.False(t, IsValid(""))
+}
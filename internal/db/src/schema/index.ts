@@ -21,3 +21,6 @@ export * from "./acme_users";
 // Routing and traffic management
 export * from "./routes";
 export * from "./domains";
+
+// Webhooks
+export * from "./webhooks";
@@ -9,6 +9,16 @@ export const ratelimitNamespaces = mysqlTable(
     id: varchar("id", { length: 256 }).primaryKey(),
     workspaceId: varchar("workspace_id", { length: 256 }).notNull(),
     name: varchar("name", { length: 512 }).notNull(),
+    /**
+     * Algorithm used for all limits in this namespace, unless an override sets its own.
+     */
+    algorithm: mysqlEnum("algorithm", ["sliding_window", "fixed_window", "token_bucket"])
+      .notNull()
+      .default("sliding_window"),
+    /**
+     * Tokens added per duration for the token_bucket algorithm, defaults to the limit.
+     */
+    refillRate: int("refill_rate"),
 
     ...lifecycleDatesMigration,
   },
@@ -55,6 +65,15 @@ export const ratelimitOverrides = mysqlTable(
      */
     sharding: mysqlEnum("sharding", ["edge"]),
 
+    /**
+     * Algorithm used for this identifier, falls back to the namespace algorithm if null.
+     */
+    algorithm: mysqlEnum("algorithm", ["sliding_window", "fixed_window", "token_bucket"]),
+    /**
+     * Tokens added per duration for the token_bucket algorithm, defaults to the limit.
+     */
+    refillRate: int("refill_rate"),
+
     ...lifecycleDatesMigration,
   },
   (table) => {
@@ -0,0 +1,72 @@
+import { relations } from "drizzle-orm";
+import {
+  bigint,
+  boolean,
+  index,
+  json,
+  mysqlTable,
+  uniqueIndex,
+  varchar,
+} from "drizzle-orm/mysql-core";
+import { embeddedEncrypted } from "./util/embedded_encrypted";
+import { lifecycleDatesMigration } from "./util/lifecycle_dates";
+import { workspaces } from "./workspaces";
+
+/**
+ * Endpoints receive signed webhooks for the events they subscribed to.
+ */
+export const webhookEndpoints = mysqlTable(
+  "webhook_endpoints",
+  {
+    id: varchar("id", { length: 256 }).primaryKey(),
+    workspaceId: varchar("workspace_id", { length: 256 }).notNull(),
+    url: varchar("url", { length: 1024 }).notNull(),
+    /**
+     * The event names this endpoint is subscribed to, for example `key.expiring_soon`.
+     */
+    events: json("events").$type<string[]>().notNull(),
+    enabled: boolean("enabled").notNull().default(true),
+
+    /**
+     * The signing secret, encrypted by vault.
+     */
+    ...embeddedEncrypted,
+    ...lifecycleDatesMigration,
+  },
+  (table) => ({
+    workspaceIdx: index("workspace_idx").on(table.workspaceId),
+  }),
+);
+
+export const webhookEndpointsRelations = relations(webhookEndpoints, ({ one }) => ({
+  workspace: one(workspaces, {
+    fields: [webhookEndpoints.workspaceId],
+    references: [workspaces.id],
+  }),
+}));
+
+/**
+ * Events that happened in a workspace and are delivered to its webhook endpoints.
+ *
+ * The dedupe key identifies the occurrence of an event, so scanning the same key
+ * twice does not record it twice.
+ */
+export const webhookEvents = mysqlTable(
+  "webhook_events",
+  {
+    id: varchar("id", { length: 256 }).primaryKey(),
+    workspaceId: varchar("workspace_id", { length: 256 }).notNull(),
+    event: varchar("event", { length: 256 }).notNull(),
+    dedupeKey: varchar("dedupe_key", { length: 256 }).notNull(),
+    payload: json("payload").notNull(),
+    createdAt: bigint("created_at", { mode: "number" }).notNull(),
+    /**
+     * Set once deliveries to all subscribed endpoints were started.
+     */
+    dispatchedAt: bigint("dispatched_at", { mode: "number" }),
+  },
+  (table) => ({
+    dedupeIdx: uniqueIndex("dedupe_idx").on(table.workspaceId, table.event, table.dedupeKey),
+    dispatchedAtIdx: index("dispatched_at_idx").on(table.dispatchedAt),
+  }),
+);
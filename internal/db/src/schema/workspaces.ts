@@ -14,6 +14,7 @@ import { permissions, roles } from "./rbac";
 import { deleteProtection } from "./util/delete_protection";
 import { lifecycleDatesMigration } from "./util/lifecycle_dates";
 import { vercelBindings, vercelIntegrations } from "./vercel_integration";
+import { webhookEndpoints } from "./webhooks";
 
 export const workspaces = mysqlTable("workspaces", {
   id: varchar("id", { length: 256 }).primaryKey(),
@@ -119,6 +120,7 @@ export const workspacesRelations = relations(workspaces, ({ many, one }) => ({
   keySpaces: many(keyAuth),
   identities: many(identities),
   quotas: one(quotas),
+  webhookEndpoints: many(webhookEndpoints),
 
   // Deployment platform relations (no foreign keys enforced)
   partition: one(partitions),
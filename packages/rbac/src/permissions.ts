@@ -24,6 +24,7 @@ const apiId = buildIdSchema("api");
 const ratelimitNamespaceId = buildIdSchema("rl");
 const rbacId = buildIdSchema("rbac");
 const identityEnvId = z.string();
+const webhookEndpointId = buildIdSchema("whep");
 export const apiActions = z.enum([
   "read_api",
   "create_api",
@@ -69,6 +70,7 @@ export const identityActions = z.enum([
   "update_identity",
   "delete_identity",
 ]);
+export const webhookActions = z.enum(["create_endpoint", "read_endpoint", "delete_endpoint"]);
 export type Resources = {
   [resourceId in `api.${z.infer<typeof apiId>}`]: z.infer<typeof apiActions>;
 } & {
@@ -79,6 +81,8 @@ export type Resources = {
   [resourceId in `rbac.${z.infer<typeof rbacId>}`]: z.infer<typeof rbacActions>;
 } & {
   [resourceId in `identity.${z.infer<typeof identityEnvId>}`]: z.infer<typeof identityActions>;
+} & {
+  [resourceId in `webhook.${z.infer<typeof webhookEndpointId>}`]: z.infer<typeof webhookActions>;
 };
 export type UnkeyPermission = Flatten<Resources> | "*";
 /**
@@ -112,6 +116,9 @@ export const unkeyPermissionValidation = z.custom<UnkeyPermission>().refine((s)
     case "identity": {
       return identityEnvId.safeParse(id).success && identityActions.safeParse(action).success;
     }
+    case "webhook": {
+      return webhookEndpointId.safeParse(id).success && webhookActions.safeParse(action).success;
+    }
     default: {
       return false;
     }